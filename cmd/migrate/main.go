@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
 	"content-service/internal/shared/logging"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -27,6 +28,10 @@ func main() {
 
 	logging.InitLogger(cfg.Environment)
 
+	if cfg.DB.Driver == database.DriverSQLite {
+		log.Fatal().Msg("SQL migrations target PostgreSQL; with DB_DRIVER=sqlite the server creates the schema with AutoMigrate on startup")
+	}
+
 	migrationsPath := "file://./migrations"
 	migrationsAbsPath, err := filepath.Abs("./migrations")
 	if err != nil {
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"content-service/internal/outbox"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+	"content-service/internal/shared/logging"
+
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load config")
+	}
+
+	logging.InitLogger(cfg.Environment)
+
+	if !cfg.Outbox.Enabled() {
+		log.Fatal().Msg("EVENT_BUS is not set to kafka or nats")
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+
+	publisher, err := outbox.NewPublisher(cfg.Outbox)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create event publisher")
+	}
+	relay := outbox.NewRelay(outbox.NewRepository(db, cfg.Outbox.MaxAttempts), publisher, cfg.Outbox)
+
+	var healthSrv *http.Server
+	if cfg.Outbox.HealthAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/health", outbox.HealthHandler(relay))
+		healthSrv = &http.Server{Addr: cfg.Outbox.HealthAddr, Handler: mux}
+		go func() {
+			if err := healthSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error().Err(err).Msg("Health server failed")
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().
+		Str("bus", cfg.Outbox.Bus).
+		Str("topic", cfg.Outbox.Topic()).
+		Str("format", cfg.Outbox.Format).
+		Msg("Outbox relay started")
+	relay.Run(ctx)
+
+	if healthSrv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := healthSrv.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("Failed to stop health server")
+		}
+	}
+	if err := publisher.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close event publisher")
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			log.Error().Err(err).Msg("Error closing database connection")
+		}
+	}
+	log.Info().Msg("Outbox relay stopped")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/search"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+	"content-service/internal/shared/logging"
+	"content-service/internal/shared/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load config")
+	}
+
+	logging.InitLogger(cfg.Environment)
+
+	if cfg.Search.Backend != "elasticsearch" {
+		log.Fatal().Msg("SEARCH_BACKEND is not set to elasticsearch")
+	}
+
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+	defer func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}()
+
+	contentStore, err := storage.NewContentStore(cfg, db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize content store")
+	}
+	articleService := article.NewService(article.NewRepository(db),
+		article.WithContentStore(contentStore, cfg.ContentStore.InlineThreshold),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	startedAt := time.Now()
+	indexer := search.NewIndexer(search.NewClient(cfg.Search), articleService)
+	indexed, err := indexer.Reindex(ctx, cfg.Search.ReindexBatch)
+	if err != nil {
+		log.Fatal().Err(err).Int("indexed", indexed).Msg("Reindex failed")
+	}
+	log.Info().
+		Str("index", cfg.Search.Index).
+		Int("indexed", indexed).
+		Dur("duration", time.Since(startedAt)).
+		Msg("Reindex complete")
+}
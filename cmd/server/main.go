@@ -4,46 +4,104 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"content-service/internal/activity"
+	"content-service/internal/admin"
 	"content-service/internal/article"
+	"content-service/internal/category"
+	"content-service/internal/feed"
+	"content-service/internal/graphql"
+	"content-service/internal/grpcserver"
+	"content-service/internal/health"
+	"content-service/internal/idempotency"
+	"content-service/internal/media"
+	"content-service/internal/moderation"
+	"content-service/internal/notification"
+	"content-service/internal/outbox"
+	"content-service/internal/page"
+	"content-service/internal/report"
+	"content-service/internal/search"
+	"content-service/internal/series"
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/buildinfo"
+	"content-service/internal/shared/cache"
 	"content-service/internal/shared/config"
+	"content-service/internal/shared/cursor"
 	"content-service/internal/shared/database"
+	"content-service/internal/shared/events"
 	"content-service/internal/shared/logging"
 	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/storage"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/webhook"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	startedAt := time.Now()
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to load config")
 	}
 
 	logging.InitLogger(cfg.Environment)
-	log.Info().Str("environment", cfg.Environment).Msg("Starting content-service")
+	build := buildinfo.Get()
+	log.Info().
+		Str("environment", cfg.Environment).
+		Str("version", build.Version).
+		Str("commit", build.Commit).
+		Str("build_date", build.BuildDate).
+		Str("go_version", build.GoVersion).
+		Msg("Starting content-service")
+
+	if cfg.Sentry.DSN != "" {
+		if err := tracking.Init(cfg); err != nil {
+			log.Fatal().Err(err).Msg("Failed to configure Sentry")
+		}
+		log.Info().Msg("Errors are reported to Sentry")
+	}
+
+	verifier, err := middleware.VerifierFor(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure JWT verification")
+	}
 
 	db, err := database.ConnectDB(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 
+	replicas, err := database.ConnectReplicas(db, cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure database replicas")
+	}
+	if replicas != nil {
+		log.Info().Int("replicas", len(cfg.DB.ReplicaDSNs)).Msg("Article reads are routed to database replicas")
+	}
+
 	autoMigrate := os.Getenv("AUTO_MIGRATE")
 	if autoMigrate == "" {
 		autoMigrate = "true"
-		if cfg.IsProduction() {
+		if cfg.IsProduction() && cfg.DB.Driver != database.DriverSQLite {
 			autoMigrate = "false"
 		}
 	}
 
 	if autoMigrate == "true" {
-		if err := db.AutoMigrate(&article.Article{}); err != nil {
+		if err := db.AutoMigrate(&category.Category{}, &article.Article{}, &article.Revision{}, &article.Tag{}, &article.SlugRedirect{}, &article.Reaction{}, &article.Author{}, &article.Translation{}, &series.Series{}, &series.Entry{}, &media.Media{}, &media.Link{}, &storage.Blob{}, &webhook.Endpoint{}, &webhook.Delivery{}, &notification.Preference{}, &activity.Entry{}, &page.Page{}, &report.Report{}, &outbox.Message{}, &idempotency.Record{}); err != nil {
+			log.Fatal().Err(err).Msg("Failed to run migrations")
+		}
+		if err := article.MigrateSearch(db); err != nil {
 			log.Fatal().Err(err).Msg("Failed to run migrations")
 		}
 		log.Info().Msg("Database AutoMigrate completed")
@@ -53,31 +111,404 @@ func main() {
 
 	gin.SetMode(cfg.App.GinMode)
 
-	articleRepo := article.NewRepository(db)
-	articleService := article.NewService(articleRepo)
+	repoOptions := []article.RepositoryOption{
+		article.WithCountStrategy(cfg.Article.CountEstimateMin, cfg.Article.CountCacheTTL),
+		article.WithBatchSize(cfg.Article.BatchSize),
+	}
+	if cfg.Outbox.Enabled() {
+		repoOptions = append(repoOptions, article.WithOutbox(outbox.NewWriter(cfg.Outbox.Topic())))
+		log.Info().Str("bus", cfg.Outbox.Bus).Str("topic", cfg.Outbox.Topic()).Msg("Article events are written to the outbox")
+	}
+	articleRepo := article.NewRepository(db, repoOptions...)
+
+	var readCache cache.Cache
+	var cacheMetrics *cache.Metrics
+	if cfg.Cache.Backend != cache.BackendNone {
+		readCache, err = cache.New(cfg.Cache)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize cache")
+		}
+		cacheMetrics = cache.NewMetrics(cfg.Cache.Backend)
+		articleRepo = article.NewCachedRepository(articleRepo, readCache, cacheMetrics, cfg.Cache)
+		log.Info().Str("backend", cfg.Cache.Backend).Dur("ttl", cfg.Cache.TTL).Dur("list_ttl", cfg.Cache.ListTTL).Msg("Article read cache ready")
+	}
+
+	rateLimiters, err := middleware.NewRateLimiterStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize rate limiter")
+	}
+	log.Info().Str("backend", cfg.RateLimit.Backend).Msg("Rate limiter ready")
+
+	if cfg.Article.SeedOnEmpty && cfg.IsProduction() {
+		log.Warn().Msg("SEED_ON_EMPTY is ignored in production")
+	}
+	seeded, err := article.SeedOnEmpty(context.Background(), articleRepo, cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to seed sample articles")
+	}
+	if seeded > 0 {
+		log.Info().Int("count", seeded).Msg("Seeded sample articles into empty database")
+	}
+	contentStore, err := storage.NewContentStore(cfg, db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize content store")
+	}
+	log.Info().Str("backend", cfg.ContentStore.Backend).Int("inline_threshold", cfg.ContentStore.InlineThreshold).Msg("Content store ready")
+
+	categoryRepo := category.NewRepository(db)
+	articleCache, _ := articleRepo.(article.Invalidator)
+	categoryService := category.NewService(categoryRepo, category.WithArticleCache(articleCache))
+	categoryHandler := category.NewHandler(categoryService)
+
+	pageService := page.NewService(page.NewRepository(db))
+	pageHandler := page.NewHandler(pageService)
+
+	objectStore, err := storage.NewObjectStore(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize media store")
+	}
+	log.Info().Str("backend", cfg.Media.Backend).Int64("max_bytes", cfg.Media.MaxBytes).Msg("Media store ready")
+
+	thumbnailer := media.NewThumbnailer(objectStore, cfg.Media.ThumbnailSizes, cfg.Media.ThumbnailQueue)
+	mediaRepo := media.NewRepository(db)
+	mediaService := media.NewService(mediaRepo, objectStore, cfg.Media, media.WithThumbnailer(thumbnailer), media.WithArticleCache(articleCache))
+	mediaHandler := media.NewHandler(mediaService, cfg.Media.MaxBytes)
+
+	seriesRepo := series.NewRepository(db)
+	seriesService := series.NewService(seriesRepo)
+	seriesHandler := series.NewHandler(seriesService)
+
+	webhookRepo := webhook.NewRepository(db)
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, cfg.Webhook)
+	webhookService := webhook.NewService(webhookRepo, webhookDispatcher, webhook.WithPrivateTargets(cfg.Webhook.AllowPrivateTargets))
+	webhookHandler := webhook.NewHandler(webhookService)
+
+	var emailTemplates *notification.Templates
+	var emailQueue *notification.Queue
+	if cfg.Notification.Provider != notification.ProviderNone {
+		emailTemplates, err = notification.LoadTemplates(cfg.Notification.TemplateDir, cfg.Notification.Events)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load email templates")
+		}
+		mailer, err := notification.NewMailer(cfg.Notification)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize mailer")
+		}
+		emailQueue = notification.NewQueue(mailer, cfg.Notification)
+		log.Info().Str("provider", cfg.Notification.Provider).Strs("events", cfg.Notification.Events).Bool("dry_run", cfg.Notification.DryRun).Msg("Email notifications enabled")
+	}
+	notificationService := notification.NewService(notification.NewRepository(db), emailTemplates, emailQueue, cfg.Feed)
+	notificationHandler := notification.NewHandler(notificationService)
+
+	activityService := activity.NewService(activity.NewRepository(db))
+	activityHandler := activity.NewHandler(activityService)
+
+	eventBus := events.NewBus(cfg.Events.QueueSize)
+
+	viewCounter := article.NewViewCounter(articleRepo)
+	trending := article.NewTrending(articleRepo, cfg.Trending)
+	articleOptions := []article.Option{
+		article.WithMinContentLength(cfg.Article.MinContentLength),
+		article.WithCountMode(cfg.Article.CountMode),
+		article.WithContentStore(contentStore, cfg.ContentStore.InlineThreshold),
+		article.WithCategories(categoryService),
+		article.WithSeries(seriesService),
+		article.WithModeration(cfg.Moderation.Enabled, cfg.Moderation.TrustedRoles),
+		article.WithCursorCodec(cursor.NewCodec(cfg.Article.CursorSecret)),
+		article.WithViewCounter(viewCounter),
+		article.WithTrending(trending),
+		article.WithCovers(mediaService),
+		article.WithMediaCollector(mediaService),
+		article.WithEvents(eventBus),
+	}
+	var searchClient *search.Client
+	if cfg.Search.Backend == "elasticsearch" {
+		searchClient = search.NewClient(cfg.Search)
+		ensureCtx, cancelEnsure := context.WithTimeout(context.Background(), cfg.Search.Timeout)
+		err := searchClient.EnsureIndex(ensureCtx)
+		cancelEnsure()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize search index")
+		}
+		articleOptions = append(articleOptions, article.WithSearch(searchClient))
+		log.Info().Str("url", cfg.Search.URL).Str("index", cfg.Search.Index).Msg("Search is served by Elasticsearch")
+	}
+	articleService := article.NewService(articleRepo, articleOptions...)
 	articleHandler := article.NewHandler(articleService)
+	moderationHandler := moderation.NewHandler(articleService)
+	graphqlHandler := graphql.NewHandler(graphql.NewResolver(articleService, categoryService), cfg.GraphQL)
+	reportHandler := report.NewHandler(report.NewService(report.NewRepository(db), articleService, cfg.Report))
+	idempotencyRepo := idempotency.NewRepository(db)
+	feedHandler := feed.NewHandler(articleService, cfg.Feed)
 
-	router := gin.Default()
+	eventBus.SubscribeAsync("webhooks", webhookService.HandleArticleEvent, webhook.Events...)
+	eventBus.SubscribeAsync("activity", activityService.HandleArticleEvent, activity.Events...)
+	if searchClient != nil {
+		eventBus.SubscribeAsync("search", search.NewIndexer(searchClient, articleService).HandleArticleEvent, search.Events...)
+	}
+	if emailQueue != nil {
+		eventBus.SubscribeAsync("email", notificationService.HandleArticleEvent, cfg.Notification.Events...)
+		emailQueue.Start(cfg.Notification.Workers)
+	}
+	if cfg.Events.LogSampleRate > 0 {
+		eventBus.SubscribeAsync("event_log", article.NewEventLogger(cfg.Events.LogSampleRate).HandleArticleEvent, cfg.Events.LogEvents...)
+	}
+	eventBus.Subscribe("sitemap", feedHandler.InvalidateSitemaps, article.EventArticlePublished, article.EventArticleUpdated, article.EventArticleDeleted)
+	eventBus.Start(cfg.Events.Workers)
 
-	router.Use(middleware.RateLimitMiddleware())
-	router.Use(middleware.CORSMiddleware(cfg))
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go viewCounter.Run(backgroundCtx, cfg.Article.ViewsFlush)
+	go trending.Run(backgroundCtx)
+	thumbnailer.Start(backgroundCtx, cfg.Media.ThumbnailWorkers)
+	go webhookDispatcher.Run(backgroundCtx)
+	if cfg.Article.PurgeAfter > 0 {
+		go article.RunPurger(backgroundCtx, articleService, cfg.Article.PurgeAfter, cfg.Article.PurgeInterval)
+		log.Info().Dur("retention", cfg.Article.PurgeAfter).Dur("interval", cfg.Article.PurgeInterval).Msg("Soft-deleted article purger started")
+	}
+	go idempotency.RunCleaner(backgroundCtx, idempotencyRepo, cfg.Idempotency.CleanupInterval)
+
+	maintenance := middleware.NewMaintenance(cfg.Maintenance.Enabled, cfg.Maintenance.RetryAfter)
 
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "content-service",
-		})
+	deprecations := middleware.NewDeprecationRegistry()
+
+	bodyRules := middleware.NewBodyRules()
+	bodyRules.Set(http.MethodPost, "/api/v1/articles/import", middleware.BodyRule{
+		MaxBytes:     article.MaxImportBytes,
+		ContentTypes: []string{"application/json", "application/zip"},
+	})
+	bodyRules.Set(http.MethodPatch, "/api/v1/articles/:id", middleware.BodyRule{
+		ContentTypes: []string{"application/json", article.MediaTypeMergePatch, article.MediaTypeJSONPatch},
 	})
+	bodyRules.Set(http.MethodPost, "/api/v1/media", middleware.BodyRule{
+		MaxBytes:     cfg.Media.MaxBytes + media.MultipartOverhead,
+		ContentTypes: []string{"multipart/form-data"},
+	})
+
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(middleware.MethodNotAllowedHandler(cfg))
+	if err := middleware.ConfigureClientIP(router, cfg.App.ClientIPHeader, cfg.App.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure trusted proxies")
+	}
+	if cfg.App.ClientIPHeader != "" && len(cfg.App.TrustedProxies) == 0 {
+		log.Warn().Str("header", cfg.App.ClientIPHeader).Msg("CLIENT_IP_HEADER is ignored until TRUSTED_PROXIES is set")
+	}
+	adminIPs, err := middleware.NewIPFilter(cfg.Admin.AllowedIPs, cfg.Admin.DeniedIPs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure admin IP filter")
+	}
+	inFlight := middleware.NewInFlightCounter()
+	slowRequests := middleware.NewSlowRequestCounter()
+
+	adminOptions := []admin.Option{
+		admin.WithArticles(articleService),
+		admin.WithInFlight(inFlight),
+		admin.WithSlowRequests(slowRequests),
+		admin.WithStartedAt(startedAt),
+	}
+	readiness := health.NewChecker(cfg.Health.Timeout)
+	if pool, err := db.DB(); err == nil {
+		adminOptions = append(adminOptions, admin.WithDatabase(pool))
+		readiness.Add(health.Check{Name: "database", Critical: true, Probe: pool.PingContext})
+
+		poolMonitor := database.NewPoolMonitor(pool, cfg.DB)
+		readiness.Add(health.Check{Name: "database_pool", Probe: poolMonitor.Check})
+		go poolMonitor.Run(backgroundCtx, cfg.DB.PoolStatsInterval)
+	}
+	if replicas != nil {
+		readiness.Add(health.Check{Name: "database_replicas", Probe: replicas.Check})
+		go replicas.Run(backgroundCtx, cfg.DB.ReplicaCheckInterval)
+	}
+	var outboxPublisher outbox.Publisher
+	outboxDone := make(chan struct{})
+	if cfg.Outbox.Enabled() && cfg.Outbox.InProcess {
+		outboxPublisher, err = outbox.NewPublisher(cfg.Outbox)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create event publisher")
+		}
+		relay := outbox.NewRelay(outbox.NewRepository(db, cfg.Outbox.MaxAttempts), outboxPublisher, cfg.Outbox)
+		readiness.Add(health.Check{Name: "outbox", Probe: relay.Check})
+		go func() {
+			defer close(outboxDone)
+			relay.Run(backgroundCtx)
+		}()
+		log.Info().Str("bus", cfg.Outbox.Bus).Msg("Outbox relay running in-process")
+	} else {
+		close(outboxDone)
+	}
+	if pinger, ok := readCache.(health.Pinger); ok {
+		readiness.Add(health.Check{Name: "cache", Probe: pinger.Ping})
+	}
+	if pinger, ok := rateLimiters.(health.Pinger); ok {
+		readiness.Add(health.Check{Name: "rate_limiter", Probe: pinger.Ping})
+	}
+	if searchClient != nil {
+		readiness.Add(health.Check{Name: "search", Probe: searchClient.Ping})
+	}
+	adminHandler := admin.NewHandler(maintenance, readCache, cacheMetrics, adminOptions...)
+
+	router.Use(middleware.InFlightMiddleware(inFlight))
+	router.Use(middleware.RequestIDMiddleware(), middleware.ErrorTrackingMiddleware())
+	router.Use(middleware.AccessLogMiddleware(cfg.App.SlowRequestThreshold, slowRequests), middleware.RecoveryMiddleware(middleware.ReportPanic))
+	router.Use(middleware.APIVersionMiddleware(buildinfo.APIVersion(), deprecations))
+	router.Use(middleware.AllowedMethodsMiddleware(cfg))
+	router.Use(middleware.RateLimitMiddleware(cfg, rateLimiters))
+	router.Use(middleware.CORSMiddleware(cfg))
+	router.Use(middleware.GzipMiddleware(cfg))
+	router.Use(middleware.RequestBodyMiddleware(cfg, bodyRules))
+	if cfg.App.LogHTTPBody {
+		log.Warn().Int("max_bytes", cfg.App.LogHTTPBodyMaxBytes).Msg("HTTP body logging is enabled")
+		router.Use(middleware.BodyLogMiddleware(cfg))
+	}
+
+	router.GET("/health", health.Liveness)
+	router.GET("/healthz", health.Liveness)
+	router.GET("/readyz", readiness.Readiness)
+	router.GET("/version", health.Version)
+
+	router.GET("/sitemap.xml", feedHandler.Sitemap)
+	router.GET("/sitemaps/:file", feedHandler.SitemapPage)
+
+	feeds := router.Group("/feeds")
+	{
+		feeds.GET("/articles.rss", feedHandler.ArticlesRSS)
+		feeds.GET("/articles.atom", feedHandler.ArticlesAtom)
+		feeds.GET("/tags/:file", feedHandler.TagFeed)
+	}
 
-	api := router.Group("/api")
+	idempotent := idempotency.Middleware(idempotencyRepo, cfg.Idempotency.TTL)
+
+	api := router.Group("/api/v1", middleware.MaintenanceMiddleware(maintenance, "/api/v1/admin"))
 	{
-		articles := api.Group("/articles")
+		articles := api.Group("/articles", middleware.ResourceScopes("articles"))
 		{
-			articles.POST("", middleware.JWTAuthMiddleware(cfg), articleHandler.CreateArticle)
-			articles.GET("", articleHandler.GetAllArticles)
-			articles.GET("/:id", articleHandler.GetArticleByID)
+			articles.POST("", middleware.JWTAuthMiddleware(cfg), idempotent, articleHandler.CreateArticle)
+			articles.POST("/bulk", middleware.JWTAuthMiddleware(cfg), idempotent, articleHandler.BulkCreateArticles)
+			articles.DELETE("/bulk", middleware.JWTAuthMiddleware(cfg), articleHandler.BulkDeleteArticles)
+			articles.GET("/export", middleware.JWTAuthMiddleware(cfg), articleHandler.ExportArticles)
+			articles.POST("/import", middleware.JWTAuthMiddleware(cfg), articleHandler.ImportArticles)
+			articles.GET("", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetAllArticles)
+			articles.GET("/schema", articleHandler.GetArticleSchema)
+			articles.GET("/tags", articleHandler.GetTagCounts)
+			articles.GET("/search", articleHandler.SearchArticles)
+			articles.GET("/popular", articleHandler.GetPopularArticles)
+			articles.GET("/trending", articleHandler.GetTrendingArticles)
+			articles.GET("/trash", middleware.JWTAuthMiddleware(cfg), articleHandler.GetTrash)
+			articles.GET("/featured", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetFeaturedArticles)
+			articles.GET("/etags", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetArticleETags)
+			articles.GET("/slug/:slug", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetArticleBySlug)
+			articles.GET("/:id", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetArticleByID)
+			articles.GET("/:id/stats", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetArticleStats)
+			articles.GET("/:id/translations", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.ListTranslations)
+			articles.GET("/:id/translations/:locale", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.GetTranslation)
+			articles.PUT("/:id/translations/:locale", middleware.JWTAuthMiddleware(cfg), articleHandler.SaveTranslation)
+			articles.DELETE("/:id/translations/:locale", middleware.JWTAuthMiddleware(cfg), articleHandler.DeleteTranslation)
+			articles.GET("/:id/authors", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.ListAuthors)
+			articles.GET("/:id/revisions", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.ListRevisions)
+			articles.GET("/:id/diff", middleware.OptionalJWTAuthMiddleware(cfg), articleHandler.DiffRevisions)
 			articles.PUT("/:id", middleware.JWTAuthMiddleware(cfg), articleHandler.UpdateArticle)
+			articles.PATCH("/:id", middleware.JWTAuthMiddleware(cfg), articleHandler.PatchArticle)
 			articles.DELETE("/:id", middleware.JWTAuthMiddleware(cfg), articleHandler.DeleteArticle)
+			articles.POST("/:id/like", middleware.JWTAuthMiddleware(cfg), articleHandler.LikeArticle)
+			articles.POST("/:id/report", middleware.JWTAuthMiddleware(cfg), reportHandler.ReportArticle)
+			articles.DELETE("/:id/like", middleware.JWTAuthMiddleware(cfg), articleHandler.UnlikeArticle)
+			articles.POST("/:id/authors", middleware.JWTAuthMiddleware(cfg), articleHandler.AddAuthor)
+			articles.DELETE("/:id/authors/:user_id", middleware.JWTAuthMiddleware(cfg), articleHandler.RemoveAuthor)
+			articles.POST("/:id/feature", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleModerator, auth.RoleAdmin), articleHandler.FeatureArticle)
+			articles.DELETE("/:id/feature", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleModerator, auth.RoleAdmin), articleHandler.UnfeatureArticle)
+			articles.POST("/:id/restore", middleware.JWTAuthMiddleware(cfg), articleHandler.RestoreArticle)
+			articles.DELETE("/:id/purge", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), articleHandler.PurgeArticle)
+			articles.POST("/:id/slug/regenerate", middleware.JWTAuthMiddleware(cfg), articleHandler.RegenerateSlug)
+			articles.GET("/:id/media", middleware.JWTAuthMiddleware(cfg), mediaHandler.ListArticleMedia)
+			articles.POST("/:id/media", middleware.JWTAuthMiddleware(cfg), mediaHandler.AttachMedia)
+			articles.DELETE("/:id/media/:media_id", middleware.JWTAuthMiddleware(cfg), mediaHandler.DetachMedia)
+		}
+
+		mediaGroup := api.Group("/media", middleware.ResourceScopes("media"))
+		{
+			mediaGroup.POST("", middleware.JWTAuthMiddleware(cfg), mediaHandler.UploadMedia)
+			mediaGroup.GET("/:id", mediaHandler.GetMedia)
+			mediaGroup.DELETE("/:id", middleware.JWTAuthMiddleware(cfg), mediaHandler.DeleteMedia)
+		}
+
+		categories := api.Group("/categories", middleware.ResourceScopes("categories"))
+		{
+			categories.GET("", categoryHandler.GetCategoryTree)
+			categories.GET("/:id", categoryHandler.GetCategory)
+			categories.POST("", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), categoryHandler.CreateCategory)
+			categories.PUT("/:id", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), categoryHandler.UpdateCategory)
+			categories.DELETE("/:id", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), categoryHandler.DeleteCategory)
+		}
+
+		pages := api.Group("/pages", middleware.ResourceScopes("pages"))
+		{
+			pages.GET("", middleware.OptionalJWTAuthMiddleware(cfg), pageHandler.ListPages)
+			pages.GET("/:slug", middleware.OptionalJWTAuthMiddleware(cfg), pageHandler.GetPage)
+			pages.POST("", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), pageHandler.CreatePage)
+			pages.PUT("/:id", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), pageHandler.UpdatePage)
+			pages.DELETE("/:id", middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin), pageHandler.DeletePage)
+		}
+
+		seriesGroup := api.Group("/series", middleware.ResourceScopes("series"))
+		{
+			seriesGroup.GET("", seriesHandler.ListSeries)
+			seriesGroup.GET("/:id", middleware.OptionalJWTAuthMiddleware(cfg), seriesHandler.GetSeries)
+			seriesGroup.POST("", middleware.JWTAuthMiddleware(cfg), seriesHandler.CreateSeries)
+			seriesGroup.PUT("/:id", middleware.JWTAuthMiddleware(cfg), seriesHandler.UpdateSeries)
+			seriesGroup.DELETE("/:id", middleware.JWTAuthMiddleware(cfg), seriesHandler.DeleteSeries)
+			seriesGroup.POST("/:id/entries", middleware.JWTAuthMiddleware(cfg), seriesHandler.AddEntry)
+			seriesGroup.PUT("/:id/entries", middleware.JWTAuthMiddleware(cfg), seriesHandler.ReorderEntries)
+			seriesGroup.DELETE("/:id/entries/:article_id", middleware.JWTAuthMiddleware(cfg), seriesHandler.RemoveEntry)
+		}
+
+		webhooks := api.Group("/webhooks", middleware.ResourceScopes("webhooks"), middleware.JWTAuthMiddleware(cfg))
+		{
+			webhooks.POST("", webhookHandler.CreateWebhook)
+			webhooks.GET("", webhookHandler.ListWebhooks)
+			webhooks.GET("/:id", webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayDelivery)
+		}
+
+		if middleware.RefreshEnabled(cfg) {
+			api.POST("/auth/refresh", middleware.RefreshHandler(cfg))
+		}
+
+		api.GET("/me/articles", middleware.RequireScope(middleware.ScopeArticlesRead), middleware.JWTAuthMiddleware(cfg), articleHandler.GetMyArticles)
+		api.GET("/users/:id/activity", middleware.RequireScope(middleware.ScopeArticlesRead), middleware.OptionalJWTAuthMiddleware(cfg), activityHandler.GetUserActivity)
+
+		api.GET("/graphql", middleware.RequireScope(middleware.ScopeArticlesRead), middleware.OptionalJWTAuthMiddleware(cfg), graphqlHandler.Serve)
+		api.POST("/graphql", middleware.RequireScope(middleware.ScopeArticlesRead), middleware.OptionalJWTAuthMiddleware(cfg), graphqlHandler.Serve)
+		api.GET("/graphql/playground", graphqlHandler.Playground)
+
+		notifications := api.Group("/notifications", middleware.ResourceScopes("notifications"), middleware.JWTAuthMiddleware(cfg))
+		{
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		moderationGroup := api.Group("/moderation", middleware.ResourceScopes("moderation"), middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleModerator, auth.RoleAdmin))
+		{
+			moderationGroup.GET("/queue", moderationHandler.GetQueue)
+			moderationGroup.POST("/:id/approve", moderationHandler.ApproveArticle)
+			moderationGroup.POST("/:id/reject", moderationHandler.RejectArticle)
+			moderationGroup.GET("/reports", reportHandler.ListReports)
+			moderationGroup.POST("/reports/:id/resolve", reportHandler.ResolveReport)
+		}
+
+		adminGroup := api.Group("/admin", middleware.IPFilterMiddleware(adminIPs), middleware.ResourceScopes("admin"), middleware.JWTAuthMiddleware(cfg), middleware.RequireRole(auth.RoleAdmin))
+		{
+			adminGroup.GET("/maintenance", adminHandler.GetMaintenance)
+			adminGroup.PUT("/maintenance", adminHandler.SetMaintenance)
+			adminGroup.GET("/cache", adminHandler.GetCacheStats)
+			adminGroup.DELETE("/cache", adminHandler.FlushCache)
+			adminGroup.GET("/stats", adminHandler.GetStats)
+			adminGroup.GET("/articles", adminHandler.ListArticles)
+			adminGroup.DELETE("/articles/:id", adminHandler.DeleteArticle)
+			adminGroup.PUT("/articles/:id/owner", adminHandler.ReassignArticle)
 		}
 	}
 
@@ -85,7 +516,7 @@ func main() {
 
 	srv := &http.Server{
 		Addr:         addr,
-		Handler:      router,
+		Handler:      middleware.HeadHandler(cfg, middleware.NegotiateAPIVersion(router, "v1", "v1")),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -98,17 +529,87 @@ func main() {
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		grpcAddr := fmt.Sprintf(":%d", cfg.GRPC.Port)
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatal().Err(err).Str("address", grpcAddr).Msg("Failed to listen for gRPC")
+		}
+		grpcServer = grpcserver.NewServer(cfg, verifier, articleService)
+		go func() {
+			log.Info().Str("address", grpcAddr).Bool("reflection", cfg.GRPC.Reflection).Msg("gRPC server starting")
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatal().Err(err).Msg("Failed to start gRPC server")
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info().Msg("Shutting down server...")
+	sig := <-quit
+
+	shutdownStartedAt := time.Now()
+	inFlightAtSignal := inFlight.Current()
+	log.Info().
+		Str("signal", sig.String()).
+		Int64("in_flight", inFlightAtSignal).
+		Msg("Shutting down server...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Err(err).Msg("Server forced to shutdown")
+		log.Fatal().
+			Err(err).
+			Dur("drain_duration", time.Since(shutdownStartedAt)).
+			Int64("in_flight", inFlight.Current()).
+			Msg("Server forced to shutdown")
+	}
+	if grpcServer != nil {
+		if err := grpcserver.Shutdown(ctx, grpcServer); err != nil {
+			log.Error().Err(err).Msg("gRPC server forced to stop")
+		}
+	}
+
+	stopBackground()
+	<-outboxDone
+	if outboxPublisher != nil {
+		if err := outboxPublisher.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close event publisher")
+		}
 	}
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Events.DrainTimeout)
+	defer cancelDrain()
+	if err := eventBus.Close(drainCtx); err != nil {
+		log.Error().Err(err).Msg("Failed to drain event bus")
+	}
+	if emailQueue != nil {
+		if err := emailQueue.Close(drainCtx); err != nil {
+			log.Error().Err(err).Msg("Failed to drain email queue")
+		}
+	}
+	if err := viewCounter.Flush(context.Background()); err != nil {
+		log.Error().Err(err).Msg("Failed to flush article views")
+	}
+	thumbnailer.Wait()
+	if readCache != nil {
+		if err := readCache.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close cache")
+		}
+	}
+	if err := rateLimiters.Close(); err != nil {
+		log.Error().Err(err).Msg("Failed to close rate limiter")
+	}
+	if cfg.Sentry.DSN != "" && !tracking.Flush(2*time.Second) {
+		log.Warn().Msg("Timed out flushing Sentry events")
+	}
+
+	drainDuration := time.Since(shutdownStartedAt)
+	log.Info().
+		Dur("drain_duration", drainDuration).
+		Int64("drained_requests", inFlightAtSignal).
+		Msg("HTTP server drained")
 
 	sqlDB, err := db.DB()
 	if err == nil {
@@ -119,5 +620,12 @@ func main() {
 		}
 	}
 
-	log.Info().Msg("Server exited gracefully")
+	log.Info().
+		Str("signal", sig.String()).
+		Dur("uptime", time.Since(startedAt)).
+		Dur("drain_duration", drainDuration).
+		Dur("shutdown_duration", time.Since(shutdownStartedAt)).
+		Int64("in_flight_at_signal", inFlightAtSignal).
+		Int64("requests_served", inFlight.Total()).
+		Msg("Server exited gracefully")
 }
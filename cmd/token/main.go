@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"strings"
 
 	"content-service/internal/shared/config"
 	"content-service/internal/shared/logging"
@@ -13,6 +14,9 @@ import (
 
 func main() {
 	var userID = flag.Uint("user-id", 1, "User ID for the token")
+	var role = flag.String("role", "", "Role for the token (e.g. admin)")
+	var refresh = flag.Bool("refresh", false, "Print a refresh token for POST /api/v1/auth/refresh instead of an access token")
+	var scope = flag.String("scope", "", "Space-separated scopes for the token (e.g. \"articles:read\"); empty grants every scope")
 	flag.Parse()
 
 	cfg, err := config.LoadConfig()
@@ -30,7 +34,15 @@ func main() {
 		log.Fatal().Msg("JWT_SECRET is not set")
 	}
 
-	token, err := middleware.CreateTestToken(*userID, cfg.JWT.Secret)
+	var token string
+	if *refresh {
+		if cfg.JWT.RefreshSecret == "" {
+			log.Fatal().Msg("JWT_REFRESH_SECRET is not set")
+		}
+		token, err = middleware.IssueRefreshToken(cfg, *userID, *role, strings.Join(strings.Fields(*scope), " "))
+	} else {
+		token, err = middleware.CreateTestToken(*userID, *role, cfg.JWT.Secret, strings.Fields(*scope)...)
+	}
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create token")
 	}
@@ -0,0 +1,9 @@
+package activity
+
+import "content-service/internal/article"
+
+var Events = []string{
+	article.EventArticleCreated,
+	article.EventArticleUpdated,
+	article.EventArticlePublished,
+}
@@ -0,0 +1,69 @@
+package activity
+
+import (
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+func parsePagination(c *gin.Context) (page, limit int) {
+	page = article.DefaultPage
+	limit = article.DefaultLimit
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = min(l, article.MaxLimit)
+	}
+	return page, limit
+}
+
+func (handler *Handler) GetUserActivity(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	page, limit := parsePagination(c)
+
+	entries, total, err := handler.service.ListUserActivity(c.Request.Context(), getViewer(c), uint(userID), page, limit)
+	if err != nil {
+		log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+		tracking.CaptureError(c.Request.Context(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": entries,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		},
+	})
+}
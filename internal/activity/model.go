@@ -0,0 +1,18 @@
+package activity
+
+import "time"
+
+type Entry struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;index:idx_activity_entries_user_occurred,priority:1" json:"user_id"`
+	ArticleID  uint      `gorm:"not null;index" json:"article_id"`
+	Event      string    `gorm:"type:varchar(64);not null" json:"event"`
+	Title      string    `gorm:"type:varchar(255);not null" json:"title"`
+	Slug       string    `gorm:"type:varchar(255);not null;default:''" json:"slug"`
+	Status     string    `gorm:"type:varchar(20);not null" json:"status"`
+	OccurredAt time.Time `gorm:"not null;index:idx_activity_entries_user_occurred,priority:2,sort:desc" json:"occurred_at"`
+}
+
+func (Entry) TableName() string {
+	return "activity_entries"
+}
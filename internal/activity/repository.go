@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	ListByUser(ctx context.Context, userID uint, public bool, page, limit int) ([]Entry, int64, error)
+}
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &activityRepository{db: db}
+}
+
+func (repo *activityRepository) Create(ctx context.Context, entry *Entry) error {
+	if err := database.Conn(ctx, repo.db).Create(entry).Error; err != nil {
+		return fmt.Errorf("repo: failed to record %s of article %d: %w", entry.Event, entry.ArticleID, err)
+	}
+	return nil
+}
+
+func (repo *activityRepository) ListByUser(ctx context.Context, userID uint, public bool, page, limit int) ([]Entry, int64, error) {
+	query := database.Conn(ctx, repo.db).Model(&Entry{}).
+		Joins("JOIN articles a ON a.id = activity_entries.article_id AND a.deleted_at IS NULL").
+		Where("activity_entries.user_id = ?", userID)
+	if public {
+		query = query.Where("activity_entries.status = ? AND a.status = ?", article.StatusPublished, article.StatusPublished)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count activity of user %d: %w", userID, err)
+	}
+
+	var entries []Entry
+	err := query.
+		Select("activity_entries.*").
+		Order("activity_entries.occurred_at DESC, activity_entries.id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to list activity of user %d: %w", userID, err)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,58 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/events"
+)
+
+type Service interface {
+	ListUserActivity(ctx context.Context, viewer article.Viewer, userID uint, page, limit int) ([]Entry, int64, error)
+	HandleArticleEvent(event events.Event) error
+}
+
+type activityService struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &activityService{repo: repo}
+}
+
+func (svc *activityService) ListUserActivity(ctx context.Context, viewer article.Viewer, userID uint, page, limit int) ([]Entry, int64, error) {
+	public := !viewer.IsAdmin() && viewer.UserID != userID
+	entries, total, err := svc.repo.ListByUser(ctx, userID, public, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list activity: %w", err)
+	}
+	return entries, total, nil
+}
+
+func (svc *activityService) HandleArticleEvent(event events.Event) error {
+	a, ok := event.Payload.(article.Article)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for event %s", event.Payload, event.Name)
+	}
+
+	userID := event.ActorID
+	if userID == 0 {
+		userID = a.UserID
+	}
+
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return svc.repo.Create(context.Background(), &Entry{
+		UserID:     userID,
+		ArticleID:  a.ID,
+		Event:      event.Name,
+		Title:      a.Title,
+		Slug:       a.Slug,
+		Status:     a.Status,
+		OccurredAt: occurredAt.UTC(),
+	})
+}
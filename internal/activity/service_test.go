@@ -0,0 +1,114 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/events"
+)
+
+type mockRepository struct {
+	entries    []Entry
+	lastPublic bool
+}
+
+func (m *mockRepository) Create(ctx context.Context, entry *Entry) error {
+	entry.ID = uint(len(m.entries) + 1)
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *mockRepository) ListByUser(ctx context.Context, userID uint, public bool, page, limit int) ([]Entry, int64, error) {
+	m.lastPublic = public
+	var list []Entry
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		entry := m.entries[i]
+		if entry.UserID == userID && (!public || entry.Status == article.StatusPublished) {
+			list = append(list, entry)
+		}
+	}
+	total := int64(len(list))
+	offset := (page - 1) * limit
+	if offset >= len(list) {
+		return nil, total, nil
+	}
+	return list[offset:min(offset+limit, len(list))], total, nil
+}
+
+func TestHandleArticleEvent(t *testing.T) {
+	repo := &mockRepository{}
+	service := NewService(repo)
+	occurredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	payload := article.Article{ID: 4, UserID: 9, Title: "Hello", Slug: "hello", Status: article.StatusDraft}
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleCreated, Payload: payload, OccurredAt: occurredAt}); err != nil {
+		t.Fatalf("HandleArticleEvent() unexpected error: %v", err)
+	}
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleUpdated, Payload: payload, ActorID: 12, OccurredAt: occurredAt}); err != nil {
+		t.Fatalf("HandleArticleEvent() unexpected error: %v", err)
+	}
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleCreated, Payload: &payload}); err == nil {
+		t.Error("Expected an error for an unexpected payload")
+	}
+
+	if len(repo.entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(repo.entries))
+	}
+	entry := repo.entries[0]
+	if entry.UserID != 9 || entry.ArticleID != 4 || entry.Event != article.EventArticleCreated || entry.Title != "Hello" || entry.Status != article.StatusDraft || !entry.OccurredAt.Equal(occurredAt) {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if entry := repo.entries[1]; entry.UserID != 12 || entry.ArticleID != 4 {
+		t.Errorf("Expected the update recorded for the acting user 12, got %+v", entry)
+	}
+}
+
+func TestListUserActivity(t *testing.T) {
+	repo := &mockRepository{}
+	service := NewService(repo)
+	for _, e := range []struct {
+		event  string
+		status string
+	}{
+		{article.EventArticleCreated, article.StatusDraft},
+		{article.EventArticleUpdated, article.StatusPublished},
+		{article.EventArticlePublished, article.StatusPublished},
+	} {
+		payload := article.Article{ID: 1, UserID: 5, Title: "Post", Status: e.status}
+		if err := service.HandleArticleEvent(events.Event{Name: e.event, Payload: payload, OccurredAt: time.Now()}); err != nil {
+			t.Fatalf("HandleArticleEvent() unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		viewer     article.Viewer
+		wantPublic bool
+		wantTotal  int64
+	}{
+		{name: "Anonymous viewer", viewer: article.Viewer{}, wantPublic: true, wantTotal: 2},
+		{name: "Other user", viewer: article.Viewer{UserID: 6}, wantPublic: true, wantTotal: 2},
+		{name: "Owner", viewer: article.Viewer{UserID: 5}, wantTotal: 3},
+		{name: "Admin", viewer: article.Viewer{UserID: 1, Role: auth.RoleAdmin}, wantTotal: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total, err := service.ListUserActivity(context.Background(), tt.viewer, 5, 1, 2)
+			if err != nil {
+				t.Fatalf("ListUserActivity() unexpected error: %v", err)
+			}
+			if repo.lastPublic != tt.wantPublic {
+				t.Errorf("Expected public = %v, got %v", tt.wantPublic, repo.lastPublic)
+			}
+			if total != tt.wantTotal || len(entries) != 2 {
+				t.Errorf("Expected total %d and a page of 2, got %d and %d", tt.wantTotal, total, len(entries))
+			}
+			if entries[0].Event != article.EventArticlePublished {
+				t.Errorf("Expected newest entry first, got %s", entries[0].Event)
+			}
+		})
+	}
+}
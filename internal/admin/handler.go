@@ -0,0 +1,334 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/buildinfo"
+	"content-service/internal/shared/cache"
+	"content-service/internal/shared/database"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type ArticleService interface {
+	ListAllArticles(ctx context.Context, filter article.ListFilter, page, limit int) ([]article.Article, int64, error)
+	DeleteAnyArticle(ctx context.Context, actorID, id uint) error
+	ReassignArticle(ctx context.Context, actorID, id, userID uint) (*article.Article, error)
+	CountArticles(ctx context.Context) (map[string]int64, error)
+}
+
+type Handler struct {
+	maintenance *middleware.Maintenance
+	cache       cache.Cache
+	metrics     *cache.Metrics
+	articles    ArticleService
+	inFlight    *middleware.InFlightCounter
+	slow        *middleware.SlowRequestCounter
+	db          *sql.DB
+	startedAt   time.Time
+}
+
+type Option func(*Handler)
+
+func WithArticles(service ArticleService) Option {
+	return func(handler *Handler) {
+		handler.articles = service
+	}
+}
+
+func WithInFlight(counter *middleware.InFlightCounter) Option {
+	return func(handler *Handler) {
+		handler.inFlight = counter
+	}
+}
+
+func WithSlowRequests(counter *middleware.SlowRequestCounter) Option {
+	return func(handler *Handler) {
+		handler.slow = counter
+	}
+}
+
+func WithDatabase(db *sql.DB) Option {
+	return func(handler *Handler) {
+		handler.db = db
+	}
+}
+
+func WithStartedAt(startedAt time.Time) Option {
+	return func(handler *Handler) {
+		handler.startedAt = startedAt
+	}
+}
+
+func NewHandler(maintenance *middleware.Maintenance, readCache cache.Cache, cacheMetrics *cache.Metrics, opts ...Option) *Handler {
+	handler := &Handler{maintenance: maintenance, cache: readCache, metrics: cacheMetrics, startedAt: time.Now()}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	return handler
+}
+
+type SetMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+type ReassignRequest struct {
+	UserID uint `json:"user_id" validate:"required"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func parsePagination(c *gin.Context) (page, limit int) {
+	page = article.DefaultPage
+	limit = article.DefaultLimit
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = min(l, article.MaxLimit)
+	}
+	return page, limit
+}
+
+var errorToStatus = map[error]int{
+	article.ErrNotFound:        http.StatusNotFound,
+	article.ErrVersionConflict: http.StatusConflict,
+	article.ErrValidation:      http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"enabled":     handler.maintenance.Enabled(),
+		"retry_after": int(handler.maintenance.RetryAfter().Seconds()),
+	})
+}
+
+func (handler *Handler) SetMaintenance(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Enabled == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "enabled is required"})
+		return
+	}
+
+	handler.maintenance.SetEnabled(*req.Enabled)
+
+	userID, _ := middleware.GetUserID(c)
+	log.Ctx(c.Request.Context()).Warn().
+		Bool("enabled", *req.Enabled).
+		Uint("admin_id", userID).
+		Msg("Maintenance mode changed")
+
+	handler.GetMaintenance(c)
+}
+
+func (handler *Handler) GetCacheStats(c *gin.Context) {
+	if handler.metrics == nil {
+		c.JSON(http.StatusOK, cache.Stats{Backend: cache.BackendNone})
+		return
+	}
+	c.JSON(http.StatusOK, handler.metrics.Snapshot())
+}
+
+func (handler *Handler) FlushCache(c *gin.Context) {
+	flusher, ok := handler.cache.(cache.Flusher)
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "the configured cache backend cannot be flushed"})
+		return
+	}
+
+	flushed, err := flusher.Flush(c.Request.Context())
+	if err != nil {
+		log.Ctx(c.Request.Context()).Error().Err(err).Msg("Failed to flush cache")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to flush cache"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	log.Ctx(c.Request.Context()).Warn().
+		Int("flushed", flushed).
+		Uint("admin_id", userID).
+		Msg("Cache flushed")
+
+	c.JSON(http.StatusOK, gin.H{"flushed": flushed})
+}
+
+func (handler *Handler) ListArticles(c *gin.Context) {
+	filter := article.ListFilter{
+		Sort:  c.Query("sort"),
+		Order: c.Query("order"),
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Statuses = strings.Split(status, ",")
+	}
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || userID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+			return
+		}
+		owner := uint(userID)
+		filter.UserID = &owner
+	}
+	page, limit := parsePagination(c)
+
+	articles, total, err := handler.articles.ListAllArticles(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": articles,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		},
+	})
+}
+
+func (handler *Handler) DeleteArticle(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	if err := handler.articles.DeleteAnyArticle(c.Request.Context(), userID, id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	log.Ctx(c.Request.Context()).Warn().
+		Uint("article_id", id).
+		Uint("admin_id", userID).
+		Msg("Article deleted by admin")
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ReassignArticle(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req ReassignRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.UserID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	reassigned, err := handler.articles.ReassignArticle(c.Request.Context(), userID, id, req.UserID)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	log.Ctx(c.Request.Context()).Warn().
+		Uint("article_id", id).
+		Uint("owner_id", req.UserID).
+		Uint("admin_id", userID).
+		Msg("Article ownership reassigned")
+
+	c.JSON(http.StatusOK, reassigned)
+}
+
+func (handler *Handler) GetStats(c *gin.Context) {
+	stats := gin.H{}
+
+	if handler.articles != nil {
+		counts, err := handler.articles.CountArticles(c.Request.Context())
+		if err != nil {
+			handler.handleError(c, err)
+			return
+		}
+		stats["articles"] = counts
+	}
+
+	if handler.inFlight != nil {
+		requests := gin.H{
+			"in_flight": handler.inFlight.Current(),
+			"total":     handler.inFlight.Total(),
+		}
+		if handler.slow != nil {
+			requests["slow"] = handler.slow.Total()
+		}
+		stats["requests"] = requests
+	}
+
+	if handler.metrics != nil {
+		stats["cache"] = handler.metrics.Snapshot()
+	} else {
+		stats["cache"] = cache.Stats{Backend: cache.BackendNone}
+	}
+
+	if handler.db != nil {
+		pool := handler.db.Stats()
+		queries := database.QueryStats()
+		var saturation float64
+		if pool.MaxOpenConnections > 0 {
+			saturation = float64(pool.InUse) / float64(pool.MaxOpenConnections)
+		}
+		stats["database"] = gin.H{
+			"max_open_connections": pool.MaxOpenConnections,
+			"saturation":           saturation,
+			"open_connections":     pool.OpenConnections,
+			"in_use":               pool.InUse,
+			"idle":                 pool.Idle,
+			"wait_count":           pool.WaitCount,
+			"wait_duration_ms":     pool.WaitDuration.Milliseconds(),
+			"slow_queries":         queries.Slow,
+			"failed_queries":       queries.Failed,
+		}
+	}
+
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+	stats["runtime"] = gin.H{
+		"version":        buildinfo.APIVersion(),
+		"go_version":     runtime.Version(),
+		"goroutines":     runtime.NumGoroutine(),
+		"heap_alloc":     memory.HeapAlloc,
+		"sys":            memory.Sys,
+		"num_gc":         memory.NumGC,
+		"uptime_seconds": int64(time.Since(handler.startedAt).Seconds()),
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,308 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/cache"
+	"content-service/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetMaintenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	maintenance := middleware.NewMaintenance(false, time.Minute)
+	handler := NewHandler(maintenance, nil, nil)
+
+	router := gin.New()
+	api := router.Group("/api", middleware.MaintenanceMiddleware(maintenance, "/api/admin"))
+	api.GET("/articles", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	api.GET("/admin/maintenance", handler.GetMaintenance)
+	api.PUT("/admin/maintenance", handler.SetMaintenance)
+
+	toggle := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/maintenance", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	articlesStatus := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/articles", nil))
+		return w.Code
+	}
+
+	w := toggle(`{"enabled": true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !resp.Enabled {
+		t.Errorf("Expected maintenance to be reported as enabled")
+	}
+	if got := articlesStatus(); got != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d while in maintenance, got %d", http.StatusServiceUnavailable, got)
+	}
+
+	if w := toggle(`{"enabled": false}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := articlesStatus(); got != http.StatusOK {
+		t.Errorf("Expected status %d after maintenance ends, got %d", http.StatusOK, got)
+	}
+
+	if w := toggle(`{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for missing enabled, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetCacheStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	metrics := cache.NewMetrics(cache.BackendMemory)
+	metrics.Hit()
+	metrics.Miss()
+
+	tests := []struct {
+		name        string
+		metrics     *cache.Metrics
+		wantBackend string
+		wantHits    int64
+	}{
+		{name: "Cache disabled", wantBackend: cache.BackendNone},
+		{name: "Cache enabled", metrics: metrics, wantBackend: cache.BackendMemory, wantHits: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/api/admin/cache", NewHandler(middleware.NewMaintenance(false, time.Minute), nil, tt.metrics).GetCacheStats)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/cache", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+			}
+			var stats cache.Stats
+			if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if stats.Backend != tt.wantBackend || stats.Hits != tt.wantHits {
+				t.Errorf("Unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestFlushCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lru := cache.NewLRUCache(10)
+	_ = lru.Set(context.Background(), "articles:id:1", []byte("cached"), time.Minute)
+	_ = lru.Set(context.Background(), "articles:id:2", []byte("cached"), time.Minute)
+
+	tests := []struct {
+		name        string
+		cache       cache.Cache
+		wantStatus  int
+		wantFlushed int
+	}{
+		{name: "LRU cache", cache: lru, wantStatus: http.StatusOK, wantFlushed: 2},
+		{name: "Cache disabled", wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.DELETE("/api/admin/cache", NewHandler(middleware.NewMaintenance(false, time.Minute), tt.cache, nil).FlushCache)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/admin/cache", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Flushed int `json:"flushed"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Flushed != tt.wantFlushed {
+				t.Errorf("Expected %d flushed entries, got %s (%v)", tt.wantFlushed, w.Body.String(), err)
+			}
+			if lru.Len() != 0 {
+				t.Errorf("Expected an empty cache, got %d entries", lru.Len())
+			}
+		})
+	}
+}
+
+type mockArticleService struct {
+	articles map[uint]*article.Article
+	filter   article.ListFilter
+	actors   []uint
+}
+
+func (m *mockArticleService) ListAllArticles(ctx context.Context, filter article.ListFilter, page, limit int) ([]article.Article, int64, error) {
+	m.filter = filter
+	var articles []article.Article
+	for _, item := range m.articles {
+		if filter.UserID == nil || item.UserID == *filter.UserID {
+			articles = append(articles, *item)
+		}
+	}
+	return articles, int64(len(articles)), nil
+}
+
+func (m *mockArticleService) DeleteAnyArticle(ctx context.Context, actorID, id uint) error {
+	if _, ok := m.articles[id]; !ok {
+		return article.ErrNotFound
+	}
+	m.actors = append(m.actors, actorID)
+	delete(m.articles, id)
+	return nil
+}
+
+func (m *mockArticleService) ReassignArticle(ctx context.Context, actorID, id, userID uint) (*article.Article, error) {
+	item, ok := m.articles[id]
+	if !ok {
+		return nil, article.ErrNotFound
+	}
+	m.actors = append(m.actors, actorID)
+	item.UserID = userID
+	return item, nil
+}
+
+func (m *mockArticleService) CountArticles(ctx context.Context) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, item := range m.articles {
+		counts[item.Status]++
+	}
+	return counts, nil
+}
+
+func TestAdminArticles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service := &mockArticleService{articles: map[uint]*article.Article{
+		1: {ID: 1, UserID: 1, Status: article.StatusDraft},
+		2: {ID: 2, UserID: 2, Status: article.StatusPublished},
+	}}
+	handler := NewHandler(middleware.NewMaintenance(false, time.Minute), nil, nil, WithArticles(service))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(7))
+	})
+	router.GET("/api/admin/articles", handler.ListArticles)
+	router.DELETE("/api/admin/articles/:id", handler.DeleteArticle)
+	router.PUT("/api/admin/articles/:id/owner", handler.ReassignArticle)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{name: "List all", method: http.MethodGet, path: "/api/admin/articles?status=draft,published", wantStatus: http.StatusOK},
+		{name: "List by owner", method: http.MethodGet, path: "/api/admin/articles?user_id=2", wantStatus: http.StatusOK},
+		{name: "Invalid owner", method: http.MethodGet, path: "/api/admin/articles?user_id=abc", wantStatus: http.StatusBadRequest},
+		{name: "Reassign", method: http.MethodPut, path: "/api/admin/articles/1/owner", body: `{"user_id": 5}`, wantStatus: http.StatusOK},
+		{name: "Reassign without user", method: http.MethodPut, path: "/api/admin/articles/1/owner", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "Reassign missing article", method: http.MethodPut, path: "/api/admin/articles/9/owner", body: `{"user_id": 5}`, wantStatus: http.StatusNotFound},
+		{name: "Delete any article", method: http.MethodDelete, path: "/api/admin/articles/2", wantStatus: http.StatusNoContent},
+		{name: "Delete missing article", method: http.MethodDelete, path: "/api/admin/articles/2", wantStatus: http.StatusNotFound},
+		{name: "Invalid ID", method: http.MethodDelete, path: "/api/admin/articles/abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if len(service.filter.Statuses) != 0 || service.filter.UserID == nil || *service.filter.UserID != 2 {
+		t.Errorf("Expected the last list filter to carry user_id 2, got %+v", service.filter)
+	}
+	if service.articles[1].UserID != 5 {
+		t.Errorf("Expected article 1 to be owned by user 5, got %d", service.articles[1].UserID)
+	}
+	if !slices.Equal(service.actors, []uint{7, 7}) {
+		t.Errorf("Expected the reassign and delete to be made by admin 7, got actors %v", service.actors)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service := &mockArticleService{articles: map[uint]*article.Article{
+		1: {ID: 1, Status: article.StatusDraft},
+		2: {ID: 2, Status: article.StatusPublished},
+		3: {ID: 3, Status: article.StatusPublished},
+	}}
+	counter := middleware.NewInFlightCounter()
+	slow := middleware.NewSlowRequestCounter()
+	handler := NewHandler(middleware.NewMaintenance(false, time.Minute), nil, nil, WithArticles(service), WithInFlight(counter), WithSlowRequests(slow))
+
+	router := gin.New()
+	router.Use(middleware.InFlightMiddleware(counter))
+	router.GET("/api/admin/stats", handler.GetStats)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp struct {
+		Articles map[string]int64 `json:"articles"`
+		Requests struct {
+			InFlight int64  `json:"in_flight"`
+			Total    int64  `json:"total"`
+			Slow     *int64 `json:"slow"`
+		} `json:"requests"`
+		Cache   cache.Stats `json:"cache"`
+		Runtime struct {
+			Goroutines int `json:"goroutines"`
+		} `json:"runtime"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Articles[article.StatusPublished] != 2 || resp.Articles[article.StatusDraft] != 1 {
+		t.Errorf("Unexpected article counts: %v", resp.Articles)
+	}
+	if resp.Requests.InFlight != 1 || resp.Requests.Total != 1 {
+		t.Errorf("Expected the stats request to be counted, got %+v", resp.Requests)
+	}
+	if resp.Requests.Slow == nil || *resp.Requests.Slow != 0 {
+		t.Errorf("Expected no slow requests to be reported, got %v", resp.Requests.Slow)
+	}
+	if resp.Cache.Backend != cache.BackendNone {
+		t.Errorf("Expected cache backend %q, got %q", cache.BackendNone, resp.Cache.Backend)
+	}
+	if resp.Runtime.Goroutines == 0 {
+		t.Errorf("Expected a goroutine count in runtime stats")
+	}
+}
@@ -0,0 +1,215 @@
+package article
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const frontMatterDelimiter = "---"
+
+type BundleArticle struct {
+	Title         string     `json:"title" yaml:"title"`
+	Slug          string     `json:"slug,omitempty" yaml:"slug,omitempty"`
+	Status        string     `json:"status,omitempty" yaml:"status,omitempty"`
+	Language      string     `json:"language,omitempty" yaml:"language,omitempty"`
+	Tags          []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	CategoryID    *uint      `json:"category_id,omitempty" yaml:"category_id,omitempty"`
+	Excerpt       string     `json:"excerpt,omitempty" yaml:"excerpt,omitempty"`
+	CoverImageURL string     `json:"cover_image_url,omitempty" yaml:"cover_image_url,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+	Content       string     `json:"content" yaml:"-"`
+}
+
+func newBundleArticle(article *Article) *BundleArticle {
+	item := &BundleArticle{
+		Title:      article.Title,
+		Slug:       article.Slug,
+		Status:     article.Status,
+		Language:   article.Language,
+		CategoryID: article.CategoryID,
+		CreatedAt:  &article.CreatedAt,
+		UpdatedAt:  &article.UpdatedAt,
+		Content:    article.Content,
+	}
+	for _, tag := range article.Tags {
+		item.Tags = append(item.Tags, tag.Name)
+	}
+	if article.ExcerptCustom {
+		item.Excerpt = article.Excerpt
+	}
+	if article.CoverMediaID == nil {
+		item.CoverImageURL = article.CoverImageURL
+	}
+	return item
+}
+
+func marshalMarkdown(item *BundleArticle) ([]byte, error) {
+	frontMatter, err := yaml.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode front matter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.Write(frontMatter)
+	buf.WriteString(frontMatterDelimiter + "\n\n")
+	buf.WriteString(item.Content)
+	return buf.Bytes(), nil
+}
+
+func unmarshalMarkdown(data []byte) (*BundleArticle, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	rest, ok := strings.CutPrefix(text, frontMatterDelimiter+"\n")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing front matter", ErrValidation)
+	}
+	frontMatter, body, ok := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+	if !ok {
+		return nil, fmt.Errorf("%w: unterminated front matter", ErrValidation)
+	}
+
+	var item BundleArticle
+	if err := yaml.Unmarshal([]byte(frontMatter), &item); err != nil {
+		return nil, fmt.Errorf("%w: invalid front matter: %v", ErrValidation, err)
+	}
+	item.Content = strings.TrimPrefix(body, "\n")
+	return &item, nil
+}
+
+type bundleWriter interface {
+	ContentType() string
+	Filename() string
+	Write(item *BundleArticle) error
+	Close() error
+}
+
+type jsonBundleWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	written int
+}
+
+func newJSONBundleWriter(w io.Writer) *jsonBundleWriter {
+	return &jsonBundleWriter{w: w, encoder: json.NewEncoder(w)}
+}
+
+func (bundle *jsonBundleWriter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (bundle *jsonBundleWriter) Filename() string {
+	return "articles.json"
+}
+
+func (bundle *jsonBundleWriter) Write(item *BundleArticle) error {
+	separator := ","
+	if bundle.written == 0 {
+		separator = "["
+	}
+	if _, err := io.WriteString(bundle.w, separator); err != nil {
+		return err
+	}
+	bundle.written++
+	return bundle.encoder.Encode(item)
+}
+
+func (bundle *jsonBundleWriter) Close() error {
+	closing := "]\n"
+	if bundle.written == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(bundle.w, closing)
+	return err
+}
+
+type markdownBundleWriter struct {
+	archive *zip.Writer
+}
+
+func newMarkdownBundleWriter(w io.Writer) *markdownBundleWriter {
+	return &markdownBundleWriter{archive: zip.NewWriter(w)}
+}
+
+func (bundle *markdownBundleWriter) ContentType() string {
+	return "application/zip"
+}
+
+func (bundle *markdownBundleWriter) Filename() string {
+	return "articles.zip"
+}
+
+func (bundle *markdownBundleWriter) Write(item *BundleArticle) error {
+	data, err := marshalMarkdown(item)
+	if err != nil {
+		return err
+	}
+	entry, err := bundle.archive.Create(item.Slug + ".md")
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", item.Slug, err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", item.Slug, err)
+	}
+	return nil
+}
+
+func (bundle *markdownBundleWriter) Close() error {
+	return bundle.archive.Close()
+}
+
+func readMarkdownBundle(data []byte) ([]BundleArticle, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid zip archive: %v", ErrValidation, err)
+	}
+
+	var items []BundleArticle
+	remaining := int64(MaxImportBytes)
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || path.Ext(file.Name) != ".md" || strings.HasPrefix(path.Base(file.Name), ".") {
+			continue
+		}
+		if len(items) == MaxImportItems {
+			return nil, fmt.Errorf("%w: import cannot exceed %d articles", ErrValidation, MaxImportItems)
+		}
+
+		data, err := readMarkdownFile(file, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		remaining -= int64(len(data))
+
+		item, err := unmarshalMarkdown(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+func readMarkdownFile(file *zip.File, limit int64) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: archive exceeds %d uncompressed bytes", ErrValidation, MaxImportBytes)
+	}
+	return data, nil
+}
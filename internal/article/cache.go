@@ -0,0 +1,328 @@
+package article
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"content-service/internal/shared/cache"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+
+	"github.com/rs/zerolog/log"
+)
+
+type listPage struct {
+	Articles []Article
+	Total    int64
+}
+
+type Invalidator interface {
+	Invalidate(ctx context.Context, ids ...uint)
+}
+
+type cachedRepository struct {
+	Repository
+	cache    cache.Cache
+	metrics  *cache.Metrics
+	cfg      config.CacheConfig
+	readOnly bool
+}
+
+func NewCachedRepository(repo Repository, store cache.Cache, metrics *cache.Metrics, cfg config.CacheConfig) Repository {
+	return &cachedRepository{Repository: repo, cache: store, metrics: metrics, cfg: cfg}
+}
+
+func (repo *cachedRepository) Uncached() Repository {
+	return repo.Repository
+}
+
+func (repo *cachedRepository) Replica() Repository {
+	replica, ok := repo.Repository.(interface{ Replica() Repository })
+	if !ok {
+		return repo
+	}
+	return &cachedRepository{Repository: replica.Replica(), cache: repo.cache, metrics: repo.metrics, cfg: repo.cfg, readOnly: true}
+}
+
+func articleKey(id uint) string {
+	return fmt.Sprintf("%s%d", CacheArticlePrefix, id)
+}
+
+func (repo *cachedRepository) load(ctx context.Context, key string, dst any) bool {
+	data, ok, err := repo.cache.Get(ctx, key)
+	if err != nil {
+		repo.metrics.Error()
+		log.Warn().Err(err).Str("key", key).Msg("Failed to read from cache")
+		return false
+	}
+	if !ok {
+		repo.metrics.Miss()
+		return false
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(dst); err != nil {
+		repo.metrics.Error()
+		log.Warn().Err(err).Str("key", key).Msg("Failed to decode cached value")
+		return false
+	}
+	repo.metrics.Hit()
+	return true
+}
+
+func (repo *cachedRepository) save(ctx context.Context, key string, value any, ttl time.Duration) {
+	if repo.readOnly {
+		return
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		repo.metrics.Error()
+		log.Warn().Err(err).Str("key", key).Msg("Failed to encode value for cache")
+		return
+	}
+	if err := repo.cache.Set(ctx, key, buf.Bytes(), ttl); err != nil {
+		repo.metrics.Error()
+		log.Warn().Err(err).Str("key", key).Msg("Failed to write to cache")
+	}
+}
+
+func (repo *cachedRepository) listKey(ctx context.Context, kind string, args ...any) (string, bool) {
+	version, ok, err := repo.cache.Get(ctx, CacheListVersionKey)
+	if err != nil {
+		repo.metrics.Error()
+		log.Warn().Err(err).Msg("Failed to read list cache version")
+		return "", false
+	}
+	if !ok {
+		version = []byte("0")
+	}
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s%s:%s:%x", CacheListPrefix, version, kind, sha256.Sum256(encoded)), true
+}
+
+func (repo *cachedRepository) hot(ctx context.Context, filter ListFilter, offset, limit int) bool {
+	return !database.InTx(ctx) && len(filter.IDs) == 0 && limit > 0 && offset < repo.cfg.ListPages*limit
+}
+
+func (repo *cachedRepository) changed(ctx context.Context, err error, ids ...uint) {
+	if err != nil {
+		return
+	}
+	database.AfterCommit(ctx, func() { repo.invalidate(ids...) })
+}
+
+func (repo *cachedRepository) invalidate(ids ...uint) {
+	ctx := context.Background()
+	if len(ids) > 0 {
+		keys := make([]string, 0, len(ids))
+		for _, id := range slices.Compact(slices.Sorted(slices.Values(ids))) {
+			keys = append(keys, articleKey(id))
+		}
+		if err := repo.cache.Delete(ctx, keys...); err != nil {
+			repo.metrics.Error()
+			log.Error().Err(err).Uints("ids", ids).Msg("Failed to invalidate cached articles")
+		}
+	}
+	if _, err := repo.cache.Incr(ctx, CacheListVersionKey); err != nil {
+		repo.metrics.Error()
+		log.Error().Err(err).Msg("Failed to invalidate cached article lists")
+	}
+}
+
+func (repo *cachedRepository) Invalidate(ctx context.Context, ids ...uint) {
+	repo.changed(ctx, nil, ids...)
+}
+
+func (repo *cachedRepository) GetByID(ctx context.Context, id uint) (*Article, error) {
+	if database.InTx(ctx) {
+		return repo.Repository.GetByID(ctx, id)
+	}
+	key := articleKey(id)
+	var article Article
+	if repo.load(ctx, key, &article) {
+		return &article, nil
+	}
+	found, err := repo.Repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	repo.save(ctx, key, found, repo.cfg.TTL)
+	return found, nil
+}
+
+func (repo *cachedRepository) GetAll(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, int64, error) {
+	if !repo.hot(ctx, filter, offset, limit) {
+		return repo.Repository.GetAll(ctx, filter, offset, limit)
+	}
+	key, ok := repo.listKey(ctx, "all", filter, offset, limit)
+	var cached listPage
+	if ok && repo.load(ctx, key, &cached) {
+		return cached.Articles, cached.Total, nil
+	}
+	articles, total, err := repo.Repository.GetAll(ctx, filter, offset, limit)
+	if err == nil && ok {
+		repo.save(ctx, key, listPage{Articles: articles, Total: total}, repo.cfg.ListTTL)
+	}
+	return articles, total, err
+}
+
+func (repo *cachedRepository) List(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, error) {
+	if !repo.hot(ctx, filter, offset, limit) {
+		return repo.Repository.List(ctx, filter, offset, limit)
+	}
+	key, ok := repo.listKey(ctx, "list", filter, offset, limit)
+	var cached listPage
+	if ok && repo.load(ctx, key, &cached) {
+		return cached.Articles, nil
+	}
+	articles, err := repo.Repository.List(ctx, filter, offset, limit)
+	if err == nil && ok {
+		repo.save(ctx, key, listPage{Articles: articles}, repo.cfg.ListTTL)
+	}
+	return articles, err
+}
+
+func (repo *cachedRepository) ListAfter(ctx context.Context, filter ListFilter, after *ListCursor, limit int) ([]Article, error) {
+	if after != nil || !repo.hot(ctx, filter, 0, limit) {
+		return repo.Repository.ListAfter(ctx, filter, after, limit)
+	}
+	key, ok := repo.listKey(ctx, "after", filter, limit)
+	var cached listPage
+	if ok && repo.load(ctx, key, &cached) {
+		return cached.Articles, nil
+	}
+	articles, err := repo.Repository.ListAfter(ctx, filter, after, limit)
+	if err == nil && ok {
+		repo.save(ctx, key, listPage{Articles: articles}, repo.cfg.ListTTL)
+	}
+	return articles, err
+}
+
+func (repo *cachedRepository) Popular(ctx context.Context, offset, limit int) ([]Article, error) {
+	if !repo.hot(ctx, ListFilter{}, offset, limit) {
+		return repo.Repository.Popular(ctx, offset, limit)
+	}
+	key, ok := repo.listKey(ctx, "popular", offset, limit)
+	var cached listPage
+	if ok && repo.load(ctx, key, &cached) {
+		return cached.Articles, nil
+	}
+	articles, err := repo.Repository.Popular(ctx, offset, limit)
+	if err == nil && ok {
+		repo.save(ctx, key, listPage{Articles: articles}, repo.cfg.ListTTL)
+	}
+	return articles, err
+}
+
+func (repo *cachedRepository) Create(ctx context.Context, article *Article) error {
+	err := repo.Repository.Create(ctx, article)
+	repo.changed(ctx, err)
+	return err
+}
+
+func (repo *cachedRepository) CreateBatch(ctx context.Context, articles []Article) error {
+	err := repo.Repository.CreateBatch(ctx, articles)
+	repo.changed(ctx, err)
+	return err
+}
+
+func (repo *cachedRepository) ImportBatch(ctx context.Context, articles []Article) error {
+	err := repo.Repository.ImportBatch(ctx, articles)
+	ids := make([]uint, len(articles))
+	for i := range articles {
+		ids[i] = articles[i].ID
+	}
+	repo.changed(ctx, err, ids...)
+	return err
+}
+
+func (repo *cachedRepository) UpdateSlug(ctx context.Context, id uint, slug string) error {
+	err := repo.Repository.UpdateSlug(ctx, id, slug)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) BumpVersion(ctx context.Context, id uint, expected int) (int, error) {
+	version, err := repo.Repository.BumpVersion(ctx, id, expected)
+	repo.changed(ctx, err, id)
+	return version, err
+}
+
+func (repo *cachedRepository) ReplaceTags(ctx context.Context, id uint, names []string) error {
+	err := repo.Repository.ReplaceTags(ctx, id, names)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) AddReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	count, err := repo.Repository.AddReaction(ctx, articleID, userID)
+	repo.changed(ctx, err, articleID)
+	return count, err
+}
+
+func (repo *cachedRepository) RemoveReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	count, err := repo.Repository.RemoveReaction(ctx, articleID, userID)
+	repo.changed(ctx, err, articleID)
+	return count, err
+}
+
+func (repo *cachedRepository) IncrementViews(ctx context.Context, counts map[uint]int64) error {
+	err := repo.Repository.IncrementViews(ctx, counts)
+	ids := make([]uint, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	repo.changed(ctx, err, ids...)
+	return err
+}
+
+func (repo *cachedRepository) SetFeatured(ctx context.Context, id uint, featuredAt *time.Time) error {
+	err := repo.Repository.SetFeatured(ctx, id, featuredAt)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) Update(ctx context.Context, id uint, updates map[string]interface{}, editorID uint) error {
+	err := repo.Repository.Update(ctx, id, updates, editorID)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) Delete(ctx context.Context, id uint) error {
+	err := repo.Repository.Delete(ctx, id)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) DeleteBatch(ctx context.Context, ids []uint) error {
+	err := repo.Repository.DeleteBatch(ctx, ids)
+	repo.changed(ctx, err, ids...)
+	return err
+}
+
+func (repo *cachedRepository) Restore(ctx context.Context, id uint) error {
+	err := repo.Repository.Restore(ctx, id)
+	repo.changed(ctx, err, id)
+	return err
+}
+
+func (repo *cachedRepository) Purge(ctx context.Context, id uint) ([]string, error) {
+	refs, err := repo.Repository.Purge(ctx, id)
+	repo.changed(ctx, err, id)
+	return refs, err
+}
+
+func (repo *cachedRepository) PurgeDeleted(ctx context.Context, ids []uint) (int64, []string, error) {
+	n, refs, err := repo.Repository.PurgeDeleted(ctx, ids)
+	if n > 0 {
+		repo.changed(ctx, err)
+	}
+	return n, refs, err
+}
@@ -0,0 +1,168 @@
+package article
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/cache"
+	"content-service/internal/shared/config"
+)
+
+type countingRepository struct {
+	Repository
+	gets  int
+	lists int
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id uint) (*Article, error) {
+	r.gets++
+	return r.Repository.GetByID(ctx, id)
+}
+
+func (r *countingRepository) GetAll(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, int64, error) {
+	r.lists++
+	return r.Repository.GetAll(ctx, filter, offset, limit)
+}
+
+func TestCachedRepository(t *testing.T) {
+	repo := &countingRepository{Repository: newMockRepository()}
+	metrics := cache.NewMetrics(cache.BackendMemory)
+	cfg := config.CacheConfig{TTL: time.Minute, ListTTL: time.Minute, ListPages: 1}
+	svc := NewService(NewCachedRepository(repo, cache.NewLRUCache(100), metrics, cfg))
+
+	created, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Cached", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	for range 3 {
+		article, err := svc.GetArticleByID(context.Background(), Viewer{}, created.ID)
+		if err != nil || article.Title != "Cached" || len(article.Tags) != len(created.Tags) {
+			t.Fatalf("Unexpected article %+v (%v)", article, err)
+		}
+	}
+	if repo.gets != 1 {
+		t.Errorf("Expected 1 repository read, got %d", repo.gets)
+	}
+
+	title := "Updated"
+	if _, err := svc.UpdateArticle(context.Background(), 1, created.ID, UpdateInput{Title: &title}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	gets := repo.gets
+	article, err := svc.GetArticleByID(context.Background(), Viewer{}, created.ID)
+	if err != nil || article.Title != "Updated" || repo.gets != gets+1 {
+		t.Errorf("Expected a fresh read after the update, got %q with %d reads (%v)", article.Title, repo.gets-gets, err)
+	}
+
+	gets = repo.gets
+	for range 2 {
+		if _, err := svc.GetArticleByID(context.Background(), Viewer{NoCache: true}, created.ID); err != nil {
+			t.Fatalf("GetArticleByID() unexpected error: %v", err)
+		}
+	}
+	if repo.gets != gets+2 {
+		t.Errorf("Expected the bypass flag to skip the cache, got %d reads", repo.gets-gets)
+	}
+
+	for range 2 {
+		if _, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 1, 10); err != nil {
+			t.Fatalf("GetAllArticles() unexpected error: %v", err)
+		}
+	}
+	if repo.lists != 1 {
+		t.Errorf("Expected the first page to be cached, got %d list queries", repo.lists)
+	}
+	if _, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 2, 10); err != nil {
+		t.Fatalf("GetAllArticles() unexpected error: %v", err)
+	}
+	if repo.lists != 2 {
+		t.Errorf("Expected pages past CACHE_LIST_PAGES to skip the cache, got %d list queries", repo.lists)
+	}
+
+	if err := svc.DeleteArticle(context.Background(), 1, created.ID); err != nil {
+		t.Fatalf("DeleteArticle() unexpected error: %v", err)
+	}
+	articles, total, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 1, 10)
+	if err != nil || total != 0 || len(articles) != 0 || repo.lists != 3 {
+		t.Errorf("Expected the list to be invalidated by the delete, got %d articles after %d queries (%v)", total, repo.lists, err)
+	}
+
+	stats := metrics.Snapshot()
+	if stats.Hits != 5 || stats.Misses != 4 || stats.Errors != 0 {
+		t.Errorf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+type replicatedRepository struct {
+	Repository
+	replica Repository
+}
+
+func (r *replicatedRepository) Replica() Repository {
+	return r.replica
+}
+
+func TestCachedRepositoryReplicaReads(t *testing.T) {
+	primary := newMockRepository()
+	replica := &countingRepository{Repository: primary}
+	cfg := config.CacheConfig{TTL: time.Minute, ListTTL: time.Minute, ListPages: 1}
+	cached := NewCachedRepository(&replicatedRepository{Repository: primary, replica: replica}, cache.NewLRUCache(100), cache.NewMetrics(cache.BackendMemory), cfg)
+	svc := NewService(cached)
+
+	created, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Replicated", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	for range 2 {
+		if _, err := svc.GetArticleByID(context.Background(), Viewer{}, created.ID); err != nil {
+			t.Fatalf("GetArticleByID() unexpected error: %v", err)
+		}
+		if _, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 1, 10); err != nil {
+			t.Fatalf("GetAllArticles() unexpected error: %v", err)
+		}
+	}
+	if replica.gets != 2 || replica.lists != 2 {
+		t.Errorf("Expected replica reads not to fill the cache, got %d reads and %d list queries", replica.gets, replica.lists)
+	}
+
+	if _, err := cached.GetByID(context.Background(), created.ID); err != nil {
+		t.Fatalf("GetByID() unexpected error: %v", err)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, created.ID); err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if replica.gets != 2 {
+		t.Errorf("Expected replica reads to use entries cached from the primary, got %d reads", replica.gets)
+	}
+}
+
+func TestCachedRepositoryExternalWrites(t *testing.T) {
+	repo := newMockRepository()
+	cfg := config.CacheConfig{TTL: time.Minute, ListTTL: time.Minute, ListPages: 1}
+	cached := NewCachedRepository(repo, cache.NewLRUCache(100), cache.NewMetrics(cache.BackendMemory), cfg)
+	svc := NewService(cached)
+
+	created, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Cached", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := cached.GetByID(context.Background(), created.ID); err != nil {
+		t.Fatalf("GetByID() unexpected error: %v", err)
+	}
+
+	if err := cached.IncrementViews(context.Background(), map[uint]int64{created.ID: 5}); err != nil {
+		t.Fatalf("IncrementViews() unexpected error: %v", err)
+	}
+	if article, err := cached.GetByID(context.Background(), created.ID); err != nil || article.Views != 5 {
+		t.Errorf("Expected flushed views to invalidate the cached article, got %d views (%v)", article.Views, err)
+	}
+
+	repo.articles[created.ID].Title = "Changed elsewhere"
+	cached.(Invalidator).Invalidate(context.Background(), created.ID)
+	if article, err := cached.GetByID(context.Background(), created.ID); err != nil || article.Title != "Changed elsewhere" {
+		t.Errorf("Expected Invalidate to drop the cached article, got %q (%v)", article.Title, err)
+	}
+}
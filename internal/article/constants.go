@@ -3,7 +3,93 @@ package article
 const (
 	MaxTitleLength = 255
 
+	DefaultMinContentLength = 1
+
+	StatusDraft         = "draft"
+	StatusPublished     = "published"
+	StatusPendingReview = "pending_review"
+	StatusRejected      = "rejected"
+	StatusDeleted       = "deleted"
+
+	MaxReviewNoteLength = 1000
+
+	MaxLanguageLength = 16
+
+	MaxTags      = 10
+	MaxTagLength = 50
+
+	MaxSearchQueryLength  = 200
+	SearchConfig          = "simple"
+	SearchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+	MaxSlugLength = 100
+	DefaultSlug   = "article"
+
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortTitle     = "title"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+
+	CountExact     = "exact"
+	CountEstimated = "estimated"
+	CountNone      = "none"
+	countCacheSize = 64
+
+	MaxBulkItems = 100
+
+	MaxSitemapURLs = 50000
+
+	EventArticleCreated   = "article.created"
+	EventArticleUpdated   = "article.updated"
+	EventArticleDeleted   = "article.deleted"
+	EventArticlePublished = "article.published"
+
+	CacheArticlePrefix  = "articles:id:"
+	CacheListPrefix     = "articles:list:"
+	CacheListVersionKey = "articles:list:version"
+	CacheBypassValue    = "bypass"
+
+	DefaultBatchSize = 100
+
+	MaxImportItems  = 1000
+	MaxImportBytes  = 32 << 20
+	ExportBatchSize = 100
+
+	ExportFormatJSON     = "json"
+	ExportFormatMarkdown = "markdown"
+
+	MediaTypeMergePatch = "application/merge-patch+json"
+	MediaTypeJSONPatch  = "application/json-patch+json"
+	MaxPatchBytes       = 8 << 20
+
+	AuthorRoleOwner  = "owner"
+	AuthorRoleEditor = "editor"
+
+	ExcerptLength    = 200
+	MaxExcerptLength = 300
+
+	MaxCoverURLLength = 2048
+
+	TrendingAgeOffsetHours = 2
+
 	DefaultPage  = 1
 	DefaultLimit = 10
 	MaxLimit     = 100
+
+	PageLimitAppliedHeader = "X-Page-Limit-Applied"
+	PageLimitMaxHeader     = "X-Page-Limit-Max"
+
+	FieldsParam      = "fields"
+	FieldsContextKey = "article_fields"
+
+	ResourceType = "articles"
+	ResourcePath = "/api/v1/articles/"
+	UserType     = "users"
+	CategoryType = "categories"
+	MediaType    = "media"
+	SeriesType   = "series"
 )
+
+var Statuses = []string{StatusDraft, StatusPublished, StatusPendingReview, StatusRejected}
@@ -0,0 +1,62 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const diffContextLines = 3
+
+func diffRevisions(from, to *Revision) (*RevisionDiff, error) {
+	fromLines := splitLines(from.Content)
+	toLines := splitLines(to.Content)
+
+	unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        fromLines,
+		B:        toLines,
+		FromFile: fmt.Sprintf("revision %d", from.Revision),
+		ToFile:   fmt.Sprintf("revision %d", to.Revision),
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff revisions: %w", err)
+	}
+
+	diff := &RevisionDiff{
+		ArticleID:    from.ArticleID,
+		From:         from.Revision,
+		To:           to.Revision,
+		TitleChanged: from.Title != to.Title,
+		Diff:         unified,
+	}
+
+	for _, op := range difflib.NewMatcher(fromLines, toLines).GetOpCodes() {
+		switch op.Tag {
+		case 'r':
+			diff.Removed += op.I2 - op.I1
+			diff.Added += op.J2 - op.J1
+		case 'd':
+			diff.Removed += op.I2 - op.I1
+		case 'i':
+			diff.Added += op.J2 - op.J1
+		}
+	}
+
+	return diff, nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n"
+	}
+	return lines
+}
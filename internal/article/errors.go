@@ -3,7 +3,14 @@ package article
 import "errors"
 
 var (
-	ErrNotFound   = errors.New("article not found")
-	ErrForbidden  = errors.New("forbidden: you can only manage your own articles")
-	ErrValidation = errors.New("validation error")
+	ErrNotFound         = errors.New("article not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrAuthorNotFound   = errors.New("author not found")
+	ErrNoTranslation    = errors.New("translation not found")
+	ErrNotPending       = errors.New("article is not awaiting review")
+	ErrForbidden        = errors.New("forbidden: you can only manage your own articles")
+	ErrNotModerator     = errors.New("forbidden: only moderators and admins can feature articles")
+	ErrValidation       = errors.New("validation error")
+	ErrModified         = errors.New("article has been modified")
+	ErrVersionConflict  = errors.New("article version conflict")
 )
@@ -0,0 +1,49 @@
+package article
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"content-service/internal/shared/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+type EventLogger struct {
+	sampleRate float64
+	sample     func() float64
+}
+
+func NewEventLogger(sampleRate float64) *EventLogger {
+	return &EventLogger{sampleRate: sampleRate, sample: rand.Float64}
+}
+
+func (logger *EventLogger) HandleArticleEvent(event events.Event) error {
+	a, ok := event.Payload.(Article)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for event %s", event.Payload, event.Name)
+	}
+	if logger.sampleRate < 1 && logger.sample() >= logger.sampleRate {
+		return nil
+	}
+
+	entry := log.Info().
+		Str("event", strings.ReplaceAll(event.Name, ".", "_")).
+		Uint("article_id", a.ID).
+		Uint("user_id", a.UserID).
+		Str("status", a.Status).
+		Int("version", a.Version).
+		Int("word_count", a.WordCount).
+		Int("tags", len(a.Tags)).
+		Time("occurred_at", event.OccurredAt.UTC()).
+		Float64("sample_rate", logger.sampleRate)
+	if a.Language != "" {
+		entry = entry.Str("language", a.Language)
+	}
+	if a.CategoryID != nil {
+		entry = entry.Uint("category_id", *a.CategoryID)
+	}
+	entry.Msg("Domain event")
+	return nil
+}
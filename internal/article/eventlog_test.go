@@ -0,0 +1,71 @@
+package article
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/events"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestEventLogger(t *testing.T) {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	categoryID := uint(4)
+	payload := Article{ID: 7, UserID: 3, Status: StatusPublished, Version: 2, CategoryID: &categoryID, Tags: []Tag{{Name: "go"}}}
+
+	tests := []struct {
+		name       string
+		sampleRate float64
+		sample     float64
+		payload    any
+		wantLogged bool
+		wantErr    bool
+	}{
+		{name: "Logged without sampling", sampleRate: 1, sample: 0.99, payload: payload, wantLogged: true},
+		{name: "Kept by sampling", sampleRate: 0.1, sample: 0.05, payload: payload, wantLogged: true},
+		{name: "Dropped by sampling", sampleRate: 0.1, sample: 0.5, payload: payload},
+		{name: "Disabled", sampleRate: 0, sample: 0, payload: payload},
+		{name: "Unexpected payload", sampleRate: 1, payload: "article", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			logger := NewEventLogger(tt.sampleRate)
+			logger.sample = func() float64 { return tt.sample }
+
+			err := logger.HandleArticleEvent(events.Event{Name: EventArticleCreated, Payload: tt.payload, OccurredAt: time.Now()})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantLogged {
+				if buf.Len() != 0 {
+					t.Errorf("Expected no log line, got %s", buf.String())
+				}
+				return
+			}
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", buf.String())
+			}
+			if entry["event"] != "article_created" {
+				t.Errorf("Expected event %q, got %v", "article_created", entry["event"])
+			}
+			if entry["article_id"] != float64(7) || entry["user_id"] != float64(3) || entry["category_id"] != float64(4) {
+				t.Errorf("Expected article, user and category IDs, got %v", entry)
+			}
+			if entry["sample_rate"] != tt.sampleRate {
+				t.Errorf("Expected sample rate %v, got %v", tt.sampleRate, entry["sample_rate"])
+			}
+		})
+	}
+}
@@ -1,11 +1,23 @@
 package article
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"content-service/internal/shared/jsonapi"
 	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/response"
+	"content-service/internal/shared/textutil"
+	"content-service/internal/shared/tracking"
 	"content-service/internal/shared/validation"
 
 	"github.com/gin-gonic/gin"
@@ -21,13 +33,54 @@ func NewHandler(service Service) *Handler {
 }
 
 type CreateArticleRequest struct {
+	Title      string   `json:"title" validate:"required,min=1,max=255"`
+	Content    string   `json:"content" validate:"required,min=1"`
+	Status     string   `json:"status" validate:"omitempty,oneof=draft published"`
+	Language   string   `json:"language" validate:"omitempty,max=16"`
+	Tags       []string `json:"tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	CategoryID *uint    `json:"category_id" validate:"omitempty,min=1"`
+	Excerpt    string   `json:"excerpt" validate:"omitempty,max=300"`
+
+	CoverImageURL string `json:"cover_image_url" validate:"omitempty,url,max=2048"`
+	CoverMediaID  *uint  `json:"cover_media_id" validate:"omitempty,min=1"`
+}
+
+type TranslationRequest struct {
 	Title   string `json:"title" validate:"required,min=1,max=255"`
 	Content string `json:"content" validate:"required,min=1"`
 }
 
+type AddAuthorRequest struct {
+	UserID uint   `json:"user_id" validate:"required,min=1"`
+	Role   string `json:"role" validate:"omitempty,oneof=owner editor"`
+}
+
+type BulkDeleteRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1,max=100"`
+}
+
 type UpdateArticleRequest struct {
-	Title   *string `json:"title" validate:"omitempty,min=1,max=255"`
-	Content *string `json:"content" validate:"omitempty,min=1"`
+	Title      *string   `json:"title" validate:"omitempty,min=1,max=255"`
+	Content    *string   `json:"content" validate:"omitempty,min=1"`
+	Status     *string   `json:"status" validate:"omitempty,oneof=draft published"`
+	Language   *string   `json:"language" validate:"omitempty,max=16"`
+	Tags       *[]string `json:"tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	CategoryID *uint     `json:"category_id"`
+	Excerpt    *string   `json:"excerpt" validate:"omitempty,max=300"`
+
+	CoverImageURL *string `json:"cover_image_url" validate:"omitempty,url,max=2048"`
+	CoverMediaID  *uint   `json:"cover_media_id"`
+
+	Version *int `json:"version" validate:"omitempty,min=1"`
+}
+
+var articleSchema = &validation.JSONSchema{
+	Schema: validation.JSONSchemaDraft,
+	Title:  "article",
+	Defs: map[string]*validation.JSONSchema{
+		"create": validation.SchemaFor(CreateArticleRequest{}),
+		"update": validation.SchemaFor(UpdateArticleRequest{}),
+	},
 }
 
 func getID(c *gin.Context) (uint, error) {
@@ -38,22 +91,224 @@ func getID(c *gin.Context) (uint, error) {
 	return uint(id), nil
 }
 
+func parseStrict(c *gin.Context) (bool, error) {
+	strictStr := c.Query("strict")
+	if strictStr == "" {
+		return true, nil
+	}
+	strict, err := strconv.ParseBool(strictStr)
+	if err != nil {
+		return false, errors.New("strict must be true or false")
+	}
+	return strict, nil
+}
+
+func parseCount(countStr string) (string, error) {
+	switch countStr {
+	case "", CountExact, CountEstimated, CountNone:
+		return countStr, nil
+	}
+	withCount, err := strconv.ParseBool(countStr)
+	if err != nil {
+		return "", fmt.Errorf("count must be one of: %s, %s, %s", CountExact, CountEstimated, CountNone)
+	}
+	if withCount {
+		return CountExact, nil
+	}
+	return CountNone, nil
+}
+
+func getViewer(c *gin.Context) Viewer {
+	userID, _ := middleware.GetUserID(c)
+	language := textutil.PreferredLanguage(c.GetHeader("Accept-Language"))
+	if locale := c.Query("locale"); locale != "" {
+		language = locale
+	}
+	return Viewer{
+		UserID:   userID,
+		Role:     middleware.GetUserRole(c),
+		Language: language,
+		NoCache:  c.Query("cache") == CacheBypassValue,
+	}
+}
+
+func varyLanguage(c *gin.Context) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+}
+
+func setLocaleHeaders(c *gin.Context, article *Article) {
+	varyLanguage(c)
+	if article.Locale != "" {
+		c.Header("Content-Language", article.Locale)
+	}
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func parseFields(c *gin.Context) ([]string, error) {
+	value, ok := c.GetQuery(FieldsParam)
+	if !ok {
+		value, ok = c.GetQuery(fmt.Sprintf("%s[%s]", FieldsParam, ResourceType))
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := fieldColumns[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q in %s", field, FieldsParam)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	c.Set(FieldsContextKey, fields)
+	return fields, nil
+}
+
+func parseListFilter(c *gin.Context) (ListFilter, error) {
+	var filter ListFilter
+
+	fields, err := parseFields(c)
+	if err != nil {
+		return filter, err
+	}
+	filter.Fields = fields
+
+	for _, param := range []string{"user_id", "author_id"} {
+		userIDStr := c.Query(param)
+		if userIDStr == "" {
+			continue
+		}
+		userID, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil || userID == 0 {
+			return filter, fmt.Errorf("%s must be a positive integer", param)
+		}
+		id := uint(userID)
+		if filter.UserID != nil && *filter.UserID != id {
+			return filter, errors.New("user_id and author_id must match when both are given")
+		}
+		filter.UserID = &id
+	}
+
+	timeParams := []struct {
+		name   string
+		target **time.Time
+	}{
+		{name: "created_after", target: &filter.CreatedAfter},
+		{name: "created_before", target: &filter.CreatedBefore},
+	}
+	for _, param := range timeParams {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := parseTimeParam(value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", param.name)
+		}
+		*param.target = &t
+	}
+
+	filter.Sort = c.Query("sort")
+	filter.Order = c.Query("order")
+	filter.Tag = c.Query("tag")
+
+	if featuredStr := c.Query("featured"); featuredStr != "" {
+		featured, err := strconv.ParseBool(featuredStr)
+		if err != nil {
+			return filter, errors.New("featured must be true or false")
+		}
+		filter.Featured = &featured
+	}
+	if hasCoverStr := c.Query("has_cover"); hasCoverStr != "" {
+		hasCover, err := strconv.ParseBool(hasCoverStr)
+		if err != nil {
+			return filter, errors.New("has_cover must be true or false")
+		}
+		filter.HasCover = &hasCover
+	}
+	if pinnedStr := c.Query("pinned_first"); pinnedStr != "" {
+		pinned, err := strconv.ParseBool(pinnedStr)
+		if err != nil {
+			return filter, errors.New("pinned_first must be true or false")
+		}
+		filter.PinnedFirst = pinned
+	}
+
+	if categoryStr := c.Query("category"); categoryStr != "" {
+		categoryID, err := strconv.ParseUint(categoryStr, 10, 32)
+		if err != nil || categoryID == 0 {
+			return filter, errors.New("category must be a positive integer")
+		}
+		id := uint(categoryID)
+		filter.CategoryID = &id
+	}
+
+	lengthParams := []struct {
+		name   string
+		target **int
+	}{
+		{name: "min_content_length", target: &filter.MinContentLength},
+		{name: "max_content_length", target: &filter.MaxContentLength},
+	}
+	for _, param := range lengthParams {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("%s must be a non-negative integer", param.name)
+		}
+		*param.target = &n
+	}
+	if filter.MinContentLength != nil && filter.MaxContentLength != nil && *filter.MinContentLength > *filter.MaxContentLength {
+		return filter, errors.New("min_content_length cannot exceed max_content_length")
+	}
+
+	return filter, nil
+}
+
 var errorToStatus = map[error]int{
-	ErrNotFound:   http.StatusNotFound,
-	ErrForbidden:  http.StatusForbidden,
-	ErrValidation: http.StatusBadRequest,
+	ErrNotFound:         http.StatusNotFound,
+	ErrRevisionNotFound: http.StatusNotFound,
+	ErrAuthorNotFound:   http.StatusNotFound,
+	ErrNoTranslation:    http.StatusNotFound,
+	ErrNotPending:       http.StatusConflict,
+	ErrForbidden:        http.StatusForbidden,
+	ErrNotModerator:     http.StatusForbidden,
+	ErrValidation:       http.StatusBadRequest,
+	ErrModified:         http.StatusPreconditionFailed,
+	ErrVersionConflict:  http.StatusConflict,
 }
 
-func (handler *Handler) handleError(c *gin.Context, err error) {
+func (handler *Handler) errorStatus(c *gin.Context, err error) (int, string) {
 	for target, status := range errorToStatus {
 		if errors.Is(err, target) {
-			c.JSON(status, gin.H{"error": err.Error()})
-			return
+			return status, err.Error()
 		}
 	}
 
-	log.Error().Err(err).Msg("Internal error")
-	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	return http.StatusInternalServerError, "internal server error"
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	status, message := handler.errorStatus(c, err)
+	if jsonapi.Requested(c) {
+		jsonapi.Error(c, status, message)
+		return
+	}
+	c.JSON(status, gin.H{"error": message})
 }
 
 func (handler *Handler) CreateArticle(c *gin.Context) {
@@ -63,6 +318,12 @@ func (handler *Handler) CreateArticle(c *gin.Context) {
 		return
 	}
 
+	strict, err := parseStrict(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req CreateArticleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		validationErrors := validation.NormalizeValidationErrors(err, req)
@@ -70,13 +331,29 @@ func (handler *Handler) CreateArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := handler.service.CreateArticle(userID, req.Title, req.Content)
+	article, err := handler.service.CreateArticle(c.Request.Context(), userID, CreateInput{
+		Title:         req.Title,
+		Content:       req.Content,
+		Status:        req.Status,
+		Language:      req.Language,
+		Tags:          req.Tags,
+		CategoryID:    req.CategoryID,
+		Excerpt:       req.Excerpt,
+		CoverImageURL: req.CoverImageURL,
+		CoverMediaID:  req.CoverMediaID,
+		Lenient:       !strict,
+		Role:          middleware.GetUserRole(c),
+	})
 	if err != nil {
 		handler.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, article)
+	handler.renderArticle(c, http.StatusCreated, article)
+}
+
+func (handler *Handler) GetArticleSchema(c *gin.Context) {
+	c.JSON(http.StatusOK, articleSchema)
 }
 
 func (handler *Handler) GetArticleByID(c *gin.Context) {
@@ -86,18 +363,76 @@ func (handler *Handler) GetArticleByID(c *gin.Context) {
 		return
 	}
 
-	article, err := handler.service.GetArticleByID(id)
+	if _, err := parseFields(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	article, err := handler.service.GetArticleByID(c.Request.Context(), getViewer(c), id)
 	if err != nil {
 		handler.handleError(c, err)
 		return
 	}
+	if c.Request.Method == http.MethodGet {
+		handler.service.RecordView(article)
+	}
 
-	c.JSON(http.StatusOK, article)
+	setLocaleHeaders(c, article)
+	handler.renderArticle(c, http.StatusOK, article)
 }
 
-func (handler *Handler) GetAllArticles(c *gin.Context) {
+func (handler *Handler) GetArticleBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+
+	if _, err := parseFields(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	article, redirected, err := handler.service.GetArticleBySlug(c.Request.Context(), getViewer(c), slug)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	if redirected {
+		location := strings.TrimSuffix(c.Request.URL.Path, slug) + url.PathEscape(article.Slug)
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	setLocaleHeaders(c, article)
+	handler.renderArticle(c, http.StatusOK, article)
+}
+
+func paginationMeta(page, limit int, total int64) gin.H {
+	return offsetMeta((page-1)*limit, limit, total)
+}
+
+func offsetMeta(offset, limit int, total int64) gin.H {
+	return gin.H{
+		"page":        offset/limit + 1,
+		"limit":       limit,
+		"offset":      offset,
+		"total":       total,
+		"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
+
+func parsePagination(c *gin.Context) (page, limit int, err error) {
+	offset, limit, err := parseOffset(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	if offset%limit != 0 {
+		return 0, 0, errors.New("offset must be a multiple of limit")
+	}
+	return offset/limit + 1, limit, nil
+}
+
+func parseOffset(c *gin.Context) (offset, limit int, err error) {
 	page := DefaultPage
-	limit := DefaultLimit
+	limit = DefaultLimit
 
 	if pageStr := c.Query("page"); pageStr != "" {
 		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
@@ -110,61 +445,278 @@ func (handler *Handler) GetAllArticles(c *gin.Context) {
 			limit = l
 		}
 	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+		c.Header(PageLimitAppliedHeader, strconv.Itoa(limit))
+		c.Header(PageLimitMaxHeader, strconv.Itoa(MaxLimit))
+	}
+
+	offsetStr, hasOffset := c.GetQuery("offset")
+	if !hasOffset {
+		return (page - 1) * limit, limit, nil
+	}
+
+	offset, err = strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		return 0, 0, errors.New("offset must be a non-negative integer")
+	}
+	if c.Query("page") != "" && page != offset/limit+1 {
+		return 0, 0, errors.New("page and offset refer to different pages")
+	}
+
+	return offset, limit, nil
+}
+
+func (handler *Handler) GetAllArticles(c *gin.Context) {
+	varyLanguage(c)
+	offset, limit, err := parseOffset(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := parseListFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if after, ok := c.GetQuery("cursor"); ok {
+		if c.Query("page") != "" || c.Query("offset") != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cursor cannot be combined with page or offset"})
+			return
+		}
+
+		articles, next, err := handler.service.GetArticlesAfter(c.Request.Context(), getViewer(c), filter, after, limit)
+		if err != nil {
+			handler.handleError(c, err)
+			return
+		}
+
+		meta := gin.H{"limit": limit, "next_cursor": nil}
+		if next != "" {
+			meta["next_cursor"] = next
+		}
+		handler.renderArticles(c, articles, meta, func() map[string]string {
+			return jsonapi.CursorLinks(c.Request.URL, next)
+		})
+		return
+	}
+
+	filter.Count, err = parseCount(c.Query("count"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.Count == CountNone {
+		articles, hasNext, err := handler.service.GetArticlesPageAt(c.Request.Context(), getViewer(c), filter, offset, limit)
+		if err != nil {
+			handler.handleError(c, err)
+			return
+		}
+
+		meta := gin.H{
+			"page":     offset/limit + 1,
+			"limit":    limit,
+			"offset":   offset,
+			"has_next": hasNext,
+		}
+		handler.renderArticles(c, articles, meta, func() map[string]string {
+			if offset%limit != 0 {
+				return jsonapi.OffsetLinks(c.Request.URL, offset, limit, hasNext, -1)
+			}
+			return jsonapi.PageLinks(c.Request.URL, offset/limit+1, limit, hasNext, 0)
+		})
+		return
+	}
 
-	articles, total, err := handler.service.GetAllArticles(page, limit)
+	articles, total, err := handler.service.GetArticlesAt(c.Request.Context(), getViewer(c), filter, offset, limit)
 	if err != nil {
 		handler.handleError(c, err)
 		return
 	}
 
-	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	handler.renderArticles(c, articles, offsetMeta(offset, limit, total), offsetLinks(c, offset, limit, total))
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": articles,
-		"meta": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       total,
-			"total_pages": totalPages,
-		},
+func (handler *Handler) GetPopularArticles(c *gin.Context) {
+	varyLanguage(c)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	articles, err := handler.service.GetPopularArticles(c.Request.Context(), getViewer(c), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	meta := gin.H{
+		"page":   page,
+		"limit":  limit,
+		"offset": (page - 1) * limit,
+	}
+	handler.renderArticles(c, articles, meta, func() map[string]string {
+		return jsonapi.PageLinks(c.Request.URL, page, limit, len(articles) == limit, 0)
 	})
 }
 
-func (handler *Handler) UpdateArticle(c *gin.Context) {
+func (handler *Handler) GetTrendingArticles(c *gin.Context) {
+	varyLanguage(c)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	articles, total, computedAt, err := handler.service.GetTrendingArticles(c.Request.Context(), getViewer(c), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	meta := paginationMeta(page, limit, total)
+	if !computedAt.IsZero() {
+		meta["computed_at"] = computedAt.UTC()
+	}
+	handler.renderArticles(c, articles, meta, pageLinks(c, page, limit, total))
+}
+
+func (handler *Handler) GetFeaturedArticles(c *gin.Context) {
+	varyLanguage(c)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	articles, total, err := handler.service.GetFeaturedArticles(c.Request.Context(), getViewer(c), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticles(c, articles, paginationMeta(page, limit, total), pageLinks(c, page, limit, total))
+}
+
+func (handler *Handler) GetTrash(c *gin.Context) {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
 		return
 	}
 
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	articles, total, err := handler.service.GetTrash(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticles(c, articles, paginationMeta(page, limit, total), pageLinks(c, page, limit, total))
+}
+
+func (handler *Handler) GetMyArticles(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := OwnFilter{Sort: c.Query("sort"), Order: c.Query("order")}
+	if statusStr := c.Query("status"); statusStr != "" {
+		for _, status := range strings.Split(statusStr, ",") {
+			status = strings.TrimSpace(status)
+			if status == StatusDeleted {
+				filter.Deleted = true
+				continue
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	articles, total, err := handler.service.GetOwnArticles(c.Request.Context(), userID, filter, page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticles(c, articles, paginationMeta(page, limit, total), pageLinks(c, page, limit, total))
+}
+
+func (handler *Handler) FeatureArticle(c *gin.Context) {
+	handler.setFeatured(c, true)
+}
+
+func (handler *Handler) UnfeatureArticle(c *gin.Context) {
+	handler.setFeatured(c, false)
+}
+
+func (handler *Handler) setFeatured(c *gin.Context, featured bool) {
+	if _, err := middleware.GetUserID(c); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
 	id, err := getID(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
 		return
 	}
 
-	var updateReq UpdateArticleRequest
-	if err := c.ShouldBindJSON(&updateReq); err != nil {
-		validationErrors := validation.NormalizeValidationErrors(err, updateReq)
-		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+	article, err := handler.service.SetFeatured(c.Request.Context(), getViewer(c), id, featured)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":          article.ID,
+		"is_featured": article.IsFeatured,
+		"featured_at": article.FeaturedAt,
+	})
+}
+
+func (handler *Handler) GetArticleETags(c *gin.Context) {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if updateReq.Title == nil && updateReq.Content == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one field (title or content) must be provided"})
+	filter, err := parseListFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	updatedArticle, err := handler.service.UpdateArticle(userID, id, updateReq.Title, updateReq.Content)
+	etags, total, err := handler.service.GetArticleETags(c.Request.Context(), getViewer(c), filter, page, limit)
 	if err != nil {
 		handler.handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedArticle)
+	c.JSON(http.StatusOK, gin.H{
+		"data": etags,
+		"meta": paginationMeta(page, limit, total),
+	})
 }
 
-func (handler *Handler) DeleteArticle(c *gin.Context) {
+func (handler *Handler) UpdateArticle(c *gin.Context) {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
@@ -177,10 +729,682 @@ func (handler *Handler) DeleteArticle(c *gin.Context) {
 		return
 	}
 
-	if err := handler.service.DeleteArticle(userID, id); err != nil {
-		handler.handleError(c, err)
+	strict, err := parseStrict(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	var updateReq UpdateArticleRequest
+	if err := c.ShouldBindJSON(&updateReq); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, updateReq)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if updateReq.Title == nil && updateReq.Content == nil && updateReq.Status == nil && updateReq.Language == nil && updateReq.Tags == nil && updateReq.CategoryID == nil && updateReq.Excerpt == nil && updateReq.CoverImageURL == nil && updateReq.CoverMediaID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one field (title, content, status, language, tags, category_id, excerpt, cover_image_url or cover_media_id) must be provided"})
+		return
+	}
+
+	updatedArticle, err := handler.service.UpdateArticle(c.Request.Context(), userID, id, updateInput(c, updateReq, strict))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticle(c, http.StatusOK, updatedArticle)
+}
+
+func updateInput(c *gin.Context, req UpdateArticleRequest, strict bool) UpdateInput {
+	return UpdateInput{
+		Title:         req.Title,
+		Content:       req.Content,
+		Status:        req.Status,
+		Language:      req.Language,
+		Tags:          req.Tags,
+		CategoryID:    req.CategoryID,
+		Excerpt:       req.Excerpt,
+		CoverImageURL: req.CoverImageURL,
+		CoverMediaID:  req.CoverMediaID,
+		Lenient:       !strict,
+		Role:          middleware.GetUserRole(c),
+		IfMatch:       c.GetHeader("If-Match"),
+		Version:       req.Version,
+	}
+}
+
+func (handler *Handler) PatchArticle(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	strict, err := parseStrict(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contentType := c.ContentType()
+	switch contentType {
+	case MediaTypeMergePatch, MediaTypeJSONPatch, "application/json":
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("content type must be %s or %s", MediaTypeMergePatch, MediaTypeJSONPatch)})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxPatchBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("patch cannot exceed %d bytes", MaxPatchBytes)})
+		return
+	}
+
+	article, err := handler.service.GetArticleByID(c.Request.Context(), Viewer{UserID: userID, Role: middleware.GetUserRole(c), NoCache: true}, id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	original := newPatchDocument(article)
+	patched, err := applyPatch(contentType, original, body)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	req := patched.changes(original)
+	if req == (UpdateArticleRequest{}) {
+		handler.renderArticle(c, http.StatusOK, article)
+		return
+	}
+	req.Version = &original.Version
+
+	updatedArticle, err := handler.service.UpdateArticle(c.Request.Context(), userID, id, updateInput(c, req, strict))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticle(c, http.StatusOK, updatedArticle)
+}
+
+func (handler *Handler) DeleteArticle(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	if err := handler.service.DeleteArticleIfMatch(c.Request.Context(), userID, id, c.GetHeader("If-Match")); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ExportArticles(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	format := c.DefaultQuery("format", ExportFormatJSON)
+	if format != ExportFormatJSON && format != ExportFormatMarkdown {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("format must be one of: %s, %s", ExportFormatJSON, ExportFormatMarkdown)})
+		return
+	}
+
+	var bundle bundleWriter = newJSONBundleWriter(c.Writer)
+	if format == ExportFormatMarkdown {
+		bundle = newMarkdownBundleWriter(c.Writer)
+	}
+
+	started := false
+	start := func() {
+		started = true
+		c.Header("Content-Type", bundle.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, bundle.Filename()))
+		c.Status(http.StatusOK)
+	}
+
+	err = handler.service.ExportArticles(c.Request.Context(), userID, func(item *BundleArticle) error {
+		if !started {
+			start()
+		}
+		return bundle.Write(item)
+	})
+	if err == nil {
+		if !started {
+			start()
+		}
+		err = bundle.Close()
+	}
+
+	if err != nil {
+		if !started {
+			handler.handleError(c, err)
+			return
+		}
+		log.Ctx(c.Request.Context()).Error().Err(err).Msg("Article export aborted")
+		c.Abort()
+	}
+}
+
+func (handler *Handler) ImportArticles(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	strict, err := parseStrict(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("import cannot exceed %d bytes", MaxImportBytes)})
+		return
+	}
+
+	var items []BundleArticle
+	switch c.ContentType() {
+	case "application/zip":
+		if items, err = readMarkdownBundle(body); err != nil {
+			handler.handleError(c, err)
+			return
+		}
+	case "application/json", "":
+		if err := json.Unmarshal(body, &items); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "request body must be a JSON array of articles"})
+			return
+		}
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "content type must be application/json or application/zip"})
+		return
+	}
+
+	articles, err := handler.service.ImportArticles(c.Request.Context(), userID, middleware.GetUserRole(c), items, !strict)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	imported := make([]gin.H, 0, len(articles))
+	for _, article := range articles {
+		imported = append(imported, gin.H{"id": article.ID, "slug": article.Slug, "title": article.Title})
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": imported})
+}
+
+func (handler *Handler) BulkCreateArticles(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	strict, err := parseStrict(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var items []json.RawMessage
+	if err := c.ShouldBindJSON(&items); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "request body must be a JSON array of articles"})
+		return
+	}
+	if err := validateBatchSize(len(items)); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	ms := response.NewMultiStatus(len(items))
+	inputs := make([]CreateInput, 0, len(items))
+	positions := make([]int, 0, len(items))
+	for i, item := range items {
+		var req CreateArticleRequest
+		if err := json.Unmarshal(item, &req); err != nil {
+			ms.Failure(i, http.StatusBadRequest, itemDecodeError(err))
+			continue
+		}
+		positions = append(positions, i)
+		inputs = append(inputs, CreateInput{
+			Title:         req.Title,
+			Content:       req.Content,
+			Status:        req.Status,
+			Language:      req.Language,
+			Tags:          req.Tags,
+			CategoryID:    req.CategoryID,
+			Excerpt:       req.Excerpt,
+			CoverImageURL: req.CoverImageURL,
+			CoverMediaID:  req.CoverMediaID,
+			Lenient:       !strict,
+			Role:          middleware.GetUserRole(c),
+		})
+	}
+	if len(inputs) == 0 {
+		ms.Write(c)
+		return
+	}
+
+	results, err := handler.service.BulkCreateArticles(c.Request.Context(), userID, inputs)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			status, message := handler.errorStatus(c, result.Err)
+			ms.Failure(positions[i], status, message)
+			continue
+		}
+		ms.SuccessWithWarning(positions[i], http.StatusCreated, result.ID, result.Warning)
+	}
+	ms.Write(c)
+}
+
+func itemDecodeError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return fmt.Sprintf("%s has the wrong type", typeErr.Field)
+	}
+	return "article must be a JSON object"
+}
+
+func (handler *Handler) BulkDeleteArticles(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	var req BulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	results, err := handler.service.BulkDeleteArticles(c.Request.Context(), userID, req.IDs)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	ms := response.NewMultiStatus(len(results))
+	for i, result := range results {
+		if result.Err != nil {
+			status, message := handler.errorStatus(c, result.Err)
+			ms.FailureWithID(i, status, result.ID, message)
+			continue
+		}
+		ms.Success(i, http.StatusNoContent, result.ID)
+	}
+	ms.Write(c)
+}
+
+func (handler *Handler) RestoreArticle(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	article, err := handler.service.RestoreArticle(c.Request.Context(), userID, id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticle(c, http.StatusOK, article)
+}
+
+func (handler *Handler) PurgeArticle(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	if err := handler.service.PurgeArticle(c.Request.Context(), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) LikeArticle(c *gin.Context) {
+	handler.react(c, handler.service.LikeArticle, true)
+}
+
+func (handler *Handler) UnlikeArticle(c *gin.Context) {
+	handler.react(c, handler.service.UnlikeArticle, false)
+}
+
+func (handler *Handler) react(c *gin.Context, apply func(context.Context, Viewer, uint) (int64, error), liked bool) {
+	if _, err := middleware.GetUserID(c); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	likes, err := apply(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":          id,
+		"liked":       liked,
+		"likes_count": likes,
+	})
+}
+
+func (handler *Handler) GetTagCounts(c *gin.Context) {
+	minCount := 1
+	if minCountStr := c.Query("min_count"); minCountStr != "" {
+		n, err := strconv.Atoi(minCountStr)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_count must be a positive integer"})
+			return
+		}
+		minCount = n
+	}
+
+	counts, err := handler.service.GetTagCounts(c.Request.Context(), minCount)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": counts})
+}
+
+func (handler *Handler) SearchArticles(c *gin.Context) {
+	varyLanguage(c)
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hits, total, err := handler.service.SearchArticles(c.Request.Context(), getViewer(c), c.Query("q"), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	handler.renderArticles(c, hits, paginationMeta(page, limit, total), pageLinks(c, page, limit, total))
+}
+
+func (handler *Handler) RegenerateSlug(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	article, err := handler.service.RegenerateSlug(c.Request.Context(), userID, id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":   article.ID,
+		"slug": article.Slug,
+	})
+}
+
+func (handler *Handler) GetArticleStats(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	stats, err := handler.service.GetArticleStats(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (handler *Handler) ListTranslations(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	translations, err := handler.service.ListTranslations(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": translations})
+}
+
+func (handler *Handler) GetTranslation(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	translation, err := handler.service.GetTranslation(c.Request.Context(), getViewer(c), id, c.Param("locale"))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func (handler *Handler) SaveTranslation(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	translation, err := handler.service.SaveTranslation(c.Request.Context(), userID, id, c.Param("locale"), TranslationInput{
+		Title:   req.Title,
+		Content: req.Content,
+		Role:    middleware.GetUserRole(c),
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+func (handler *Handler) DeleteTranslation(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	if err := handler.service.DeleteTranslation(c.Request.Context(), userID, id, c.Param("locale")); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ListAuthors(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	authors, err := handler.service.ListAuthors(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": authors})
+}
+
+func (handler *Handler) AddAuthor(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req AddAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	author, err := handler.service.AddAuthor(c.Request.Context(), userID, id, AuthorInput{UserID: req.UserID, Role: req.Role})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, author)
+}
+
+func (handler *Handler) RemoveAuthor(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	authorID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := handler.service.RemoveAuthor(c.Request.Context(), userID, id, uint(authorID)); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ListRevisions(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	revisions, err := handler.service.ListRevisions(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": revisions})
+}
+
+func (handler *Handler) DiffRevisions(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to revision numbers are required"})
+		return
+	}
+
+	diff, err := handler.service.DiffRevisions(c.Request.Context(), getViewer(c), id, from, to)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
 }
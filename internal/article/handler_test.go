@@ -0,0 +1,1479 @@
+package article
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/cursor"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func newTestRouter(handler *Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	articles := router.Group("/api/articles")
+	{
+		articles.GET("", handler.GetAllArticles)
+		articles.GET("/schema", handler.GetArticleSchema)
+		articles.GET("/tags", handler.GetTagCounts)
+		articles.GET("/search", handler.SearchArticles)
+		articles.GET("/etags", handler.GetArticleETags)
+		articles.GET("/:id", handler.GetArticleByID)
+	}
+
+	return router
+}
+
+func performRequest(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetArticleSchema(t *testing.T) {
+	router := newTestRouter(NewHandler(NewService(newMockRepository())))
+
+	w := performRequest(router, http.MethodGet, "/api/articles/schema")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var doc struct {
+		Defs map[string]struct {
+			Required   []string `json:"required"`
+			Properties map[string]struct {
+				Type      string `json:"type"`
+				MinLength *int   `json:"minLength"`
+				MaxLength *int   `json:"maxLength"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode schema: %v", err)
+	}
+
+	create, ok := doc.Defs["create"]
+	if !ok {
+		t.Fatalf("Expected create schema in $defs")
+	}
+	for _, field := range []string{"title", "content"} {
+		if !slices.Contains(create.Required, field) {
+			t.Errorf("Expected %q to be required in create schema, got %v", field, create.Required)
+		}
+	}
+	if title := create.Properties["title"]; title.MaxLength == nil || *title.MaxLength != MaxTitleLength {
+		t.Errorf("Expected create title maxLength %d, got %v", MaxTitleLength, title.MaxLength)
+	}
+	if content := create.Properties["content"]; content.MinLength == nil || *content.MinLength != 1 {
+		t.Errorf("Expected create content minLength 1, got %v", content.MinLength)
+	}
+
+	update, ok := doc.Defs["update"]
+	if !ok {
+		t.Fatalf("Expected update schema in $defs")
+	}
+	if len(update.Required) != 0 {
+		t.Errorf("Expected no required fields in update schema, got %v", update.Required)
+	}
+	if title := update.Properties["title"]; title.Type != "string" || title.MaxLength == nil || *title.MaxLength != MaxTitleLength {
+		t.Errorf("Expected update title string with maxLength %d, got %+v", MaxTitleLength, title)
+	}
+}
+
+func TestGetAllArticlesOffsetPagination(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for i := 1; i <= 25; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPage   int
+		wantOffset int
+		wantCount  int
+	}{
+		{
+			name:       "Page form",
+			query:      "page=2&limit=10",
+			wantStatus: http.StatusOK,
+			wantPage:   2,
+			wantOffset: 10,
+			wantCount:  10,
+		},
+		{
+			name:       "Offset form",
+			query:      "offset=20&limit=10",
+			wantStatus: http.StatusOK,
+			wantPage:   3,
+			wantOffset: 20,
+			wantCount:  5,
+		},
+		{
+			name:       "Zero offset",
+			query:      "offset=0&limit=5",
+			wantStatus: http.StatusOK,
+			wantPage:   1,
+			wantOffset: 0,
+			wantCount:  5,
+		},
+		{
+			name:       "Matching page and offset",
+			query:      "page=3&offset=20&limit=10",
+			wantStatus: http.StatusOK,
+			wantPage:   3,
+			wantOffset: 20,
+			wantCount:  5,
+		},
+		{
+			name:       "Conflicting page and offset",
+			query:      "page=1&offset=20&limit=10",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Offset not aligned to limit",
+			query:      "offset=15&limit=10",
+			wantStatus: http.StatusOK,
+			wantPage:   2,
+			wantOffset: 15,
+			wantCount:  10,
+		},
+		{
+			name:       "Offset within the page",
+			query:      "page=2&offset=15&limit=10",
+			wantStatus: http.StatusOK,
+			wantPage:   2,
+			wantOffset: 15,
+			wantCount:  10,
+		},
+		{
+			name:       "Offset outside the page",
+			query:      "page=1&offset=15&limit=10",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Negative offset",
+			query:      "offset=-10",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, "/api/articles?"+tt.query)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Data []Article `json:"data"`
+				Meta struct {
+					Page   int `json:"page"`
+					Offset int `json:"offset"`
+					Total  int `json:"total"`
+				} `json:"meta"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Meta.Page != tt.wantPage {
+				t.Errorf("Expected page %d, got %d", tt.wantPage, resp.Meta.Page)
+			}
+			if resp.Meta.Offset != tt.wantOffset {
+				t.Errorf("Expected offset %d, got %d", tt.wantOffset, resp.Meta.Offset)
+			}
+			if len(resp.Data) != tt.wantCount {
+				t.Errorf("Expected %d articles, got %d", tt.wantCount, len(resp.Data))
+			}
+			if resp.Meta.Total != 25 {
+				t.Errorf("Expected total 25, got %d", resp.Meta.Total)
+			}
+			if len(resp.Data) > 0 && resp.Data[0].ID != uint(25-tt.wantOffset) {
+				t.Errorf("Expected the page to start at article %d, got %d", 25-tt.wantOffset, resp.Data[0].ID)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/articles?offset=5&limit=10", nil)
+	req.Header.Set("Accept", "application/vnd.api+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var doc struct {
+		Links map[string]string `json:"links"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	wantLinks := map[string]string{
+		"first": "/api/articles?limit=10&offset=0",
+		"prev":  "/api/articles?limit=10&offset=0",
+		"next":  "/api/articles?limit=10&offset=15",
+		"last":  "/api/articles?limit=10&offset=15",
+	}
+	for name, want := range wantLinks {
+		if doc.Links[name] != want {
+			t.Errorf("Expected %s link %q, got %q", name, want, doc.Links[name])
+		}
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles?offset=5&limit=10&count=false")
+	var page struct {
+		Data []Article `json:"data"`
+		Meta struct {
+			Offset  int  `json:"offset"`
+			HasNext bool `json:"has_next"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if page.Meta.Offset != 5 || !page.Meta.HasNext || len(page.Data) != 10 || page.Data[0].ID != 20 {
+		t.Errorf("Expected 10 articles from article 20 at offset 5 with a next page, got %d articles at offset %d (has_next %v)", len(page.Data), page.Meta.Offset, page.Meta.HasNext)
+	}
+}
+
+func TestHandleErrorLogsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	originalLogger := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = originalLogger }()
+
+	repo := newMockRepository()
+	repo.err = errors.New("connection reset")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestIDMiddleware())
+	router.GET("/api/articles/:id", NewHandler(NewService(repo)).GetArticleByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/articles/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-12345")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+	}
+	if got := w.Header().Get(middleware.RequestIDHeader); got != "req-12345" {
+		t.Errorf("Expected response request ID %q, got %q", "req-12345", got)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode log line %q: %v", buf.String(), err)
+	}
+	if entry["request_id"] != "req-12345" {
+		t.Errorf("Expected error log to carry request_id %q, got %v", "req-12345", entry["request_id"])
+	}
+	if entry["error"] != "connection reset" {
+		t.Errorf("Expected error log to carry the error, got %v", entry["error"])
+	}
+}
+
+func TestGetAllArticlesWithoutCount(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	for i := 1; i <= 3; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	w := performRequest(router, http.MethodGet, "/api/articles?count=false&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resp struct {
+		Data []Article              `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Errorf("Expected 2 articles, got %d", len(resp.Data))
+	}
+	if resp.Meta["has_next"] != true {
+		t.Errorf("Expected has_next true, got %v", resp.Meta["has_next"])
+	}
+	for _, key := range []string{"total", "total_pages"} {
+		if _, ok := resp.Meta[key]; ok {
+			t.Errorf("Expected %q to be omitted from meta", key)
+		}
+	}
+	if repo.countCalls != 0 {
+		t.Errorf("Expected no count queries, got %d", repo.countCalls)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles?count=maybe")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid count, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "", want: ""},
+		{value: "exact", want: CountExact},
+		{value: "estimated", want: CountEstimated},
+		{value: "none", want: CountNone},
+		{value: "true", want: CountExact},
+		{value: "false", want: CountNone},
+		{value: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCount(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetArticleETagsMatchesDetail(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for i := 1; i <= 3; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Content", Status: StatusDraft}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	listETags := func() map[uint]string {
+		w := performRequest(router, http.MethodGet, "/api/articles/etags")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		var resp struct {
+			Data []ArticleETag `json:"data"`
+			Meta struct {
+				Total int `json:"total"`
+			} `json:"meta"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Meta.Total != 3 {
+			t.Errorf("Expected total 3 published articles, got %d", resp.Meta.Total)
+		}
+		etags := make(map[uint]string)
+		for _, item := range resp.Data {
+			etags[item.ID] = item.ETag
+		}
+		return etags
+	}
+
+	before := listETags()
+	if len(before) != 3 {
+		t.Fatalf("Expected 3 etags, got %d", len(before))
+	}
+
+	for id, etag := range before {
+		w := performRequest(router, http.MethodGet, fmt.Sprintf("/api/articles/%d", id))
+		if got := w.Header().Get("ETag"); got != etag {
+			t.Errorf("Article %d: expected detail ETag %q to match list ETag %q", id, got, etag)
+		}
+	}
+
+	time.Sleep(time.Millisecond)
+	newTitle := "Changed"
+	if _, err := svc.UpdateArticle(context.Background(), 1, 1, UpdateInput{Title: &newTitle}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+
+	after := listETags()
+	if after[1] == before[1] {
+		t.Errorf("Expected ETag of updated article to change")
+	}
+	if after[2] != before[2] {
+		t.Errorf("Expected ETag of untouched article to stay the same")
+	}
+}
+
+func TestGetArticleBySlugRedirect(t *testing.T) {
+	svc := NewService(newMockRepository())
+	created, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Old Title", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	newTitle := "New Title"
+	if _, err := svc.UpdateArticle(context.Background(), 1, created.ID, UpdateInput{Title: &newTitle}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+	if _, err := svc.RegenerateSlug(context.Background(), 1, created.ID); err != nil {
+		t.Fatalf("Failed to regenerate slug: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/articles/slug/:slug", NewHandler(svc).GetArticleBySlug)
+
+	w := performRequest(router, http.MethodGet, "/api/articles/slug/old-title")
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("Expected status %d, got %d", http.StatusMovedPermanently, w.Code)
+	}
+	if got := w.Header().Get("Location"); got != "/api/articles/slug/new-title" {
+		t.Errorf("Expected Location %q, got %q", "/api/articles/slug/new-title", got)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles/slug/new-title")
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles/slug/missing")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestGetAllArticlesClampsLimit(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for i := 1; i <= MaxLimit+5; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	w := performRequest(router, http.MethodGet, "/api/articles?limit=500")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get(PageLimitAppliedHeader); got != strconv.Itoa(MaxLimit) {
+		t.Errorf("Expected %s %d, got %q", PageLimitAppliedHeader, MaxLimit, got)
+	}
+	if got := w.Header().Get(PageLimitMaxHeader); got != strconv.Itoa(MaxLimit) {
+		t.Errorf("Expected %s %d, got %q", PageLimitMaxHeader, MaxLimit, got)
+	}
+
+	var resp struct {
+		Data []Article `json:"data"`
+		Meta struct {
+			Limit      int `json:"limit"`
+			TotalPages int `json:"total_pages"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Meta.Limit != MaxLimit {
+		t.Errorf("Expected meta.limit %d, got %d", MaxLimit, resp.Meta.Limit)
+	}
+	if len(resp.Data) != MaxLimit {
+		t.Errorf("Expected %d articles, got %d", MaxLimit, len(resp.Data))
+	}
+	if resp.Meta.TotalPages != 2 {
+		t.Errorf("Expected 2 total pages, got %d", resp.Meta.TotalPages)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles?limit=20")
+	if got := w.Header().Get(PageLimitAppliedHeader); got != "" {
+		t.Errorf("Expected no %s header for in-range limit, got %q", PageLimitAppliedHeader, got)
+	}
+}
+
+func TestCreateArticleNonStrict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/articles", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	}, NewHandler(NewService(newMockRepository(), WithMinContentLength(20))).CreateArticle)
+
+	tests := []struct {
+		name         string
+		query        string
+		body         string
+		wantStatus   int
+		wantWarnings bool
+	}{
+		{
+			name:         "Short draft with strict=false",
+			query:        "?strict=false",
+			body:         `{"title":"Draft","content":"short","status":"draft"}`,
+			wantStatus:   http.StatusCreated,
+			wantWarnings: true,
+		},
+		{
+			name:       "Short draft in strict mode",
+			body:       `{"title":"Draft","content":"short","status":"draft"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Short published article with strict=false",
+			query:      "?strict=false",
+			body:       `{"title":"Published","content":"short","status":"published"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Missing title with strict=false",
+			query:      "?strict=false",
+			body:       `{"content":"short","status":"draft"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid strict value",
+			query:      "?strict=sometimes",
+			body:       `{"title":"Draft","content":"short","status":"draft"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/articles"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			var resp struct {
+				Warnings []string `json:"warnings"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if tt.wantWarnings != (len(resp.Warnings) > 0) {
+				t.Errorf("Expected warnings=%v, got %v", tt.wantWarnings, resp.Warnings)
+			}
+		})
+	}
+}
+
+func TestGetTagCountsHandler(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for _, tags := range [][]string{{"go", "web"}, {"go"}} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content", Tags: tags}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	w := performRequest(router, http.MethodGet, "/api/articles/tags?min_count=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var resp struct {
+		Data []TagCount `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if want := []TagCount{{Name: "go", Count: 2}}; !slices.Equal(resp.Data, want) {
+		t.Errorf("Expected %v, got %v", want, resp.Data)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles/tags?min_count=0")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid min_count, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles/1")
+	var article Article
+	if err := json.Unmarshal(w.Body.Bytes(), &article); err != nil {
+		t.Fatalf("Failed to decode article: %v", err)
+	}
+	if got := tagNames(article.Tags); !slices.Equal(got, []string{"go", "web"}) {
+		t.Errorf("Expected article tags [go web], got %v", got)
+	}
+}
+
+func TestSearchArticlesHandler(t *testing.T) {
+	svc := NewService(newMockRepository())
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Go tips", Content: "Short go tips"}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantTotal  int64
+	}{
+		{name: "Match", path: "/api/articles/search?q=tips", wantStatus: http.StatusOK, wantTotal: 1},
+		{name: "No match", path: "/api/articles/search?q=rust", wantStatus: http.StatusOK, wantTotal: 0},
+		{name: "Missing query", path: "/api/articles/search", wantStatus: http.StatusBadRequest},
+		{name: "Invalid offset", path: "/api/articles/search?q=go&offset=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, tt.path)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Data []SearchHit `json:"data"`
+				Meta struct {
+					Total int64 `json:"total"`
+				} `json:"meta"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Meta.Total != tt.wantTotal || int64(len(resp.Data)) != tt.wantTotal {
+				t.Errorf("Expected %d hits, got total %d with %d hits", tt.wantTotal, resp.Meta.Total, len(resp.Data))
+			}
+		})
+	}
+}
+
+func TestGetAllArticlesCursor(t *testing.T) {
+	svc := NewService(newMockRepository(), WithCursorCodec(cursor.NewCodec("test-secret")))
+	for i := 0; i < 3; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	type cursorResponse struct {
+		Data []Article `json:"data"`
+		Meta struct {
+			Limit      int     `json:"limit"`
+			NextCursor *string `json:"next_cursor"`
+		} `json:"meta"`
+	}
+
+	w := performRequest(router, http.MethodGet, "/api/articles?cursor=&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var first cursorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(first.Data) != 2 || first.Meta.NextCursor == nil {
+		t.Fatalf("Expected 2 articles and a next cursor, got %d articles and %v", len(first.Data), first.Meta.NextCursor)
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles?limit=2&cursor="+url.QueryEscape(*first.Meta.NextCursor))
+	var second cursorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(second.Data) != 1 || second.Meta.NextCursor != nil {
+		t.Errorf("Expected last article without next cursor, got %d articles and %v", len(second.Data), second.Meta.NextCursor)
+	}
+	if len(second.Data) == 1 && second.Data[0].ID == first.Data[1].ID {
+		t.Errorf("Expected second page to continue after article %d", first.Data[1].ID)
+	}
+
+	for _, path := range []string{"/api/articles?cursor=garbage", "/api/articles?cursor=&page=2"} {
+		w = performRequest(router, http.MethodGet, path)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusBadRequest, path, w.Code)
+		}
+	}
+}
+
+func TestParseListFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		check   func(ListFilter) bool
+		wantErr bool
+	}{
+		{name: "Author alias", query: "author_id=7", check: func(f ListFilter) bool { return f.UserID != nil && *f.UserID == 7 }},
+		{name: "Matching user and author", query: "user_id=7&author_id=7", check: func(f ListFilter) bool { return *f.UserID == 7 }},
+		{name: "Conflicting user and author", query: "user_id=7&author_id=8", wantErr: true},
+		{name: "Date", query: "created_after=2024-03-01", check: func(f ListFilter) bool {
+			return f.CreatedAfter != nil && f.CreatedAfter.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		}},
+		{name: "Timestamp", query: "created_before=2024-03-01T12:00:00Z", check: func(f ListFilter) bool {
+			return f.CreatedBefore != nil && f.CreatedBefore.Hour() == 12
+		}},
+		{name: "Invalid date", query: "created_after=yesterday", wantErr: true},
+		{name: "Sort and order", query: "sort=title&order=asc", check: func(f ListFilter) bool { return f.Sort == SortTitle && f.Order == OrderAsc }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/articles?"+tt.query, nil)
+
+			filter, err := parseListFilter(c)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.query)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseListFilter() unexpected error: %v", err)
+			}
+			if !tt.check(filter) {
+				t.Errorf("Unexpected filter for %q: %+v", tt.query, filter)
+			}
+		})
+	}
+}
+
+func TestBulkArticlesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewHandler(NewService(newMockRepository()))
+	router := gin.New()
+	authenticated := func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	}
+	router.POST("/api/articles/bulk", authenticated, handler.BulkCreateArticles)
+	router.DELETE("/api/articles/bulk", authenticated, handler.BulkDeleteArticles)
+
+	send := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/articles/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, `[{"title":"One","content":"Valid content"},{"content":"No title"}]`)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusMultiStatus, w.Code, w.Body.String())
+	}
+	var created response.MultiStatusBody
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created.Summary.Succeeded != 1 || created.Summary.Failed != 1 {
+		t.Errorf("Expected 1 success and 1 failure, got %+v", created.Summary)
+	}
+	if created.Results[0].Status != http.StatusCreated || created.Results[1].Status != http.StatusBadRequest {
+		t.Errorf("Expected statuses 201 and 400, got %d and %d", created.Results[0].Status, created.Results[1].Status)
+	}
+
+	w = send(http.MethodDelete, fmt.Sprintf(`{"ids":[%d, 42]}`, *created.Results[0].ID))
+	var deleted response.MultiStatusBody
+	if err := json.Unmarshal(w.Body.Bytes(), &deleted); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusMultiStatus || deleted.Results[0].Status != http.StatusNoContent || deleted.Results[1].Status != http.StatusNotFound {
+		t.Errorf("Expected 204 and 404 item statuses, got %s", w.Body.String())
+	}
+
+	w = send(http.MethodPost, `["Not an object",{"title":"Two","content":"Valid content"},{"title":"Three","content":"Valid content","tags":"go"}]`)
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusMultiStatus, w.Code, w.Body.String())
+	}
+	var mixed response.MultiStatusBody
+	if err := json.Unmarshal(w.Body.Bytes(), &mixed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	wantResults := []response.ItemResult{
+		{Index: 0, Status: http.StatusBadRequest, Error: "article must be a JSON object"},
+		{Index: 1, Status: http.StatusCreated},
+		{Index: 2, Status: http.StatusBadRequest, Error: "tags has the wrong type"},
+	}
+	if len(mixed.Results) != len(wantResults) {
+		t.Fatalf("Expected %d results, got %s", len(wantResults), w.Body.String())
+	}
+	for i, want := range wantResults {
+		got := mixed.Results[i]
+		if got.Index != want.Index || got.Status != want.Status || got.Error != want.Error {
+			t.Errorf("Expected result %+v, got %+v", want, got)
+		}
+	}
+
+	w = send(http.MethodPost, `[1, 2]`)
+	var failed response.MultiStatusBody
+	if err := json.Unmarshal(w.Body.Bytes(), &failed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusMultiStatus || failed.Summary.Failed != 2 {
+		t.Errorf("Expected a 207 with two failed items, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, body := range []string{`{"title":"Not an array"}`, `[]`, `[` + strings.Repeat(`{},`, MaxBulkItems) + `{}]`} {
+		if w := send(http.MethodPost, body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for %.40s, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
+
+func TestGetArticleByIDLocaleHeaders(t *testing.T) {
+	svc := NewService(newMockRepository())
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Hello", Content: "Original content", Language: "en"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := svc.SaveTranslation(context.Background(), 1, article.ID, "fr", TranslationInput{Title: "Bonjour", Content: "Contenu traduit"}); err != nil {
+		t.Fatalf("Failed to save translation: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/articles/:id", NewHandler(svc).GetArticleByID)
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		wantLanguage   string
+	}{
+		{name: "Default locale", target: "/api/articles/1"},
+		{name: "Accept-Language", target: "/api/articles/1", acceptLanguage: "fr-FR,fr;q=0.9", wantLanguage: "fr"},
+		{name: "Locale parameter wins", target: "/api/articles/1?locale=en", acceptLanguage: "fr"},
+	}
+
+	etags := make(map[string]bool)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("Expected Content-Language %q, got %q", tt.wantLanguage, got)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept-Language" {
+				t.Errorf("Expected Vary Accept-Language, got %q", got)
+			}
+			etags[w.Header().Get("ETag")] = true
+		})
+	}
+	if len(etags) != 2 {
+		t.Errorf("Expected translated and original responses to have different ETags, got %v", etags)
+	}
+}
+
+func TestListsVaryOnLanguage(t *testing.T) {
+	router := newTestRouter(NewHandler(NewService(newMockRepository())))
+
+	for _, path := range []string{"/api/articles", "/api/articles?count=false", "/api/articles?cursor=", "/api/articles/search?q=go"} {
+		t.Run(path, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, path)
+			if vary := w.Header().Values("Vary"); !slices.Contains(vary, "Accept-Language") {
+				t.Errorf("Expected Vary to include Accept-Language, got %v", vary)
+			}
+		})
+	}
+}
+
+func TestExportImportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	source := NewService(newMockRepository())
+	if _, err := source.CreateArticle(context.Background(), 1, CreateInput{Title: "First post", Content: "First body\n\nwith two paragraphs", Tags: []string{"go"}, Excerpt: "Custom summary"}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := source.CreateArticle(context.Background(), 1, CreateInput{Title: "Second: a draft", Content: "Draft body", Status: StatusDraft, Language: "en"}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := source.CreateArticle(context.Background(), 2, CreateInput{Title: "Someone else", Content: "Not exported"}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	newRouter := func(svc Service, userID uint) *gin.Engine {
+		handler := NewHandler(svc)
+		router := gin.New()
+		authenticated := func(c *gin.Context) {
+			c.Set(middleware.UserIDKey, userID)
+		}
+		router.GET("/api/articles/export", authenticated, handler.ExportArticles)
+		router.POST("/api/articles/import", authenticated, handler.ImportArticles)
+		return router
+	}
+	exporter := newRouter(source, 1)
+
+	w := performRequest(exporter, http.MethodGet, "/api/articles/export")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var exported []BundleArticle
+	if err := json.Unmarshal(w.Body.Bytes(), &exported); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+	if len(exported) != 2 || exported[0].Slug != "first-post" || exported[0].Excerpt != "Custom summary" || exported[1].Status != StatusDraft {
+		t.Errorf("Expected both articles of user 1 in creation order, got %+v", exported)
+	}
+
+	if w := performRequest(exporter, http.MethodGet, "/api/articles/export?format=pdf"); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an unknown format, got %d", http.StatusBadRequest, w.Code)
+	}
+	if w := performRequest(newRouter(source, 9), http.MethodGet, "/api/articles/export"); w.Body.String() != "[]\n" {
+		t.Errorf("Expected an empty JSON array for a user without articles, got %q", w.Body.String())
+	}
+
+	w = performRequest(exporter, http.MethodGet, "/api/articles/export?format=markdown")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("Expected a zip archive, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	archive := w.Body.Bytes()
+
+	target := newMockRepository()
+	importer := newRouter(NewService(target), 3)
+	send := func(contentType string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/articles/import", bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		importer.ServeHTTP(w, req)
+		return w
+	}
+
+	w = send("application/zip", archive)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	if len(target.articles) != 2 {
+		t.Fatalf("Expected 2 imported articles, got %d", len(target.articles))
+	}
+	for _, original := range exported {
+		var imported *Article
+		for _, article := range target.articles {
+			if article.Slug == original.Slug {
+				imported = article
+			}
+		}
+		if imported == nil {
+			t.Errorf("Expected article with slug %q to be imported", original.Slug)
+			continue
+		}
+		if imported.UserID != 3 || imported.Title != original.Title || imported.Content != original.Content || imported.Status != original.Status {
+			t.Errorf("Expected %+v to round-trip, got %+v", original, *imported)
+		}
+		if !imported.CreatedAt.Equal(*original.CreatedAt) {
+			t.Errorf("Expected created_at %v to be kept, got %v", *original.CreatedAt, imported.CreatedAt)
+		}
+	}
+
+	var bomb bytes.Buffer
+	bombWriter := zip.NewWriter(&bomb)
+	padding := strings.Repeat("a", MaxImportBytes/4)
+	for i := 0; i < 5; i++ {
+		file, err := bombWriter.Create(fmt.Sprintf("article-%d.md", i))
+		if err != nil {
+			t.Fatalf("Failed to create archive entry: %v", err)
+		}
+		if _, err := file.Write([]byte("---\ntitle: Large\n---\n" + padding)); err != nil {
+			t.Fatalf("Failed to write archive entry: %v", err)
+		}
+	}
+	if err := bombWriter.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	if w := send("application/zip", bomb.Bytes()); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "uncompressed bytes") {
+		t.Errorf("Expected an archive over the uncompressed limit to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{name: "Invalid item rejects the import", contentType: "application/json", body: `[{"title":"Valid","content":"Valid content"},{"title":"No content"}]`, wantStatus: http.StatusBadRequest},
+		{name: "Empty import", contentType: "application/json", body: `[]`, wantStatus: http.StatusBadRequest},
+		{name: "Not a zip archive", contentType: "application/zip", body: "not a zip", wantStatus: http.StatusBadRequest},
+		{name: "Unsupported content type", contentType: "text/plain", body: "hello", wantStatus: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := send(tt.contentType, []byte(tt.body)); w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+	if len(target.articles) != 2 {
+		t.Errorf("Expected failed imports to create nothing, got %d articles", len(target.articles))
+	}
+}
+
+func TestJSONAPIResponses(t *testing.T) {
+	svc := NewService(newMockRepository(), WithCategories(&fakeCategories{parents: map[uint]uint{3: 0}}))
+	categoryID := uint(3)
+	for i := 1; i <= 5; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 7, CreateInput{Title: "Article", Content: "Content", CategoryID: &categoryID}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	request := func(target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/api/articles/2", "application/vnd.api+json")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/vnd.api+json" {
+		t.Errorf("Expected Content-Type application/vnd.api+json, got %q", got)
+	}
+	if vary := w.Header().Values("Vary"); !slices.Contains(vary, "Accept") {
+		t.Errorf("Expected Vary to include Accept, got %v", vary)
+	}
+
+	var single struct {
+		Data struct {
+			Type          string         `json:"type"`
+			ID            string         `json:"id"`
+			Attributes    map[string]any `json:"attributes"`
+			Relationships map[string]struct {
+				Data *struct {
+					Type string `json:"type"`
+					ID   string `json:"id"`
+				} `json:"data"`
+			} `json:"relationships"`
+			Links map[string]string `json:"links"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &single); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	if single.Data.Type != ResourceType || single.Data.ID != "2" || single.Data.Links["self"] != ResourcePath+"2" {
+		t.Errorf("Unexpected resource identity: %+v", single.Data)
+	}
+	if single.Data.Attributes["title"] != "Article" {
+		t.Errorf("Expected title attribute, got %v", single.Data.Attributes)
+	}
+	for _, key := range []string{"id", "user_id", "category_id"} {
+		if _, ok := single.Data.Attributes[key]; ok {
+			t.Errorf("Expected %q to be left out of attributes", key)
+		}
+	}
+	if author := single.Data.Relationships["author"].Data; author == nil || author.Type != UserType || author.ID != "7" {
+		t.Errorf("Expected author relationship to users/7, got %+v", author)
+	}
+	if category := single.Data.Relationships["category"].Data; category == nil || category.Type != CategoryType || category.ID != "3" {
+		t.Errorf("Expected category relationship to categories/3, got %+v", category)
+	}
+	if cover, ok := single.Data.Relationships["cover"]; !ok || cover.Data != nil {
+		t.Errorf("Expected empty cover relationship, got %+v", cover)
+	}
+
+	w = request("/api/articles?page=2&limit=2&status=published", "application/vnd.api+json")
+	var list struct {
+		Data  []json.RawMessage `json:"data"`
+		Meta  map[string]any    `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to decode document: %v", err)
+	}
+	if len(list.Data) != 2 || list.Meta["total"] != float64(5) {
+		t.Errorf("Expected 2 of 5 resources, got %d (meta %v)", len(list.Data), list.Meta)
+	}
+	wantLinks := map[string]string{
+		"self":  "/api/articles?page=2&limit=2&status=published",
+		"first": "/api/articles?limit=2&page=1&status=published",
+		"prev":  "/api/articles?limit=2&page=1&status=published",
+		"next":  "/api/articles?limit=2&page=3&status=published",
+		"last":  "/api/articles?limit=2&page=3&status=published",
+	}
+	for rel, want := range wantLinks {
+		if got := list.Links[rel]; got != want {
+			t.Errorf("Expected %s link %q, got %q", rel, want, got)
+		}
+	}
+
+	w = request("/api/articles/99", "application/vnd.api+json")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), `"errors":[{"status":"404","title":"Not Found"`) {
+		t.Errorf("Expected JSON:API error document, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = request("/api/articles/2", "application/json")
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") || strings.Contains(w.Body.String(), `"attributes"`) {
+		t.Errorf("Expected plain JSON without Accept: application/vnd.api+json, got %q", got)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for i := 1; i <= 3; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewHandler(svc)
+	authenticated := func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	}
+	router.GET("/api/articles", handler.GetAllArticles)
+	router.GET("/api/articles/:id", handler.GetArticleByID)
+	router.PUT("/api/articles/:id", authenticated, handler.UpdateArticle)
+	router.DELETE("/api/articles/:id", authenticated, handler.DeleteArticle)
+
+	request := func(method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	detail := request(http.MethodGet, "/api/articles/1", "", nil)
+	list := request(http.MethodGet, "/api/articles?limit=3", "", nil)
+	etag, listTag := detail.Header().Get("ETag"), list.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `"`) || !strings.HasPrefix(listTag, `W/"`) {
+		t.Fatalf("Expected a strong article ETag and a weak list ETag, got %q and %q", etag, listTag)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		headers    map[string]string
+		wantStatus int
+	}{
+		{name: "Detail not modified", method: http.MethodGet, target: "/api/articles/1", headers: map[string]string{"If-None-Match": etag}, wantStatus: http.StatusNotModified},
+		{name: "Detail with another ETag", method: http.MethodGet, target: "/api/articles/1", headers: map[string]string{"If-None-Match": `W/"other"`}, wantStatus: http.StatusOK},
+		{name: "List not modified", method: http.MethodGet, target: "/api/articles?limit=3", headers: map[string]string{"If-None-Match": `W/"other", ` + listTag}, wantStatus: http.StatusNotModified},
+		{name: "Other page of the list", method: http.MethodGet, target: "/api/articles?limit=3&page=2", headers: map[string]string{"If-None-Match": listTag}, wantStatus: http.StatusOK},
+		{name: "Update with weak ETag", method: http.MethodPut, target: "/api/articles/1", body: `{"title":"Weak"}`, headers: map[string]string{"If-Match": "W/" + etag}, wantStatus: http.StatusPreconditionFailed},
+		{name: "Update with current ETag", method: http.MethodPut, target: "/api/articles/1", body: `{"title":"Renamed"}`, headers: map[string]string{"If-Match": etag}, wantStatus: http.StatusOK},
+		{name: "Update with stale ETag", method: http.MethodPut, target: "/api/articles/1", body: `{"title":"Lost update"}`, headers: map[string]string{"If-Match": etag}, wantStatus: http.StatusPreconditionFailed},
+		{name: "Detail changed after update", method: http.MethodGet, target: "/api/articles/1", headers: map[string]string{"If-None-Match": etag}, wantStatus: http.StatusOK},
+		{name: "List changed after update", method: http.MethodGet, target: "/api/articles?limit=3", headers: map[string]string{"If-None-Match": listTag}, wantStatus: http.StatusOK},
+		{name: "Delete with stale ETag", method: http.MethodDelete, target: "/api/articles/1", headers: map[string]string{"If-Match": etag}, wantStatus: http.StatusPreconditionFailed},
+		{name: "Delete with any ETag", method: http.MethodDelete, target: "/api/articles/1", headers: map[string]string{"If-Match": "*"}, wantStatus: http.StatusNoContent},
+		{name: "Update without If-Match", method: http.MethodPut, target: "/api/articles/2", body: `{"title":"Unconditional"}`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(tt.method, tt.target, tt.body, tt.headers)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("Expected empty body for 304, got %q", w.Body.String())
+			}
+		})
+	}
+
+	updated := request(http.MethodPut, "/api/articles/2", `{"title":"Again"}`, nil)
+	current := request(http.MethodGet, "/api/articles/2", "", nil)
+	if updated.Header().Get("ETag") != current.Header().Get("ETag") {
+		t.Errorf("Expected the update response ETag %q to match the stored article, got %q", updated.Header().Get("ETag"), current.Header().Get("ETag"))
+	}
+}
+
+func TestMatchVersion(t *testing.T) {
+	article := Article{ID: 7, UpdatedAt: time.Date(2024, 3, 1, 12, 0, 0, 123456789, time.UTC)}
+	stored := Article{ID: 7, UpdatedAt: article.UpdatedAt.Truncate(time.Microsecond)}
+	translated := stored
+	translated.Locale = "pt-BR"
+	translated.TranslatedAt = time.Now()
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "Same version", header: article.ETag(), want: true},
+		{name: "Any version", header: "*", want: true},
+		{name: "Localized ETag", header: translated.ETag(), want: true},
+		{name: "One of several", header: `"7-1", ` + translated.ETag(), want: true},
+		{name: "Weak ETag", header: "W/" + article.ETag()},
+		{name: "Other version", header: `"7-1"`},
+		{name: "Other article", header: fmt.Sprintf(`"8-%x"`, versionStamp(article.UpdatedAt))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchVersion(tt.header, stored.ETag()); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPatchArticle(t *testing.T) {
+	svc := NewService(newMockRepository())
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content", Tags: []string{"go"}}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PATCH("/api/articles/:id", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	}, NewHandler(svc).PatchArticle)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantTitle   string
+		wantTags    []string
+		wantVersion int
+	}{
+		{name: "Merge patch", contentType: MediaTypeMergePatch, body: `{"title":"Merged"}`, wantStatus: http.StatusOK, wantTitle: "Merged", wantTags: []string{"go"}, wantVersion: 2},
+		{name: "Plain JSON is a merge patch", contentType: "application/json", body: `{"tags":["go","rest"]}`, wantStatus: http.StatusOK, wantTitle: "Merged", wantTags: []string{"go", "rest"}, wantVersion: 3},
+		{name: "JSON Patch", contentType: MediaTypeJSONPatch, body: `[{"op":"test","path":"/version","value":3},{"op":"remove","path":"/tags/0"},{"op":"replace","path":"/title","value":"Patched"}]`, wantStatus: http.StatusOK, wantTitle: "Patched", wantTags: []string{"rest"}, wantVersion: 4},
+		{name: "Unchanged article", contentType: MediaTypeMergePatch, body: `{"title":"Patched"}`, wantStatus: http.StatusOK, wantTitle: "Patched", wantTags: []string{"rest"}, wantVersion: 4},
+		{name: "Failed test operation", contentType: MediaTypeJSONPatch, body: `[{"op":"test","path":"/version","value":1}]`, wantStatus: http.StatusBadRequest},
+		{name: "Stale version", contentType: MediaTypeMergePatch, body: `{"version":1,"title":"Lost"}`, wantStatus: http.StatusConflict},
+		{name: "Unknown field", contentType: MediaTypeMergePatch, body: `{"views":100}`, wantStatus: http.StatusBadRequest},
+		{name: "Invalid JSON Patch", contentType: MediaTypeJSONPatch, body: `{"title":"Object"}`, wantStatus: http.StatusBadRequest},
+		{name: "Unsupported content type", contentType: "text/plain", body: `title=Text`, wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/articles/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got Article
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			tags := make([]string, len(got.Tags))
+			for i, tag := range got.Tags {
+				tags[i] = tag.Name
+			}
+			if got.Title != tt.wantTitle || !slices.Equal(tags, tt.wantTags) || got.Version != tt.wantVersion {
+				t.Errorf("Expected title %q, tags %v and version %d, got %q, %v and %d", tt.wantTitle, tt.wantTags, tt.wantVersion, got.Title, tags, got.Version)
+			}
+		})
+	}
+}
+
+func TestPatchArticleReadsPrimary(t *testing.T) {
+	primary, replica := newMockRepository(), newMockRepository()
+	for _, repo := range []Repository{primary, replica} {
+		if _, err := NewService(repo).CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	svc := NewService(&replicatedRepository{Repository: primary, replica: replica})
+	title := "Updated"
+	if _, err := svc.UpdateArticle(context.Background(), 1, 1, UpdateInput{Title: &title}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PATCH("/api/articles/:id", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	}, NewHandler(svc).PatchArticle)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/articles/1", strings.NewReader(`{"title":"Patched"}`))
+	req.Header.Set("Content-Type", MediaTypeMergePatch)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d despite a lagging replica, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var got Article
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got.Title != "Patched" || got.Version != 3 {
+		t.Errorf("Expected title %q and version 3, got %q and %d", "Patched", got.Title, got.Version)
+	}
+}
+
+func TestContentNegotiation(t *testing.T) {
+	svc := NewService(newMockRepository())
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Negotiated", Content: "Content", Tags: []string{"go"}}); err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	w := get("/api/articles/1?fields=title", "application/xml")
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/xml") {
+		t.Errorf("Expected XML content type, got %q", got)
+	}
+	var detail struct {
+		ID    uint   `xml:"id"`
+		Title string `xml:"title"`
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("Failed to decode XML: %v", err)
+	}
+	if detail.ID != 1 || detail.Title != "Negotiated" {
+		t.Errorf("Unexpected article: %+v", detail)
+	}
+
+	w = get("/api/articles", "application/msgpack")
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/msgpack") {
+		t.Errorf("Expected MessagePack content type, got %q", got)
+	}
+	if !slices.Contains(w.Header().Values("Vary"), "Accept") {
+		t.Errorf("Expected Vary: Accept, got %v", w.Header().Values("Vary"))
+	}
+	if plain := get("/api/articles", "application/json"); plain.Header().Get("ETag") == w.Header().Get("ETag") {
+		t.Errorf("Expected list ETags to differ between representations")
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for i := 1; i <= 2; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content", Tags: []string{"go"}}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	router := newTestRouter(NewHandler(svc))
+
+	keysOf := func(item map[string]any) []string {
+		keys := make([]string, 0, len(item))
+		for key := range item {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		return keys
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		accept     string
+		wantStatus int
+		wantKeys   []string
+	}{
+		{name: "Detail", path: "/api/articles/1?fields=title,created_at", wantStatus: http.StatusOK, wantKeys: []string{"created_at", "id", "title"}},
+		{name: "Duplicate fields", path: "/api/articles/1?fields=title,title", wantStatus: http.StatusOK, wantKeys: []string{"id", "title"}},
+		{name: "List", path: "/api/articles?fields=id,title,tags", wantStatus: http.StatusOK, wantKeys: []string{"id", "tags", "title"}},
+		{name: "Cursor list", path: "/api/articles?cursor=&fields=status", wantStatus: http.StatusOK, wantKeys: []string{"id", "status"}},
+		{name: "JSON:API detail", path: "/api/articles/1?fields[articles]=title,author", accept: "application/vnd.api+json", wantStatus: http.StatusOK, wantKeys: []string{"author", "title"}},
+		{name: "JSON:API list", path: "/api/articles?fields[articles]=views", accept: "application/vnd.api+json", wantStatus: http.StatusOK, wantKeys: []string{"views"}},
+		{name: "Unknown field", path: "/api/articles?fields=title,password", wantStatus: http.StatusBadRequest},
+		{name: "Empty field", path: "/api/articles/1?fields=", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			raw := w.Body.Bytes()
+			if tt.accept != "" || strings.HasPrefix(tt.path, "/api/articles?") {
+				raw = body.Data
+			}
+
+			var items []map[string]any
+			if err := json.Unmarshal(raw, &items); err != nil {
+				var item map[string]any
+				if err := json.Unmarshal(raw, &item); err != nil {
+					t.Fatalf("Failed to decode articles: %v", err)
+				}
+				items = []map[string]any{item}
+			}
+			if len(items) == 0 {
+				t.Fatal("Expected at least one article")
+			}
+			for _, item := range items {
+				if tt.accept != "" {
+					attributes, _ := item["attributes"].(map[string]any)
+					relationships, _ := item["relationships"].(map[string]any)
+					item = map[string]any{}
+					for key, value := range attributes {
+						item[key] = value
+					}
+					for key, value := range relationships {
+						item[key] = value
+					}
+				}
+				if got := keysOf(item); !slices.Equal(got, tt.wantKeys) {
+					t.Errorf("Expected fields %v, got %v", tt.wantKeys, got)
+				}
+			}
+		})
+	}
+}
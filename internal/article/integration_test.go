@@ -0,0 +1,415 @@
+package article
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/storage"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.ConnectDB(&config.Config{
+		Environment: "test",
+		DB: config.DBConfig{
+			Driver:          database.DriverSQLite,
+			SQLitePath:      ":memory:",
+			ConnectAttempts: 1,
+			RetryAttempts:   1,
+			RetryBackoffMin: time.Millisecond,
+			RetryBackoffMax: time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to open SQLite: %v", err)
+	}
+	if err := db.AutoMigrate(&Article{}, &Revision{}, &Tag{}, &SlugRedirect{}, &Reaction{}, &Author{}, &Translation{}); err != nil {
+		t.Fatalf("Failed to migrate SQLite: %v", err)
+	}
+	for _, table := range []string{"series_entries", "article_media"} {
+		if err := db.Exec("CREATE TABLE " + table + " (article_id integer NOT NULL)").Error; err != nil {
+			t.Fatalf("Failed to create %s: %v", table, err)
+		}
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func newIntegrationRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	handler := NewHandler(NewService(NewRepository(openSQLite(t))))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+	})
+	articles := router.Group("/api/articles")
+	{
+		articles.POST("", handler.CreateArticle)
+		articles.GET("", handler.GetAllArticles)
+		articles.GET("/search", handler.SearchArticles)
+		articles.GET("/:id", handler.GetArticleByID)
+		articles.PUT("/:id", handler.UpdateArticle)
+		articles.DELETE("/:id", handler.DeleteArticle)
+	}
+	return router
+}
+
+func sendJSON(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestArticleLifecycleSQLite(t *testing.T) {
+	router := newIntegrationRouter(t)
+
+	w := sendJSON(router, http.MethodPost, "/api/articles", `{"title":"SQLite in tests","content":"Running the handlers against an embedded database","status":"published","tags":["go","sqlite"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created Article
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.ID == 0 {
+		t.Fatalf("Expected the created article, got %s (%v)", w.Body.String(), err)
+	}
+	path := fmt.Sprintf("/api/articles/%d", created.ID)
+	etag := w.Header().Get("ETag")
+
+	w = performRequest(router, http.MethodGet, path)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var fetched Article
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil || fetched.Slug != "sqlite-in-tests" || len(fetched.Tags) != 2 {
+		t.Errorf("Unexpected article %s (%v)", w.Body.String(), err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"title":"SQLite in integration tests"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = performRequest(router, http.MethodGet, "/api/articles/search?q=integration")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var results struct {
+		Data []SearchHit `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil || len(results.Data) != 1 || results.Data[0].ID != created.ID {
+		t.Errorf("Expected the updated article in the search results, got %s (%v)", w.Body.String(), err)
+	}
+
+	w = performRequest(router, http.MethodDelete, path)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+	w = performRequest(router, http.MethodGet, path)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestPurgeArticleContentSQLite(t *testing.T) {
+	store := storage.NewMemoryStore()
+	svc := NewService(NewRepository(openSQLite(t)), WithContentStore(store, 10))
+	ctx := context.Background()
+
+	shared := "A body long enough to be offloaded and shared"
+	first, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "First", Content: shared})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	if _, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "Second", Content: shared}); err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	third, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "Third", Content: "A body that only the third article uses"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	edited := "An edited body that only the third article uses"
+	if _, err := svc.UpdateArticle(ctx, 1, third.ID, UpdateInput{Content: &edited}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if store.Len() != 3 {
+		t.Fatalf("Expected 3 stored bodies, got %d", store.Len())
+	}
+
+	if err := svc.PurgeArticle(ctx, first.ID); err != nil {
+		t.Fatalf("PurgeArticle() unexpected error: %v", err)
+	}
+	if store.Len() != 3 {
+		t.Errorf("Expected the shared body to be kept, got %d stored bodies", store.Len())
+	}
+	if err := svc.PurgeArticle(ctx, third.ID); err != nil {
+		t.Fatalf("PurgeArticle() unexpected error: %v", err)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected the bodies of the third article and its revisions to be deleted, got %d stored bodies", store.Len())
+	}
+}
+
+func TestBulkCreateRollsBackContentSQLite(t *testing.T) {
+	db := openSQLite(t)
+	store := storage.NewMemoryStore()
+	ctx := context.Background()
+
+	shared := "A body long enough to be offloaded and shared"
+	if _, err := NewService(NewRepository(db), WithContentStore(store, 10)).CreateArticle(ctx, 1, CreateInput{Title: "Existing", Content: shared}); err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	svc := NewService(NewRepository(db, WithOutbox(failingOutbox{event: EventArticleCreated})), WithContentStore(store, 10))
+	results, err := svc.BulkCreateArticles(ctx, 1, []CreateInput{
+		{Title: "Shared", Content: shared},
+		{Title: "Fresh", Content: "A body that only the failed batch uses"},
+	})
+	if err != nil {
+		t.Fatalf("BulkCreateArticles() unexpected error: %v", err)
+	}
+	for i, result := range results {
+		if result.Err == nil {
+			t.Errorf("Item %d: expected the batch to fail when its events cannot be recorded", i)
+		}
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected only the body of the existing article to be kept, got %d stored bodies", store.Len())
+	}
+}
+
+func TestSearchOffloadedContentSQLite(t *testing.T) {
+	svc := NewService(NewRepository(openSQLite(t)), WithContentStore(storage.NewMemoryStore(), 10))
+	ctx := context.Background()
+
+	created, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "Offloaded", Content: "A body mentioning goroutines", Status: StatusPublished})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	hits, total, err := svc.SearchArticles(ctx, Viewer{}, "goroutines", 1, 10)
+	if err != nil || total != 1 || len(hits) != 1 || hits[0].ID != created.ID {
+		t.Fatalf("Expected the offloaded article to match, got %v (%d, %v)", hits, total, err)
+	}
+
+	edited := "A body mentioning channels instead"
+	if _, err := svc.UpdateArticle(ctx, 1, created.ID, UpdateInput{Content: &edited}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if _, total, err := svc.SearchArticles(ctx, Viewer{}, "goroutines", 1, 10); err != nil || total != 0 {
+		t.Errorf("Expected the old body not to match, got %d (%v)", total, err)
+	}
+	if _, total, err := svc.SearchArticles(ctx, Viewer{}, "channels", 1, 10); err != nil || total != 1 {
+		t.Errorf("Expected the edited body to match, got %d (%v)", total, err)
+	}
+}
+
+type failingOutbox struct {
+	event string
+}
+
+func (outbox failingOutbox) Add(tx *gorm.DB, event string, article *Article) error {
+	if event == outbox.event {
+		return errors.New("outbox unavailable")
+	}
+	return nil
+}
+
+func TestUpdateContentStatsSQLite(t *testing.T) {
+	repo := NewRepository(openSQLite(t))
+	ctx := context.Background()
+
+	created, err := NewService(repo).CreateArticle(ctx, 1, CreateInput{Title: "Stats", Content: "A short body"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	content := "One two three\n\nfour five"
+	if err := repo.Update(ctx, created.ID, map[string]interface{}{"content": content}, 1); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	stored, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() unexpected error: %v", err)
+	}
+	if stored.ContentLen != len(content) || stored.WordCount != 5 || stored.Paragraphs != 2 {
+		t.Errorf("Expected stats of the new content, got length %d, %d words, %d paragraphs", stored.ContentLen, stored.WordCount, stored.Paragraphs)
+	}
+
+	if err := repo.Update(ctx, 999, map[string]interface{}{"content": content}, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a missing article, got %v", err)
+	}
+}
+
+type txMediaCollector struct {
+	removed []uint
+}
+
+func (c *txMediaCollector) CollectArticleMedia(ctx context.Context, articleIDs []uint) error {
+	database.AfterCommit(ctx, func() {
+		c.removed = append(c.removed, articleIDs...)
+	})
+	return nil
+}
+
+type failingPurgeRepository struct {
+	Repository
+}
+
+func (repo failingPurgeRepository) Purge(ctx context.Context, id uint) ([]string, error) {
+	return nil, errors.New("purge failed")
+}
+
+func TestPurgeKeepsMediaOnFailureSQLite(t *testing.T) {
+	repo := NewRepository(openSQLite(t))
+	collector := &txMediaCollector{}
+	ctx := context.Background()
+
+	created, err := NewService(repo).CreateArticle(ctx, 1, CreateInput{Title: "Kept", Content: "A body with media"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	if err := NewService(failingPurgeRepository{repo}, WithMediaCollector(collector)).PurgeArticle(ctx, created.ID); err == nil {
+		t.Fatal("Expected the purge to fail")
+	}
+	if len(collector.removed) != 0 {
+		t.Errorf("Expected the media of an article that was not purged to be kept, got %v removed", collector.removed)
+	}
+	if _, err := repo.GetByID(ctx, created.ID); err != nil {
+		t.Errorf("Expected the article to survive a failed purge, got %v", err)
+	}
+
+	if err := NewService(repo, WithMediaCollector(collector)).PurgeArticle(ctx, created.ID); err != nil {
+		t.Fatalf("PurgeArticle() unexpected error: %v", err)
+	}
+	if !slices.Equal(collector.removed, []uint{created.ID}) {
+		t.Errorf("Expected the media to be removed once the purge committed, got %v", collector.removed)
+	}
+}
+
+func TestPurgeDeletedSkipsRestoredSQLite(t *testing.T) {
+	repo := NewRepository(openSQLite(t))
+	collector := &txMediaCollector{}
+	svc := NewService(repo, WithMediaCollector(collector))
+	ctx := context.Background()
+
+	var ids []uint
+	for _, title := range []string{"Purged", "Restored"} {
+		created, err := svc.CreateArticle(ctx, 1, CreateInput{Title: title, Content: "A body with media"})
+		if err != nil {
+			t.Fatalf("CreateArticle() unexpected error: %v", err)
+		}
+		if err := svc.DeleteArticle(ctx, 1, created.ID); err != nil {
+			t.Fatalf("DeleteArticle() unexpected error: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+	if _, err := svc.RestoreArticle(ctx, 1, ids[1]); err != nil {
+		t.Fatalf("RestoreArticle() unexpected error: %v", err)
+	}
+
+	purged, err := svc.PurgeDeleted(ctx, -time.Hour)
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected 1 purged article, got %d (%v)", purged, err)
+	}
+	if !slices.Equal(collector.removed, ids[:1]) {
+		t.Errorf("Expected only the media of the purged article to be removed, got %v", collector.removed)
+	}
+}
+
+func TestUpdateRollsBackSQLite(t *testing.T) {
+	db := openSQLite(t)
+	repo := NewRepository(db, WithOutbox(failingOutbox{event: EventArticleUpdated}))
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	created, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "Original", Content: "The original body", Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	title, content, tags := "Renamed", "An edited body", []string{"sqlite"}
+	if _, err := svc.UpdateArticle(ctx, 1, created.ID, UpdateInput{Title: &title, Content: &content, Tags: &tags}); err == nil {
+		t.Fatal("Expected the update to fail when its event cannot be recorded")
+	}
+
+	stored, err := repo.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() unexpected error: %v", err)
+	}
+	if stored.Title != "Original" || stored.Content != "The original body" || stored.Version != created.Version || len(stored.Tags) != 1 || stored.Tags[0].Name != "go" {
+		t.Errorf("Expected the article to be unchanged, got %+v", stored)
+	}
+	revisions, err := repo.ListRevisions(ctx, created.ID)
+	if err != nil || len(revisions) != 1 {
+		t.Errorf("Expected only the first revision, got %d (%v)", len(revisions), err)
+	}
+	var newTags int64
+	if err := db.Model(&Tag{}).Where("name = ?", "sqlite").Count(&newTags).Error; err != nil || newTags != 0 {
+		t.Errorf("Expected the new tag to be rolled back, got %d (%v)", newTags, err)
+	}
+}
+
+func TestConcurrentUpdatesSQLite(t *testing.T) {
+	repo := NewRepository(openSQLite(t))
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	created, err := svc.CreateArticle(ctx, 1, CreateInput{Title: "Contended", Content: "The original body"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	const editors = 5
+	errs := make(chan error, editors)
+	for i := range editors {
+		go func() {
+			content := fmt.Sprintf("Body written by editor %d", i)
+			_, err := svc.UpdateArticle(ctx, 1, created.ID, UpdateInput{Content: &content})
+			errs <- err
+		}()
+	}
+	for range editors {
+		if err := <-errs; err != nil {
+			t.Errorf("UpdateArticle() unexpected error: %v", err)
+		}
+	}
+
+	revisions, err := repo.ListRevisions(ctx, created.ID)
+	if err != nil || len(revisions) != editors+1 {
+		t.Fatalf("Expected %d revisions, got %d (%v)", editors+1, len(revisions), err)
+	}
+	seen := make(map[int]bool)
+	for _, revision := range revisions {
+		seen[revision.Revision] = true
+	}
+	for n := 1; n <= editors+1; n++ {
+		if !seen[n] {
+			t.Errorf("Expected revision %d, got %v", n, seen)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package article
+
+import (
+	"content-service/internal/shared/jsonapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func toResource(article *Article, value any) (jsonapi.Resource, error) {
+	attributes, err := jsonapi.Attributes(value, "user_id", "category_id", "cover_media_id", "series")
+	if err != nil {
+		return jsonapi.Resource{}, err
+	}
+
+	relationships := map[string]jsonapi.Relationship{
+		"author":   jsonapi.To(UserType, article.UserID),
+		"category": {},
+		"cover":    {},
+	}
+	if article.CategoryID != nil {
+		relationships["category"] = jsonapi.To(CategoryType, *article.CategoryID)
+	}
+	if article.CoverMediaID != nil {
+		relationships["cover"] = jsonapi.To(MediaType, *article.CoverMediaID)
+	}
+	if series := article.Series; series != nil {
+		relationship := jsonapi.To(SeriesType, series.ID)
+		relationship.Meta = map[string]any{"position": series.Position, "total": series.Total, "prev": series.Prev, "next": series.Next}
+		relationships["series"] = relationship
+	}
+
+	id := jsonapi.ID(article.ID)
+	return jsonapi.Resource{
+		Type:          ResourceType,
+		ID:            id,
+		Attributes:    attributes,
+		Relationships: relationships,
+		Links:         map[string]string{"self": ResourcePath + id},
+	}, nil
+}
+
+func toResources(items any) ([]jsonapi.Resource, error) {
+	resources := []jsonapi.Resource{}
+	err := eachArticle(items, func(article *Article, value any) error {
+		resource, err := toResource(article, value)
+		if err == nil {
+			resources = append(resources, resource)
+		}
+		return err
+	})
+	return resources, err
+}
+
+func pageLinks(c *gin.Context, page, limit int, total int64) func() map[string]string {
+	return func() map[string]string {
+		lastPage := max(int((total+int64(limit)-1)/int64(limit)), 1)
+		return jsonapi.PageLinks(c.Request.URL, page, limit, page < lastPage, lastPage)
+	}
+}
+
+func offsetLinks(c *gin.Context, offset, limit int, total int64) func() map[string]string {
+	if offset%limit == 0 {
+		return pageLinks(c, offset/limit+1, limit, total)
+	}
+	return func() map[string]string {
+		last := offset + max(int((total-int64(offset)-1)/int64(limit)), 0)*limit
+		return jsonapi.OffsetLinks(c.Request.URL, offset, limit, int64(offset+limit) < total, last)
+	}
+}
@@ -1,21 +1,328 @@
 package article
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"content-service/internal/shared/textutil"
 
 	"gorm.io/gorm"
 )
 
 type Article struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Title     string         `gorm:"type:varchar(255);not null" json:"title"`
-	Content   string         `gorm:"type:text;not null" json:"content"`
-	UserID    uint           `gorm:"not null;index" json:"user_id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Title      string         `gorm:"type:varchar(255);not null" json:"title"`
+	Slug       string         `gorm:"type:varchar(255);uniqueIndex" json:"slug"`
+	Content    string         `gorm:"type:text;not null" json:"content"`
+	ContentRef string         `gorm:"type:varchar(255)" json:"-"`
+	SearchText string         `gorm:"type:text;not null;default:'';->:false;<-" json:"-"`
+	ContentLen int            `gorm:"column:content_length;not null;default:0;index" json:"-"`
+	WordCount  int            `gorm:"not null;default:0" json:"-"`
+	Paragraphs int            `gorm:"column:paragraph_count;not null;default:0" json:"-"`
+	UserID     uint           `gorm:"not null;index" json:"user_id"`
+	Status     string         `gorm:"type:varchar(20);not null;default:published;index" json:"status"`
+	Version    int            `gorm:"not null;default:1" json:"version"`
+	Language   string         `gorm:"type:varchar(16)" json:"language,omitempty"`
+	CategoryID *uint          `gorm:"index" json:"category_id,omitempty"`
+	LikesCount int64          `gorm:"not null;default:0" json:"likes_count"`
+	Views      int64          `gorm:"not null;default:0" json:"views"`
+	IsFeatured bool           `gorm:"not null;default:false;index" json:"is_featured"`
+	FeaturedAt *time.Time     `json:"featured_at,omitempty"`
+	ReviewNote string         `gorm:"type:text;not null;default:''" json:"review_note,omitempty"`
+	ReviewedBy *uint          `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time     `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	Tags       []Tag          `gorm:"many2many:article_tags" json:"tags,omitempty"`
+
+	Excerpt            string `gorm:"type:varchar(300)" json:"excerpt"`
+	ExcerptCustom      bool   `gorm:"not null;default:false" json:"-"`
+	ReadingTimeMinutes int    `gorm:"not null;default:0" json:"reading_time_minutes"`
+
+	CoverImageURL string `gorm:"type:varchar(2048);not null;default:''" json:"cover_image_url,omitempty"`
+	CoverMediaID  *uint  `gorm:"index" json:"cover_media_id,omitempty"`
+
+	Locale       string            `gorm:"-" json:"locale,omitempty"`
+	TranslatedAt time.Time         `gorm:"-" json:"-"`
+	Series       *SeriesMembership `gorm:"-" json:"series,omitempty"`
+	Warnings     []string          `gorm:"-" json:"warnings,omitempty"`
 }
 
 func (Article) TableName() string {
 	return "articles"
 }
+
+func versionStamp(t time.Time) int64 {
+	return t.Truncate(time.Microsecond).UnixNano()
+}
+
+func (a *Article) ETag() string {
+	if a.Locale != "" {
+		return fmt.Sprintf(`"%d-%x-%s-%x"`, a.ID, versionStamp(a.UpdatedAt), a.Locale, versionStamp(a.TranslatedAt))
+	}
+	return fmt.Sprintf(`"%d-%x"`, a.ID, versionStamp(a.UpdatedAt))
+}
+
+func MatchETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func baseETag(etag string) string {
+	parts := strings.SplitN(strings.Trim(etag, `"`), "-", 3)
+	if len(parts) < 2 {
+		return etag
+	}
+	return `"` + parts[0] + "-" + parts[1] + `"`
+}
+
+func MatchVersion(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if !strings.HasPrefix(candidate, "W/") && baseETag(candidate) == baseETag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Article) detached() Article {
+	a.Tags = slices.Clone(a.Tags)
+	return a
+}
+
+func detachedBatch(articles []Article) []Article {
+	copies := make([]Article, len(articles))
+	for i := range articles {
+		copies[i] = articles[i].detached()
+	}
+	return copies
+}
+
+func (a *Article) BeforeCreate(tx *gorm.DB) error {
+	if a.Version == 0 {
+		a.Version = 1
+	}
+	return nil
+}
+
+func (a *Article) BeforeSave(tx *gorm.DB) error {
+	if a.ContentRef == "" && a.Content != "" {
+		a.setContentStats(a.Content)
+	}
+	return nil
+}
+
+func (a *Article) setContentStats(content string) {
+	words, chars := textutil.Count(content, a.Language)
+	a.ContentLen = utf8.RuneCountInString(content)
+	a.WordCount = words + chars
+	a.Paragraphs = textutil.Paragraphs(content)
+}
+
+type TrashedArticle struct {
+	Article
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type OwnArticle struct {
+	Article
+	Deleted   bool       `json:"deleted"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type SeriesLink struct {
+	ID    uint   `json:"id"`
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+type SeriesMembership struct {
+	ID       uint        `json:"id"`
+	Slug     string      `json:"slug"`
+	Title    string      `json:"title"`
+	Position int         `json:"position"`
+	Total    int         `json:"total"`
+	Prev     *SeriesLink `json:"prev"`
+	Next     *SeriesLink `json:"next"`
+}
+
+type Stats struct {
+	ID                 uint       `json:"id"`
+	WordCount          int        `json:"word_count"`
+	CharacterCount     int        `json:"character_count"`
+	ParagraphCount     int        `json:"paragraph_count"`
+	ReadingTimeMinutes int        `json:"reading_time_minutes"`
+	RevisionCount      int        `json:"revision_count"`
+	LastEditorID       uint       `json:"last_editor_id"`
+	LastEditedAt       *time.Time `json:"last_edited_at"`
+}
+
+type Reaction struct {
+	ArticleID uint      `gorm:"primaryKey;autoIncrement:false" json:"article_id"`
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Reaction) TableName() string {
+	return "article_reactions"
+}
+
+type Author struct {
+	ArticleID uint      `gorm:"primaryKey;autoIncrement:false" json:"article_id"`
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false;index" json:"user_id"`
+	Role      string    `gorm:"type:varchar(20);not null" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Author) TableName() string {
+	return "article_authors"
+}
+
+type Translation struct {
+	ArticleID    uint      `gorm:"primaryKey;autoIncrement:false" json:"article_id"`
+	Locale       string    `gorm:"primaryKey;type:varchar(16)" json:"locale"`
+	Title        string    `gorm:"type:varchar(255);not null" json:"title"`
+	Content      string    `gorm:"type:text;not null" json:"content,omitempty"`
+	Excerpt      string    `gorm:"type:varchar(300)" json:"excerpt"`
+	TranslatorID uint      `gorm:"not null" json:"translator_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Translation) TableName() string {
+	return "article_translations"
+}
+
+type ListCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uint      `json:"id"`
+}
+
+type SearchHit struct {
+	Article
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type SearchMatch struct {
+	ArticleID uint
+	Rank      float64
+	Snippet   string
+}
+
+type Revision struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ArticleID  uint      `gorm:"not null;uniqueIndex:idx_article_revisions_article_revision" json:"article_id"`
+	Revision   int       `gorm:"not null;uniqueIndex:idx_article_revisions_article_revision" json:"revision"`
+	Title      string    `gorm:"type:varchar(255);not null" json:"title"`
+	Content    string    `gorm:"type:text;not null" json:"-"`
+	ContentRef string    `gorm:"type:varchar(255)" json:"-"`
+	EditorID   uint      `gorm:"not null" json:"editor_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (Revision) TableName() string {
+	return "article_revisions"
+}
+
+type Tag struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"type:varchar(50);not null;uniqueIndex"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Name)
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Name)
+}
+
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type SlugRedirect struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Slug      string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"slug"`
+	ArticleID uint      `gorm:"not null;index" json:"article_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (SlugRedirect) TableName() string {
+	return "article_slug_redirects"
+}
+
+type RevisionDiff struct {
+	ArticleID    uint   `json:"article_id"`
+	From         int    `json:"from"`
+	To           int    `json:"to"`
+	TitleChanged bool   `json:"title_changed"`
+	Added        int    `json:"added"`
+	Removed      int    `json:"removed"`
+	Diff         string `json:"diff"`
+}
+
+type ArticleETag struct {
+	ID        uint      `json:"id"`
+	ETag      string    `json:"etag"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SitemapEntry struct {
+	Slug      string    `json:"slug"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type EventData struct {
+	ID         uint      `json:"id"`
+	UserID     uint      `json:"user_id"`
+	Title      string    `json:"title"`
+	Slug       string    `json:"slug"`
+	Status     string    `json:"status"`
+	Language   string    `json:"language,omitempty"`
+	CategoryID *uint     `json:"category_id,omitempty"`
+	Tags       []Tag     `json:"tags"`
+	Excerpt    string    `json:"excerpt"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewEventData(a *Article) EventData {
+	tags := a.Tags
+	if tags == nil {
+		tags = []Tag{}
+	}
+	return EventData{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Title:      a.Title,
+		Slug:       a.Slug,
+		Status:     a.Status,
+		Language:   a.Language,
+		CategoryID: a.CategoryID,
+		Tags:       tags,
+		Excerpt:    a.Excerpt,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
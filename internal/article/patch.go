@@ -0,0 +1,119 @@
+package article
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+type patchDocument struct {
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	Status        string   `json:"status"`
+	Language      string   `json:"language"`
+	Tags          []string `json:"tags"`
+	CategoryID    *uint    `json:"category_id"`
+	Excerpt       string   `json:"excerpt"`
+	CoverImageURL string   `json:"cover_image_url"`
+	CoverMediaID  *uint    `json:"cover_media_id"`
+	Version       int      `json:"version"`
+}
+
+func newPatchDocument(article *Article) patchDocument {
+	tags := make([]string, len(article.Tags))
+	for i, tag := range article.Tags {
+		tags[i] = tag.Name
+	}
+	doc := patchDocument{
+		Title:         article.Title,
+		Content:       article.Content,
+		Status:        article.Status,
+		Language:      article.Language,
+		Tags:          tags,
+		CategoryID:    article.CategoryID,
+		CoverImageURL: article.CoverImageURL,
+		CoverMediaID:  article.CoverMediaID,
+		Version:       article.Version,
+	}
+	if article.ExcerptCustom {
+		doc.Excerpt = article.Excerpt
+	}
+	return doc
+}
+
+func applyPatch(contentType string, original patchDocument, patch []byte) (patchDocument, error) {
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		return patchDocument{}, err
+	}
+
+	var patched []byte
+	if contentType == MediaTypeJSONPatch {
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return patchDocument{}, fmt.Errorf("%w: invalid JSON Patch: %v", ErrValidation, err)
+		}
+		if patched, err = operations.Apply(encoded); err != nil {
+			return patchDocument{}, fmt.Errorf("%w: failed to apply JSON Patch: %v", ErrValidation, err)
+		}
+	} else if patched, err = jsonpatch.MergePatch(encoded, patch); err != nil {
+		return patchDocument{}, fmt.Errorf("%w: invalid merge patch: %v", ErrValidation, err)
+	}
+
+	var doc patchDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return patchDocument{}, fmt.Errorf("%w: patched article is invalid: %v", ErrValidation, err)
+	}
+	if doc.Version != original.Version {
+		return patchDocument{}, fmt.Errorf("%w: expected version %d, current version is %d", ErrVersionConflict, doc.Version, original.Version)
+	}
+	return doc, nil
+}
+
+func optionalID(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func (doc patchDocument) changes(original patchDocument) UpdateArticleRequest {
+	var req UpdateArticleRequest
+	if doc.Title != original.Title {
+		req.Title = &doc.Title
+	}
+	if doc.Content != original.Content {
+		req.Content = &doc.Content
+	}
+	if doc.Status != original.Status {
+		req.Status = &doc.Status
+	}
+	if doc.Language != original.Language {
+		req.Language = &doc.Language
+	}
+	if !slices.Equal(doc.Tags, original.Tags) {
+		tags := doc.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		req.Tags = &tags
+	}
+	if categoryID := optionalID(doc.CategoryID); categoryID != optionalID(original.CategoryID) {
+		req.CategoryID = &categoryID
+	}
+	if doc.Excerpt != original.Excerpt {
+		req.Excerpt = &doc.Excerpt
+	}
+	if doc.CoverImageURL != original.CoverImageURL {
+		req.CoverImageURL = &doc.CoverImageURL
+	}
+	if mediaID := optionalID(doc.CoverMediaID); mediaID != optionalID(original.CoverMediaID) {
+		req.CoverMediaID = &mediaID
+	}
+	return req
+}
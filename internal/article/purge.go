@@ -0,0 +1,28 @@
+package article
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func RunPurger(ctx context.Context, svc Service, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := svc.PurgeDeleted(ctx, retention)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to purge soft-deleted articles")
+		} else if purged > 0 {
+			log.Info().Int64("purged", purged).Dur("retention", retention).Msg("Purged soft-deleted articles")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package article
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"content-service/internal/shared/jsonapi"
+	"content-service/internal/shared/negotiate"
+
+	"github.com/gin-gonic/gin"
+)
+
+func eachArticle(items any, fn func(article *Article, value any) error) error {
+	var err error
+	switch items := items.(type) {
+	case []Article:
+		for i := 0; i < len(items) && err == nil; i++ {
+			err = fn(&items[i], items[i])
+		}
+	case []TrashedArticle:
+		for i := 0; i < len(items) && err == nil; i++ {
+			err = fn(&items[i].Article, items[i])
+		}
+	case []OwnArticle:
+		for i := 0; i < len(items) && err == nil; i++ {
+			err = fn(&items[i].Article, items[i])
+		}
+	case []TrendingArticle:
+		for i := 0; i < len(items) && err == nil; i++ {
+			err = fn(&items[i].Article, items[i])
+		}
+	case []SearchHit:
+		for i := 0; i < len(items) && err == nil; i++ {
+			err = fn(&items[i].Article, items[i])
+		}
+	}
+	return err
+}
+
+func listETag(items any, meta gin.H, mediaType string) string {
+	hash := sha256.New()
+	_ = eachArticle(items, func(article *Article, _ any) error {
+		fmt.Fprintln(hash, article.ETag())
+		return nil
+	})
+	_ = json.NewEncoder(hash).Encode(meta)
+	fmt.Fprintln(hash, mediaType)
+	return fmt.Sprintf(`W/"%x"`, hash.Sum(nil)[:16])
+}
+
+func requestedFields(c *gin.Context) []string {
+	value, _ := c.Get(FieldsContextKey)
+	fields, _ := value.([]string)
+	return fields
+}
+
+func sparseFields(value any, fields []string) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string]any)
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, err
+	}
+	selected := map[string]any{"id": all["id"]}
+	for _, field := range fields {
+		if v, ok := all[field]; ok {
+			selected[field] = v
+		}
+	}
+	return selected, nil
+}
+
+func sparseList(items any, fields []string) ([]map[string]any, error) {
+	selected := []map[string]any{}
+	err := eachArticle(items, func(_ *Article, value any) error {
+		item, err := sparseFields(value, fields)
+		if err == nil {
+			selected = append(selected, item)
+		}
+		return err
+	})
+	return selected, err
+}
+
+func sparseResource(resource *jsonapi.Resource, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	for key := range resource.Attributes {
+		if !slices.Contains(fields, key) {
+			delete(resource.Attributes, key)
+		}
+	}
+	for key := range resource.Relationships {
+		if !slices.Contains(fields, key) {
+			delete(resource.Relationships, key)
+		}
+	}
+}
+
+func notModified(c *gin.Context, etag string) bool {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifNoneMatch == "" || !MatchETag(ifNoneMatch, etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+func (handler *Handler) renderArticle(c *gin.Context, status int, article *Article) {
+	jsonapi.VaryAccept(c)
+	etag := article.ETag()
+	c.Header("ETag", etag)
+	if status == http.StatusOK && notModified(c, etag) {
+		return
+	}
+	fields := requestedFields(c)
+	if !jsonapi.Requested(c) {
+		var body any = article
+		if len(fields) > 0 {
+			selected, err := sparseFields(article, fields)
+			if err != nil {
+				handler.handleError(c, err)
+				return
+			}
+			body = selected
+		}
+		if err := negotiate.Render(c, status, body); err != nil {
+			handler.handleError(c, err)
+		}
+		return
+	}
+
+	resource, err := toResource(article, article)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+	sparseResource(&resource, fields)
+	jsonapi.Render(c, status, jsonapi.Document{Data: resource})
+}
+
+func (handler *Handler) renderArticles(c *gin.Context, items any, meta gin.H, links func() map[string]string) {
+	jsonapi.VaryAccept(c)
+	requested := jsonapi.Requested(c)
+	mediaType := jsonapi.MediaType
+	if !requested {
+		mediaType = negotiate.MediaType(c)
+	}
+	etag := listETag(items, meta, mediaType)
+	c.Header("ETag", etag)
+	if notModified(c, etag) {
+		return
+	}
+	fields := requestedFields(c)
+	if !requested {
+		data := items
+		if len(fields) > 0 {
+			selected, err := sparseList(items, fields)
+			if err != nil {
+				handler.handleError(c, err)
+				return
+			}
+			data = selected
+		}
+		if err := negotiate.Render(c, http.StatusOK, gin.H{"data": data, "meta": meta}); err != nil {
+			handler.handleError(c, err)
+		}
+		return
+	}
+
+	resources, err := toResources(items)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+	for i := range resources {
+		sparseResource(&resources[i], fields)
+	}
+	jsonapi.Render(c, http.StatusOK, jsonapi.Document{Data: resources, Meta: meta, Links: links()})
+}
@@ -1,38 +1,362 @@
 package article
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"content-service/internal/shared/cache"
+	"content-service/internal/shared/database"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+type ListFilter struct {
+	IDs              []uint
+	UserID           *uint
+	Statuses         []string
+	MinContentLength *int
+	MaxContentLength *int
+	CategoryID       *uint
+	CategoryIDs      []uint
+	CreatedAfter     *time.Time
+	CreatedBefore    *time.Time
+	Featured         *bool
+	HasCover         *bool
+	Tag              string
+	PinnedFirst      bool
+	Sort             string
+	Order            string
+	Fields           []string
+	Count            string
+}
+
+func (filter ListFilter) unfiltered() bool {
+	return len(filter.IDs) == 0 && filter.UserID == nil &&
+		filter.MinContentLength == nil && filter.MaxContentLength == nil &&
+		filter.CategoryID == nil && len(filter.CategoryIDs) == 0 &&
+		filter.CreatedAfter == nil && filter.CreatedBefore == nil &&
+		filter.Featured == nil && filter.HasCover == nil && filter.Tag == ""
+}
+
+type OwnFilter struct {
+	Statuses []string
+	Deleted  bool
+	Sort     string
+	Order    string
+}
+
 type Repository interface {
-	Create(article *Article) error
-	GetByID(id uint) (*Article, error)
-	GetAll(page, limit int) ([]Article, int64, error)
-	Update(id uint, updates map[string]interface{}) error
-	Delete(id uint) error
+	Create(ctx context.Context, article *Article) error
+	CreateBatch(ctx context.Context, articles []Article) error
+	ImportBatch(ctx context.Context, articles []Article) error
+	GetByID(ctx context.Context, id uint) (*Article, error)
+	LockVersion(ctx context.Context, id uint) (time.Time, error)
+	BumpVersion(ctx context.Context, id uint, expected int) (int, error)
+	GetBySlug(ctx context.Context, slug string) (*Article, error)
+	GetSlugRedirect(ctx context.Context, slug string) (*SlugRedirect, error)
+	SlugOwner(ctx context.Context, slug string) (uint, error)
+	UpdateSlug(ctx context.Context, id uint, slug string) error
+	ReplaceTags(ctx context.Context, id uint, names []string) error
+	AddReaction(ctx context.Context, articleID, userID uint) (int64, error)
+	RemoveReaction(ctx context.Context, articleID, userID uint) (int64, error)
+	AuthorRole(ctx context.Context, articleID, userID uint) (string, error)
+	ListAuthors(ctx context.Context, articleID uint) ([]Author, error)
+	SetAuthor(ctx context.Context, author *Author) error
+	RemoveAuthor(ctx context.Context, articleID, userID uint) error
+	SaveTranslation(ctx context.Context, translation *Translation) error
+	GetTranslation(ctx context.Context, articleID uint, locale string) (*Translation, error)
+	ListTranslations(ctx context.Context, articleID uint) ([]Translation, error)
+	FindTranslations(ctx context.Context, articleIDs []uint, language string) ([]Translation, error)
+	DeleteTranslation(ctx context.Context, articleID uint, locale string) error
+	TagCounts(ctx context.Context, minCount int) ([]TagCount, error)
+	IncrementViews(ctx context.Context, counts map[uint]int64) error
+	SetFeatured(ctx context.Context, id uint, featuredAt *time.Time) error
+	Popular(ctx context.Context, offset, limit int) ([]Article, error)
+	Search(ctx context.Context, query string, page, limit int) ([]SearchHit, int64, error)
+	GetAll(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, int64, error)
+	List(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, error)
+	ListAfter(ctx context.Context, filter ListFilter, after *ListCursor, limit int) ([]Article, error)
+	GetAllVersions(ctx context.Context, filter ListFilter, page, limit int) ([]Article, int64, error)
+	CountAll(ctx context.Context) (int64, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}, editorID uint) error
+	Delete(ctx context.Context, id uint) error
+	DeleteBatch(ctx context.Context, ids []uint) error
+	GetDeletedByID(ctx context.Context, id uint) (*Article, error)
+	GetDeleted(ctx context.Context, ownerID uint, page, limit int) ([]Article, int64, error)
+	GetOwned(ctx context.Context, userID uint, filter OwnFilter, page, limit int) ([]Article, int64, error)
+	StatusCounts(ctx context.Context) (map[string]int64, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) ([]string, error)
+	DeletedBefore(ctx context.Context, cutoff time.Time) ([]uint, error)
+	PurgeDeleted(ctx context.Context, ids []uint) (int64, []string, error)
+	UnreferencedRefs(ctx context.Context, refs []string) ([]string, error)
+	TrendingCandidates(ctx context.Context, since time.Time) ([]TrendingCandidate, error)
+	GetRevision(ctx context.Context, articleID uint, revision int) (*Revision, error)
+	LatestRevision(ctx context.Context, articleID uint) (*Revision, error)
+	ListRevisions(ctx context.Context, articleID uint) ([]Revision, error)
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	RecordEvents(ctx context.Context, article *Article, events ...string) error
+}
+
+type Outbox interface {
+	Add(tx *gorm.DB, event string, article *Article) error
 }
 
 type articleRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	outbox      Outbox
+	replica     bool
+	estimateMin int64
+	counts      *cache.LRUCache
+	countTTL    time.Duration
+	batchSize   int
+}
+
+type RepositoryOption func(*articleRepository)
+
+func WithOutbox(outbox Outbox) RepositoryOption {
+	return func(repo *articleRepository) {
+		repo.outbox = outbox
+	}
+}
+
+func WithCountStrategy(estimateMin int64, cacheTTL time.Duration) RepositoryOption {
+	return func(repo *articleRepository) {
+		repo.estimateMin = estimateMin
+		repo.countTTL = cacheTTL
+		if cacheTTL > 0 {
+			repo.counts = cache.NewLRUCache(countCacheSize)
+		}
+	}
+}
+
+func WithBatchSize(size int) RepositoryOption {
+	return func(repo *articleRepository) {
+		repo.batchSize = size
+	}
+}
+
+func NewRepository(db *gorm.DB, opts ...RepositoryOption) Repository {
+	repo := &articleRepository{db: db, batchSize: DefaultBatchSize}
+	for _, opt := range opts {
+		opt(repo)
+	}
+	return repo
+}
+
+func (repo *articleRepository) Replica() Repository {
+	replica := *repo
+	replica.replica = true
+	return &replica
+}
+
+func (repo *articleRepository) reads(ctx context.Context) *gorm.DB {
+	if repo.replica {
+		return database.ReadReplica(database.Conn(ctx, repo.db))
+	}
+	return database.Conn(ctx, repo.db)
 }
 
-func NewRepository(db *gorm.DB) Repository {
-	return &articleRepository{db: db}
+func (repo *articleRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return database.WithTx(ctx, repo.db, fn)
+}
+
+func (repo *articleRepository) RecordEvents(ctx context.Context, article *Article, events ...string) error {
+	if repo.outbox == nil {
+		return nil
+	}
+	for _, event := range events {
+		if err := repo.outbox.Add(database.Conn(ctx, repo.db), event, article); err != nil {
+			return fmt.Errorf("repo: failed to record %s event of article %d: %w", event, article.ID, err)
+		}
+	}
+	return nil
 }
 
-func (repo *articleRepository) Create(article *Article) error {
-	if err := repo.db.Create(article).Error; err != nil {
+func (repo *articleRepository) Create(ctx context.Context, article *Article) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		tags, err := resolveTags(tx, article.Tags)
+		if err != nil {
+			return err
+		}
+		article.Tags = tags
+
+		if err := tx.Create(article).Error; err != nil {
+			return err
+		}
+		if err := createOwner(tx, article); err != nil {
+			return err
+		}
+		return createRevision(tx, article, article.UserID)
+	})
+	if err != nil {
 		return fmt.Errorf("repo: failed to create article: %w", err)
 	}
 	return nil
 }
 
-func (repo *articleRepository) GetByID(id uint) (*Article, error) {
+func stampBatch(articles []Article, at time.Time) {
+	for i := range articles {
+		articles[i].CreatedAt = at
+		articles[i].UpdatedAt = at
+	}
+}
+
+func (repo *articleRepository) CreateBatch(ctx context.Context, articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	stampBatch(articles, time.Now())
+	return repo.insertBatch(ctx, articles)
+}
+
+func (repo *articleRepository) ImportBatch(ctx context.Context, articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	return repo.insertBatch(ctx, articles)
+}
+
+func (repo *articleRepository) insertBatch(ctx context.Context, articles []Article) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := resolveBatchTags(tx, articles); err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(&articles, repo.batchSize).Error; err != nil {
+			return err
+		}
+
+		authors := make([]Author, 0, len(articles))
+		revisions := make([]Revision, 0, len(articles))
+		for _, article := range articles {
+			authors = append(authors, Author{ArticleID: article.ID, UserID: article.UserID, Role: AuthorRoleOwner})
+			revisions = append(revisions, Revision{
+				ArticleID:  article.ID,
+				Revision:   1,
+				Title:      article.Title,
+				Content:    article.Content,
+				ContentRef: article.ContentRef,
+				EditorID:   article.UserID,
+			})
+		}
+		if err := tx.CreateInBatches(&authors, repo.batchSize).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(&revisions, repo.batchSize).Error
+	})
+	if err != nil {
+		return fmt.Errorf("repo: failed to create %d articles: %w", len(articles), err)
+	}
+	return nil
+}
+
+func resolveBatchTags(tx *gorm.DB, articles []Article) error {
+	var tags []Tag
+	seen := map[string]bool{}
+	for _, article := range articles {
+		for _, tag := range article.Tags {
+			if !seen[tag.Name] {
+				seen[tag.Name] = true
+				tags = append(tags, Tag{Name: tag.Name})
+			}
+		}
+	}
+
+	resolved, err := resolveTags(tx, tags)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]Tag, len(resolved))
+	for _, tag := range resolved {
+		byName[tag.Name] = tag
+	}
+
+	for i := range articles {
+		if len(articles[i].Tags) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(articles[i].Tags))
+		for _, tag := range articles[i].Tags {
+			names = append(names, tag.Name)
+		}
+		slices.Sort(names)
+		tags := make([]Tag, 0, len(names))
+		for _, name := range slices.Compact(names) {
+			tags = append(tags, byName[name])
+		}
+		articles[i].Tags = tags
+	}
+	return nil
+}
+
+func createOwner(tx *gorm.DB, article *Article) error {
+	return tx.Create(&Author{ArticleID: article.ID, UserID: article.UserID, Role: AuthorRoleOwner}).Error
+}
+
+func resolveTags(tx *gorm.DB, tags []Tag) ([]Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var resolved []Tag
+	if err := tx.Where("name IN ?", names).Order("name ASC").Find(&resolved).Error; err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func preloadTags(query *gorm.DB) *gorm.DB {
+	return query.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name ASC")
+	})
+}
+
+func createRevision(tx *gorm.DB, article *Article, editorID uint) error {
+	var locked Article
+	if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, article.ID).Error; err != nil {
+		return err
+	}
+
+	var last int
+	err := tx.Model(&Revision{}).
+		Where("article_id = ?", article.ID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&Revision{
+		ArticleID:  article.ID,
+		Revision:   last + 1,
+		Title:      article.Title,
+		Content:    article.Content,
+		ContentRef: article.ContentRef,
+		EditorID:   editorID,
+	}).Error
+}
+
+func (repo *articleRepository) GetByID(ctx context.Context, id uint) (*Article, error) {
 	var article Article
-	err := repo.db.First(&article, id).Error
+	err := preloadTags(repo.reads(ctx)).First(&article, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrNotFound
@@ -42,44 +366,760 @@ func (repo *articleRepository) GetByID(id uint) (*Article, error) {
 	return &article, nil
 }
 
-func (repo *articleRepository) GetAll(page, limit int) ([]Article, int64, error) {
+func (repo *articleRepository) LockVersion(ctx context.Context, id uint) (time.Time, error) {
+	var article Article
+	err := database.Conn(ctx, repo.db).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "updated_at").First(&article, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, ErrNotFound
+		}
+		return time.Time{}, fmt.Errorf("repo: failed to lock article %d: %w", id, err)
+	}
+	return article.UpdatedAt, nil
+}
+
+func (repo *articleRepository) BumpVersion(ctx context.Context, id uint, expected int) (int, error) {
+	query := database.Conn(ctx, repo.db).Model(&Article{}).Where("id = ?", id)
+	if expected > 0 {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo: failed to bump version of article %d: %w", id, result.Error)
+	}
+
+	var article Article
+	if err := database.Conn(ctx, repo.db).Select("version").First(&article, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("repo: failed to get version of article %d: %w", id, err)
+	}
+	if result.RowsAffected == 0 {
+		return article.Version, ErrVersionConflict
+	}
+	return article.Version, nil
+}
+
+func (repo *articleRepository) GetBySlug(ctx context.Context, slug string) (*Article, error) {
+	var article Article
+	err := preloadTags(database.Conn(ctx, repo.db)).Where("slug = ?", slug).First(&article).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get article by slug %q: %w", slug, err)
+	}
+	return &article, nil
+}
+
+func (repo *articleRepository) GetSlugRedirect(ctx context.Context, slug string) (*SlugRedirect, error) {
+	var redirect SlugRedirect
+	err := database.Conn(ctx, repo.db).Where("slug = ?", slug).First(&redirect).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get slug redirect %q: %w", slug, err)
+	}
+	return &redirect, nil
+}
+
+func (repo *articleRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	var ids []uint
+	err := database.Conn(ctx, repo.db).Unscoped().Model(&Article{}).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to check slug %q: %w", slug, err)
+	}
+	if len(ids) > 0 {
+		return ids[0], nil
+	}
+
+	err = database.Conn(ctx, repo.db).Model(&SlugRedirect{}).Where("slug = ?", slug).Limit(1).Pluck("article_id", &ids).Error
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to check slug redirect %q: %w", slug, err)
+	}
+	if len(ids) > 0 {
+		return ids[0], nil
+	}
+	return 0, nil
+}
+
+func (repo *articleRepository) UpdateSlug(ctx context.Context, id uint, slug string) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var article Article
+		if err := tx.First(&article, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if article.Slug == slug {
+			return nil
+		}
+
+		if err := tx.Model(&article).Update("slug", slug).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("slug = ? AND article_id = ?", slug, id).Delete(&SlugRedirect{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&SlugRedirect{Slug: article.Slug, ArticleID: id}).Error
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to update slug of article %d: %w", id, err)
+	}
+	return nil
+}
+
+var baseColumns = []string{"id", "language", "created_at", "updated_at"}
+
+var fieldColumns = map[string][]string{
+	"id":                   nil,
+	"title":                {"title"},
+	"slug":                 {"slug"},
+	"content":              {"content", "content_ref"},
+	"user_id":              {"user_id"},
+	"author":               {"user_id"},
+	"status":               {"status"},
+	"version":              {"version"},
+	"language":             nil,
+	"category_id":          {"category_id"},
+	"category":             {"category_id"},
+	"likes_count":          {"likes_count"},
+	"views":                {"views"},
+	"is_featured":          {"is_featured"},
+	"featured_at":          {"featured_at"},
+	"review_note":          {"review_note"},
+	"reviewed_by":          {"reviewed_by"},
+	"reviewed_at":          {"reviewed_at"},
+	"created_at":           nil,
+	"updated_at":           nil,
+	"tags":                 nil,
+	"excerpt":              {"excerpt", "excerpt_custom", "content", "content_ref"},
+	"reading_time_minutes": {"reading_time_minutes", "content", "content_ref"},
+	"cover_image_url":      {"cover_image_url"},
+	"cover_media_id":       {"cover_media_id"},
+	"cover":                {"cover_media_id"},
+	"locale":               nil,
+	"series":               nil,
+}
+
+func selectFields(query *gorm.DB, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return preloadTags(query)
+	}
+	if slices.Contains(fields, "tags") {
+		query = preloadTags(query)
+	}
+	columns := slices.Clone(baseColumns)
+	for _, field := range fields {
+		columns = append(columns, fieldColumns[field]...)
+	}
+	slices.Sort(columns)
+	return query.Select(slices.Compact(columns))
+}
+
+func applyListFilter(query *gorm.DB, filter ListFilter) *gorm.DB {
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.MinContentLength != nil {
+		query = query.Where("content_length >= ?", *filter.MinContentLength)
+	}
+	if filter.MaxContentLength != nil {
+		query = query.Where("content_length <= ?", *filter.MaxContentLength)
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("category_id IN ?", filter.CategoryIDs)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.Featured != nil {
+		query = query.Where("is_featured = ?", *filter.Featured)
+	}
+	if filter.HasCover != nil {
+		if *filter.HasCover {
+			query = query.Where("cover_image_url <> ''")
+		} else {
+			query = query.Where("cover_image_url = ''")
+		}
+	}
+	if filter.Tag != "" {
+		query = query.Where("id IN (SELECT article_tags.article_id FROM article_tags JOIN tags ON tags.id = article_tags.tag_id WHERE tags.name = ?)", filter.Tag)
+	}
+	return query
+}
+
+func listOrder(filter ListFilter) string {
+	column, direction := SortCreatedAt, OrderDesc
+	switch filter.Sort {
+	case SortUpdatedAt, SortTitle:
+		column = filter.Sort
+	}
+	if filter.Order == OrderAsc {
+		direction = OrderAsc
+	}
+	order := fmt.Sprintf("%s %s, id %s", column, direction, direction)
+	if filter.PinnedFirst {
+		order = "is_featured DESC, featured_at DESC NULLS LAST, " + order
+	}
+	return order
+}
+
+func (repo *articleRepository) GetAll(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, int64, error) {
 	var articles []Article
+
+	total, err := repo.count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = applyListFilter(selectFields(repo.reads(ctx), filter.Fields), filter).
+		Order(listOrder(filter)).
+		Offset(offset).
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to get articles: %w", err)
+	}
+
+	return articles, total, nil
+}
+
+func (repo *articleRepository) count(ctx context.Context, filter ListFilter) (int64, error) {
+	var key string
+	if repo.counts != nil && filter.unfiltered() && !database.InTx(ctx) {
+		key = fmt.Sprintf("%s:%s", filter.Count, strings.Join(filter.Statuses, ","))
+		if cached, ok, _ := repo.counts.Get(ctx, key); ok {
+			if total, err := strconv.ParseInt(string(cached), 10, 64); err == nil {
+				return total, nil
+			}
+		}
+	}
+
+	total, err := repo.countRows(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if key != "" {
+		_ = repo.counts.Set(ctx, key, []byte(strconv.FormatInt(total, 10)), repo.countTTL)
+	}
+	return total, nil
+}
+
+func (repo *articleRepository) countRows(ctx context.Context, filter ListFilter) (int64, error) {
+	if filter.Count == CountEstimated && !database.IsSQLite(repo.db) {
+		var plan string
+		query := applyListFilter(repo.reads(ctx).Model(&Article{}).Select("1"), filter)
+		if err := repo.reads(ctx).Raw("EXPLAIN (FORMAT JSON) ?", query).Scan(&plan).Error; err != nil {
+			return 0, fmt.Errorf("repo: failed to estimate articles: %w", err)
+		}
+		estimate, err := planRows(plan)
+		if err != nil {
+			return 0, fmt.Errorf("repo: failed to estimate articles: %w", err)
+		}
+		if estimate >= repo.estimateMin {
+			return estimate, nil
+		}
+	}
+
 	var total int64
+	if err := applyListFilter(repo.reads(ctx).Model(&Article{}), filter).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("repo: failed to count articles: %w", err)
+	}
+	return total, nil
+}
 
-	if err := repo.db.Model(&Article{}).Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("repo: failed to count articles: %w", err)
+func planRows(plan string) (int64, error) {
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil {
+		return 0, err
 	}
+	if len(explained) == 0 {
+		return 0, errors.New("empty query plan")
+	}
+	return int64(explained[0].Plan.Rows), nil
+}
 
-	offset := (page - 1) * limit
+func (repo *articleRepository) List(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, error) {
+	var articles []Article
 
-	err := repo.db.Order("created_at DESC").
+	err := applyListFilter(selectFields(repo.reads(ctx), filter.Fields), filter).
+		Order(listOrder(filter)).
 		Offset(offset).
 		Limit(limit).
 		Find(&articles).Error
 	if err != nil {
-		return nil, 0, fmt.Errorf("repo: failed to get articles: %w", err)
+		return nil, fmt.Errorf("repo: failed to list articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+func (repo *articleRepository) ListAfter(ctx context.Context, filter ListFilter, after *ListCursor, limit int) ([]Article, error) {
+	var articles []Article
+
+	query := applyListFilter(selectFields(repo.reads(ctx), filter.Fields), filter)
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to list articles after cursor: %w", err)
+	}
+
+	return articles, nil
+}
+
+func (repo *articleRepository) GetAllVersions(ctx context.Context, filter ListFilter, page, limit int) ([]Article, int64, error) {
+	var articles []Article
+	var total int64
+
+	if err := applyListFilter(database.Conn(ctx, repo.db).Model(&Article{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count articles: %w", err)
+	}
+
+	err := applyListFilter(database.Conn(ctx, repo.db), filter).
+		Select("id", "slug", "updated_at").
+		Order("id ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to get article versions: %w", err)
 	}
 
 	return articles, total, nil
 }
 
-func (repo *articleRepository) Update(id uint, updates map[string]interface{}) error {
+func (repo *articleRepository) CountAll(ctx context.Context) (int64, error) {
+	var total int64
+	if err := database.Conn(ctx, repo.db).Unscoped().Model(&Article{}).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("repo: failed to count all articles: %w", err)
+	}
+	return total, nil
+}
+
+func withContentStats(tx *gorm.DB, id uint, updates map[string]interface{}) (map[string]interface{}, error) {
+	_, contentChanged := updates["content"]
+	_, languageChanged := updates["language"]
+	if _, ok := updates["content_length"]; ok || (!contentChanged && !languageChanged) {
+		return updates, nil
+	}
+
+	var article Article
+	if err := tx.Select("content", "content_ref", "language").First(&article, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if content, ok := updates["content"].(string); ok {
+		article.Content = content
+	}
+	if ref, ok := updates["content_ref"].(string); ok {
+		article.ContentRef = ref
+	}
+	if language, ok := updates["language"].(string); ok {
+		article.Language = language
+	}
+	if article.ContentRef != "" {
+		return updates, nil
+	}
+
+	article.setContentStats(article.Content)
+	updates = maps.Clone(updates)
+	updates["content_length"] = article.ContentLen
+	updates["word_count"] = article.WordCount
+	updates["paragraph_count"] = article.Paragraphs
+	return updates, nil
+}
+
+func (repo *articleRepository) Update(ctx context.Context, id uint, updates map[string]interface{}, editorID uint) error {
 	if len(updates) == 0 {
 		return fmt.Errorf("repo: no fields to update")
 	}
 
-	updateResult := repo.db.Model(&Article{}).Where("id = ?", id).Updates(updates)
-	if updateResult.Error != nil {
-		return fmt.Errorf("repo: failed to update article %d: %w", id, updateResult.Error)
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		updates, err := withContentStats(tx, id, updates)
+		if err != nil {
+			return err
+		}
+		updateResult := tx.Model(&Article{}).Where("id = ?", id).Updates(updates)
+		if updateResult.Error != nil {
+			return updateResult.Error
+		}
+		if updateResult.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		_, titleChanged := updates["title"]
+		_, contentChanged := updates["content"]
+		if !titleChanged && !contentChanged {
+			return nil
+		}
+
+		var article Article
+		if err := tx.First(&article, id).Error; err != nil {
+			return err
+		}
+		return createRevision(tx, &article, editorID)
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to update article %d: %w", id, err)
 	}
-	if updateResult.RowsAffected == 0 {
+	return nil
+}
+
+func (repo *articleRepository) ReplaceTags(ctx context.Context, id uint, names []string) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		touchResult := tx.Model(&Article{}).Where("id = ?", id).Update("updated_at", time.Now())
+		if touchResult.Error != nil {
+			return touchResult.Error
+		}
+		if touchResult.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		tags := make([]Tag, 0, len(names))
+		for _, name := range names {
+			tags = append(tags, Tag{Name: name})
+		}
+		resolved, err := resolveTags(tx, tags)
+		if err != nil {
+			return err
+		}
+
+		association := tx.Model(&Article{ID: id}).Association("Tags")
+		if len(resolved) == 0 {
+			return association.Clear()
+		}
+		return association.Replace(resolved)
+	})
+	if errors.Is(err, ErrNotFound) {
 		return ErrNotFound
 	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to replace tags of article %d: %w", id, err)
+	}
+	return nil
+}
+
+func (repo *articleRepository) changeReaction(ctx context.Context, articleID, userID uint, add bool) (int64, error) {
+	var likes int64
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		reaction := &Reaction{ArticleID: articleID, UserID: userID}
+
+		var result *gorm.DB
+		delta := 1
+		if add {
+			result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+		} else {
+			result = tx.Where("article_id = ? AND user_id = ?", articleID, userID).Delete(&Reaction{})
+			delta = -1
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			updateResult := tx.Model(&Article{}).Where("id = ?", articleID).
+				UpdateColumn("likes_count", gorm.Expr("likes_count + ?", delta))
+			if updateResult.Error != nil {
+				return updateResult.Error
+			}
+		}
+
+		return tx.Model(&Article{}).Where("id = ?", articleID).Pluck("likes_count", &likes).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to update reaction of user %d on article %d: %w", userID, articleID, err)
+	}
+	return likes, nil
+}
+
+func (repo *articleRepository) AddReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	return repo.changeReaction(ctx, articleID, userID, true)
+}
+
+func (repo *articleRepository) RemoveReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	return repo.changeReaction(ctx, articleID, userID, false)
+}
+
+func (repo *articleRepository) AuthorRole(ctx context.Context, articleID, userID uint) (string, error) {
+	var roles []string
+	err := database.Conn(ctx, repo.db).Model(&Author{}).
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error
+	if err != nil {
+		return "", fmt.Errorf("repo: failed to get role of user %d on article %d: %w", userID, articleID, err)
+	}
+	if len(roles) == 0 {
+		return "", nil
+	}
+	return roles[0], nil
+}
+
+func (repo *articleRepository) ListAuthors(ctx context.Context, articleID uint) ([]Author, error) {
+	var authors []Author
+	err := database.Conn(ctx, repo.db).Where("article_id = ?", articleID).
+		Order("created_at ASC, user_id ASC").
+		Find(&authors).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to list authors of article %d: %w", articleID, err)
+	}
+	return authors, nil
+}
+
+func (repo *articleRepository) SetAuthor(ctx context.Context, author *Author) error {
+	err := database.Conn(ctx, repo.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(author).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to set author %d on article %d: %w", author.UserID, author.ArticleID, err)
+	}
+	return nil
+}
+
+func (repo *articleRepository) RemoveAuthor(ctx context.Context, articleID, userID uint) error {
+	result := database.Conn(ctx, repo.db).Where("article_id = ? AND user_id = ?", articleID, userID).Delete(&Author{})
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to remove author %d from article %d: %w", userID, articleID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAuthorNotFound
+	}
+	return nil
+}
+
+func (repo *articleRepository) SaveTranslation(ctx context.Context, translation *Translation) error {
+	err := database.Conn(ctx, repo.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "content", "excerpt", "translator_id", "updated_at"}),
+	}).Create(translation).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to save %s translation of article %d: %w", translation.Locale, translation.ArticleID, err)
+	}
 	return nil
 }
 
-func (repo *articleRepository) Delete(id uint) error {
-	deleteResult := repo.db.Delete(&Article{}, id)
+func (repo *articleRepository) GetTranslation(ctx context.Context, articleID uint, locale string) (*Translation, error) {
+	var translation Translation
+	err := database.Conn(ctx, repo.db).Where("article_id = ? AND locale = ?", articleID, locale).First(&translation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoTranslation
+		}
+		return nil, fmt.Errorf("repo: failed to get %s translation of article %d: %w", locale, articleID, err)
+	}
+	return &translation, nil
+}
+
+func (repo *articleRepository) ListTranslations(ctx context.Context, articleID uint) ([]Translation, error) {
+	var translations []Translation
+	err := database.Conn(ctx, repo.db).Omit("content").
+		Where("article_id = ?", articleID).
+		Order("locale ASC").
+		Find(&translations).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to list translations of article %d: %w", articleID, err)
+	}
+	return translations, nil
+}
+
+func (repo *articleRepository) FindTranslations(ctx context.Context, articleIDs []uint, language string) ([]Translation, error) {
+	if len(articleIDs) == 0 {
+		return nil, nil
+	}
+
+	var translations []Translation
+	err := database.Conn(ctx, repo.db).Where("article_id IN ?", articleIDs).
+		Where("locale = ? OR locale LIKE ?", language, language+"-%").
+		Order("locale ASC").
+		Find(&translations).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to find %s translations: %w", language, err)
+	}
+	return translations, nil
+}
+
+func (repo *articleRepository) DeleteTranslation(ctx context.Context, articleID uint, locale string) error {
+	result := database.Conn(ctx, repo.db).Where("article_id = ? AND locale = ?", articleID, locale).Delete(&Translation{})
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to delete %s translation of article %d: %w", locale, articleID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNoTranslation
+	}
+	return nil
+}
+
+func (repo *articleRepository) SetFeatured(ctx context.Context, id uint, featuredAt *time.Time) error {
+	result := database.Conn(ctx, repo.db).Model(&Article{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"is_featured": featuredAt != nil, "featured_at": featuredAt})
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to set featured flag of article %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (repo *articleRepository) IncrementViews(ctx context.Context, counts map[uint]int64) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		for id, views := range counts {
+			err := tx.Model(&Article{}).Where("id = ?", id).
+				UpdateColumn("views", gorm.Expr("views + ?", views)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repo: failed to increment views of %d articles: %w", len(counts), err)
+	}
+	return nil
+}
+
+func (repo *articleRepository) Popular(ctx context.Context, offset, limit int) ([]Article, error) {
+	var articles []Article
+
+	err := preloadTags(repo.reads(ctx)).
+		Where("status = ?", StatusPublished).
+		Order("views DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to get popular articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+func (repo *articleRepository) TrendingCandidates(ctx context.Context, since time.Time) ([]TrendingCandidate, error) {
+	var candidates []TrendingCandidate
+
+	err := database.Conn(ctx, repo.db).Model(&Article{}).
+		Select("id, views, likes_count, created_at").
+		Where("status = ? AND created_at >= ?", StatusPublished, since).
+		Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to get trending candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+func (repo *articleRepository) TagCounts(ctx context.Context, minCount int) ([]TagCount, error) {
+	var counts []TagCount
+	err := database.Conn(ctx, repo.db).Table("tags").
+		Select("tags.name AS name, COUNT(*) AS count").
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("JOIN articles ON articles.id = article_tags.article_id").
+		Where("articles.status = ? AND articles.deleted_at IS NULL", StatusPublished).
+		Group("tags.name").
+		Having("COUNT(*) >= ?", minCount).
+		Order("count DESC, tags.name ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to count tags: %w", err)
+	}
+	return counts, nil
+}
+
+func MigrateSearch(db *gorm.DB) error {
+	if database.IsSQLite(db) {
+		return nil
+	}
+	err := db.Exec(`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(content, '') || ' ' || coalesce(search_text, '')), 'B')
+		) STORED`).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to add search vector: %w", err)
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)").Error; err != nil {
+		return fmt.Errorf("repo: failed to index search vector: %w", err)
+	}
+	return nil
+}
+
+func searchScope(query string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Table("articles").
+			Where("articles.status = ? AND articles.deleted_at IS NULL", StatusPublished)
+		if !database.IsSQLite(db) {
+			return db.Where("articles.search_vector @@ websearch_to_tsquery(?, ?)", SearchConfig, query)
+		}
+		for _, term := range strings.Fields(query) {
+			pattern := "%" + term + "%"
+			db = db.Where("(articles.title LIKE ? OR articles.excerpt LIKE ? OR articles.content LIKE ? OR articles.search_text LIKE ?)", pattern, pattern, pattern, pattern)
+		}
+		return db
+	}
+}
+
+func (repo *articleRepository) Search(ctx context.Context, query string, page, limit int) ([]SearchHit, int64, error) {
+	var total int64
+	if err := repo.reads(ctx).Scopes(searchScope(query)).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count search results: %w", err)
+	}
+
+	search := repo.reads(ctx).Scopes(searchScope(query))
+	if database.IsSQLite(search) {
+		search = search.Select("articles.*, 0 AS rank, COALESCE(NULLIF(articles.excerpt, ''), articles.title) AS snippet")
+	} else {
+		search = search.Select("articles.*, "+
+			"ts_rank(articles.search_vector, websearch_to_tsquery(?, ?)) AS rank, "+
+			"ts_headline(?, COALESCE(NULLIF(articles.content, ''), NULLIF(articles.search_text, ''), articles.title), websearch_to_tsquery(?, ?), ?) AS snippet",
+			SearchConfig, query, SearchConfig, SearchConfig, query, SearchHeadlineOptions)
+	}
+
+	var hits []SearchHit
+	err := search.
+		Order("rank DESC, articles.created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to search articles: %w", err)
+	}
+	return hits, total, nil
+}
+
+func (repo *articleRepository) Delete(ctx context.Context, id uint) error {
+	deleteResult := database.Conn(ctx, repo.db).Delete(&Article{}, id)
 	if deleteResult.Error != nil {
 		return fmt.Errorf("repo: failed to delete article %d: %w", id, deleteResult.Error)
 	}
@@ -88,3 +1128,296 @@ func (repo *articleRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+func (repo *articleRepository) DeleteBatch(ctx context.Context, ids []uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		deleteResult := tx.Delete(&Article{}, ids)
+		if deleteResult.Error != nil {
+			return deleteResult.Error
+		}
+		if deleteResult.RowsAffected != int64(len(ids)) {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repo: failed to delete %d articles: %w", len(ids), err)
+	}
+	return nil
+}
+
+func (repo *articleRepository) GetDeletedByID(ctx context.Context, id uint) (*Article, error) {
+	var article Article
+	err := database.Conn(ctx, repo.db).Unscoped().Where("deleted_at IS NOT NULL").First(&article, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get deleted article %d: %w", id, err)
+	}
+	return &article, nil
+}
+
+func (repo *articleRepository) GetDeleted(ctx context.Context, ownerID uint, page, limit int) ([]Article, int64, error) {
+	owned := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where("articles.deleted_at IS NOT NULL").
+			Where("articles.user_id = ? OR articles.id IN (?)", ownerID,
+				database.Conn(ctx, repo.db).Model(&Author{}).Select("article_id").Where("user_id = ? AND role = ?", ownerID, AuthorRoleOwner))
+	}
+
+	var total int64
+	if err := owned(database.Conn(ctx, repo.db).Model(&Article{})).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count deleted articles of user %d: %w", ownerID, err)
+	}
+
+	var articles []Article
+	err := owned(preloadTags(database.Conn(ctx, repo.db))).
+		Order("articles.deleted_at DESC, articles.id DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to get deleted articles of user %d: %w", ownerID, err)
+	}
+	return articles, total, nil
+}
+
+func (repo *articleRepository) GetOwned(ctx context.Context, userID uint, filter OwnFilter, page, limit int) ([]Article, int64, error) {
+	owned := func(db *gorm.DB) *gorm.DB {
+		query := db.Unscoped().
+			Where("articles.user_id = ? OR articles.id IN (?)", userID,
+				database.Conn(ctx, repo.db).Model(&Author{}).Select("article_id").Where("user_id = ?", userID))
+		live := database.Conn(ctx, repo.db).Where("articles.deleted_at IS NULL AND articles.status IN ?", filter.Statuses)
+		switch {
+		case len(filter.Statuses) > 0 && filter.Deleted:
+			return query.Where(live.Or("articles.deleted_at IS NOT NULL"))
+		case filter.Deleted:
+			return query.Where("articles.deleted_at IS NOT NULL")
+		default:
+			return query.Where(live)
+		}
+	}
+
+	var total int64
+	if err := owned(database.Conn(ctx, repo.db).Model(&Article{})).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count articles of user %d: %w", userID, err)
+	}
+
+	var articles []Article
+	err := owned(preloadTags(database.Conn(ctx, repo.db))).
+		Order(listOrder(ListFilter{Sort: filter.Sort, Order: filter.Order})).
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&articles).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to get articles of user %d: %w", userID, err)
+	}
+	return articles, total, nil
+}
+
+func (repo *articleRepository) StatusCounts(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := database.Conn(ctx, repo.db).Model(&Article{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to count articles by status: %w", err)
+	}
+
+	var deleted int64
+	if err := database.Conn(ctx, repo.db).Unscoped().Model(&Article{}).Where("deleted_at IS NOT NULL").Count(&deleted).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to count deleted articles: %w", err)
+	}
+
+	counts := map[string]int64{StatusDeleted: deleted}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (repo *articleRepository) Restore(ctx context.Context, id uint) error {
+	restoreResult := database.Conn(ctx, repo.db).Unscoped().Model(&Article{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()})
+	if restoreResult.Error != nil {
+		return fmt.Errorf("repo: failed to restore article %d: %w", id, restoreResult.Error)
+	}
+	if restoreResult.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func contentRefs(tx *gorm.DB, ids []uint) ([]string, error) {
+	var refs, revisionRefs []string
+	if err := tx.Unscoped().Model(&Article{}).Distinct("content_ref").Where("id IN ? AND content_ref <> ''", ids).Pluck("content_ref", &refs).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&Revision{}).Distinct("content_ref").Where("article_id IN ? AND content_ref <> ''", ids).Pluck("content_ref", &revisionRefs).Error; err != nil {
+		return nil, err
+	}
+	for _, ref := range revisionRefs {
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+func unreferencedRefs(tx *gorm.DB, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var used, revisionUsed []string
+	if err := tx.Unscoped().Model(&Article{}).Distinct("content_ref").Where("content_ref IN ?", refs).Pluck("content_ref", &used).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&Revision{}).Distinct("content_ref").Where("content_ref IN ?", refs).Pluck("content_ref", &revisionUsed).Error; err != nil {
+		return nil, err
+	}
+	used = append(used, revisionUsed...)
+	return slices.DeleteFunc(refs, func(ref string) bool {
+		return slices.Contains(used, ref)
+	}), nil
+}
+
+func purgeArticles(tx *gorm.DB, ids []uint) ([]string, error) {
+	refs, err := contentRefs(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := deleteArticleRows(tx, ids); err != nil {
+		return nil, err
+	}
+	return unreferencedRefs(tx, refs)
+}
+
+func deleteArticleRows(tx *gorm.DB, ids []uint) error {
+	if err := tx.Where("article_id IN ?", ids).Delete(&Revision{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id IN ?", ids).Delete(&SlugRedirect{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id IN ?", ids).Delete(&Reaction{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id IN ?", ids).Delete(&Author{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("article_id IN ?", ids).Delete(&Translation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM article_tags WHERE article_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM series_entries WHERE article_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM article_media WHERE article_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&Article{}, ids).Error
+}
+
+func (repo *articleRepository) Purge(ctx context.Context, id uint) ([]string, error) {
+	var refs []string
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Unscoped().Model(&Article{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrNotFound
+		}
+		var err error
+		refs, err = purgeArticles(tx, []uint{id})
+		return err
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to purge article %d: %w", id, err)
+	}
+	return refs, nil
+}
+
+func (repo *articleRepository) DeletedBefore(ctx context.Context, cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	query := database.Conn(ctx, repo.db).Unscoped().Model(&Article{}).Where("deleted_at < ?", cutoff)
+	if database.InTx(ctx) {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := query.Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to list articles deleted before %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+	return ids, nil
+}
+
+func (repo *articleRepository) UnreferencedRefs(ctx context.Context, refs []string) ([]string, error) {
+	unused, err := unreferencedRefs(database.Conn(ctx, repo.db), refs)
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to check content references: %w", err)
+	}
+	return unused, nil
+}
+
+func (repo *articleRepository) PurgeDeleted(ctx context.Context, ids []uint) (int64, []string, error) {
+	if len(ids) == 0 {
+		return 0, nil, nil
+	}
+	var purged []uint
+	var refs []string
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&Article{}).Where("id IN ? AND deleted_at IS NOT NULL", ids).Pluck("id", &purged).Error; err != nil {
+			return err
+		}
+		if len(purged) == 0 {
+			return nil
+		}
+		var err error
+		refs, err = purgeArticles(tx, purged)
+		return err
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("repo: failed to purge deleted articles: %w", err)
+	}
+	return int64(len(purged)), refs, nil
+}
+
+func (repo *articleRepository) GetRevision(ctx context.Context, articleID uint, revision int) (*Revision, error) {
+	var rev Revision
+	err := database.Conn(ctx, repo.db).Where("article_id = ? AND revision = ?", articleID, revision).First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get revision %d of article %d: %w", revision, articleID, err)
+	}
+	return &rev, nil
+}
+
+func (repo *articleRepository) LatestRevision(ctx context.Context, articleID uint) (*Revision, error) {
+	var rev Revision
+	err := database.Conn(ctx, repo.db).Where("article_id = ?", articleID).Order("revision DESC").First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get latest revision of article %d: %w", articleID, err)
+	}
+	return &rev, nil
+}
+
+func (repo *articleRepository) ListRevisions(ctx context.Context, articleID uint) ([]Revision, error) {
+	var revisions []Revision
+	err := database.Conn(ctx, repo.db).Where("article_id = ?", articleID).
+		Order("revision ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to list revisions of article %d: %w", articleID, err)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,130 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestPlanRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    string
+		want    int64
+		wantErr bool
+	}{
+		{name: "Seq scan", plan: `[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "articles", "Plan Rows": 125000}}]`, want: 125000},
+		{name: "Fractional estimate", plan: `[{"Plan": {"Node Type": "Index Scan", "Plan Rows": 42.7}}]`, want: 42},
+		{name: "Empty plan", plan: `[]`, wantErr: true},
+		{name: "Invalid JSON", plan: `Seq Scan on articles`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planRows(tt.plan)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d rows, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCountCache(t *testing.T) {
+	db := openSQLite(t)
+	repo := NewRepository(db, WithCountStrategy(0, time.Minute))
+	ctx := context.Background()
+
+	created := 0
+	create := func() {
+		t.Helper()
+		created++
+		slug := fmt.Sprintf("counted-%d", created)
+		if err := repo.Create(ctx, &Article{UserID: 1, Title: "Counted", Slug: slug, Content: "Counted content", Status: StatusPublished}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	total := func(filter ListFilter) int64 {
+		t.Helper()
+		_, total, err := repo.GetAll(ctx, filter, 0, 10)
+		if err != nil {
+			t.Fatalf("GetAll() unexpected error: %v", err)
+		}
+		return total
+	}
+
+	create()
+	published := ListFilter{Statuses: []string{StatusPublished}, Count: CountExact}
+	if got := total(published); got != 1 {
+		t.Fatalf("Expected 1 article, got %d", got)
+	}
+
+	create()
+	if got := total(published); got != 1 {
+		t.Errorf("Expected the cached count of an unfiltered listing, got %d", got)
+	}
+	userID := uint(1)
+	if got := total(ListFilter{Statuses: []string{StatusPublished}, UserID: &userID, Count: CountExact}); got != 2 {
+		t.Errorf("Expected a fresh count for a filtered listing, got %d", got)
+	}
+	if got := total(ListFilter{Statuses: []string{StatusPublished}, Count: CountEstimated}); got != 2 {
+		t.Errorf("Expected the estimated mode to fall back to an exact count on SQLite, got %d", got)
+	}
+}
+
+func TestCreateBatchChunks(t *testing.T) {
+	db := openSQLite(t)
+	inserts := map[string]int{}
+	err := db.Callback().Create().After("gorm:create").Register("test:count_inserts", func(tx *gorm.DB) {
+		inserts[tx.Statement.Table]++
+	})
+	if err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	repo := NewRepository(db, WithBatchSize(100))
+
+	articles := make([]Article, 250)
+	for i := range articles {
+		articles[i] = Article{
+			UserID:  uint(i%3 + 1),
+			Title:   fmt.Sprintf("Bulk %d", i),
+			Slug:    fmt.Sprintf("bulk-%d", i),
+			Content: "Bulk content",
+			Status:  StatusPublished,
+			Tags:    []Tag{{Name: fmt.Sprintf("tag-%d", i%5)}, {Name: "bulk"}},
+		}
+	}
+	if err := repo.CreateBatch(context.Background(), articles); err != nil {
+		t.Fatalf("CreateBatch() unexpected error: %v", err)
+	}
+
+	for _, table := range []string{"articles", "article_authors", "article_revisions"} {
+		if inserts[table] != 3 {
+			t.Errorf("Expected 3 batched inserts into %s, got %d", table, inserts[table])
+		}
+	}
+	for i, article := range articles {
+		if article.ID == 0 {
+			t.Fatalf("Expected article %d to get an ID", i)
+		}
+	}
+
+	var tags, links, authors, revisions int64
+	db.Model(&Tag{}).Count(&tags)
+	db.Table("article_tags").Count(&links)
+	db.Model(&Author{}).Count(&authors)
+	db.Model(&Revision{}).Where("revision = ?", 1).Count(&revisions)
+	if tags != 6 || links != 500 || authors != 250 || revisions != 250 {
+		t.Errorf("Expected 6 tags, 500 tag links, 250 owners and 250 revisions, got %d, %d, %d and %d", tags, links, authors, revisions)
+	}
+
+	stored, err := repo.GetByID(context.Background(), articles[7].ID)
+	if err != nil || len(stored.Tags) != 2 || stored.Tags[0].Name != "bulk" || stored.Tags[1].Name != "tag-2" {
+		t.Errorf("Unexpected tags on a stored article: %+v (%v)", stored, err)
+	}
+}
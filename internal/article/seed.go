@@ -0,0 +1,59 @@
+package article
+
+import (
+	"context"
+	"fmt"
+
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/textutil"
+)
+
+const SampleAuthorID = 1
+
+var sampleArticles = []Article{
+	{
+		Title:   "Welcome to content-service",
+		Content: "This is a sample article created because the database was empty. Delete it once you have real content.",
+		Status:  StatusPublished,
+	},
+	{
+		Title:   "Writing your first article",
+		Content: "Send a POST request to /api/articles with a title and content, authenticated with a JWT from the token tool.",
+		Status:  StatusPublished,
+	},
+	{
+		Title:   "Drafts stay private",
+		Content: "Articles created with status \"draft\" are only visible to their author and to admins.",
+		Status:  StatusDraft,
+	},
+}
+
+func SeedOnEmpty(ctx context.Context, repo Repository, cfg *config.Config) (int, error) {
+	if !cfg.Article.SeedOnEmpty || cfg.IsProduction() {
+		return 0, nil
+	}
+
+	total, err := repo.CountAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check for existing articles: %w", err)
+	}
+	if total > 0 {
+		return 0, nil
+	}
+
+	articles := make([]Article, len(sampleArticles))
+	for i, sample := range sampleArticles {
+		articles[i] = sample
+		articles[i].UserID = SampleAuthorID
+		articles[i].Slug = Slugify(sample.Title)
+		articles[i].setContentStats(sample.Content)
+		articles[i].Excerpt = textutil.Excerpt(sample.Content, ExcerptLength)
+		articles[i].ReadingTimeMinutes = textutil.ReadingMinutes(sample.Content, sample.Language)
+	}
+
+	if err := repo.CreateBatch(ctx, articles); err != nil {
+		return 0, fmt.Errorf("failed to seed sample articles: %w", err)
+	}
+
+	return len(sampleArticles), nil
+}
@@ -0,0 +1,140 @@
+package article
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+func TestSeedOnEmpty(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		enabled     bool
+		existing    int
+		wantSeeded  int
+	}{
+		{
+			name:        "Empty development database",
+			environment: "development",
+			enabled:     true,
+			existing:    0,
+			wantSeeded:  len(sampleArticles),
+		},
+		{
+			name:        "Disabled",
+			environment: "development",
+			enabled:     false,
+			existing:    0,
+			wantSeeded:  0,
+		},
+		{
+			name:        "Never in production",
+			environment: "production",
+			enabled:     true,
+			existing:    0,
+			wantSeeded:  0,
+		},
+		{
+			name:        "Existing articles",
+			environment: "development",
+			enabled:     true,
+			existing:    1,
+			wantSeeded:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			for i := 0; i < tt.existing; i++ {
+				if err := repo.Create(context.Background(), &Article{UserID: 2, Title: "Existing", Content: "Content"}); err != nil {
+					t.Fatalf("Failed to create test article: %v", err)
+				}
+			}
+
+			cfg := &config.Config{
+				Environment: tt.environment,
+				Article:     config.ArticleConfig{SeedOnEmpty: tt.enabled},
+			}
+
+			seeded, err := SeedOnEmpty(context.Background(), repo, cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if seeded != tt.wantSeeded {
+				t.Errorf("Expected %d seeded articles, got %d", tt.wantSeeded, seeded)
+			}
+			if len(repo.articles) != tt.existing+tt.wantSeeded {
+				t.Errorf("Expected %d articles in repository, got %d", tt.existing+tt.wantSeeded, len(repo.articles))
+			}
+		})
+	}
+}
+
+func TestSeedOnEmptyDoesNotDuplicate(t *testing.T) {
+	repo := newMockRepository()
+	cfg := &config.Config{
+		Environment: "development",
+		Article:     config.ArticleConfig{SeedOnEmpty: true},
+	}
+
+	if _, err := SeedOnEmpty(context.Background(), repo, cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := repo.GetByID(context.Background(), 1); err != nil {
+		t.Fatalf("Expected seeded article: %v", err)
+	}
+	if err := repo.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Failed to delete seeded article: %v", err)
+	}
+
+	seeded, err := SeedOnEmpty(context.Background(), repo, cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if seeded != 0 {
+		t.Errorf("Expected no articles on second run, got %d", seeded)
+	}
+}
+
+func TestSeedOnEmptySharesBatchTimestamp(t *testing.T) {
+	repo := newMockRepository()
+	cfg := &config.Config{
+		Environment: "development",
+		Article:     config.ArticleConfig{SeedOnEmpty: true},
+	}
+
+	if _, err := SeedOnEmpty(context.Background(), repo, cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	first := repo.articles[1]
+	if first.CreatedAt.IsZero() {
+		t.Fatalf("Expected seeded articles to be timestamped")
+	}
+	for id, article := range repo.articles {
+		if !article.CreatedAt.Equal(first.CreatedAt) || !article.UpdatedAt.Equal(first.CreatedAt) {
+			t.Errorf("Article %d: expected shared timestamp %v, got created %v updated %v", id, first.CreatedAt, article.CreatedAt, article.UpdatedAt)
+		}
+	}
+}
+
+func TestStampBatch(t *testing.T) {
+	articles := []Article{
+		{Title: "One", CreatedAt: time.Unix(100, 0)},
+		{Title: "Two"},
+		{Title: "Three", UpdatedAt: time.Unix(200, 0)},
+	}
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	stampBatch(articles, at)
+
+	for _, article := range articles {
+		if !article.CreatedAt.Equal(at) || !article.UpdatedAt.Equal(at) {
+			t.Errorf("%s: expected created and updated at %v, got %v and %v", article.Title, at, article.CreatedAt, article.UpdatedAt)
+		}
+	}
+}
@@ -1,130 +1,2459 @@
 package article
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/cursor"
+	"content-service/internal/shared/events"
+	"content-service/internal/shared/storage"
+	"content-service/internal/shared/textutil"
+
+	"github.com/rs/zerolog/log"
 )
 
-type Service interface {
-	CreateArticle(userID uint, title, content string) (*Article, error)
-	GetArticleByID(id uint) (*Article, error)
-	GetAllArticles(page, limit int) ([]Article, int64, error)
-	UpdateArticle(userID, id uint, title, content *string) (*Article, error)
-	DeleteArticle(userID, id uint) error
+var languagePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+type Viewer struct {
+	UserID   uint
+	Role     string
+	Language string
+	NoCache  bool
+}
+
+func (v Viewer) IsAdmin() bool {
+	return v.Role == auth.RoleAdmin
+}
+
+func (v Viewer) IsModerator() bool {
+	return v.Role == auth.RoleModerator || v.Role == auth.RoleAdmin
+}
+
+func (v Viewer) canSeeDraftsOf(userID uint) bool {
+	return v.IsAdmin() || (v.UserID != 0 && v.UserID == userID)
+}
+
+type CreateInput struct {
+	Title         string
+	Content       string
+	Status        string
+	Language      string
+	Tags          []string
+	CategoryID    *uint
+	Excerpt       string
+	CoverImageURL string
+	CoverMediaID  *uint
+	Lenient       bool
+	Role          string
+}
+
+type UpdateInput struct {
+	Title         *string
+	Content       *string
+	Status        *string
+	Language      *string
+	Tags          *[]string
+	CategoryID    *uint
+	Excerpt       *string
+	CoverImageURL *string
+	CoverMediaID  *uint
+	Lenient       bool
+	Role          string
+	IfMatch       string
+	Version       *int
+}
+
+type TranslationInput struct {
+	Title   string
+	Content string
+	Role    string
+}
+
+type AuthorInput struct {
+	UserID uint
+	Role   string
+}
+
+type BulkResult struct {
+	ID      uint
+	Warning string
+	Err     error
+}
+
+type Service interface {
+	CreateArticle(ctx context.Context, userID uint, input CreateInput) (*Article, error)
+	GetArticleByID(ctx context.Context, viewer Viewer, id uint) (*Article, error)
+	GetArticleBySlug(ctx context.Context, viewer Viewer, slug string) (*Article, bool, error)
+	GetVisibleArticle(ctx context.Context, viewer Viewer, id uint) (*Article, error)
+	RecordView(article *Article)
+	GetAllArticles(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]Article, int64, error)
+	GetArticlesAt(ctx context.Context, viewer Viewer, filter ListFilter, offset, limit int) ([]Article, int64, error)
+	GetArticlesPage(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]Article, bool, error)
+	GetArticlesPageAt(ctx context.Context, viewer Viewer, filter ListFilter, offset, limit int) ([]Article, bool, error)
+	GetArticlesAfter(ctx context.Context, viewer Viewer, filter ListFilter, after string, limit int) ([]Article, string, error)
+	GetPopularArticles(ctx context.Context, viewer Viewer, page, limit int) ([]Article, error)
+	GetTrendingArticles(ctx context.Context, viewer Viewer, page, limit int) ([]TrendingArticle, int64, time.Time, error)
+	GetFeaturedArticles(ctx context.Context, viewer Viewer, page, limit int) ([]Article, int64, error)
+	SetFeatured(ctx context.Context, viewer Viewer, id uint, featured bool) (*Article, error)
+	GetArticleETags(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]ArticleETag, int64, error)
+	GetSitemapEntries(ctx context.Context, page, limit int) ([]SitemapEntry, int64, error)
+	UpdateArticle(ctx context.Context, userID, id uint, input UpdateInput) (*Article, error)
+	DeleteArticle(ctx context.Context, userID, id uint) error
+	DeleteArticleIfMatch(ctx context.Context, userID, id uint, ifMatch string) error
+	BulkCreateArticles(ctx context.Context, userID uint, inputs []CreateInput) ([]BulkResult, error)
+	BulkDeleteArticles(ctx context.Context, userID uint, ids []uint) ([]BulkResult, error)
+	ExportArticles(ctx context.Context, userID uint, emit func(*BundleArticle) error) error
+	ImportArticles(ctx context.Context, userID uint, role string, items []BundleArticle, lenient bool) ([]Article, error)
+	RestoreArticle(ctx context.Context, userID, id uint) (*Article, error)
+	GetTrash(ctx context.Context, userID uint, page, limit int) ([]TrashedArticle, int64, error)
+	GetOwnArticles(ctx context.Context, userID uint, filter OwnFilter, page, limit int) ([]OwnArticle, int64, error)
+	ListAllArticles(ctx context.Context, filter ListFilter, page, limit int) ([]Article, int64, error)
+	DeleteAnyArticle(ctx context.Context, actorID, id uint) error
+	ReassignArticle(ctx context.Context, actorID, id, userID uint) (*Article, error)
+	CountArticles(ctx context.Context) (map[string]int64, error)
+	PurgeArticle(ctx context.Context, id uint) error
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
+	GetTagCounts(ctx context.Context, minCount int) ([]TagCount, error)
+	LikeArticle(ctx context.Context, viewer Viewer, id uint) (int64, error)
+	UnlikeArticle(ctx context.Context, viewer Viewer, id uint) (int64, error)
+	SearchArticles(ctx context.Context, viewer Viewer, query string, page, limit int) ([]SearchHit, int64, error)
+	GetSearchDocuments(ctx context.Context, ids []uint) ([]Article, error)
+	ScanSearchDocuments(ctx context.Context, batchSize int, fn func(articles []Article) error) error
+	RegenerateSlug(ctx context.Context, userID, id uint) (*Article, error)
+	ListAuthors(ctx context.Context, viewer Viewer, id uint) ([]Author, error)
+	SaveTranslation(ctx context.Context, userID, id uint, locale string, input TranslationInput) (*Translation, error)
+	GetTranslation(ctx context.Context, viewer Viewer, id uint, locale string) (*Translation, error)
+	ListTranslations(ctx context.Context, viewer Viewer, id uint) ([]Translation, error)
+	DeleteTranslation(ctx context.Context, userID, id uint, locale string) error
+	AddAuthor(ctx context.Context, userID, id uint, input AuthorInput) (*Author, error)
+	RemoveAuthor(ctx context.Context, userID, id, authorID uint) error
+	ListRevisions(ctx context.Context, viewer Viewer, id uint) ([]Revision, error)
+	GetArticleStats(ctx context.Context, viewer Viewer, id uint) (*Stats, error)
+	DiffRevisions(ctx context.Context, viewer Viewer, id uint, from, to int) (*RevisionDiff, error)
+	ModerationQueue(ctx context.Context, page, limit int) ([]Article, int64, error)
+	ApproveArticle(ctx context.Context, moderatorID, id uint) (*Article, error)
+	RejectArticle(ctx context.Context, moderatorID, id uint, reason string) (*Article, error)
+	HoldForReview(ctx context.Context, id uint, note string) (*Article, error)
+}
+
+type CategoryResolver interface {
+	CategoryExists(ctx context.Context, id uint) (bool, error)
+	SubtreeIDs(ctx context.Context, id uint) ([]uint, error)
+}
+
+type SeriesResolver interface {
+	ArticleSeries(ctx context.Context, articleID uint) (*SeriesMembership, error)
+}
+
+type CoverResolver interface {
+	CoverImageURL(ctx context.Context, userID, mediaID uint) (string, bool, error)
+}
+
+type MediaCollector interface {
+	CollectArticleMedia(ctx context.Context, articleIDs []uint) error
+}
+
+type EventPublisher interface {
+	Publish(event events.Event)
+}
+
+type SearchService interface {
+	Search(query string, page, limit int) ([]SearchMatch, int64, error)
+}
+
+type articleService struct {
+	repo             Repository
+	minContentLength int
+	countMode        string
+	contentStore     storage.ContentStore
+	inlineThreshold  int
+	categories       CategoryResolver
+	series           SeriesResolver
+	covers           CoverResolver
+	media            MediaCollector
+	events           EventPublisher
+	search           SearchService
+	moderation       bool
+	trustedRoles     []string
+	cursors          *cursor.Codec
+	views            *ViewCounter
+	trending         *Trending
+}
+
+type Option func(*articleService)
+
+func WithMinContentLength(n int) Option {
+	return func(svc *articleService) {
+		svc.minContentLength = n
+	}
+}
+
+func WithCountMode(mode string) Option {
+	return func(svc *articleService) {
+		svc.countMode = mode
+	}
+}
+
+func WithContentStore(store storage.ContentStore, inlineThreshold int) Option {
+	return func(svc *articleService) {
+		svc.contentStore = store
+		svc.inlineThreshold = inlineThreshold
+	}
+}
+
+func WithCategories(resolver CategoryResolver) Option {
+	return func(svc *articleService) {
+		svc.categories = resolver
+	}
+}
+
+func WithSeries(resolver SeriesResolver) Option {
+	return func(svc *articleService) {
+		svc.series = resolver
+	}
+}
+
+func WithCovers(resolver CoverResolver) Option {
+	return func(svc *articleService) {
+		svc.covers = resolver
+	}
+}
+
+func WithMediaCollector(collector MediaCollector) Option {
+	return func(svc *articleService) {
+		svc.media = collector
+	}
+}
+
+func WithEvents(publisher EventPublisher) Option {
+	return func(svc *articleService) {
+		svc.events = publisher
+	}
+}
+
+func WithSearch(search SearchService) Option {
+	return func(svc *articleService) {
+		svc.search = search
+	}
+}
+
+func WithModeration(enabled bool, trustedRoles []string) Option {
+	return func(svc *articleService) {
+		svc.moderation = enabled
+		svc.trustedRoles = trustedRoles
+	}
+}
+
+func WithCursorCodec(codec *cursor.Codec) Option {
+	return func(svc *articleService) {
+		svc.cursors = codec
+	}
+}
+
+func WithViewCounter(counter *ViewCounter) Option {
+	return func(svc *articleService) {
+		svc.views = counter
+	}
+}
+
+func WithTrending(trending *Trending) Option {
+	return func(svc *articleService) {
+		svc.trending = trending
+	}
+}
+
+func NewService(repo Repository, opts ...Option) Service {
+	svc := &articleService{
+		repo:             repo,
+		minContentLength: DefaultMinContentLength,
+		countMode:        CountExact,
+		cursors:          cursor.NewCodec(""),
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func (svc *articleService) validateContentLength(content string, soft bool) (string, error) {
+	if utf8.RuneCountInString(content) >= svc.minContentLength {
+		return "", nil
+	}
+
+	msg := fmt.Sprintf("content must be at least %d characters", svc.minContentLength)
+	if soft {
+		return msg, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrValidation, msg)
+}
+
+func (svc *articleService) offloadContent(ctx context.Context, content string) (string, string, error) {
+	if svc.contentStore == nil || len(content) <= svc.inlineThreshold {
+		return content, "", nil
+	}
+
+	ref := storage.ContentKey(content)
+	if err := svc.contentStore.Put(ctx, ref, content); err != nil {
+		return "", "", fmt.Errorf("failed to store content: %w", err)
+	}
+	return "", ref, nil
+}
+
+func searchText(content, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	return content
+}
+
+func (svc *articleService) deleteContent(ctx context.Context, refs []string) {
+	if svc.contentStore == nil {
+		return
+	}
+	for _, ref := range refs {
+		if err := svc.contentStore.Delete(ctx, ref); err != nil {
+			log.Warn().Err(err).Str("ref", ref).Msg("Failed to delete stored content")
+		}
+	}
+}
+
+func (svc *articleService) discardContent(ctx context.Context, articles []Article) {
+	if svc.contentStore == nil {
+		return
+	}
+	var refs []string
+	for i := range articles {
+		if ref := articles[i].ContentRef; ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		return
+	}
+	unused, err := svc.repo.UnreferencedRefs(ctx, refs)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check content of rolled back articles")
+		return
+	}
+	svc.deleteContent(ctx, unused)
+}
+
+func (svc *articleService) resolveContent(ctx context.Context, content, ref string) (string, error) {
+	if ref == "" {
+		return content, nil
+	}
+	if svc.contentStore == nil {
+		return "", fmt.Errorf("failed to load content %s: no content store configured", ref)
+	}
+
+	stored, err := svc.contentStore.Get(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to load content %s: %w", ref, err)
+	}
+	return stored, nil
+}
+
+func readingLanguage(viewer Viewer, article *Article) string {
+	if textutil.IsCJKLanguage(viewer.Language) {
+		return viewer.Language
+	}
+	return article.Language
+}
+
+func canonicalLocale(tag string) string {
+	parts := strings.Split(tag, "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func (svc *articleService) findTranslations(ctx context.Context, locale string, ids ...uint) (map[uint][]Translation, error) {
+	if locale == "" || validateLanguage(locale) != nil {
+		return nil, nil
+	}
+
+	translations, err := svc.repo.FindTranslations(ctx, ids, textutil.BaseLanguage(locale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translations: %w", err)
+	}
+	byArticle := make(map[uint][]Translation, len(translations))
+	for _, translation := range translations {
+		byArticle[translation.ArticleID] = append(byArticle[translation.ArticleID], translation)
+	}
+	return byArticle, nil
+}
+
+func pickTranslation(article *Article, candidates []Translation, locale string) *Translation {
+	if len(candidates) == 0 {
+		return nil
+	}
+	locale = canonicalLocale(locale)
+	for i := range candidates {
+		if candidates[i].Locale == locale {
+			return &candidates[i]
+		}
+	}
+
+	base := textutil.BaseLanguage(locale)
+	if textutil.BaseLanguage(article.Language) == base {
+		return nil
+	}
+	for i := range candidates {
+		if candidates[i].Locale == base {
+			return &candidates[i]
+		}
+	}
+	return &candidates[0]
+}
+
+func (svc *articleService) loadContent(ctx context.Context, viewer Viewer, article *Article) error {
+	translations, err := svc.findTranslations(ctx, viewer.Language, article.ID)
+	if err != nil {
+		return err
+	}
+	return svc.fillContent(ctx, viewer, article, translations[article.ID])
+}
+
+func (svc *articleService) fillContent(ctx context.Context, viewer Viewer, article *Article, translations []Translation) error {
+	if translation := pickTranslation(article, translations, viewer.Language); translation != nil {
+		article.Title = translation.Title
+		article.Content = translation.Content
+		article.ContentRef = ""
+		article.Excerpt = translation.Excerpt
+		article.Locale = translation.Locale
+		article.TranslatedAt = translation.UpdatedAt
+		article.ReadingTimeMinutes = textutil.ReadingMinutes(translation.Content, translation.Locale)
+		return nil
+	}
+
+	content, err := svc.resolveContent(ctx, article.Content, article.ContentRef)
+	if err != nil {
+		return err
+	}
+	article.Content = content
+	if language := readingLanguage(viewer, article); language != article.Language || article.ReadingTimeMinutes == 0 {
+		article.ReadingTimeMinutes = textutil.ReadingMinutes(content, language)
+	}
+	if article.Excerpt == "" && !article.ExcerptCustom {
+		article.Excerpt = textutil.Excerpt(content, ExcerptLength)
+	}
+	return nil
+}
+
+func (svc *articleService) loadContents(ctx context.Context, viewer Viewer, articles []Article) error {
+	ids := make([]uint, len(articles))
+	for i := range articles {
+		ids[i] = articles[i].ID
+	}
+	translations, err := svc.findTranslations(ctx, viewer.Language, ids...)
+	if err != nil {
+		return err
+	}
+
+	for i := range articles {
+		if err := svc.fillContent(ctx, viewer, &articles[i], translations[articles[i].ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (svc *articleService) loadSeries(ctx context.Context, article *Article) error {
+	if svc.series == nil {
+		return nil
+	}
+	membership, err := svc.series.ArticleSeries(ctx, article.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load article series: %w", err)
+	}
+	article.Series = membership
+	return nil
+}
+
+func normalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag := strings.ToLower(strings.TrimSpace(name))
+		if tag == "" {
+			return nil, fmt.Errorf("%w: tags cannot be empty", ErrValidation)
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: tags cannot exceed %d characters", ErrValidation, MaxTagLength)
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > MaxTags {
+		return nil, fmt.Errorf("%w: at most %d tags are allowed", ErrValidation, MaxTags)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+func tagsFromNames(names []string) []Tag {
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
+
+func validateLanguage(language string) error {
+	if language == "" {
+		return nil
+	}
+	if len(language) > MaxLanguageLength || !languagePattern.MatchString(language) {
+		return fmt.Errorf("%w: language must be a language tag such as en or zh-CN", ErrValidation)
+	}
+	return nil
+}
+
+func (svc *articleService) validateCategory(ctx context.Context, categoryID uint) error {
+	if svc.categories == nil {
+		return fmt.Errorf("%w: categories are not supported", ErrValidation)
+	}
+	exists, err := svc.categories.CategoryExists(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to check category: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: category %d does not exist", ErrValidation, categoryID)
+	}
+	return nil
+}
+
+func (svc *articleService) resolveCover(ctx context.Context, userID uint, coverURL string, mediaID *uint) (string, *uint, error) {
+	coverURL = strings.TrimSpace(coverURL)
+	if mediaID != nil && *mediaID != 0 {
+		if coverURL != "" {
+			return "", nil, fmt.Errorf("%w: cover_image_url and cover_media_id cannot be combined", ErrValidation)
+		}
+		if svc.covers == nil {
+			return "", nil, fmt.Errorf("%w: media covers are not supported", ErrValidation)
+		}
+		resolved, ok, err := svc.covers.CoverImageURL(ctx, userID, *mediaID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to check cover media: %w", err)
+		}
+		if !ok {
+			return "", nil, fmt.Errorf("%w: media %d is not one of your images", ErrValidation, *mediaID)
+		}
+		id := *mediaID
+		return resolved, &id, nil
+	}
+
+	if coverURL == "" {
+		return "", nil, nil
+	}
+	if len(coverURL) > MaxCoverURLLength {
+		return "", nil, fmt.Errorf("%w: cover_image_url cannot exceed %d characters", ErrValidation, MaxCoverURLLength)
+	}
+	parsed, err := url.Parse(coverURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", nil, fmt.Errorf("%w: cover_image_url must be an absolute http or https URL", ErrValidation)
+	}
+	return coverURL, nil, nil
+}
+
+func normalizeExcerpt(excerpt string) (string, error) {
+	excerpt = strings.TrimSpace(excerpt)
+	if utf8.RuneCountInString(excerpt) > MaxExcerptLength {
+		return "", fmt.Errorf("%w: excerpt cannot exceed %d characters", ErrValidation, MaxExcerptLength)
+	}
+	return excerpt, nil
+}
+
+func (svc *articleService) reviewStatus(status, role string) string {
+	if status != StatusPublished || !svc.moderation {
+		return status
+	}
+	if role == auth.RoleAdmin || role == auth.RoleModerator || slices.Contains(svc.trustedRoles, role) {
+		return status
+	}
+	return StatusPendingReview
+}
+
+func validateStatus(status string) error {
+	switch status {
+	case StatusDraft, StatusPublished:
+		return nil
+	default:
+		return fmt.Errorf("%w: status must be one of: %s, %s", ErrValidation, StatusDraft, StatusPublished)
+	}
+}
+
+func (svc *articleService) newArticle(ctx context.Context, userID uint, input CreateInput, taken map[string]bool) (*Article, string, error) {
+	if userID == 0 {
+		return nil, "", fmt.Errorf("%w: user_id cannot be empty", ErrValidation)
+	}
+	if input.Title == "" {
+		return nil, "", fmt.Errorf("%w: title is required", ErrValidation)
+	}
+	if len(input.Title) > MaxTitleLength {
+		return nil, "", fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
+	}
+	if input.Content == "" {
+		return nil, "", fmt.Errorf("%w: content is required", ErrValidation)
+	}
+
+	status := input.Status
+	if status == "" {
+		status = StatusPublished
+	}
+	if err := validateStatus(status); err != nil {
+		return nil, "", err
+	}
+	status = svc.reviewStatus(status, input.Role)
+
+	warning, err := svc.validateContentLength(input.Content, input.Lenient && status == StatusDraft)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := validateLanguage(input.Language); err != nil {
+		return nil, "", err
+	}
+
+	tags, err := normalizeTags(input.Tags)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if input.CategoryID != nil {
+		if err := svc.validateCategory(ctx, *input.CategoryID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	excerpt, err := normalizeExcerpt(input.Excerpt)
+	if err != nil {
+		return nil, "", err
+	}
+	excerptCustom := excerpt != ""
+
+	coverURL, coverMediaID, err := svc.resolveCover(ctx, userID, input.CoverImageURL, input.CoverMediaID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !excerptCustom {
+		excerpt = textutil.Excerpt(input.Content, ExcerptLength)
+	}
+
+	slug, err := svc.uniqueSlug(ctx, input.Title, 0, taken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	inline, ref, err := svc.offloadContent(ctx, input.Content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	article := &Article{
+		UserID:     userID,
+		Title:      input.Title,
+		Slug:       slug,
+		Content:    inline,
+		ContentRef: ref,
+		SearchText: searchText(input.Content, ref),
+		Status:     status,
+		Language:   input.Language,
+		CategoryID: input.CategoryID,
+		Tags:       tagsFromNames(tags),
+
+		Excerpt:            excerpt,
+		ExcerptCustom:      excerptCustom,
+		ReadingTimeMinutes: textutil.ReadingMinutes(input.Content, input.Language),
+
+		CoverImageURL: coverURL,
+		CoverMediaID:  coverMediaID,
+	}
+	article.setContentStats(input.Content)
+	return article, warning, nil
+}
+
+func (svc *articleService) CreateArticle(ctx context.Context, userID uint, input CreateInput) (*Article, error) {
+	article, warning, err := svc.newArticle(ctx, userID, input, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := article.detached()
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		*article = draft.detached()
+		if err := svc.repo.Create(ctx, article); err != nil {
+			return fmt.Errorf("failed to create article: %w", err)
+		}
+		return svc.repo.RecordEvents(ctx, article, createdEvents(article)...)
+	})
+	if err != nil {
+		svc.discardContent(ctx, []Article{draft})
+		return nil, err
+	}
+
+	article.Content = input.Content
+	if warning != "" {
+		article.Warnings = []string{warning}
+	}
+	svc.publish(article, userID, createdEvents(article)...)
+	return article, nil
+}
+
+func (svc *articleService) GetArticleByID(ctx context.Context, viewer Viewer, id uint) (*Article, error) {
+	article, err := svc.visibleArticle(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.loadContent(ctx, viewer, article); err != nil {
+		return nil, err
+	}
+	if err := svc.loadSeries(ctx, article); err != nil {
+		return nil, err
+	}
+	if svc.views != nil {
+		article.Views += svc.views.Pending(article.ID)
+	}
+	return article, nil
+}
+
+func (svc *articleService) GetVisibleArticle(ctx context.Context, viewer Viewer, id uint) (*Article, error) {
+	return svc.visibleArticle(ctx, viewer, id)
+}
+
+func (svc *articleService) RecordView(article *Article) {
+	if svc.views == nil {
+		return
+	}
+	svc.views.Record(article.ID)
+	article.Views++
+}
+
+func (svc *articleService) GetArticleBySlug(ctx context.Context, viewer Viewer, slug string) (*Article, bool, error) {
+	article, err := svc.repo.GetBySlug(ctx, slug)
+	if err == nil {
+		if err := svc.checkVisible(ctx, viewer, article); err != nil {
+			return nil, false, err
+		}
+		if err := svc.loadContent(ctx, viewer, article); err != nil {
+			return nil, false, err
+		}
+		if err := svc.loadSeries(ctx, article); err != nil {
+			return nil, false, err
+		}
+		return article, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	redirect, err := svc.repo.GetSlugRedirect(ctx, slug)
+	if err != nil {
+		return nil, false, err
+	}
+	article, err = svc.GetArticleByID(ctx, viewer, redirect.ArticleID)
+	if err != nil {
+		return nil, false, err
+	}
+	return article, true, nil
+}
+
+func (svc *articleService) uniqueSlug(ctx context.Context, title string, articleID uint, taken map[string]bool) (string, error) {
+	base := Slugify(title)
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		owner, err := svc.repo.SlugOwner(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if (owner == 0 || owner == articleID) && !taken[candidate] {
+			return candidate, nil
+		}
+	}
+}
+
+func visibleFilter(viewer Viewer, filter ListFilter) ListFilter {
+	filter.Statuses = []string{StatusPublished}
+	if filter.UserID != nil && viewer.canSeeDraftsOf(*filter.UserID) {
+		filter.Statuses = nil
+	}
+	return filter
+}
+
+func validateListFilter(filter ListFilter) error {
+	switch filter.Sort {
+	case "", SortCreatedAt, SortUpdatedAt, SortTitle:
+	default:
+		return fmt.Errorf("%w: sort must be one of: %s, %s, %s", ErrValidation, SortCreatedAt, SortUpdatedAt, SortTitle)
+	}
+	switch filter.Order {
+	case "", OrderAsc, OrderDesc:
+	default:
+		return fmt.Errorf("%w: order must be one of: %s, %s", ErrValidation, OrderAsc, OrderDesc)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return fmt.Errorf("%w: created_after must be before created_before", ErrValidation)
+	}
+	if utf8.RuneCountInString(filter.Tag) > MaxTagLength {
+		return fmt.Errorf("%w: tag cannot exceed %d characters", ErrValidation, MaxTagLength)
+	}
+	return nil
+}
+
+func (svc *articleService) listFilter(ctx context.Context, viewer Viewer, filter ListFilter) (ListFilter, error) {
+	if err := validateListFilter(filter); err != nil {
+		return filter, err
+	}
+	return svc.resolveFilter(ctx, visibleFilter(viewer, filter))
+}
+
+func (svc *articleService) resolveFilter(ctx context.Context, filter ListFilter) (ListFilter, error) {
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	if filter.CategoryID == nil {
+		return filter, nil
+	}
+	filter.CategoryIDs = []uint{*filter.CategoryID}
+	if svc.categories == nil {
+		return filter, nil
+	}
+	ids, err := svc.categories.SubtreeIDs(ctx, *filter.CategoryID)
+	if err != nil {
+		return filter, fmt.Errorf("failed to resolve category: %w", err)
+	}
+	if len(ids) > 0 {
+		filter.CategoryIDs = ids
+	}
+	return filter, nil
+}
+
+func normalizePagination(page, limit int) (int, int) {
+	if page < 1 {
+		page = DefaultPage
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return page, limit
+}
+
+func (svc *articleService) GetAllArticles(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]Article, int64, error) {
+	page, limit = normalizePagination(page, limit)
+	return svc.GetArticlesAt(ctx, viewer, filter, (page-1)*limit, limit)
+}
+
+func (svc *articleService) GetArticlesAt(ctx context.Context, viewer Viewer, filter ListFilter, offset, limit int) ([]Article, int64, error) {
+	_, limit = normalizePagination(DefaultPage, limit)
+	offset = max(offset, 0)
+
+	filter, err := svc.listFilter(ctx, viewer, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.Count == "" {
+		filter.Count = svc.countMode
+	}
+
+	articles, total, err := svc.reader(viewer).GetAll(ctx, filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, viewer, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+func (svc *articleService) GetArticlesPage(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]Article, bool, error) {
+	page, limit = normalizePagination(page, limit)
+	return svc.GetArticlesPageAt(ctx, viewer, filter, (page-1)*limit, limit)
+}
+
+func (svc *articleService) GetArticlesPageAt(ctx context.Context, viewer Viewer, filter ListFilter, offset, limit int) ([]Article, bool, error) {
+	_, limit = normalizePagination(DefaultPage, limit)
+	offset = max(offset, 0)
+
+	filter, err := svc.listFilter(ctx, viewer, filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	articles, err := svc.reader(viewer).List(ctx, filter, offset, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	hasNext := len(articles) > limit
+	if hasNext {
+		articles = articles[:limit]
+	}
+	if err := svc.loadContents(ctx, viewer, articles); err != nil {
+		return nil, false, err
+	}
+	return articles, hasNext, nil
+}
+
+func (svc *articleService) GetArticlesAfter(ctx context.Context, viewer Viewer, filter ListFilter, after string, limit int) ([]Article, string, error) {
+	_, limit = normalizePagination(DefaultPage, limit)
+
+	if (filter.Sort != "" && filter.Sort != SortCreatedAt) || filter.Order == OrderAsc {
+		return nil, "", fmt.Errorf("%w: cursor pagination only supports sort=%s&order=%s", ErrValidation, SortCreatedAt, OrderDesc)
+	}
+	if filter.PinnedFirst {
+		return nil, "", fmt.Errorf("%w: cursor pagination does not support pinned_first", ErrValidation)
+	}
+
+	var position *ListCursor
+	if after != "" {
+		position = &ListCursor{}
+		if err := svc.cursors.Decode(after, position); err != nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+
+	filter, err := svc.listFilter(ctx, viewer, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	articles, err := svc.reader(viewer).ListAfter(ctx, filter, position, limit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	var next string
+	if len(articles) > limit {
+		articles = articles[:limit]
+		last := articles[limit-1]
+		next, err = svc.cursors.Encode(ListCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if err := svc.loadContents(ctx, viewer, articles); err != nil {
+		return nil, "", err
+	}
+	return articles, next, nil
+}
+
+func (svc *articleService) GetPopularArticles(ctx context.Context, viewer Viewer, page, limit int) ([]Article, error) {
+	page, limit = normalizePagination(page, limit)
+
+	articles, err := svc.reader(viewer).Popular(ctx, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, viewer, articles); err != nil {
+		return nil, err
+	}
+	if svc.views != nil {
+		for i := range articles {
+			articles[i].Views += svc.views.Pending(articles[i].ID)
+		}
+	}
+	return articles, nil
+}
+
+func (svc *articleService) GetTrendingArticles(ctx context.Context, viewer Viewer, page, limit int) ([]TrendingArticle, int64, time.Time, error) {
+	page, limit = normalizePagination(page, limit)
+	if svc.trending == nil {
+		return []TrendingArticle{}, 0, time.Time{}, nil
+	}
+
+	scores, total, computedAt := svc.trending.Page((page-1)*limit, limit)
+	if len(scores) == 0 {
+		return []TrendingArticle{}, total, computedAt, nil
+	}
+	ids := make([]uint, len(scores))
+	for i, score := range scores {
+		ids[i] = score.ArticleID
+	}
+	articles, err := svc.reader(viewer).List(ctx, ListFilter{IDs: ids, Statuses: []string{StatusPublished}}, 0, len(ids))
+	if err != nil {
+		return nil, 0, time.Time{}, fmt.Errorf("failed to get trending articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, viewer, articles); err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	byID := make(map[uint]Article, len(articles))
+	for _, article := range articles {
+		if svc.views != nil {
+			article.Views += svc.views.Pending(article.ID)
+		}
+		byID[article.ID] = article
+	}
+
+	trending := make([]TrendingArticle, 0, len(scores))
+	for _, score := range scores {
+		if article, ok := byID[score.ArticleID]; ok {
+			trending = append(trending, TrendingArticle{Article: article, Score: score.Score})
+		}
+	}
+	return trending, total, computedAt, nil
+}
+
+func (svc *articleService) GetFeaturedArticles(ctx context.Context, viewer Viewer, page, limit int) ([]Article, int64, error) {
+	featured := true
+	return svc.GetAllArticles(ctx, viewer, ListFilter{Featured: &featured, PinnedFirst: true}, page, limit)
+}
+
+func (svc *articleService) SetFeatured(ctx context.Context, viewer Viewer, id uint, featured bool) (*Article, error) {
+	if !viewer.IsModerator() {
+		return nil, ErrNotModerator
+	}
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if featured && article.Status != StatusPublished {
+		return nil, fmt.Errorf("%w: only published articles can be featured", ErrValidation)
+	}
+	if article.IsFeatured == featured {
+		return article, nil
+	}
+
+	var featuredAt *time.Time
+	if featured {
+		now := time.Now()
+		featuredAt = &now
+	}
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.SetFeatured(ctx, id, featuredAt); err != nil {
+			return fmt.Errorf("failed to update featured flag: %w", err)
+		}
+		version, err := svc.repo.BumpVersion(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+		article.Version = version
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	article.IsFeatured = featured
+	article.FeaturedAt = featuredAt
+	return article, nil
+}
+
+func (svc *articleService) GetArticleETags(ctx context.Context, viewer Viewer, filter ListFilter, page, limit int) ([]ArticleETag, int64, error) {
+	page, limit = normalizePagination(page, limit)
+
+	filter, err := svc.listFilter(ctx, viewer, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articles, total, err := svc.repo.GetAllVersions(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get article etags: %w", err)
+	}
+
+	etags := make([]ArticleETag, 0, len(articles))
+	for i := range articles {
+		etags = append(etags, ArticleETag{
+			ID:        articles[i].ID,
+			ETag:      articles[i].ETag(),
+			UpdatedAt: articles[i].UpdatedAt,
+		})
+	}
+	return etags, total, nil
+}
+
+func (svc *articleService) GetSitemapEntries(ctx context.Context, page, limit int) ([]SitemapEntry, int64, error) {
+	if page < 1 || limit < 1 || limit > MaxSitemapURLs {
+		return nil, 0, fmt.Errorf("%w: sitemap pages hold 1..%d URLs", ErrValidation, MaxSitemapURLs)
+	}
+
+	filter := ListFilter{Statuses: []string{StatusPublished}}
+	articles, total, err := svc.repo.GetAllVersions(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sitemap entries: %w", err)
+	}
+
+	entries := make([]SitemapEntry, 0, len(articles))
+	for i := range articles {
+		entries = append(entries, SitemapEntry{
+			Slug:      articles[i].Slug,
+			UpdatedAt: articles[i].UpdatedAt,
+		})
+	}
+	return entries, total, nil
+}
+
+func (svc *articleService) UpdateArticle(ctx context.Context, userID, id uint, input UpdateInput) (*Article, error) {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner, AuthorRoleEditor); err != nil {
+		return nil, err
+	}
+	wasPublished := article.Status == StatusPublished
+
+	updates := make(map[string]interface{})
+
+	if input.Title != nil {
+		if *input.Title == "" {
+			return nil, fmt.Errorf("%w: title cannot be empty", ErrValidation)
+		}
+		if len(*input.Title) > MaxTitleLength {
+			return nil, fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
+		}
+		updates["title"] = *input.Title
+		article.Title = *input.Title
+	}
+
+	publishing := false
+	if input.Status != nil {
+		if err := validateStatus(*input.Status); err != nil {
+			return nil, err
+		}
+		publishing = *input.Status == StatusPublished && article.Status != StatusPublished
+		status := *input.Status
+		if publishing {
+			status = svc.reviewStatus(status, input.Role)
+		}
+		if status == StatusPendingReview {
+			updates["review_note"] = ""
+			article.ReviewNote = ""
+		}
+		updates["status"] = status
+		article.Status = status
+	}
+
+	if input.Content != nil {
+		if *input.Content == "" {
+			return nil, fmt.Errorf("%w: content cannot be empty", ErrValidation)
+		}
+		warning, err := svc.validateContentLength(*input.Content, input.Lenient && article.Status == StatusDraft)
+		if err != nil {
+			return nil, err
+		}
+		if warning != "" {
+			article.Warnings = append(article.Warnings, warning)
+		}
+		inline, ref, err := svc.offloadContent(ctx, *input.Content)
+		if err != nil {
+			return nil, err
+		}
+		updates["content"] = inline
+		updates["content_ref"] = ref
+		updates["search_text"] = searchText(*input.Content, ref)
+		article.Content = *input.Content
+		article.ContentRef = ref
+	} else {
+		if err := svc.loadContent(ctx, Viewer{}, article); err != nil {
+			return nil, err
+		}
+		if publishing {
+			if _, err := svc.validateContentLength(article.Content, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if input.Language != nil {
+		if err := validateLanguage(*input.Language); err != nil {
+			return nil, err
+		}
+		updates["language"] = *input.Language
+		article.Language = *input.Language
+	}
+
+	if input.CategoryID != nil {
+		if *input.CategoryID == 0 {
+			updates["category_id"] = nil
+			article.CategoryID = nil
+		} else {
+			if err := svc.validateCategory(ctx, *input.CategoryID); err != nil {
+				return nil, err
+			}
+			categoryID := *input.CategoryID
+			updates["category_id"] = categoryID
+			article.CategoryID = &categoryID
+		}
+	}
+
+	if input.CoverImageURL != nil || input.CoverMediaID != nil {
+		var coverURL string
+		if input.CoverImageURL != nil {
+			coverURL = *input.CoverImageURL
+		}
+		resolved, coverMediaID, err := svc.resolveCover(ctx, userID, coverURL, input.CoverMediaID)
+		if err != nil {
+			return nil, err
+		}
+		updates["cover_image_url"] = resolved
+		if coverMediaID == nil {
+			updates["cover_media_id"] = nil
+		} else {
+			updates["cover_media_id"] = *coverMediaID
+		}
+		article.CoverImageURL = resolved
+		article.CoverMediaID = coverMediaID
+	}
+
+	if input.Excerpt != nil {
+		excerpt, err := normalizeExcerpt(*input.Excerpt)
+		if err != nil {
+			return nil, err
+		}
+		article.ExcerptCustom = excerpt != ""
+		article.Excerpt = excerpt
+		updates["excerpt_custom"] = article.ExcerptCustom
+	}
+	if !article.ExcerptCustom && (input.Content != nil || input.Excerpt != nil) {
+		article.Excerpt = textutil.Excerpt(article.Content, ExcerptLength)
+	}
+	if input.Excerpt != nil || input.Content != nil {
+		updates["excerpt"] = article.Excerpt
+	}
+
+	if input.Content != nil || input.Language != nil {
+		article.ReadingTimeMinutes = textutil.ReadingMinutes(article.Content, article.Language)
+		article.setContentStats(article.Content)
+		updates["reading_time_minutes"] = article.ReadingTimeMinutes
+		updates["content_length"] = article.ContentLen
+		updates["word_count"] = article.WordCount
+		updates["paragraph_count"] = article.Paragraphs
+	}
+
+	if article.Status == StatusPublished && (input.Title != nil || input.Content != nil) {
+		if status := svc.reviewStatus(StatusPublished, input.Role); status == StatusPendingReview {
+			updates["status"] = status
+			updates["review_note"] = ""
+			article.Status = status
+			article.ReviewNote = ""
+		}
+	}
+
+	var tags []string
+	if input.Tags != nil {
+		if tags, err = normalizeTags(*input.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updates) == 0 && input.Tags == nil {
+		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+	}
+
+	changed := []string{EventArticleUpdated}
+	if !wasPublished && article.Status == StatusPublished {
+		changed = append(changed, EventArticlePublished)
+	}
+
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkVersion(ctx, svc.repo, id, input.IfMatch); err != nil {
+			return err
+		}
+
+		expected := 0
+		if input.Version != nil {
+			expected = *input.Version
+		}
+		version, err := svc.repo.BumpVersion(ctx, id, expected)
+		if errors.Is(err, ErrVersionConflict) {
+			return fmt.Errorf("%w: expected version %d, current version is %d", ErrVersionConflict, expected, version)
+		}
+		if err != nil {
+			return err
+		}
+		article.Version = version
+
+		if len(updates) > 0 {
+			if err := svc.repo.Update(ctx, id, updates, userID); err != nil {
+				return fmt.Errorf("failed to update article: %w", err)
+			}
+		}
+
+		if input.Tags != nil {
+			if err := svc.repo.ReplaceTags(ctx, id, tags); err != nil {
+				return fmt.Errorf("failed to update tags: %w", err)
+			}
+			article.Tags = tagsFromNames(tags)
+		}
+
+		updatedAt, err := svc.repo.LockVersion(ctx, id)
+		if err != nil {
+			return err
+		}
+		article.UpdatedAt = updatedAt
+		return svc.repo.RecordEvents(ctx, article, changed...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.publish(article, userID, changed...)
+	return article, nil
+}
+
+func checkVersion(ctx context.Context, repo Repository, id uint, ifMatch string) error {
+	if ifMatch == "" {
+		return nil
+	}
+	updatedAt, err := repo.LockVersion(ctx, id)
+	if err != nil {
+		return err
+	}
+	current := Article{ID: id, UpdatedAt: updatedAt}
+	if !MatchVersion(ifMatch, current.ETag()) {
+		return fmt.Errorf("%w: current ETag is %s", ErrModified, current.ETag())
+	}
+	return nil
+}
+
+func (svc *articleService) DeleteArticle(ctx context.Context, userID, id uint) error {
+	return svc.DeleteArticleIfMatch(ctx, userID, id, "")
+}
+
+func (svc *articleService) DeleteArticleIfMatch(ctx context.Context, userID, id uint, ifMatch string) error {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner); err != nil {
+		return err
+	}
+	return svc.removeArticle(ctx, userID, article, ifMatch)
+}
+
+func (svc *articleService) DeleteAnyArticle(ctx context.Context, actorID, id uint) error {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return svc.removeArticle(ctx, actorID, article, "")
+}
+
+func (svc *articleService) removeArticle(ctx context.Context, actorID uint, article *Article, ifMatch string) error {
+	id := article.ID
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := checkVersion(ctx, svc.repo, id, ifMatch); err != nil {
+			return err
+		}
+		if err := svc.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete article: %w", err)
+		}
+		return svc.repo.RecordEvents(ctx, article, EventArticleDeleted)
+	})
+	if err != nil {
+		return err
+	}
+
+	svc.publish(article, actorID, EventArticleDeleted)
+	return nil
+}
+
+func (svc *articleService) collectMedia(ctx context.Context, ids []uint) error {
+	if svc.media == nil {
+		return nil
+	}
+	if err := svc.media.CollectArticleMedia(ctx, ids); err != nil {
+		return fmt.Errorf("failed to collect media: %w", err)
+	}
+	return nil
+}
+
+func createdEvents(article *Article) []string {
+	if article.Status == StatusPublished {
+		return []string{EventArticleCreated, EventArticlePublished}
+	}
+	return []string{EventArticleCreated}
+}
+
+func (svc *articleService) publish(article *Article, actorID uint, names ...string) {
+	if svc.events == nil {
+		return
+	}
+	for _, name := range names {
+		svc.events.Publish(events.Event{Name: name, Payload: *article, ActorID: actorID})
+	}
+}
+
+func validateBatchSize(n int) error {
+	if n == 0 {
+		return fmt.Errorf("%w: batch cannot be empty", ErrValidation)
+	}
+	if n > MaxBulkItems {
+		return fmt.Errorf("%w: batch cannot exceed %d items", ErrValidation, MaxBulkItems)
+	}
+	return nil
+}
+
+func (svc *articleService) BulkCreateArticles(ctx context.Context, userID uint, inputs []CreateInput) ([]BulkResult, error) {
+	if err := validateBatchSize(len(inputs)); err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkResult, len(inputs))
+	batch := make([]Article, 0, len(inputs))
+	positions := make([]int, 0, len(inputs))
+	taken := make(map[string]bool)
+
+	for i, input := range inputs {
+		article, warning, err := svc.newArticle(ctx, userID, input, taken)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Warning = warning
+		taken[article.Slug] = true
+		batch = append(batch, *article)
+		positions = append(positions, i)
+	}
+
+	if len(batch) == 0 {
+		return results, nil
+	}
+
+	draft := detachedBatch(batch)
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		batch = detachedBatch(draft)
+		if err := svc.repo.CreateBatch(ctx, batch); err != nil {
+			return err
+		}
+		for n := range batch {
+			if err := svc.repo.RecordEvents(ctx, &batch[n], createdEvents(&batch[n])...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		svc.discardContent(ctx, draft)
+		err = fmt.Errorf("failed to create articles: %w", err)
+		for _, i := range positions {
+			results[i].Err = err
+			results[i].Warning = ""
+		}
+		return results, nil
+	}
+
+	for n, i := range positions {
+		results[i].ID = batch[n].ID
+		svc.publish(&batch[n], userID, createdEvents(&batch[n])...)
+	}
+	return results, nil
+}
+
+func (svc *articleService) ExportArticles(ctx context.Context, userID uint, emit func(*BundleArticle) error) error {
+	filter := ListFilter{UserID: &userID, Sort: SortCreatedAt, Order: OrderAsc}
+	for offset := 0; ; offset += ExportBatchSize {
+		articles, err := svc.repo.List(ctx, filter, offset, ExportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to export articles: %w", err)
+		}
+		if err := svc.loadContents(ctx, Viewer{}, articles); err != nil {
+			return err
+		}
+		for i := range articles {
+			if err := emit(newBundleArticle(&articles[i])); err != nil {
+				return err
+			}
+		}
+		if len(articles) < ExportBatchSize {
+			return nil
+		}
+	}
+}
+
+func (svc *articleService) ImportArticles(ctx context.Context, userID uint, role string, items []BundleArticle, lenient bool) ([]Article, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: import cannot be empty", ErrValidation)
+	}
+	if len(items) > MaxImportItems {
+		return nil, fmt.Errorf("%w: import cannot exceed %d articles", ErrValidation, MaxImportItems)
+	}
+
+	now := time.Now()
+	batch := make([]Article, 0, len(items))
+	taken := make(map[string]bool)
+
+	for i, item := range items {
+		article, _, err := svc.newArticle(ctx, userID, CreateInput{
+			Title:         item.Title,
+			Content:       item.Content,
+			Status:        item.Status,
+			Language:      item.Language,
+			Tags:          item.Tags,
+			CategoryID:    item.CategoryID,
+			Excerpt:       item.Excerpt,
+			CoverImageURL: item.CoverImageURL,
+			Lenient:       lenient,
+			Role:          role,
+		}, taken)
+		if err != nil {
+			return nil, fmt.Errorf("article %d: %w", i+1, err)
+		}
+
+		if item.Slug != "" && Slugify(item.Slug) != article.Slug {
+			if article.Slug, err = svc.uniqueSlug(ctx, item.Slug, 0, taken); err != nil {
+				return nil, err
+			}
+		}
+		article.CreatedAt, article.UpdatedAt = now, now
+		if item.CreatedAt != nil {
+			if item.CreatedAt.After(now) {
+				return nil, fmt.Errorf("article %d: %w: created_at cannot be in the future", i+1, ErrValidation)
+			}
+			article.CreatedAt, article.UpdatedAt = *item.CreatedAt, *item.CreatedAt
+			if item.UpdatedAt != nil && item.UpdatedAt.After(article.CreatedAt) && !item.UpdatedAt.After(now) {
+				article.UpdatedAt = *item.UpdatedAt
+			}
+		}
+
+		taken[article.Slug] = true
+		batch = append(batch, *article)
+	}
+
+	draft := detachedBatch(batch)
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		batch = detachedBatch(draft)
+		if err := svc.repo.ImportBatch(ctx, batch); err != nil {
+			return err
+		}
+		for i := range batch {
+			if err := svc.repo.RecordEvents(ctx, &batch[i], createdEvents(&batch[i])...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		svc.discardContent(ctx, draft)
+		return nil, fmt.Errorf("failed to import articles: %w", err)
+	}
+	for i := range batch {
+		svc.publish(&batch[i], userID, createdEvents(&batch[i])...)
+	}
+	return batch, nil
+}
+
+func (svc *articleService) BulkDeleteArticles(ctx context.Context, userID uint, ids []uint) ([]BulkResult, error) {
+	if err := validateBatchSize(len(ids)); err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkResult, len(ids))
+	deletable := make([]uint, 0, len(ids))
+	deleted := make([]*Article, 0, len(ids))
+	positions := make([]int, 0, len(ids))
+	seen := make(map[uint]bool)
+
+	for i, id := range ids {
+		results[i].ID = id
+		if seen[id] {
+			results[i].Err = fmt.Errorf("%w: duplicate article ID %d", ErrValidation, id)
+			continue
+		}
+		seen[id] = true
+
+		article, err := svc.repo.GetByID(ctx, id)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := svc.authorize(ctx, article, userID, AuthorRoleOwner); err != nil {
+			results[i].Err = err
+			continue
+		}
+		deletable = append(deletable, id)
+		deleted = append(deleted, article)
+		positions = append(positions, i)
+	}
+
+	if len(deletable) == 0 {
+		return results, nil
+	}
+
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.DeleteBatch(ctx, deletable); err != nil {
+			return err
+		}
+		for _, article := range deleted {
+			if err := svc.repo.RecordEvents(ctx, article, EventArticleDeleted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to delete articles: %w", err)
+		for _, i := range positions {
+			results[i].Err = err
+		}
+		return results, nil
+	}
+
+	for _, article := range deleted {
+		svc.publish(article, userID, EventArticleDeleted)
+	}
+	return results, nil
+}
+
+func (svc *articleService) RestoreArticle(ctx context.Context, userID, id uint) (*Article, error) {
+	article, err := svc.repo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner); err != nil {
+		return nil, err
+	}
+
+	var restored *Article
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.Restore(ctx, id); err != nil {
+			return fmt.Errorf("failed to restore article: %w", err)
+		}
+
+		restored, err = svc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return svc.repo.RecordEvents(ctx, restored, EventArticleUpdated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.publish(restored, userID, EventArticleUpdated)
+	if err := svc.loadContent(ctx, Viewer{UserID: userID}, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (svc *articleService) GetTrash(ctx context.Context, userID uint, page, limit int) ([]TrashedArticle, int64, error) {
+	page, limit = normalizePagination(page, limit)
+
+	articles, total, err := svc.repo.GetDeleted(ctx, userID, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get deleted articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, Viewer{UserID: userID}, articles); err != nil {
+		return nil, 0, err
+	}
+
+	trash := make([]TrashedArticle, 0, len(articles))
+	for _, article := range articles {
+		trash = append(trash, TrashedArticle{Article: article, DeletedAt: article.DeletedAt.Time})
+	}
+	return trash, total, nil
+}
+
+func (svc *articleService) GetOwnArticles(ctx context.Context, userID uint, filter OwnFilter, page, limit int) ([]OwnArticle, int64, error) {
+	page, limit = normalizePagination(page, limit)
+
+	if err := validateListFilter(ListFilter{Sort: filter.Sort, Order: filter.Order}); err != nil {
+		return nil, 0, err
+	}
+	for _, status := range filter.Statuses {
+		if !slices.Contains(Statuses, status) {
+			return nil, 0, fmt.Errorf("%w: status must be one of: %s, %s", ErrValidation, strings.Join(Statuses, ", "), StatusDeleted)
+		}
+	}
+	if len(filter.Statuses) == 0 && !filter.Deleted {
+		filter.Statuses = Statuses
+		filter.Deleted = true
+	}
+
+	articles, total, err := svc.repo.GetOwned(ctx, userID, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get articles of user %d: %w", userID, err)
+	}
+	if err := svc.loadContents(ctx, Viewer{UserID: userID}, articles); err != nil {
+		return nil, 0, err
+	}
+
+	own := make([]OwnArticle, 0, len(articles))
+	for _, article := range articles {
+		item := OwnArticle{Article: article, Deleted: article.DeletedAt.Valid}
+		if article.DeletedAt.Valid {
+			item.DeletedAt = &article.DeletedAt.Time
+		}
+		own = append(own, item)
+	}
+	return own, total, nil
+}
+
+func (svc *articleService) ListAllArticles(ctx context.Context, filter ListFilter, page, limit int) ([]Article, int64, error) {
+	page, limit = normalizePagination(page, limit)
+
+	if err := validateListFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	for _, status := range filter.Statuses {
+		if !slices.Contains(Statuses, status) {
+			return nil, 0, fmt.Errorf("%w: status must be one of: %s", ErrValidation, strings.Join(Statuses, ", "))
+		}
+	}
+	filter, err := svc.resolveFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articles, total, err := svc.repo.GetAll(ctx, filter, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, Viewer{}, articles); err != nil {
+		return nil, 0, err
+	}
+	return articles, total, nil
+}
+
+func (svc *articleService) ReassignArticle(ctx context.Context, actorID, id, userID uint) (*Article, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("%w: user_id cannot be empty", ErrValidation)
+	}
+
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if article.UserID == userID {
+		return article, nil
+	}
+
+	previous := article.UserID
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.Update(ctx, id, map[string]interface{}{"user_id": userID}, actorID); err != nil {
+			return fmt.Errorf("failed to reassign article: %w", err)
+		}
+		if _, err := svc.repo.BumpVersion(ctx, id, 0); err != nil {
+			return fmt.Errorf("failed to reassign article: %w", err)
+		}
+		if err := svc.repo.RemoveAuthor(ctx, id, previous); err != nil && !errors.Is(err, ErrAuthorNotFound) {
+			return fmt.Errorf("failed to reassign article: %w", err)
+		}
+		if err := svc.repo.SetAuthor(ctx, &Author{ArticleID: id, UserID: userID, Role: AuthorRoleOwner}); err != nil {
+			return fmt.Errorf("failed to reassign article: %w", err)
+		}
+
+		reassigned, err := svc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		article = reassigned
+		return svc.repo.RecordEvents(ctx, article, EventArticleUpdated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.publish(article, actorID, EventArticleUpdated)
+	return article, nil
+}
+
+func (svc *articleService) CountArticles(ctx context.Context) (map[string]int64, error) {
+	counts, err := svc.repo.StatusCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count articles: %w", err)
+	}
+	return counts, nil
+}
+
+func (svc *articleService) PurgeArticle(ctx context.Context, id uint) error {
+	var refs []string
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.collectMedia(ctx, []uint{id}); err != nil {
+			return err
+		}
+		var err error
+		refs, err = svc.repo.Purge(ctx, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge article: %w", err)
+	}
+	svc.deleteContent(ctx, refs)
+	return nil
+}
+
+func (svc *articleService) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	var purged int64
+	var refs []string
+	err := svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		ids, err := svc.repo.DeletedBefore(ctx, time.Now().Add(-olderThan))
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		if err := svc.collectMedia(ctx, ids); err != nil {
+			return err
+		}
+		purged, refs, err = svc.repo.PurgeDeleted(ctx, ids)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted articles: %w", err)
+	}
+	svc.deleteContent(ctx, refs)
+	return purged, nil
+}
+
+func (svc *articleService) reader(viewer Viewer) Repository {
+	if viewer.NoCache {
+		if cached, ok := svc.repo.(interface{ Uncached() Repository }); ok {
+			return cached.Uncached()
+		}
+		return svc.repo
+	}
+	if replica, ok := svc.repo.(interface{ Replica() Repository }); ok {
+		return replica.Replica()
+	}
+	return svc.repo
 }
 
-type articleService struct {
-	repo Repository
+func (svc *articleService) visibleArticle(ctx context.Context, viewer Viewer, id uint) (*Article, error) {
+	article, err := svc.reader(viewer).GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkVisible(ctx, viewer, article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+func (svc *articleService) checkVisible(ctx context.Context, viewer Viewer, article *Article) error {
+	if article.Status == StatusPublished || viewer.canSeeDraftsOf(article.UserID) {
+		return nil
+	}
+	if viewer.UserID != 0 {
+		role, err := svc.repo.AuthorRole(ctx, article.ID, viewer.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to check article authors: %w", err)
+		}
+		if role != "" {
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (svc *articleService) authorize(ctx context.Context, article *Article, userID uint, roles ...string) error {
+	role, err := svc.repo.AuthorRole(ctx, article.ID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check article authors: %w", err)
+	}
+	if role == "" && article.UserID == userID {
+		role = AuthorRoleOwner
+	}
+	if !slices.Contains(roles, role) {
+		return ErrForbidden
+	}
+	return nil
 }
 
-func NewService(repo Repository) Service {
-	return &articleService{repo: repo}
+func validateAuthorRole(role string) error {
+	switch role {
+	case AuthorRoleOwner, AuthorRoleEditor:
+		return nil
+	default:
+		return fmt.Errorf("%w: role must be one of: %s, %s", ErrValidation, AuthorRoleOwner, AuthorRoleEditor)
+	}
 }
 
-func (svc *articleService) CreateArticle(userID uint, title, content string) (*Article, error) {
-	if userID == 0 {
-		return nil, fmt.Errorf("%w: user_id cannot be empty", ErrValidation)
+func (svc *articleService) GetArticleStats(ctx context.Context, viewer Viewer, id uint) (*Stats, error) {
+	article, err := svc.visibleArticle(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+	if article.ReadingTimeMinutes == 0 || (article.WordCount == 0 && article.ContentLen > 0) {
+		if err := svc.loadContent(ctx, Viewer{}, article); err != nil {
+			return nil, err
+		}
+		article.setContentStats(article.Content)
+	}
+
+	stats := &Stats{
+		ID:                 article.ID,
+		WordCount:          article.WordCount,
+		CharacterCount:     article.ContentLen,
+		ParagraphCount:     article.Paragraphs,
+		ReadingTimeMinutes: article.ReadingTimeMinutes,
+		LastEditorID:       article.UserID,
+	}
+
+	latest, err := svc.repo.LatestRevision(ctx, id)
+	if err != nil && !errors.Is(err, ErrRevisionNotFound) {
+		return nil, fmt.Errorf("failed to get latest revision: %w", err)
+	}
+	if latest != nil {
+		stats.RevisionCount = latest.Revision
+		stats.LastEditorID = latest.EditorID
+		stats.LastEditedAt = &latest.CreatedAt
+	}
+	return stats, nil
+}
+
+func validateLocale(locale string) (string, error) {
+	if locale == "" {
+		return "", fmt.Errorf("%w: locale is required", ErrValidation)
+	}
+	if err := validateLanguage(locale); err != nil {
+		return "", fmt.Errorf("%w: locale must be a language tag such as en or zh-CN", ErrValidation)
+	}
+	return canonicalLocale(locale), nil
+}
+
+func (svc *articleService) SaveTranslation(ctx context.Context, userID, id uint, locale string, input TranslationInput) (*Translation, error) {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner, AuthorRoleEditor); err != nil {
+		return nil, err
+	}
+
+	locale, err = validateLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if article.Language != "" && locale == canonicalLocale(article.Language) {
+		return nil, fmt.Errorf("%w: %s is the article's own language", ErrValidation, locale)
 	}
-	if title == "" {
+	if input.Title == "" {
 		return nil, fmt.Errorf("%w: title is required", ErrValidation)
 	}
-	if len(title) > MaxTitleLength {
+	if len(input.Title) > MaxTitleLength {
 		return nil, fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
 	}
-	if content == "" {
+	if input.Content == "" {
 		return nil, fmt.Errorf("%w: content is required", ErrValidation)
 	}
+	if _, err := svc.validateContentLength(input.Content, false); err != nil {
+		return nil, err
+	}
 
-	article := &Article{
-		UserID:  userID,
-		Title:   title,
-		Content: content,
+	translation := &Translation{
+		ArticleID:    id,
+		Locale:       locale,
+		Title:        input.Title,
+		Content:      input.Content,
+		Excerpt:      textutil.Excerpt(input.Content, ExcerptLength),
+		TranslatorID: userID,
+	}
+	held := article.Status == StatusPublished && svc.reviewStatus(StatusPublished, input.Role) == StatusPendingReview
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.SaveTranslation(ctx, translation); err != nil {
+			return fmt.Errorf("failed to save translation: %w", err)
+		}
+		if held {
+			updates := map[string]interface{}{"status": StatusPendingReview, "review_note": ""}
+			if err := svc.repo.Update(ctx, id, updates, userID); err != nil {
+				return fmt.Errorf("failed to update article: %w", err)
+			}
+			version, err := svc.repo.BumpVersion(ctx, id, 0)
+			if err != nil {
+				return err
+			}
+			article.Status = StatusPendingReview
+			article.ReviewNote = ""
+			article.Version = version
+		}
+		return svc.repo.RecordEvents(ctx, article, EventArticleUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := svc.repo.Create(article); err != nil {
-		return nil, fmt.Errorf("failed to create article: %w", err)
+	svc.publish(article, userID, EventArticleUpdated)
+	return translation, nil
+}
+
+func (svc *articleService) GetTranslation(ctx context.Context, viewer Viewer, id uint, locale string) (*Translation, error) {
+	if _, err := svc.visibleArticle(ctx, viewer, id); err != nil {
+		return nil, err
+	}
+	locale, err := validateLocale(locale)
+	if err != nil {
+		return nil, err
 	}
+	return svc.repo.GetTranslation(ctx, id, locale)
+}
 
-	return article, nil
+func (svc *articleService) ListTranslations(ctx context.Context, viewer Viewer, id uint) ([]Translation, error) {
+	if _, err := svc.visibleArticle(ctx, viewer, id); err != nil {
+		return nil, err
+	}
+
+	translations, err := svc.repo.ListTranslations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	return translations, nil
 }
 
-func (svc *articleService) GetArticleByID(id uint) (*Article, error) {
-	article, err := svc.repo.GetByID(id)
+func (svc *articleService) DeleteTranslation(ctx context.Context, userID, id uint, locale string) error {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner, AuthorRoleEditor); err != nil {
+		return err
+	}
+	locale, err = validateLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.DeleteTranslation(ctx, id, locale); err != nil {
+			if errors.Is(err, ErrNoTranslation) {
+				return err
+			}
+			return fmt.Errorf("failed to delete translation: %w", err)
+		}
+		return svc.repo.RecordEvents(ctx, article, EventArticleUpdated)
+	})
 	if err != nil {
+		return err
+	}
+
+	svc.publish(article, userID, EventArticleUpdated)
+	return nil
+}
+
+func (svc *articleService) ListAuthors(ctx context.Context, viewer Viewer, id uint) ([]Author, error) {
+	if _, err := svc.visibleArticle(ctx, viewer, id); err != nil {
 		return nil, err
 	}
-	return article, nil
+
+	authors, err := svc.repo.ListAuthors(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authors: %w", err)
+	}
+	return authors, nil
 }
 
-func (svc *articleService) GetAllArticles(page, limit int) ([]Article, int64, error) {
-	if page < 1 {
-		page = DefaultPage
+func (svc *articleService) AddAuthor(ctx context.Context, userID, id uint, input AuthorInput) (*Author, error) {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if limit < 1 || limit > MaxLimit {
-		limit = DefaultLimit
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if input.UserID == 0 {
+		return nil, fmt.Errorf("%w: user_id is required", ErrValidation)
+	}
+	if input.Role == "" {
+		input.Role = AuthorRoleEditor
+	}
+	if err := validateAuthorRole(input.Role); err != nil {
+		return nil, err
+	}
+	if input.UserID == article.UserID && input.Role != AuthorRoleOwner {
+		return nil, fmt.Errorf("%w: the creator of an article always stays an owner", ErrValidation)
+	}
+
+	author := &Author{ArticleID: id, UserID: input.UserID, Role: input.Role}
+	if err := svc.repo.SetAuthor(ctx, author); err != nil {
+		return nil, fmt.Errorf("failed to add author: %w", err)
+	}
+	return author, nil
+}
+
+func (svc *articleService) RemoveAuthor(ctx context.Context, userID, id, authorID uint) error {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if authorID != userID {
+		if err := svc.authorize(ctx, article, userID, AuthorRoleOwner); err != nil {
+			return err
+		}
+	}
+	if authorID == article.UserID {
+		return fmt.Errorf("%w: the creator of an article cannot be removed", ErrValidation)
+	}
+
+	if err := svc.repo.RemoveAuthor(ctx, id, authorID); err != nil {
+		if errors.Is(err, ErrAuthorNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove author: %w", err)
 	}
+	return nil
+}
 
-	articles, total, err := svc.repo.GetAll(page, limit)
+func (svc *articleService) ModerationQueue(ctx context.Context, page, limit int) ([]Article, int64, error) {
+	page, limit = normalizePagination(page, limit)
+
+	filter := ListFilter{Statuses: []string{StatusPendingReview}, Sort: SortUpdatedAt, Order: OrderAsc}
+	articles, total, err := svc.repo.GetAll(ctx, filter, (page-1)*limit, limit)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get articles: %w", err)
+		return nil, 0, fmt.Errorf("failed to get moderation queue: %w", err)
+	}
+	if err := svc.loadContents(ctx, Viewer{}, articles); err != nil {
+		return nil, 0, err
 	}
 	return articles, total, nil
 }
 
-func (svc *articleService) UpdateArticle(userID, id uint, title, content *string) (*Article, error) {
-	article, err := svc.repo.GetByID(id)
+func (svc *articleService) review(ctx context.Context, moderatorID, id uint, status, note string) (*Article, error) {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != StatusPendingReview {
+		return nil, ErrNotPending
+	}
+
+	reviewedAt := time.Now()
+	updates := map[string]interface{}{
+		"status":      status,
+		"review_note": note,
+		"reviewed_by": moderatorID,
+		"reviewed_at": reviewedAt,
+	}
+	var changed []string
+	if status == StatusPublished {
+		changed = append(changed, EventArticlePublished)
+	}
+
+	article.Status = status
+	article.ReviewNote = note
+	article.ReviewedBy = &moderatorID
+	article.ReviewedAt = &reviewedAt
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.Update(ctx, id, updates, moderatorID); err != nil {
+			return fmt.Errorf("failed to review article: %w", err)
+		}
+		version, err := svc.repo.BumpVersion(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+		article.Version = version
+		return svc.repo.RecordEvents(ctx, article, changed...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.loadContent(ctx, Viewer{}, article); err != nil {
+		return nil, err
+	}
+	svc.publish(article, moderatorID, changed...)
+	return article, nil
+}
+
+func (svc *articleService) ApproveArticle(ctx context.Context, moderatorID, id uint) (*Article, error) {
+	return svc.review(ctx, moderatorID, id, StatusPublished, "")
+}
+
+func (svc *articleService) RejectArticle(ctx context.Context, moderatorID, id uint, reason string) (*Article, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(reason) > MaxReviewNoteLength {
+		return nil, fmt.Errorf("%w: reason cannot exceed %d characters", ErrValidation, MaxReviewNoteLength)
+	}
+	return svc.review(ctx, moderatorID, id, StatusRejected, reason)
+}
+
+func (svc *articleService) HoldForReview(ctx context.Context, id uint, note string) (*Article, error) {
+	article, err := svc.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if article.Status != StatusPublished {
+		return article, nil
+	}
 
-	if article.UserID != userID {
-		return nil, ErrForbidden
+	updates := map[string]interface{}{
+		"status":      StatusPendingReview,
+		"review_note": note,
+		"reviewed_by": nil,
+		"reviewed_at": nil,
+	}
+	article.Status = StatusPendingReview
+	article.ReviewNote = note
+	article.ReviewedBy = nil
+	article.ReviewedAt = nil
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.Update(ctx, id, updates, 0); err != nil {
+			return fmt.Errorf("failed to hold article for review: %w", err)
+		}
+		version, err := svc.repo.BumpVersion(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+		article.Version = version
+		return svc.repo.RecordEvents(ctx, article, EventArticleUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	updates := make(map[string]interface{})
+	if err := svc.loadContent(ctx, Viewer{}, article); err != nil {
+		return nil, err
+	}
+	svc.publish(article, 0, EventArticleUpdated)
+	return article, nil
+}
 
-	if title != nil {
-		if *title == "" {
-			return nil, fmt.Errorf("%w: title cannot be empty", ErrValidation)
+func (svc *articleService) LikeArticle(ctx context.Context, viewer Viewer, id uint) (int64, error) {
+	if _, err := svc.visibleArticle(ctx, viewer, id); err != nil {
+		return 0, err
+	}
+
+	likes, err := svc.repo.AddReaction(ctx, id, viewer.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to like article: %w", err)
+	}
+	return likes, nil
+}
+
+func (svc *articleService) UnlikeArticle(ctx context.Context, viewer Viewer, id uint) (int64, error) {
+	if _, err := svc.visibleArticle(ctx, viewer, id); err != nil {
+		return 0, err
+	}
+
+	likes, err := svc.repo.RemoveReaction(ctx, id, viewer.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unlike article: %w", err)
+	}
+	return likes, nil
+}
+
+func (svc *articleService) GetTagCounts(ctx context.Context, minCount int) ([]TagCount, error) {
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	counts, err := svc.repo.TagCounts(ctx, minCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag counts: %w", err)
+	}
+	return counts, nil
+}
+
+func sanitizeSearchQuery(query string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '"' || r == '-' {
+			return r
 		}
-		if len(*title) > MaxTitleLength {
-			return nil, fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
+		return ' '
+	}, query)
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+
+	if cleaned == "" {
+		return "", fmt.Errorf("%w: search query is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(cleaned) > MaxSearchQueryLength {
+		return "", fmt.Errorf("%w: search query cannot exceed %d characters", ErrValidation, MaxSearchQueryLength)
+	}
+	return cleaned, nil
+}
+
+func (svc *articleService) SearchArticles(ctx context.Context, viewer Viewer, query string, page, limit int) ([]SearchHit, int64, error) {
+	query, err := sanitizeSearchQuery(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, limit = normalizePagination(page, limit)
+
+	var hits []SearchHit
+	var total int64
+	if svc.search != nil {
+		hits, total, err = svc.searchIndex(ctx, query, page, limit)
+	} else {
+		hits, total, err = svc.reader(viewer).Search(ctx, query, page, limit)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search articles: %w", err)
+	}
+	for i := range hits {
+		if err := svc.loadContent(ctx, viewer, &hits[i].Article); err != nil {
+			return nil, 0, err
 		}
-		updates["title"] = *title
-		article.Title = *title
 	}
+	return hits, total, nil
+}
 
-	if content != nil {
-		if *content == "" {
-			return nil, fmt.Errorf("%w: content cannot be empty", ErrValidation)
+func (svc *articleService) searchIndex(ctx context.Context, query string, page, limit int) ([]SearchHit, int64, error) {
+	matches, total, err := svc.search.Search(query, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(matches) == 0 {
+		return []SearchHit{}, total, nil
+	}
+
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ArticleID
+	}
+	articles, err := svc.repo.List(ctx, ListFilter{IDs: ids, Statuses: []string{StatusPublished}}, 0, len(ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]Article, len(articles))
+	for _, article := range articles {
+		byID[article.ID] = article
+	}
+
+	hits := make([]SearchHit, 0, len(matches))
+	for _, match := range matches {
+		if article, ok := byID[match.ArticleID]; ok {
+			hits = append(hits, SearchHit{Article: article, Rank: match.Rank, Snippet: match.Snippet})
 		}
-		updates["content"] = *content
-		article.Content = *content
 	}
+	return hits, total, nil
+}
 
-	if len(updates) == 0 {
-		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+func (svc *articleService) GetSearchDocuments(ctx context.Context, ids []uint) ([]Article, error) {
+	if len(ids) == 0 {
+		return []Article{}, nil
+	}
+	articles, err := svc.repo.List(ctx, ListFilter{IDs: ids, Statuses: []string{StatusPublished}}, 0, len(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get articles: %w", err)
+	}
+	if err := svc.loadContents(ctx, Viewer{}, articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+func (svc *articleService) ScanSearchDocuments(ctx context.Context, batchSize int, fn func(articles []Article) error) error {
+	filter := ListFilter{Statuses: []string{StatusPublished}}
+	var position *ListCursor
+	for {
+		articles, err := svc.repo.ListAfter(ctx, filter, position, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get articles: %w", err)
+		}
+		if len(articles) == 0 {
+			return nil
+		}
+		last := articles[len(articles)-1]
+		if err := svc.loadContents(ctx, Viewer{}, articles); err != nil {
+			return err
+		}
+		if err := fn(articles); err != nil {
+			return err
+		}
+		if len(articles) < batchSize {
+			return nil
+		}
+		position = &ListCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+func (svc *articleService) RegenerateSlug(ctx context.Context, userID, id uint) (*Article, error) {
+	article, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := svc.repo.Update(id, updates); err != nil {
-		return nil, fmt.Errorf("failed to update article: %w", err)
+	if err := svc.authorize(ctx, article, userID, AuthorRoleOwner, AuthorRoleEditor); err != nil {
+		return nil, err
+	}
+
+	slug, err := svc.uniqueSlug(ctx, article.Title, article.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if slug == article.Slug {
+		return article, nil
+	}
+
+	err = svc.repo.WithTx(ctx, func(ctx context.Context) error {
+		if err := svc.repo.UpdateSlug(ctx, id, slug); err != nil {
+			return fmt.Errorf("failed to update slug: %w", err)
+		}
+		version, err := svc.repo.BumpVersion(ctx, id, 0)
+		if err != nil {
+			return err
+		}
+		article.Slug = slug
+		article.Version = version
+		return svc.repo.RecordEvents(ctx, article, EventArticleUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	svc.publish(article, userID, EventArticleUpdated)
 	return article, nil
 }
 
-func (svc *articleService) DeleteArticle(userID, id uint) error {
-	article, err := svc.repo.GetByID(id)
+func (svc *articleService) ListRevisions(ctx context.Context, viewer Viewer, id uint) ([]Revision, error) {
+	if _, err := svc.GetArticleByID(ctx, viewer, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := svc.repo.ListRevisions(ctx, id)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
 	}
+	return revisions, nil
+}
 
-	if article.UserID != userID {
-		return ErrForbidden
+func (svc *articleService) DiffRevisions(ctx context.Context, viewer Viewer, id uint, from, to int) (*RevisionDiff, error) {
+	if from < 1 || to < 1 {
+		return nil, fmt.Errorf("%w: from and to must be positive revision numbers", ErrValidation)
 	}
 
-	if err := svc.repo.Delete(id); err != nil {
-		return fmt.Errorf("failed to delete article: %w", err)
+	if _, err := svc.GetArticleByID(ctx, viewer, id); err != nil {
+		return nil, err
 	}
 
-	return nil
+	fromRevision, err := svc.repo.GetRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := svc.repo.GetRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromRevision.Content, err = svc.resolveContent(ctx, fromRevision.Content, fromRevision.ContentRef); err != nil {
+		return nil, err
+	}
+	if toRevision.Content, err = svc.resolveContent(ctx, toRevision.Content, toRevision.ContentRef); err != nil {
+		return nil, err
+	}
+
+	return diffRevisions(fromRevision, toRevision)
 }
@@ -1,310 +1,828 @@
 package article
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/cursor"
+	"content-service/internal/shared/events"
+	"content-service/internal/shared/storage"
+	"content-service/internal/shared/textutil"
+
+	"gorm.io/gorm"
 )
 
 type mockRepository struct {
-	articles map[uint]*Article
-	nextID   uint
+	articles     map[uint]*Article
+	deleted      map[uint]*Article
+	revisions    map[uint][]Revision
+	redirects    map[string]uint
+	reactions    map[uint]map[uint]bool
+	authors      map[uint][]Author
+	translations map[uint]map[string]Translation
+	recorded     []string
+	nextID       uint
+	err          error
+	countCalls   int
 }
 
 func newMockRepository() *mockRepository {
 	return &mockRepository{
-		articles: make(map[uint]*Article),
-		nextID:   1,
+		articles:     make(map[uint]*Article),
+		deleted:      make(map[uint]*Article),
+		revisions:    make(map[uint][]Revision),
+		redirects:    make(map[string]uint),
+		reactions:    make(map[uint]map[uint]bool),
+		authors:      make(map[uint][]Author),
+		translations: make(map[uint]map[string]Translation),
+		nextID:       1,
 	}
 }
 
-func (m *mockRepository) Create(article *Article) error {
+func (m *mockRepository) addRevision(article *Article, editorID uint) {
+	m.revisions[article.ID] = append(m.revisions[article.ID], Revision{
+		ArticleID:  article.ID,
+		Revision:   len(m.revisions[article.ID]) + 1,
+		Title:      article.Title,
+		Content:    article.Content,
+		ContentRef: article.ContentRef,
+		EditorID:   editorID,
+		CreatedAt:  time.Now(),
+	})
+}
+
+func (m *mockRepository) Create(ctx context.Context, article *Article) error {
 	article.ID = m.nextID
 	m.nextID++
-	m.articles[article.ID] = article
+	article.CreatedAt = time.Now()
+	article.UpdatedAt = article.CreatedAt
+	article.Version = 1
+	stored := *article
+	m.articles[article.ID] = &stored
+	m.authors[article.ID] = []Author{{ArticleID: article.ID, UserID: article.UserID, Role: AuthorRoleOwner}}
+	m.addRevision(&stored, article.UserID)
+	return nil
+}
+
+func (m *mockRepository) CreateBatch(ctx context.Context, articles []Article) error {
+	stampBatch(articles, time.Now())
+	return m.ImportBatch(ctx, articles)
+}
+
+func (m *mockRepository) ImportBatch(ctx context.Context, articles []Article) error {
+	for i := range articles {
+		articles[i].ID = m.nextID
+		m.nextID++
+		stored := articles[i]
+		m.articles[stored.ID] = &stored
+		m.authors[stored.ID] = []Author{{ArticleID: stored.ID, UserID: stored.UserID, Role: AuthorRoleOwner}}
+		m.addRevision(&stored, stored.UserID)
+	}
 	return nil
 }
 
-func (m *mockRepository) GetByID(id uint) (*Article, error) {
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Article, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	article, ok := m.articles[id]
 	if !ok {
 		return nil, ErrNotFound
 	}
-	return article, nil
+	copied := *article
+	return &copied, nil
 }
 
-func (m *mockRepository) GetAll(page, limit int) ([]Article, int64, error) {
-	allArticles := make([]Article, 0, len(m.articles))
-	for _, article := range m.articles {
-		allArticles = append(allArticles, *article)
+func (m *mockRepository) LockVersion(ctx context.Context, id uint) (time.Time, error) {
+	article, ok := m.articles[id]
+	if !ok {
+		return time.Time{}, ErrNotFound
 	}
+	return article.UpdatedAt, nil
+}
 
-	total := int64(len(allArticles))
-
-	offset := (page - 1) * limit
+func (m *mockRepository) BumpVersion(ctx context.Context, id uint, expected int) (int, error) {
+	article, ok := m.articles[id]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	if expected > 0 && article.Version != expected {
+		return article.Version, ErrVersionConflict
+	}
+	article.Version++
+	return article.Version, nil
+}
 
-	if offset >= len(allArticles) {
-		return []Article{}, total, nil
+func (m *mockRepository) GetBySlug(ctx context.Context, slug string) (*Article, error) {
+	for _, article := range m.articles {
+		if article.Slug == slug {
+			copied := *article
+			return &copied, nil
+		}
 	}
+	return nil, ErrNotFound
+}
 
-	end := offset + limit
-	if end > len(allArticles) {
-		end = len(allArticles)
+func (m *mockRepository) GetSlugRedirect(ctx context.Context, slug string) (*SlugRedirect, error) {
+	articleID, ok := m.redirects[slug]
+	if !ok {
+		return nil, ErrNotFound
 	}
+	return &SlugRedirect{Slug: slug, ArticleID: articleID}, nil
+}
 
-	return allArticles[offset:end], total, nil
+func (m *mockRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	if article, err := m.GetBySlug(ctx, slug); err == nil {
+		return article.ID, nil
+	}
+	return m.redirects[slug], nil
 }
 
-func (m *mockRepository) Update(id uint, updates map[string]interface{}) error {
+func (m *mockRepository) UpdateSlug(ctx context.Context, id uint, slug string) error {
 	article, ok := m.articles[id]
 	if !ok {
 		return ErrNotFound
 	}
-	if title, ok := updates["title"].(string); ok {
-		article.Title = title
-	}
-	if content, ok := updates["content"].(string); ok {
-		article.Content = content
+	if article.Slug == slug {
+		return nil
 	}
+	delete(m.redirects, slug)
+	m.redirects[article.Slug] = id
+	article.Slug = slug
+	article.UpdatedAt = time.Now()
 	return nil
 }
 
-func (m *mockRepository) Delete(id uint) error {
-	if _, ok := m.articles[id]; !ok {
+func (m *mockRepository) ReplaceTags(ctx context.Context, id uint, names []string) error {
+	article, ok := m.articles[id]
+	if !ok {
 		return ErrNotFound
 	}
-	delete(m.articles, id)
+	article.Tags = tagsFromNames(names)
+	article.UpdatedAt = time.Now()
 	return nil
 }
 
-func TestCreateArticle(t *testing.T) {
-	repo := newMockRepository()
-	svc := NewService(repo)
+func (m *mockRepository) AddReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	article, ok := m.articles[articleID]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	if m.reactions[articleID] == nil {
+		m.reactions[articleID] = make(map[uint]bool)
+	}
+	if !m.reactions[articleID][userID] {
+		m.reactions[articleID][userID] = true
+		article.LikesCount++
+	}
+	return article.LikesCount, nil
+}
 
-	tests := []struct {
-		name      string
-		userID    uint
-		title     string
-		content   string
-		wantError bool
-	}{
-		{
-			name:      "Valid article",
-			userID:    1,
-			title:     "Test Article",
-			content:   "Test Content",
-			wantError: false,
-		},
-		{
-			name:      "Empty title",
-			userID:    1,
-			title:     "",
-			content:   "Test Content",
-			wantError: true,
-		},
-		{
-			name:      "Empty content",
-			userID:    1,
-			title:     "Test Article",
-			content:   "",
-			wantError: true,
-		},
-		{
-			name:      "Zero user ID",
-			userID:    0,
-			title:     "Test Article",
-			content:   "Test Content",
-			wantError: true,
-		},
-		{
-			name:      "Title too long",
-			userID:    1,
-			title:     string(make([]byte, 256)),
-			content:   "Test Content",
-			wantError: true,
-		},
+func (m *mockRepository) AuthorRole(ctx context.Context, articleID, userID uint) (string, error) {
+	for _, author := range m.authors[articleID] {
+		if author.UserID == userID {
+			return author.Role, nil
+		}
 	}
+	return "", nil
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			article, err := svc.CreateArticle(tt.userID, tt.title, tt.content)
-			if tt.wantError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Unexpected error: %v", err)
-				}
-				if article == nil {
-					t.Errorf("Expected article but got nil")
-				}
-				if article.Title != tt.title {
-					t.Errorf("Expected title %q, got %q", tt.title, article.Title)
-				}
-			}
-		})
+func (m *mockRepository) ListAuthors(ctx context.Context, articleID uint) ([]Author, error) {
+	return m.authors[articleID], nil
+}
+
+func (m *mockRepository) SetAuthor(ctx context.Context, author *Author) error {
+	authors := m.authors[author.ArticleID]
+	for i := range authors {
+		if authors[i].UserID == author.UserID {
+			authors[i].Role = author.Role
+			return nil
+		}
 	}
+	m.authors[author.ArticleID] = append(authors, *author)
+	return nil
 }
 
-func TestGetArticleByID(t *testing.T) {
-	repo := newMockRepository()
-	svc := NewService(repo)
+func (m *mockRepository) RemoveAuthor(ctx context.Context, articleID, userID uint) error {
+	authors := m.authors[articleID]
+	for i := range authors {
+		if authors[i].UserID == userID {
+			m.authors[articleID] = slices.Delete(authors, i, i+1)
+			return nil
+		}
+	}
+	return ErrAuthorNotFound
+}
 
-	article, err := svc.CreateArticle(1, "Test", "Content")
-	if err != nil {
-		t.Fatalf("Failed to create test article: %v", err)
+func (m *mockRepository) RemoveReaction(ctx context.Context, articleID, userID uint) (int64, error) {
+	article, ok := m.articles[articleID]
+	if !ok {
+		return 0, ErrNotFound
 	}
+	if m.reactions[articleID][userID] {
+		delete(m.reactions[articleID], userID)
+		article.LikesCount--
+	}
+	return article.LikesCount, nil
+}
 
-	tests := []struct {
-		name      string
-		id        uint
-		wantError bool
-	}{
-		{
-			name:      "Existing article",
-			id:        article.ID,
-			wantError: false,
-		},
-		{
-			name:      "Non-existing article",
-			id:        999,
-			wantError: true,
-		},
+func (m *mockRepository) SaveTranslation(ctx context.Context, translation *Translation) error {
+	if m.translations[translation.ArticleID] == nil {
+		m.translations[translation.ArticleID] = make(map[string]Translation)
+	}
+	now := time.Now()
+	translation.CreatedAt = now
+	if existing, ok := m.translations[translation.ArticleID][translation.Locale]; ok {
+		translation.CreatedAt = existing.CreatedAt
 	}
+	translation.UpdatedAt = now
+	m.translations[translation.ArticleID][translation.Locale] = *translation
+	return nil
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			found, err := svc.GetArticleByID(tt.id)
-			if tt.wantError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-				}
-				if !errors.Is(err, ErrNotFound) {
-					t.Errorf("Expected ErrNotFound, got %v", err)
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Unexpected error: %v", err)
-				}
-				if found == nil {
-					t.Errorf("Expected article but got nil")
-				}
-			}
-		})
+func (m *mockRepository) GetTranslation(ctx context.Context, articleID uint, locale string) (*Translation, error) {
+	translation, ok := m.translations[articleID][locale]
+	if !ok {
+		return nil, ErrNoTranslation
 	}
+	return &translation, nil
 }
 
-func TestUpdateArticle(t *testing.T) {
-	repo := newMockRepository()
-	svc := NewService(repo)
+func (m *mockRepository) ListTranslations(ctx context.Context, articleID uint) ([]Translation, error) {
+	translations := make([]Translation, 0, len(m.translations[articleID]))
+	for _, translation := range m.translations[articleID] {
+		translation.Content = ""
+		translations = append(translations, translation)
+	}
+	sort.Slice(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations, nil
+}
 
-	article, err := svc.CreateArticle(1, "Original Title", "Original Content")
-	if err != nil {
-		t.Fatalf("Failed to create test article: %v", err)
+func (m *mockRepository) FindTranslations(ctx context.Context, articleIDs []uint, language string) ([]Translation, error) {
+	var translations []Translation
+	for _, id := range articleIDs {
+		for locale, translation := range m.translations[id] {
+			if locale == language || strings.HasPrefix(locale, language+"-") {
+				translations = append(translations, translation)
+			}
+		}
 	}
+	sort.Slice(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations, nil
+}
 
-	newTitle := "Updated Title"
-	newContent := "Updated Content"
+func (m *mockRepository) DeleteTranslation(ctx context.Context, articleID uint, locale string) error {
+	if _, ok := m.translations[articleID][locale]; !ok {
+		return ErrNoTranslation
+	}
+	delete(m.translations[articleID], locale)
+	return nil
+}
 
-	tests := []struct {
-		name      string
-		userID    uint
-		id        uint
-		title     *string
-		content   *string
-		wantError bool
-	}{
-		{
-			name:      "Update title",
-			userID:    1,
-			id:        article.ID,
-			title:     &newTitle,
-			content:   nil,
-			wantError: false,
-		},
-		{
-			name:      "Update content",
-			userID:    1,
-			id:        article.ID,
-			title:     nil,
-			content:   &newContent,
-			wantError: false,
-		},
-		{
-			name:      "Wrong user",
-			userID:    2,
-			id:        article.ID,
-			title:     &newTitle,
-			content:   nil,
-			wantError: true,
-		},
-		{
-			name:      "Non-existing article",
-			userID:    1,
-			id:        999,
-			title:     &newTitle,
-			content:   nil,
-			wantError: true,
-		},
+func (m *mockRepository) SetFeatured(ctx context.Context, id uint, featuredAt *time.Time) error {
+	article, ok := m.articles[id]
+	if !ok {
+		return ErrNotFound
 	}
+	article.IsFeatured = featuredAt != nil
+	article.FeaturedAt = featuredAt
+	return nil
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			updated, err := svc.UpdateArticle(tt.userID, tt.id, tt.title, tt.content)
-			if tt.wantError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("Unexpected error: %v", err)
-				}
-				if updated == nil {
-					t.Errorf("Expected updated article but got nil")
-				}
-			}
-		})
+func (m *mockRepository) IncrementViews(ctx context.Context, counts map[uint]int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	for id, views := range counts {
+		if article, ok := m.articles[id]; ok {
+			article.Views += views
+		}
 	}
+	return nil
 }
 
-func TestDeleteArticle(t *testing.T) {
-	repo := newMockRepository()
-	svc := NewService(repo)
+func (m *mockRepository) Popular(ctx context.Context, offset, limit int) ([]Article, error) {
+	articles := m.sorted(ListFilter{Statuses: []string{StatusPublished}})
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].Views > articles[j].Views
+	})
+	return paginate(articles, offset, limit), nil
+}
 
-	article, err := svc.CreateArticle(1, "Test", "Content")
-	if err != nil {
-		t.Fatalf("Failed to create test article: %v", err)
+func (m *mockRepository) TagCounts(ctx context.Context, minCount int) ([]TagCount, error) {
+	counts := make(map[string]int64)
+	for _, article := range m.articles {
+		if article.Status != StatusPublished {
+			continue
+		}
+		for _, tag := range article.Tags {
+			counts[tag.Name]++
+		}
 	}
 
-	tests := []struct {
-		name      string
-		userID    uint
-		id        uint
-		wantError bool
-	}{
+	result := make([]TagCount, 0, len(counts))
+	for name, count := range counts {
+		if count >= int64(minCount) {
+			result = append(result, TagCount{Name: name, Count: count})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (m *mockRepository) Search(ctx context.Context, query string, page, limit int) ([]SearchHit, int64, error) {
+	terms := strings.Fields(strings.ToLower(strings.ReplaceAll(query, `"`, "")))
+	hits := make([]SearchHit, 0)
+	for _, article := range m.sorted(ListFilter{Statuses: []string{StatusPublished}}) {
+		text := strings.ToLower(article.Title + " " + article.Content)
+		rank := 0
+		for _, term := range terms {
+			rank += strings.Count(text, term)
+		}
+		if rank == 0 {
+			continue
+		}
+		hits = append(hits, SearchHit{Article: article, Rank: float64(rank)})
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Rank > hits[j].Rank
+	})
+
+	total := int64(len(hits))
+	offset := (page - 1) * limit
+	if offset >= len(hits) {
+		return []SearchHit{}, total, nil
+	}
+	return hits[offset:min(offset+limit, len(hits))], total, nil
+}
+
+func (m *mockRepository) sorted(filter ListFilter) []Article {
+	allArticles := make([]Article, 0, len(m.articles))
+	for _, article := range m.articles {
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, article.ID) {
+			continue
+		}
+		if filter.UserID != nil && article.UserID != *filter.UserID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, article.Status) {
+			continue
+		}
+		if filter.MinContentLength != nil && article.ContentLen < *filter.MinContentLength {
+			continue
+		}
+		if filter.MaxContentLength != nil && article.ContentLen > *filter.MaxContentLength {
+			continue
+		}
+		if len(filter.CategoryIDs) > 0 && (article.CategoryID == nil || !slices.Contains(filter.CategoryIDs, *article.CategoryID)) {
+			continue
+		}
+		if filter.CreatedAfter != nil && article.CreatedAt.Before(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !article.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		if filter.Featured != nil && article.IsFeatured != *filter.Featured {
+			continue
+		}
+		if filter.HasCover != nil && (article.CoverImageURL != "") != *filter.HasCover {
+			continue
+		}
+		if filter.Tag != "" && !slices.ContainsFunc(article.Tags, func(tag Tag) bool { return tag.Name == filter.Tag }) {
+			continue
+		}
+		allArticles = append(allArticles, *article)
+	}
+	sort.Slice(allArticles, func(i, j int) bool {
+		a, b := allArticles[i], allArticles[j]
+		if filter.PinnedFirst && a.IsFeatured != b.IsFeatured {
+			return a.IsFeatured
+		}
+		if filter.PinnedFirst && a.IsFeatured && !a.FeaturedAt.Equal(*b.FeaturedAt) {
+			return a.FeaturedAt.After(*b.FeaturedAt)
+		}
+		var order int
+		switch filter.Sort {
+		case SortUpdatedAt:
+			order = a.UpdatedAt.Compare(b.UpdatedAt)
+		case SortTitle:
+			order = strings.Compare(a.Title, b.Title)
+		default:
+			order = a.CreatedAt.Compare(b.CreatedAt)
+		}
+		if order == 0 {
+			order = cmp.Compare(a.ID, b.ID)
+		}
+		if filter.Order == OrderAsc {
+			return order < 0
+		}
+		return order > 0
+	})
+	return allArticles
+}
+
+func listedBefore(article Article, createdAt time.Time, id uint) bool {
+	if !article.CreatedAt.Equal(createdAt) {
+		return article.CreatedAt.After(createdAt)
+	}
+	return article.ID > id
+}
+
+func paginate(articles []Article, offset, limit int) []Article {
+	if offset >= len(articles) {
+		return []Article{}
+	}
+
+	end := offset + limit
+	if end > len(articles) {
+		end = len(articles)
+	}
+
+	return articles[offset:end]
+}
+
+func (m *mockRepository) GetAll(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, int64, error) {
+	m.countCalls++
+	allArticles := m.sorted(filter)
+	total := int64(len(allArticles))
+	return paginate(allArticles, offset, limit), total, nil
+}
+
+func (m *mockRepository) List(ctx context.Context, filter ListFilter, offset, limit int) ([]Article, error) {
+	return paginate(m.sorted(filter), offset, limit), nil
+}
+
+func (m *mockRepository) ListAfter(ctx context.Context, filter ListFilter, after *ListCursor, limit int) ([]Article, error) {
+	articles := m.sorted(filter)
+	if after != nil {
+		remaining := make([]Article, 0, len(articles))
+		for _, article := range articles {
+			if !listedBefore(article, after.CreatedAt, after.ID) && !(article.CreatedAt.Equal(after.CreatedAt) && article.ID == after.ID) {
+				remaining = append(remaining, article)
+			}
+		}
+		articles = remaining
+	}
+	return paginate(articles, 0, limit), nil
+}
+
+func (m *mockRepository) GetAllVersions(ctx context.Context, filter ListFilter, page, limit int) ([]Article, int64, error) {
+	return m.GetAll(ctx, filter, (page-1)*limit, limit)
+}
+
+func (m *mockRepository) CountAll(ctx context.Context) (int64, error) {
+	return int64(m.nextID - 1), nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, id uint, updates map[string]interface{}, editorID uint) error {
+	article, ok := m.articles[id]
+	if !ok {
+		return ErrNotFound
+	}
+	article.UpdatedAt = time.Now()
+	if title, ok := updates["title"].(string); ok {
+		article.Title = title
+	}
+	if content, ok := updates["content"].(string); ok {
+		article.Content = content
+	}
+	if ref, ok := updates["content_ref"].(string); ok {
+		article.ContentRef = ref
+	}
+	if length, ok := updates["content_length"].(int); ok {
+		article.ContentLen = length
+	}
+	if status, ok := updates["status"].(string); ok {
+		article.Status = status
+	}
+	if language, ok := updates["language"].(string); ok {
+		article.Language = language
+	}
+	if userID, ok := updates["user_id"].(uint); ok {
+		article.UserID = userID
+	}
+	if value, ok := updates["category_id"]; ok {
+		if categoryID, ok := value.(uint); ok {
+			article.CategoryID = &categoryID
+		} else {
+			article.CategoryID = nil
+		}
+	}
+	if excerpt, ok := updates["excerpt"].(string); ok {
+		article.Excerpt = excerpt
+	}
+	if coverURL, ok := updates["cover_image_url"].(string); ok {
+		article.CoverImageURL = coverURL
+	}
+	if value, ok := updates["cover_media_id"]; ok {
+		if mediaID, ok := value.(uint); ok {
+			article.CoverMediaID = &mediaID
+		} else {
+			article.CoverMediaID = nil
+		}
+	}
+	if custom, ok := updates["excerpt_custom"].(bool); ok {
+		article.ExcerptCustom = custom
+	}
+	if minutes, ok := updates["reading_time_minutes"].(int); ok {
+		article.ReadingTimeMinutes = minutes
+	}
+	if words, ok := updates["word_count"].(int); ok {
+		article.WordCount = words
+	}
+	if paragraphs, ok := updates["paragraph_count"].(int); ok {
+		article.Paragraphs = paragraphs
+	}
+	if note, ok := updates["review_note"].(string); ok {
+		article.ReviewNote = note
+	}
+	if reviewedBy, ok := updates["reviewed_by"].(uint); ok {
+		article.ReviewedBy = &reviewedBy
+	}
+	if reviewedAt, ok := updates["reviewed_at"].(time.Time); ok {
+		article.ReviewedAt = &reviewedAt
+	}
+	article.UpdatedAt = time.Now()
+
+	_, titleChanged := updates["title"]
+	_, contentChanged := updates["content"]
+	if titleChanged || contentChanged {
+		m.addRevision(article, editorID)
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uint) error {
+	article, ok := m.articles[id]
+	if !ok {
+		return ErrNotFound
+	}
+	article.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	m.deleted[id] = article
+	delete(m.articles, id)
+	return nil
+}
+
+func (m *mockRepository) DeleteBatch(ctx context.Context, ids []uint) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, id := range ids {
+		if _, ok := m.articles[id]; !ok {
+			return ErrNotFound
+		}
+	}
+	for _, id := range ids {
+		if err := m.Delete(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) GetDeletedByID(ctx context.Context, id uint) (*Article, error) {
+	article, ok := m.deleted[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *article
+	return &copied, nil
+}
+
+func (m *mockRepository) GetDeleted(ctx context.Context, ownerID uint, page, limit int) ([]Article, int64, error) {
+	var articles []Article
+	for _, article := range m.deleted {
+		role, _ := m.AuthorRole(ctx, article.ID, ownerID)
+		if article.UserID == ownerID || role == AuthorRoleOwner {
+			articles = append(articles, *article)
+		}
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		if !articles[i].DeletedAt.Time.Equal(articles[j].DeletedAt.Time) {
+			return articles[i].DeletedAt.Time.After(articles[j].DeletedAt.Time)
+		}
+		return articles[i].ID > articles[j].ID
+	})
+	return paginate(articles, (page-1)*limit, limit), int64(len(articles)), nil
+}
+
+func (m *mockRepository) GetOwned(ctx context.Context, userID uint, filter OwnFilter, page, limit int) ([]Article, int64, error) {
+	var articles []Article
+	for _, set := range []map[uint]*Article{m.articles, m.deleted} {
+		for _, article := range set {
+			role, _ := m.AuthorRole(ctx, article.ID, userID)
+			if article.UserID != userID && role == "" {
+				continue
+			}
+			if article.DeletedAt.Valid && filter.Deleted || !article.DeletedAt.Valid && slices.Contains(filter.Statuses, article.Status) {
+				articles = append(articles, *article)
+			}
+		}
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return articles[i].ID > articles[j].ID
+	})
+	return paginate(articles, (page-1)*limit, limit), int64(len(articles)), nil
+}
+
+func (m *mockRepository) StatusCounts(ctx context.Context) (map[string]int64, error) {
+	counts := map[string]int64{StatusDeleted: int64(len(m.deleted))}
+	for _, article := range m.articles {
+		counts[article.Status]++
+	}
+	return counts, nil
+}
+
+func (m *mockRepository) Restore(ctx context.Context, id uint) error {
+	article, ok := m.deleted[id]
+	if !ok {
+		return ErrNotFound
+	}
+	article.DeletedAt = gorm.DeletedAt{}
+	article.UpdatedAt = time.Now()
+	m.articles[id] = article
+	delete(m.deleted, id)
+	return nil
+}
+
+func (m *mockRepository) contentRefs(id uint) []string {
+	var refs []string
+	article, ok := m.articles[id]
+	if !ok {
+		article, ok = m.deleted[id]
+	}
+	if ok && article.ContentRef != "" {
+		refs = append(refs, article.ContentRef)
+	}
+	for _, rev := range m.revisions[id] {
+		if rev.ContentRef != "" && !slices.Contains(refs, rev.ContentRef) {
+			refs = append(refs, rev.ContentRef)
+		}
+	}
+	return refs
+}
+
+func (m *mockRepository) Purge(ctx context.Context, id uint) ([]string, error) {
+	_, live := m.articles[id]
+	_, deleted := m.deleted[id]
+	if !live && !deleted {
+		return nil, ErrNotFound
+	}
+	refs := m.contentRefs(id)
+	delete(m.articles, id)
+	delete(m.deleted, id)
+	delete(m.revisions, id)
+	delete(m.authors, id)
+	return refs, nil
+}
+
+func (m *mockRepository) UnreferencedRefs(ctx context.Context, refs []string) ([]string, error) {
+	var used []string
+	for id := range m.articles {
+		used = append(used, m.contentRefs(id)...)
+	}
+	for id := range m.deleted {
+		used = append(used, m.contentRefs(id)...)
+	}
+	return slices.DeleteFunc(slices.Clone(refs), func(ref string) bool {
+		return slices.Contains(used, ref)
+	}), nil
+}
+
+func (m *mockRepository) DeletedBefore(ctx context.Context, cutoff time.Time) ([]uint, error) {
+	var ids []uint
+	for id, article := range m.deleted {
+		if article.DeletedAt.Time.Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockRepository) PurgeDeleted(ctx context.Context, ids []uint) (int64, []string, error) {
+	var purged int64
+	var refs []string
+	for _, id := range ids {
+		if _, ok := m.deleted[id]; ok {
+			refs = append(refs, m.contentRefs(id)...)
+			delete(m.deleted, id)
+			delete(m.revisions, id)
+			delete(m.authors, id)
+			purged++
+		}
+	}
+	return purged, refs, nil
+}
+
+func (m *mockRepository) TrendingCandidates(ctx context.Context, since time.Time) ([]TrendingCandidate, error) {
+	var candidates []TrendingCandidate
+	for _, article := range m.articles {
+		if article.Status == StatusPublished && !article.CreatedAt.Before(since) {
+			candidates = append(candidates, TrendingCandidate{ID: article.ID, Views: article.Views, LikesCount: article.LikesCount, CreatedAt: article.CreatedAt})
+		}
+	}
+	return candidates, m.err
+}
+
+func (m *mockRepository) GetRevision(ctx context.Context, articleID uint, revision int) (*Revision, error) {
+	revisions := m.revisions[articleID]
+	if revision < 1 || revision > len(revisions) {
+		return nil, ErrRevisionNotFound
+	}
+	rev := revisions[revision-1]
+	return &rev, nil
+}
+
+func (m *mockRepository) LatestRevision(ctx context.Context, articleID uint) (*Revision, error) {
+	revisions := m.revisions[articleID]
+	if len(revisions) == 0 {
+		return nil, ErrRevisionNotFound
+	}
+	rev := revisions[len(revisions)-1]
+	return &rev, nil
+}
+
+func (m *mockRepository) ListRevisions(ctx context.Context, articleID uint) ([]Revision, error) {
+	return m.revisions[articleID], nil
+}
+
+func (m *mockRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *mockRepository) RecordEvents(ctx context.Context, article *Article, events ...string) error {
+	for _, event := range events {
+		m.recorded = append(m.recorded, fmt.Sprintf("%s:%d", event, article.ID))
+	}
+	return nil
+}
+
+func TestCreateArticle(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	tests := []struct {
+		name      string
+		userID    uint
+		title     string
+		content   string
+		wantError bool
+	}{
 		{
-			name:      "Wrong user",
-			userID:    2,
-			id:        article.ID,
+			name:      "Valid article",
+			userID:    1,
+			title:     "Test Article",
+			content:   "Test Content",
+			wantError: false,
+		},
+		{
+			name:      "Empty title",
+			userID:    1,
+			title:     "",
+			content:   "Test Content",
 			wantError: true,
 		},
 		{
-			name:      "Correct user",
+			name:      "Empty content",
 			userID:    1,
-			id:        article.ID,
-			wantError: false,
+			title:     "Test Article",
+			content:   "",
+			wantError: true,
 		},
 		{
-			name:      "Already deleted",
+			name:      "Zero user ID",
+			userID:    0,
+			title:     "Test Article",
+			content:   "Test Content",
+			wantError: true,
+		},
+		{
+			name:      "Title too long",
 			userID:    1,
-			id:        article.ID,
+			title:     string(make([]byte, 256)),
+			content:   "Test Content",
 			wantError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := svc.DeleteArticle(tt.userID, tt.id)
+			article, err := svc.CreateArticle(context.Background(), tt.userID, CreateInput{Title: tt.title, Content: tt.content})
 			if tt.wantError {
 				if err == nil {
 					t.Errorf("Expected error but got none")
@@ -313,41 +831,274 @@ func TestDeleteArticle(t *testing.T) {
 				if err != nil {
 					t.Errorf("Unexpected error: %v", err)
 				}
+				if article == nil {
+					t.Errorf("Expected article but got nil")
+				}
+				if article.Title != tt.title {
+					t.Errorf("Expected title %q, got %q", tt.title, article.Title)
+				}
 			}
 		})
 	}
 }
 
-func TestGetAllArticles(t *testing.T) {
+func TestGetArticleByID(t *testing.T) {
 	repo := newMockRepository()
 	svc := NewService(repo)
 
-	for i := 1; i <= 5; i++ {
-		_, err := svc.CreateArticle(1, "Article", "Content")
-		if err != nil {
-			t.Fatalf("Failed to create test article: %v", err)
-		}
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Test", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
 	}
 
 	tests := []struct {
 		name      string
-		page      int
-		limit     int
-		wantCount int
+		id        uint
+		wantError bool
 	}{
 		{
-			name:      "Default pagination",
-			page:      1,
-			limit:     10,
-			wantCount: 5,
+			name:      "Existing article",
+			id:        article.ID,
+			wantError: false,
 		},
 		{
-			name:      "Invalid page",
-			page:      0,
-			limit:     10,
-			wantCount: 5,
+			name:      "Non-existing article",
+			id:        999,
+			wantError: true,
 		},
-		{
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := svc.GetArticleByID(context.Background(), Viewer{}, tt.id)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("Expected ErrNotFound, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				if found == nil {
+					t.Errorf("Expected article but got nil")
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateArticle(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Original Title", Content: "Original Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	newTitle := "Updated Title"
+	newContent := "Updated Content"
+
+	tests := []struct {
+		name      string
+		userID    uint
+		id        uint
+		title     *string
+		content   *string
+		wantError bool
+	}{
+		{
+			name:      "Update title",
+			userID:    1,
+			id:        article.ID,
+			title:     &newTitle,
+			content:   nil,
+			wantError: false,
+		},
+		{
+			name:      "Update content",
+			userID:    1,
+			id:        article.ID,
+			title:     nil,
+			content:   &newContent,
+			wantError: false,
+		},
+		{
+			name:      "Wrong user",
+			userID:    2,
+			id:        article.ID,
+			title:     &newTitle,
+			content:   nil,
+			wantError: true,
+		},
+		{
+			name:      "Non-existing article",
+			userID:    1,
+			id:        999,
+			title:     &newTitle,
+			content:   nil,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := svc.UpdateArticle(context.Background(), tt.userID, tt.id, UpdateInput{Title: tt.title, Content: tt.content})
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				if updated == nil {
+					t.Errorf("Expected updated article but got nil")
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateArticleVersion(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Original Title", Content: "Original Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if article.Version != 1 {
+		t.Fatalf("Expected new article at version 1, got %d", article.Version)
+	}
+
+	title := "Updated Title"
+	tags := []string{"go"}
+	version := func(n int) *int { return &n }
+
+	tests := []struct {
+		name        string
+		input       UpdateInput
+		wantErr     error
+		wantVersion int
+	}{
+		{name: "Expected version matches", input: UpdateInput{Title: &title, Version: version(1)}, wantVersion: 2},
+		{name: "Stale version", input: UpdateInput{Title: &title, Version: version(1)}, wantErr: ErrVersionConflict},
+		{name: "No expected version", input: UpdateInput{Title: &title}, wantVersion: 3},
+		{name: "Tags only", input: UpdateInput{Tags: &tags, Version: version(3)}, wantVersion: 4},
+		{name: "Future version", input: UpdateInput{Title: &title, Version: version(9)}, wantErr: ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := svc.UpdateArticle(context.Background(), 1, article.ID, tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if updated.Version != tt.wantVersion {
+				t.Errorf("Expected version %d, got %d", tt.wantVersion, updated.Version)
+			}
+		})
+	}
+
+	stored, err := svc.GetArticleByID(context.Background(), Viewer{UserID: 1}, article.ID)
+	if err != nil {
+		t.Fatalf("Failed to get article: %v", err)
+	}
+	if stored.Version != 4 {
+		t.Errorf("Expected stored version 4, got %d", stored.Version)
+	}
+}
+
+func TestDeleteArticle(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Test", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		userID    uint
+		id        uint
+		wantError bool
+	}{
+		{
+			name:      "Wrong user",
+			userID:    2,
+			id:        article.ID,
+			wantError: true,
+		},
+		{
+			name:      "Correct user",
+			userID:    1,
+			id:        article.ID,
+			wantError: false,
+		},
+		{
+			name:      "Already deleted",
+			userID:    1,
+			id:        article.ID,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.DeleteArticle(context.Background(), tt.userID, tt.id)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetAllArticles(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	for i := 1; i <= 5; i++ {
+		_, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"})
+		if err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		wantCount int
+	}{
+		{
+			name:      "Default pagination",
+			page:      1,
+			limit:     10,
+			wantCount: 5,
+		},
+		{
+			name:      "Invalid page",
+			page:      0,
+			limit:     10,
+			wantCount: 5,
+		},
+		{
 			name:      "Invalid limit",
 			page:      1,
 			limit:     0,
@@ -357,7 +1108,7 @@ func TestGetAllArticles(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			articles, total, err := svc.GetAllArticles(tt.page, tt.limit)
+			articles, total, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, tt.page, tt.limit)
 			if err != nil {
 				t.Errorf("Unexpected error: %v", err)
 			}
@@ -370,3 +1121,2506 @@ func TestGetAllArticles(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllArticlesByTag(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	inputs := []CreateInput{
+		{Title: "Go", Content: "Content", Tags: []string{"go", "web"}},
+		{Title: "Rust", Content: "Content", Tags: []string{"rust"}},
+		{Title: "Go draft", Content: "Content", Tags: []string{"go"}, Status: StatusDraft},
+	}
+	for _, input := range inputs {
+		if _, err := svc.CreateArticle(context.Background(), 1, input); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tag       string
+		wantCount int
+		wantError bool
+	}{
+		{name: "Matching tag", tag: "go", wantCount: 1},
+		{name: "Normalized tag", tag: " Web ", wantCount: 1},
+		{name: "Unknown tag", tag: "java", wantCount: 0},
+		{name: "Too long", tag: strings.Repeat("a", MaxTagLength+1), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, total, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{Tag: tt.tag}, 1, 10)
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(articles) != tt.wantCount || int(total) != tt.wantCount {
+				t.Errorf("Expected %d articles, got %d (total %d)", tt.wantCount, len(articles), total)
+			}
+		})
+	}
+}
+
+func TestGetSitemapEntries(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	for _, status := range []string{StatusPublished, StatusPublished, StatusDraft} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content", Status: status}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	entries, total, err := svc.GetSitemapEntries(context.Background(), 1, MaxSitemapURLs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 2 || len(entries) != 2 {
+		t.Errorf("Expected 2 published entries, got %d (total %d)", len(entries), total)
+	}
+	for _, entry := range entries {
+		if entry.Slug == "" || entry.UpdatedAt.IsZero() {
+			t.Errorf("Expected slug and updated_at to be set, got %+v", entry)
+		}
+	}
+
+	if _, _, err := svc.GetSitemapEntries(context.Background(), 1, MaxSitemapURLs+1); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an oversized page, got %v", err)
+	}
+}
+
+func TestMinContentLength(t *testing.T) {
+	svc := NewService(newMockRepository(), WithMinContentLength(5))
+
+	tests := []struct {
+		name      string
+		content   string
+		wantError bool
+	}{
+		{
+			name:      "Below minimum",
+			content:   "abcd",
+			wantError: true,
+		},
+		{
+			name:      "At minimum",
+			content:   "abcde",
+			wantError: false,
+		},
+		{
+			name:      "Multibyte at minimum",
+			content:   "привт",
+			wantError: false,
+		},
+		{
+			name:      "Multibyte counted as runes",
+			content:   "日本語です",
+			wantError: false,
+		},
+		{
+			name:      "Multibyte short despite byte length",
+			content:   "日本語で",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("Create/"+tt.name, func(t *testing.T) {
+			_, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Title", Content: tt.content})
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				if err != nil && !strings.Contains(err.Error(), "at least 5 characters") {
+					t.Errorf("Expected error to mention the minimum, got %q", err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Title", Content: "Long enough content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run("Update/"+tt.name, func(t *testing.T) {
+			content := tt.content
+			_, err := svc.UpdateArticle(context.Background(), 1, article.ID, UpdateInput{Content: &content})
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDefaultMinContentLength(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Title", Content: "x"}); err != nil {
+		t.Errorf("Expected single character content to be accepted by default, got %v", err)
+	}
+}
+
+func TestGetArticlesPage(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	for i := 1; i <= 5; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Content"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		page        int
+		limit       int
+		wantCount   int
+		wantHasNext bool
+	}{
+		{
+			name:        "First page with more",
+			page:        1,
+			limit:       2,
+			wantCount:   2,
+			wantHasNext: true,
+		},
+		{
+			name:        "Last partial page",
+			page:        3,
+			limit:       2,
+			wantCount:   1,
+			wantHasNext: false,
+		},
+		{
+			name:        "Exact fit",
+			page:        1,
+			limit:       5,
+			wantCount:   5,
+			wantHasNext: false,
+		},
+		{
+			name:        "Past the end",
+			page:        4,
+			limit:       2,
+			wantCount:   0,
+			wantHasNext: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, hasNext, err := svc.GetArticlesPage(context.Background(), Viewer{}, ListFilter{}, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(articles) != tt.wantCount {
+				t.Errorf("Expected %d articles, got %d", tt.wantCount, len(articles))
+			}
+			if hasNext != tt.wantHasNext {
+				t.Errorf("Expected has_next %v, got %v", tt.wantHasNext, hasNext)
+			}
+		})
+	}
+
+	if repo.countCalls != 0 {
+		t.Errorf("Expected no count queries, got %d", repo.countCalls)
+	}
+}
+
+func TestListingVisibility(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	fixtures := []struct {
+		userID uint
+		status string
+	}{
+		{userID: 1, status: StatusPublished},
+		{userID: 1, status: StatusDraft},
+		{userID: 1, status: StatusDraft},
+		{userID: 2, status: StatusPublished},
+		{userID: 2, status: StatusDraft},
+	}
+	for _, f := range fixtures {
+		if _, err := svc.CreateArticle(context.Background(), f.userID, CreateInput{Title: "Article", Content: "Content", Status: f.status}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	author := uint(1)
+
+	tests := []struct {
+		name      string
+		viewer    Viewer
+		filter    ListFilter
+		wantTotal int64
+	}{
+		{
+			name:      "Anonymous sees only published",
+			viewer:    Viewer{},
+			filter:    ListFilter{},
+			wantTotal: 2,
+		},
+		{
+			name:      "Anonymous filtering by author sees only published",
+			viewer:    Viewer{},
+			filter:    ListFilter{UserID: &author},
+			wantTotal: 1,
+		},
+		{
+			name:      "Other user filtering by author sees only published",
+			viewer:    Viewer{UserID: 2},
+			filter:    ListFilter{UserID: &author},
+			wantTotal: 1,
+		},
+		{
+			name:      "Author sees own drafts",
+			viewer:    Viewer{UserID: 1},
+			filter:    ListFilter{UserID: &author},
+			wantTotal: 3,
+		},
+		{
+			name:      "Admin viewing another user's drafts",
+			viewer:    Viewer{UserID: 99, Role: auth.RoleAdmin},
+			filter:    ListFilter{UserID: &author},
+			wantTotal: 3,
+		},
+		{
+			name:      "Admin without author filter sees only published",
+			viewer:    Viewer{UserID: 99, Role: auth.RoleAdmin},
+			filter:    ListFilter{},
+			wantTotal: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, total, err := svc.GetAllArticles(context.Background(), tt.viewer, tt.filter, 1, 10)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			if int64(len(articles)) != tt.wantTotal {
+				t.Errorf("Expected %d articles, got %d", tt.wantTotal, len(articles))
+			}
+		})
+	}
+}
+
+func TestGetDraftByID(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Content", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		viewer    Viewer
+		wantError bool
+	}{
+		{name: "Anonymous", viewer: Viewer{}, wantError: true},
+		{name: "Other user", viewer: Viewer{UserID: 2}, wantError: true},
+		{name: "Owner", viewer: Viewer{UserID: 1}, wantError: false},
+		{name: "Admin", viewer: Viewer{UserID: 2, Role: auth.RoleAdmin}, wantError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetArticleByID(context.Background(), tt.viewer, draft.ID)
+			if tt.wantError && !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound, got %v", err)
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Title", Content: "first line\nsecond line\nthird line\n"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	changed := "first line\nsecond line, edited\nthird line\n"
+	if _, err := svc.UpdateArticle(context.Background(), 1, article.ID, UpdateInput{Content: &changed}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+
+	other, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Other", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	diff, err := svc.DiffRevisions(context.Background(), Viewer{}, article.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantDiff := "--- revision 1\n" +
+		"+++ revision 2\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" first line\n" +
+		"-second line\n" +
+		"+second line, edited\n" +
+		" third line\n"
+	if diff.Diff != wantDiff {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", wantDiff, diff.Diff)
+	}
+	if diff.Added != 1 || diff.Removed != 1 {
+		t.Errorf("Expected 1 added and 1 removed line, got %d added and %d removed", diff.Added, diff.Removed)
+	}
+	if diff.TitleChanged {
+		t.Errorf("Expected title to be unchanged")
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		from    int
+		to      int
+		wantErr error
+	}{
+		{name: "Unknown revision", id: article.ID, from: 1, to: 3, wantErr: ErrRevisionNotFound},
+		{name: "Revision of another article", id: other.ID, from: 1, to: 2, wantErr: ErrRevisionNotFound},
+		{name: "Invalid revision number", id: article.ID, from: 0, to: 2, wantErr: ErrValidation},
+		{name: "Unknown article", id: 999, from: 1, to: 2, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.DiffRevisions(context.Background(), Viewer{}, tt.id, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStatusChangeDoesNotCreateRevision(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Title", Content: "Content", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	published := StatusPublished
+	if _, err := svc.UpdateArticle(context.Background(), 1, article.ID, UpdateInput{Status: &published}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+
+	revisions, err := svc.ListRevisions(context.Background(), Viewer{}, article.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(revisions) != 1 {
+		t.Errorf("Expected 1 revision, got %d", len(revisions))
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Hello World", want: "hello-world"},
+		{title: "  Go 1.24: What's New?  ", want: "go-1-24-what-s-new"},
+		{title: "Привет, мир", want: "привет-мир"},
+		{title: "!!!", want: DefaultSlug},
+		{title: strings.Repeat("a", MaxSlugLength+20), want: strings.Repeat("a", MaxSlugLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := Slugify(tt.title); got != tt.want {
+				t.Errorf("Expected slug %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRegenerateSlug(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	first, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Hello World", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	second, err := svc.CreateArticle(context.Background(), 2, CreateInput{Title: "Hello World", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if first.Slug != "hello-world" || second.Slug != "hello-world-2" {
+		t.Fatalf("Expected slugs hello-world and hello-world-2, got %q and %q", first.Slug, second.Slug)
+	}
+
+	newTitle := "Completely New Title"
+	if _, err := svc.UpdateArticle(context.Background(), 1, first.ID, UpdateInput{Title: &newTitle}); err != nil {
+		t.Fatalf("Failed to update test article: %v", err)
+	}
+	if repo.articles[first.ID].Slug != "hello-world" {
+		t.Errorf("Expected slug to stay stable on title update, got %q", repo.articles[first.ID].Slug)
+	}
+
+	if _, err := svc.RegenerateSlug(context.Background(), 2, first.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for non-owner, got %v", err)
+	}
+
+	version := repo.articles[first.ID].Version
+	regenerated, err := svc.RegenerateSlug(context.Background(), 1, first.ID)
+	if err != nil {
+		t.Fatalf("RegenerateSlug() unexpected error: %v", err)
+	}
+	if regenerated.Slug != "completely-new-title" {
+		t.Errorf("Expected slug %q, got %q", "completely-new-title", regenerated.Slug)
+	}
+	if regenerated.Version != version+1 || repo.articles[first.ID].Version != version+1 {
+		t.Errorf("Expected version %d after regenerating the slug, got %d (stored %d)", version+1, regenerated.Version, repo.articles[first.ID].Version)
+	}
+	if repo.redirects["hello-world"] != first.ID {
+		t.Errorf("Expected redirect from hello-world to article %d, got %v", first.ID, repo.redirects)
+	}
+
+	article, redirected, err := svc.GetArticleBySlug(context.Background(), Viewer{}, "hello-world")
+	if err != nil {
+		t.Fatalf("GetArticleBySlug() unexpected error: %v", err)
+	}
+	if !redirected || article.ID != first.ID {
+		t.Errorf("Expected old slug to redirect to article %d, got article %d (redirected=%v)", first.ID, article.ID, redirected)
+	}
+
+	third, err := svc.CreateArticle(context.Background(), 3, CreateInput{Title: "Hello World", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if third.Slug != "hello-world-3" {
+		t.Errorf("Expected redirected slug to stay reserved, got %q", third.Slug)
+	}
+
+	again, err := svc.RegenerateSlug(context.Background(), 1, first.ID)
+	if err != nil {
+		t.Fatalf("RegenerateSlug() unexpected error: %v", err)
+	}
+	if again.Slug != "completely-new-title" || len(repo.redirects) != 1 {
+		t.Errorf("Expected regenerating an up-to-date slug to be a no-op, got %q with redirects %v", again.Slug, repo.redirects)
+	}
+}
+
+func TestContentStoreOffload(t *testing.T) {
+	repo := newMockRepository()
+	store := storage.NewMemoryStore()
+	svc := NewService(repo, WithContentStore(store, 16))
+
+	small, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Small", Content: "short body"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if stored := repo.articles[small.ID]; stored.ContentRef != "" || stored.Content != "short body" {
+		t.Errorf("Expected small content to stay inline, got content %q ref %q", stored.Content, stored.ContentRef)
+	}
+
+	body := strings.Repeat("large body line\n", 8)
+	large, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Large", Content: body})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if large.Content != body {
+		t.Errorf("Expected created article to carry full content")
+	}
+	stored := repo.articles[large.ID]
+	if stored.Content != "" || stored.ContentRef == "" {
+		t.Fatalf("Expected large content to be offloaded, got content %q ref %q", stored.Content, stored.ContentRef)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected 1 object in store, got %d", store.Len())
+	}
+
+	got, err := svc.GetArticleByID(context.Background(), Viewer{}, large.ID)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if got.Content != body {
+		t.Errorf("Expected content to be read back from the store")
+	}
+
+	articles, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAllArticles() unexpected error: %v", err)
+	}
+	for _, article := range articles {
+		if article.Content == "" {
+			t.Errorf("Expected article %d to have content in listing", article.ID)
+		}
+	}
+
+	shortened := "now short"
+	if _, err := svc.UpdateArticle(context.Background(), 1, large.ID, UpdateInput{Content: &shortened}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if stored := repo.articles[large.ID]; stored.ContentRef != "" || stored.Content != shortened {
+		t.Errorf("Expected shortened content to move inline, got content %q ref %q", stored.Content, stored.ContentRef)
+	}
+
+	diff, err := svc.DiffRevisions(context.Background(), Viewer{}, large.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("DiffRevisions() unexpected error: %v", err)
+	}
+	if diff.Removed != 8 || diff.Added != 1 {
+		t.Errorf("Expected diff against offloaded revision to remove 8 and add 1 lines, got -%d +%d", diff.Removed, diff.Added)
+	}
+}
+
+func TestLenientDraftValidation(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo, WithMinContentLength(20))
+
+	tests := []struct {
+		name         string
+		input        CreateInput
+		wantError    bool
+		wantWarnings int
+	}{
+		{
+			name:         "Lenient draft with short content warns",
+			input:        CreateInput{Title: "Draft", Content: "short", Status: StatusDraft, Lenient: true},
+			wantWarnings: 1,
+		},
+		{
+			name:      "Strict draft with short content fails",
+			input:     CreateInput{Title: "Draft", Content: "short", Status: StatusDraft},
+			wantError: true,
+		},
+		{
+			name:      "Lenient published with short content fails",
+			input:     CreateInput{Title: "Published", Content: "short", Status: StatusPublished, Lenient: true},
+			wantError: true,
+		},
+		{
+			name:      "Lenient draft with empty title fails",
+			input:     CreateInput{Title: "", Content: "short", Status: StatusDraft, Lenient: true},
+			wantError: true,
+		},
+		{
+			name:  "Lenient draft with valid content has no warnings",
+			input: CreateInput{Title: "Draft", Content: "long enough content here", Status: StatusDraft, Lenient: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.CreateArticle(context.Background(), 1, tt.input)
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(article.Warnings) != tt.wantWarnings {
+				t.Errorf("Expected %d warnings, got %v", tt.wantWarnings, article.Warnings)
+			}
+		})
+	}
+
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "short", Status: StatusDraft, Lenient: true})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	shorter := "tiny"
+	updated, err := svc.UpdateArticle(context.Background(), 1, draft.ID, UpdateInput{Content: &shorter, Lenient: true})
+	if err != nil {
+		t.Fatalf("Expected lenient draft update to succeed, got %v", err)
+	}
+	if len(updated.Warnings) != 1 {
+		t.Errorf("Expected 1 warning on update, got %v", updated.Warnings)
+	}
+
+	published := StatusPublished
+	if _, err := svc.UpdateArticle(context.Background(), 1, draft.ID, UpdateInput{Status: &published, Lenient: true}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected publishing a too-short draft to fail, got %v", err)
+	}
+}
+
+func TestArticleTags(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Tagged", Content: "Content", Tags: []string{" Go ", "web", "go"}})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if got := tagNames(article.Tags); !slices.Equal(got, []string{"go", "web"}) {
+		t.Errorf("Expected normalized tags [go web], got %v", got)
+	}
+
+	tooMany := make([]string, MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%d", i)
+	}
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Tagged", Content: "Content", Tags: tooMany}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for too many tags, got %v", err)
+	}
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Tagged", Content: "Content", Tags: []string{"  "}}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for blank tag, got %v", err)
+	}
+
+	newTags := []string{"databases"}
+	updated, err := svc.UpdateArticle(context.Background(), 1, article.ID, UpdateInput{Tags: &newTags})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if got := tagNames(updated.Tags); !slices.Equal(got, newTags) {
+		t.Errorf("Expected tags %v after update, got %v", newTags, got)
+	}
+	if got := tagNames(repo.articles[article.ID].Tags); !slices.Equal(got, newTags) {
+		t.Errorf("Expected stored tags %v, got %v", newTags, got)
+	}
+	if len(repo.revisions[article.ID]) != 1 {
+		t.Errorf("Expected tag-only update not to create a revision, got %d revisions", len(repo.revisions[article.ID]))
+	}
+}
+
+func TestGetTagCounts(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	fixtures := []CreateInput{
+		{Title: "One", Content: "Content", Tags: []string{"go", "web"}},
+		{Title: "Two", Content: "Content", Tags: []string{"go", "databases"}},
+		{Title: "Three", Content: "Content", Tags: []string{"go", "web"}},
+		{Title: "Four", Content: "Content", Tags: []string{"rust"}},
+		{Title: "Draft", Content: "Content", Tags: []string{"rust", "go"}, Status: StatusDraft},
+	}
+	for _, input := range fixtures {
+		if _, err := svc.CreateArticle(context.Background(), 1, input); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		minCount int
+		want     []TagCount
+	}{
+		{
+			name:     "All tags ordered by count",
+			minCount: 0,
+			want: []TagCount{
+				{Name: "go", Count: 3},
+				{Name: "web", Count: 2},
+				{Name: "databases", Count: 1},
+				{Name: "rust", Count: 1},
+			},
+		},
+		{
+			name:     "Minimum count",
+			minCount: 2,
+			want: []TagCount{
+				{Name: "go", Count: 3},
+				{Name: "web", Count: 2},
+			},
+		},
+		{
+			name:     "Minimum above every count",
+			minCount: 10,
+			want:     []TagCount{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.GetTagCounts(context.Background(), tt.minCount)
+			if err != nil {
+				t.Fatalf("GetTagCounts() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestReadingTimeLanguage(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	english, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "English", Content: strings.Repeat("word ", 1000), Language: "en"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	chinese, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Chinese", Content: strings.Repeat("汉字", 500), Language: "zh"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	mislabeled, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Mislabeled", Content: strings.Repeat("汉字", 500), Language: "en"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		id     uint
+		viewer Viewer
+		want   int
+	}{
+		{name: "English by words", id: english.ID, want: 5},
+		{name: "Chinese by characters", id: chinese.ID, want: 2},
+		{name: "Non-CJK language counts words", id: mislabeled.ID, want: 1},
+		{name: "CJK Accept-Language override", id: mislabeled.ID, viewer: Viewer{Language: "zh-CN"}, want: 2},
+		{name: "Non-CJK Accept-Language does not override", id: chinese.ID, viewer: Viewer{Language: "en-US"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.GetArticleByID(context.Background(), tt.viewer, tt.id)
+			if err != nil {
+				t.Fatalf("GetArticleByID() unexpected error: %v", err)
+			}
+			if article.ReadingTimeMinutes != tt.want {
+				t.Errorf("Expected reading time %d, got %d", tt.want, article.ReadingTimeMinutes)
+			}
+		})
+	}
+
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Bad", Content: "Content", Language: "not a tag"}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for invalid language, got %v", err)
+	}
+}
+
+func TestArticleExcerpt(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	auto, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Auto", Content: strings.Repeat("lorem ipsum ", 50)})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if !strings.HasSuffix(auto.Excerpt, textutil.Ellipsis) || utf8.RuneCountInString(auto.Excerpt) > ExcerptLength+1 {
+		t.Errorf("Expected truncated excerpt, got %q", auto.Excerpt)
+	}
+	if auto.ReadingTimeMinutes != 1 {
+		t.Errorf("Expected reading time 1, got %d", auto.ReadingTimeMinutes)
+	}
+
+	custom, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Custom", Content: "Original body", Excerpt: "  Hand-written summary  "})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if custom.Excerpt != "Hand-written summary" {
+		t.Errorf("Expected custom excerpt, got %q", custom.Excerpt)
+	}
+
+	newContent := "Rewritten body"
+	updated, err := svc.UpdateArticle(context.Background(), 1, custom.ID, UpdateInput{Content: &newContent})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if updated.Excerpt != "Hand-written summary" {
+		t.Errorf("Expected custom excerpt to survive content update, got %q", updated.Excerpt)
+	}
+
+	reset := ""
+	updated, err = svc.UpdateArticle(context.Background(), 1, custom.ID, UpdateInput{Excerpt: &reset})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if updated.Excerpt != newContent {
+		t.Errorf("Expected excerpt regenerated from content, got %q", updated.Excerpt)
+	}
+
+	newContent = "Second rewrite"
+	if _, err := svc.UpdateArticle(context.Background(), 1, custom.ID, UpdateInput{Content: &newContent}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	listed, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAllArticles() unexpected error: %v", err)
+	}
+	for _, article := range listed {
+		if article.ID == custom.ID && article.Excerpt != newContent {
+			t.Errorf("Expected listed excerpt %q, got %q", newContent, article.Excerpt)
+		}
+	}
+
+	tooLong := strings.Repeat("x", MaxExcerptLength+1)
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Long", Content: "Body", Excerpt: tooLong}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for long excerpt, got %v", err)
+	}
+}
+
+func TestCoAuthors(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Shared", Content: "Valid content for test", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	title := "Edited"
+	if _, err := svc.UpdateArticle(context.Background(), 2, article.ID, UpdateInput{Title: &title}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden before user 2 is added, got %v", err)
+	}
+	if _, err := svc.AddAuthor(context.Background(), 2, article.ID, AuthorInput{UserID: 2}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when a stranger adds authors, got %v", err)
+	}
+
+	author, err := svc.AddAuthor(context.Background(), 1, article.ID, AuthorInput{UserID: 2})
+	if err != nil {
+		t.Fatalf("AddAuthor() unexpected error: %v", err)
+	}
+	if author.Role != AuthorRoleEditor {
+		t.Errorf("Expected default role %s, got %s", AuthorRoleEditor, author.Role)
+	}
+
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{UserID: 2}, article.ID); err != nil {
+		t.Errorf("Expected editor to see the draft, got %v", err)
+	}
+	if _, err := svc.UpdateArticle(context.Background(), 2, article.ID, UpdateInput{Title: &title}); err != nil {
+		t.Errorf("Expected editor to update, got %v", err)
+	}
+	if err := svc.DeleteArticle(context.Background(), 2, article.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when an editor deletes, got %v", err)
+	}
+	if _, err := svc.AddAuthor(context.Background(), 2, article.ID, AuthorInput{UserID: 3}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when an editor adds authors, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input AuthorInput
+	}{
+		{name: "Missing user", input: AuthorInput{Role: AuthorRoleEditor}},
+		{name: "Unknown role", input: AuthorInput{UserID: 3, Role: "viewer"}},
+		{name: "Demote creator", input: AuthorInput{UserID: 1, Role: AuthorRoleEditor}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.AddAuthor(context.Background(), 1, article.ID, tt.input); !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected ErrValidation, got %v", err)
+			}
+		})
+	}
+
+	if _, err := svc.AddAuthor(context.Background(), 1, article.ID, AuthorInput{UserID: 2, Role: AuthorRoleOwner}); err != nil {
+		t.Fatalf("AddAuthor() unexpected error: %v", err)
+	}
+	authors, err := svc.ListAuthors(context.Background(), Viewer{UserID: 1}, article.ID)
+	if err != nil {
+		t.Fatalf("ListAuthors() unexpected error: %v", err)
+	}
+	if len(authors) != 2 || authors[1].Role != AuthorRoleOwner {
+		t.Errorf("Expected user 2 promoted to owner, got %+v", authors)
+	}
+
+	if err := svc.RemoveAuthor(context.Background(), 2, article.ID, 1); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation when removing the creator, got %v", err)
+	}
+	if err := svc.RemoveAuthor(context.Background(), 1, article.ID, 3); !errors.Is(err, ErrAuthorNotFound) {
+		t.Errorf("Expected ErrAuthorNotFound, got %v", err)
+	}
+	if err := svc.RemoveAuthor(context.Background(), 1, article.ID, 2); err != nil {
+		t.Fatalf("RemoveAuthor() unexpected error: %v", err)
+	}
+	if _, err := svc.UpdateArticle(context.Background(), 2, article.ID, UpdateInput{Title: &title}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden after removal, got %v", err)
+	}
+}
+
+func TestGetArticleStats(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Stats", Content: "One two three.\n\nFour five."})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := svc.AddAuthor(context.Background(), 1, article.ID, AuthorInput{UserID: 2}); err != nil {
+		t.Fatalf("AddAuthor() unexpected error: %v", err)
+	}
+	content := "One two three.\n\nFour five.\n\nSix 七八"
+	if _, err := svc.UpdateArticle(context.Background(), 2, article.ID, UpdateInput{Content: &content}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+
+	stats, err := svc.GetArticleStats(context.Background(), Viewer{}, article.ID)
+	if err != nil {
+		t.Fatalf("GetArticleStats() unexpected error: %v", err)
+	}
+	want := Stats{ID: article.ID, WordCount: 8, CharacterCount: 34, ParagraphCount: 3, ReadingTimeMinutes: 1, RevisionCount: 2, LastEditorID: 2}
+	stats.LastEditedAt = nil
+	if *stats != want {
+		t.Errorf("Expected stats %+v, got %+v", want, *stats)
+	}
+
+	legacy := repo.articles[article.ID]
+	legacy.WordCount, legacy.Paragraphs, legacy.ReadingTimeMinutes = 0, 0, 0
+	if stats, err = svc.GetArticleStats(context.Background(), Viewer{}, article.ID); err != nil {
+		t.Fatalf("GetArticleStats() unexpected error: %v", err)
+	}
+	if stats.WordCount != 8 || stats.ParagraphCount != 3 || stats.ReadingTimeMinutes != 1 {
+		t.Errorf("Expected stats computed for rows without cached values, got %+v", *stats)
+	}
+
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Valid content for test", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	if _, err := svc.GetArticleStats(context.Background(), Viewer{UserID: 3}, draft.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another user's draft, got %v", err)
+	}
+}
+
+func TestFeaturedArticles(t *testing.T) {
+	svc := NewService(newMockRepository())
+
+	var ids []uint
+	for _, title := range []string{"First", "Second", "Third"} {
+		article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: title, Content: "Valid content for test"})
+		if err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+		ids = append(ids, article.ID)
+	}
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Valid content for test", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	moderator := Viewer{UserID: 50, Role: auth.RoleModerator}
+	for _, viewer := range []Viewer{{UserID: 1}, {UserID: 2}} {
+		if _, err := svc.SetFeatured(context.Background(), viewer, ids[0], true); !errors.Is(err, ErrNotModerator) {
+			t.Errorf("Expected ErrNotModerator for user %d, got %v", viewer.UserID, err)
+		}
+	}
+	if _, err := svc.SetFeatured(context.Background(), moderator, draft.ID, true); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for a draft, got %v", err)
+	}
+
+	if _, err := svc.SetFeatured(context.Background(), moderator, ids[0], true); err != nil {
+		t.Fatalf("SetFeatured() unexpected error: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	article, err := svc.SetFeatured(context.Background(), Viewer{UserID: 99, Role: auth.RoleAdmin}, ids[1], true)
+	if err != nil {
+		t.Fatalf("SetFeatured() unexpected error: %v", err)
+	}
+	if !article.IsFeatured || article.FeaturedAt == nil {
+		t.Errorf("Expected article to be featured, got %+v", article)
+	}
+	if article.Version != 2 {
+		t.Errorf("Expected featuring to bump the version to 2, got %d", article.Version)
+	}
+
+	featured, total, err := svc.GetFeaturedArticles(context.Background(), Viewer{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetFeaturedArticles() unexpected error: %v", err)
+	}
+	if total != 2 || featured[0].ID != ids[1] || featured[1].ID != ids[0] {
+		t.Errorf("Expected articles %d and %d by featured_at, got %d articles", ids[1], ids[0], total)
+	}
+
+	listed, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{PinnedFirst: true}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAllArticles() unexpected error: %v", err)
+	}
+	got := []uint{listed[0].ID, listed[1].ID, listed[2].ID}
+	if want := []uint{ids[1], ids[0], ids[2]}; !slices.Equal(got, want) {
+		t.Errorf("Expected order %v, got %v", want, got)
+	}
+
+	if _, err := svc.SetFeatured(context.Background(), moderator, ids[1], false); err != nil {
+		t.Fatalf("SetFeatured() unexpected error: %v", err)
+	}
+	notFeatured := false
+	if _, total, _ := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{Featured: &notFeatured}, 1, 10); total != 2 {
+		t.Errorf("Expected 2 articles that are not featured, got %d", total)
+	}
+
+	if _, _, err := svc.GetArticlesAfter(context.Background(), Viewer{}, ListFilter{PinnedFirst: true}, "", 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for pinned_first with cursor, got %v", err)
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	svc := NewService(newMockRepository(), WithMinContentLength(10))
+
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Hello", Content: "Original English content", Language: "en"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	if _, err := svc.SaveTranslation(context.Background(), 2, article.ID, "de", TranslationInput{Title: "Hallo", Content: "Inhalt"}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for a stranger, got %v", err)
+	}
+	invalid := []struct {
+		name   string
+		locale string
+		input  TranslationInput
+	}{
+		{name: "Invalid locale", locale: "not a tag", input: TranslationInput{Title: "T", Content: "C"}},
+		{name: "Own language", locale: "EN", input: TranslationInput{Title: "T", Content: "C"}},
+		{name: "Missing title", locale: "de", input: TranslationInput{Content: "C"}},
+		{name: "Missing content", locale: "de", input: TranslationInput{Title: "T"}},
+		{name: "Short content", locale: "de", input: TranslationInput{Title: "T", Content: "Kurz"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.SaveTranslation(context.Background(), 1, article.ID, tt.locale, tt.input); !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected ErrValidation, got %v", err)
+			}
+		})
+	}
+
+	translation, err := svc.SaveTranslation(context.Background(), 1, article.ID, "de-at", TranslationInput{Title: "Servus", Content: "Österreichischer Inhalt"})
+	if err != nil {
+		t.Fatalf("SaveTranslation() unexpected error: %v", err)
+	}
+	if translation.Locale != "de-AT" {
+		t.Errorf("Expected canonical locale de-AT, got %s", translation.Locale)
+	}
+	if _, err := svc.SaveTranslation(context.Background(), 1, article.ID, "de", TranslationInput{Title: "Hallo", Content: "Deutscher Inhalt"}); err != nil {
+		t.Fatalf("SaveTranslation() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		language  string
+		wantTitle string
+		locale    string
+	}{
+		{name: "No preference", wantTitle: "Hello"},
+		{name: "Exact locale", language: "de-AT", wantTitle: "Servus", locale: "de-AT"},
+		{name: "Base language", language: "de-CH", wantTitle: "Hallo", locale: "de"},
+		{name: "Article language", language: "en-US", wantTitle: "Hello"},
+		{name: "Missing translation falls back", language: "fr", wantTitle: "Hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.GetArticleByID(context.Background(), Viewer{Language: tt.language}, article.ID)
+			if err != nil {
+				t.Fatalf("GetArticleByID() unexpected error: %v", err)
+			}
+			if got.Title != tt.wantTitle || got.Locale != tt.locale {
+				t.Errorf("Expected %q in %q, got %q in %q", tt.wantTitle, tt.locale, got.Title, got.Locale)
+			}
+
+			listed, _, err := svc.GetAllArticles(context.Background(), Viewer{Language: tt.language}, ListFilter{}, 1, 10)
+			if err != nil {
+				t.Fatalf("GetAllArticles() unexpected error: %v", err)
+			}
+			if listed[0].Title != tt.wantTitle {
+				t.Errorf("Expected listed title %q, got %q", tt.wantTitle, listed[0].Title)
+			}
+		})
+	}
+
+	translations, err := svc.ListTranslations(context.Background(), Viewer{}, article.ID)
+	if err != nil {
+		t.Fatalf("ListTranslations() unexpected error: %v", err)
+	}
+	if len(translations) != 2 || translations[0].Locale != "de" || translations[0].Content != "" {
+		t.Errorf("Expected de and de-AT without content, got %+v", translations)
+	}
+
+	if err := svc.DeleteTranslation(context.Background(), 1, article.ID, "de"); err != nil {
+		t.Fatalf("DeleteTranslation() unexpected error: %v", err)
+	}
+	if _, err := svc.GetTranslation(context.Background(), Viewer{}, article.ID, "de"); !errors.Is(err, ErrNoTranslation) {
+		t.Errorf("Expected ErrNoTranslation after delete, got %v", err)
+	}
+	if err := svc.DeleteTranslation(context.Background(), 1, article.ID, "de"); !errors.Is(err, ErrNoTranslation) {
+		t.Errorf("Expected ErrNoTranslation, got %v", err)
+	}
+}
+
+func TestContentLengthFilter(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for _, content := range []string{"a", "stub", "short body", "日本語の本文", strings.Repeat("x", 100)} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: content}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name      string
+		filter    ListFilter
+		wantTotal int64
+	}{
+		{name: "No filter", filter: ListFilter{}, wantTotal: 5},
+		{name: "Stubs only", filter: ListFilter{MaxContentLength: intPtr(4)}, wantTotal: 2},
+		{name: "Runes not bytes", filter: ListFilter{MinContentLength: intPtr(6), MaxContentLength: intPtr(6)}, wantTotal: 1},
+		{name: "Long only", filter: ListFilter{MinContentLength: intPtr(11)}, wantTotal: 1},
+		{name: "Range", filter: ListFilter{MinContentLength: intPtr(4), MaxContentLength: intPtr(10)}, wantTotal: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, total, err := svc.GetAllArticles(context.Background(), Viewer{}, tt.filter, 1, 10)
+			if err != nil {
+				t.Fatalf("GetAllArticles() unexpected error: %v", err)
+			}
+			if total != tt.wantTotal || int64(len(articles)) != tt.wantTotal {
+				t.Errorf("Expected total %d, got total %d with %d articles", tt.wantTotal, total, len(articles))
+			}
+		})
+	}
+
+	article, _, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{MaxContentLength: intPtr(1)}, 1, 10)
+	if err != nil || len(article) != 1 {
+		t.Fatalf("Expected a single one-character article, got %v (%v)", article, err)
+	}
+	longer := "now a longer body"
+	if _, err := svc.UpdateArticle(context.Background(), 1, article[0].ID, UpdateInput{Content: &longer}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if _, total, _ := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{MaxContentLength: intPtr(1)}, 1, 10); total != 0 {
+		t.Errorf("Expected content length to follow updates, got %d short articles", total)
+	}
+}
+
+type fakeCategories struct {
+	parents map[uint]uint
+}
+
+func (f *fakeCategories) CategoryExists(ctx context.Context, id uint) (bool, error) {
+	_, ok := f.parents[id]
+	return ok, nil
+}
+
+func (f *fakeCategories) SubtreeIDs(ctx context.Context, id uint) ([]uint, error) {
+	if _, ok := f.parents[id]; !ok {
+		return nil, nil
+	}
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		for child, parent := range f.parents {
+			if parent == ids[i] {
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func TestArticleCategories(t *testing.T) {
+	categories := &fakeCategories{parents: map[uint]uint{1: 0, 2: 1, 3: 2, 4: 0}}
+	svc := NewService(newMockRepository(), WithCategories(categories))
+
+	uintPtr := func(n uint) *uint { return &n }
+
+	for _, categoryID := range []*uint{uintPtr(1), uintPtr(2), uintPtr(3), uintPtr(4), nil} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test", CategoryID: categoryID}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test", CategoryID: uintPtr(99)}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for unknown category, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		category  uint
+		wantTotal int64
+	}{
+		{name: "Root includes descendants", category: 1, wantTotal: 3},
+		{name: "Middle includes child", category: 2, wantTotal: 2},
+		{name: "Leaf", category: 3, wantTotal: 1},
+		{name: "Sibling tree", category: 4, wantTotal: 1},
+		{name: "Unknown category", category: 99, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, total, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{CategoryID: uintPtr(tt.category)}, 1, 10)
+			if err != nil {
+				t.Fatalf("GetAllArticles() unexpected error: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+		})
+	}
+
+	article, err := svc.UpdateArticle(context.Background(), 1, 5, UpdateInput{CategoryID: uintPtr(3)})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if article.CategoryID == nil || *article.CategoryID != 3 {
+		t.Errorf("Expected category 3, got %v", article.CategoryID)
+	}
+	if _, total, _ := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{CategoryID: uintPtr(2)}, 1, 10); total != 3 {
+		t.Errorf("Expected moved article in subtree, got total %d", total)
+	}
+
+	article, err = svc.UpdateArticle(context.Background(), 1, 5, UpdateInput{CategoryID: uintPtr(0)})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if article.CategoryID != nil {
+		t.Errorf("Expected category to be cleared, got %v", *article.CategoryID)
+	}
+}
+
+func TestModeration(t *testing.T) {
+	svc := NewService(newMockRepository(), WithModeration(true, []string{"trusted"}))
+
+	statusTests := []struct {
+		name       string
+		role       string
+		status     string
+		wantStatus string
+	}{
+		{name: "Untrusted publish waits for review", role: "", status: StatusPublished, wantStatus: StatusPendingReview},
+		{name: "Untrusted draft stays draft", role: "", status: StatusDraft, wantStatus: StatusDraft},
+		{name: "Configured trusted role", role: "trusted", status: StatusPublished, wantStatus: StatusPublished},
+		{name: "Moderator", role: auth.RoleModerator, status: StatusPublished, wantStatus: StatusPublished},
+		{name: "Admin", role: auth.RoleAdmin, status: StatusPublished, wantStatus: StatusPublished},
+	}
+	for _, tt := range statusTests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: tt.name, Content: "Valid content for test", Status: tt.status, Role: tt.role})
+			if err != nil {
+				t.Fatalf("CreateArticle() unexpected error: %v", err)
+			}
+			if article.Status != tt.wantStatus {
+				t.Errorf("Expected status %q, got %q", tt.wantStatus, article.Status)
+			}
+		})
+	}
+
+	queue, total, err := svc.ModerationQueue(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("ModerationQueue() unexpected error: %v", err)
+	}
+	if total != 1 || len(queue) != 1 || queue[0].ID != 1 {
+		t.Fatalf("Expected article 1 alone in the queue, got %d articles (total %d)", len(queue), total)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected pending article to be hidden, got %v", err)
+	}
+
+	if _, err := svc.RejectArticle(context.Background(), 9, 1, "   "); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for empty reason, got %v", err)
+	}
+	if _, err := svc.ApproveArticle(context.Background(), 9, 3); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Expected ErrNotPending for published article, got %v", err)
+	}
+
+	rejected, err := svc.RejectArticle(context.Background(), 9, 1, "Please cite your sources")
+	if err != nil {
+		t.Fatalf("RejectArticle() unexpected error: %v", err)
+	}
+	if rejected.Status != StatusRejected || rejected.ReviewNote != "Please cite your sources" || rejected.ReviewedBy == nil || *rejected.ReviewedBy != 9 {
+		t.Errorf("Expected rejection by 9 with reason, got status %q note %q", rejected.Status, rejected.ReviewNote)
+	}
+	own, err := svc.GetArticleByID(context.Background(), Viewer{UserID: 1}, 1)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if own.ReviewNote != "Please cite your sources" {
+		t.Errorf("Expected author to see the rejection reason, got %q", own.ReviewNote)
+	}
+
+	published := StatusPublished
+	resubmitted, err := svc.UpdateArticle(context.Background(), 1, 1, UpdateInput{Status: &published})
+	if err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if resubmitted.Status != StatusPendingReview || resubmitted.ReviewNote != "" {
+		t.Errorf("Expected resubmission to clear the reason and wait for review, got status %q note %q", resubmitted.Status, resubmitted.ReviewNote)
+	}
+
+	approved, err := svc.ApproveArticle(context.Background(), 9, 1)
+	if err != nil {
+		t.Fatalf("ApproveArticle() unexpected error: %v", err)
+	}
+	if approved.Status != StatusPublished || approved.ReviewedAt == nil {
+		t.Errorf("Expected approved article to be published, got %q", approved.Status)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, 1); err != nil {
+		t.Errorf("Expected approved article to be public, got %v", err)
+	}
+	if _, total, _ := svc.ModerationQueue(context.Background(), 1, 10); total != 0 {
+		t.Errorf("Expected empty queue, got total %d", total)
+	}
+
+	title, content, tags := "Edited title", "Edited content for test", []string{"go"}
+	editTests := []struct {
+		name       string
+		id         uint
+		input      UpdateInput
+		wantStatus string
+	}{
+		{name: "Untrusted tag edit stays published", id: 1, input: UpdateInput{Tags: &tags}, wantStatus: StatusPublished},
+		{name: "Moderator content edit stays published", id: 4, input: UpdateInput{Content: &content, Role: auth.RoleModerator}, wantStatus: StatusPublished},
+		{name: "Untrusted title edit waits for review", id: 3, input: UpdateInput{Title: &title}, wantStatus: StatusPendingReview},
+		{name: "Untrusted content edit waits for review", id: 1, input: UpdateInput{Content: &content}, wantStatus: StatusPendingReview},
+	}
+	for _, tt := range editTests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.UpdateArticle(context.Background(), 1, tt.id, tt.input)
+			if err != nil {
+				t.Fatalf("UpdateArticle() unexpected error: %v", err)
+			}
+			if article.Status != tt.wantStatus {
+				t.Errorf("Expected status %q, got %q", tt.wantStatus, article.Status)
+			}
+		})
+	}
+	if _, total, _ := svc.ModerationQueue(context.Background(), 1, 10); total != 2 {
+		t.Errorf("Expected the edited articles back in the queue, got total %d", total)
+	}
+
+	translated := TranslationInput{Title: "Titel", Content: "Übersetzter Inhalt zum Test"}
+	if _, err := svc.SaveTranslation(context.Background(), 1, 4, "de", TranslationInput{Title: translated.Title, Content: translated.Content, Role: auth.RoleModerator}); err != nil {
+		t.Fatalf("SaveTranslation() unexpected error: %v", err)
+	}
+	if article, _ := svc.GetArticleByID(context.Background(), Viewer{}, 4); article == nil || article.Status != StatusPublished {
+		t.Errorf("Expected a moderator's translation to keep the article published, got %+v", article)
+	}
+	if _, err := svc.SaveTranslation(context.Background(), 1, 4, "fr", translated); err != nil {
+		t.Fatalf("SaveTranslation() unexpected error: %v", err)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, 4); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an untrusted translation to send the article back to review, got %v", err)
+	}
+
+	unmoderated := NewService(newMockRepository())
+	article, err := unmoderated.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	if article.Status != StatusPublished {
+		t.Errorf("Expected status %q without moderation, got %q", StatusPublished, article.Status)
+	}
+}
+
+func TestHoldForReview(t *testing.T) {
+	svc := NewService(newMockRepository())
+	published, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Published", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Valid content for test", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         uint
+		wantStatus string
+		wantError  error
+	}{
+		{name: "Published article is held", id: published.ID, wantStatus: StatusPendingReview},
+		{name: "Draft is left alone", id: draft.ID, wantStatus: StatusDraft},
+		{name: "Unknown article", id: 99, wantError: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.HoldForReview(context.Background(), tt.id, "Reported 3 times")
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if err == nil && article.Status != tt.wantStatus {
+				t.Errorf("Expected status %q, got %q", tt.wantStatus, article.Status)
+			}
+		})
+	}
+
+	if _, total, _ := svc.ModerationQueue(context.Background(), 1, 10); total != 1 {
+		t.Errorf("Expected the held article in the queue, got total %d", total)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, published.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected held article to be hidden, got %v", err)
+	}
+}
+
+type fakeSeries map[uint]*SeriesMembership
+
+func (f fakeSeries) ArticleSeries(ctx context.Context, articleID uint) (*SeriesMembership, error) {
+	return f[articleID], nil
+}
+
+func TestArticleSeriesMembership(t *testing.T) {
+	series := fakeSeries{1: {ID: 7, Slug: "learning-go", Title: "Learning Go", Position: 1, Total: 2, Next: &SeriesLink{ID: 2, Slug: "part-two", Title: "Part two"}}}
+	svc := NewService(newMockRepository(), WithSeries(series))
+
+	for _, title := range []string{"Part one", "Part two"} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: title, Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	article, err := svc.GetArticleByID(context.Background(), Viewer{}, 1)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if article.Series == nil || article.Series.ID != 7 || article.Series.Next == nil || article.Series.Next.ID != 2 {
+		t.Errorf("Expected membership of series 7 with next article 2, got %+v", article.Series)
+	}
+
+	article, _, err = svc.GetArticleBySlug(context.Background(), Viewer{}, "part-one")
+	if err != nil {
+		t.Fatalf("GetArticleBySlug() unexpected error: %v", err)
+	}
+	if article.Series == nil || article.Series.Slug != "learning-go" {
+		t.Errorf("Expected membership by slug, got %+v", article.Series)
+	}
+
+	article, err = svc.GetArticleByID(context.Background(), Viewer{}, 2)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if article.Series != nil {
+		t.Errorf("Expected no series membership, got %+v", article.Series)
+	}
+}
+
+func TestSanitizeSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Plain words", query: "golang tips", want: "golang tips"},
+		{name: "Collapses whitespace", query: "  golang \t\n tips ", want: "golang tips"},
+		{name: "Keeps phrases and exclusions", query: `"web server" -java`, want: `"web server" -java`},
+		{name: "Strips operators", query: "go & (rust | c++); DROP", want: "go rust c DROP"},
+		{name: "Unicode letters", query: "日本語 café", want: "日本語 café"},
+		{name: "Empty", query: "", wantErr: true},
+		{name: "Only punctuation", query: "!!! ()", wantErr: true},
+		{name: "Too long", query: strings.Repeat("a", MaxSearchQueryLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeSearchQuery(tt.query)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sanitizeSearchQuery() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchArticles(t *testing.T) {
+	svc := NewService(newMockRepository())
+	inputs := []CreateInput{
+		{Title: "Go concurrency", Content: "Goroutines and channels in go"},
+		{Title: "Cooking pasta", Content: "Boil water, add salt"},
+		{Title: "Go draft", Content: "Unpublished go notes", Status: StatusDraft},
+		{Title: "Testing", Content: "Table-driven tests in go"},
+	}
+	for _, input := range inputs {
+		if _, err := svc.CreateArticle(context.Background(), 1, input); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	hits, total, err := svc.SearchArticles(context.Background(), Viewer{}, "  go!! ", 1, 10)
+	if err != nil {
+		t.Fatalf("SearchArticles() unexpected error: %v", err)
+	}
+	if total != 2 || len(hits) != 2 {
+		t.Fatalf("Expected 2 published hits, got total %d with %d hits", total, len(hits))
+	}
+	if hits[0].Title != "Go concurrency" {
+		t.Errorf("Expected best ranked hit first, got %q", hits[0].Title)
+	}
+	if hits[0].ReadingTimeMinutes != 1 {
+		t.Errorf("Expected reading time on hits, got %d", hits[0].ReadingTimeMinutes)
+	}
+
+	hits, total, err = svc.SearchArticles(context.Background(), Viewer{}, "go", 2, 1)
+	if err != nil || total != 2 || len(hits) != 1 || hits[0].Title != "Testing" {
+		t.Errorf("Expected second page to hold the lower ranked hit, got %v (total %d, %v)", hits, total, err)
+	}
+
+	if _, _, err := svc.SearchArticles(context.Background(), Viewer{}, "()", 1, 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for empty query, got %v", err)
+	}
+}
+
+type fakeSearchService struct {
+	matches []SearchMatch
+	total   int64
+	query   string
+}
+
+func (f *fakeSearchService) Search(query string, page, limit int) ([]SearchMatch, int64, error) {
+	f.query = query
+	return f.matches, f.total, nil
+}
+
+func TestSearchArticlesWithIndex(t *testing.T) {
+	index := &fakeSearchService{}
+	svc := NewService(newMockRepository(), WithSearch(index))
+	var ids []uint
+	for _, input := range []CreateInput{
+		{Title: "First", Content: "Indexed content"},
+		{Title: "Second", Content: "Indexed content"},
+		{Title: "Draft", Content: "Indexed content", Status: StatusDraft},
+	} {
+		article, err := svc.CreateArticle(context.Background(), 1, input)
+		if err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+		ids = append(ids, article.ID)
+	}
+
+	index.matches = []SearchMatch{
+		{ArticleID: ids[1], Rank: 2.5, Snippet: "<mark>Indexed</mark> content"},
+		{ArticleID: ids[2], Rank: 2},
+		{ArticleID: 99, Rank: 1.5},
+		{ArticleID: ids[0], Rank: 1},
+	}
+	index.total = 4
+
+	hits, total, err := svc.SearchArticles(context.Background(), Viewer{}, " indexed! ", 1, 10)
+	if err != nil {
+		t.Fatalf("SearchArticles() unexpected error: %v", err)
+	}
+	if index.query != "indexed" {
+		t.Errorf("Expected the sanitized query to reach the index, got %q", index.query)
+	}
+	if total != 4 || len(hits) != 2 {
+		t.Fatalf("Expected 2 hits out of 4, got %d hits (total %d)", len(hits), total)
+	}
+	if hits[0].Title != "Second" || hits[0].Rank != 2.5 || hits[0].Snippet != "<mark>Indexed</mark> content" || hits[1].Title != "First" {
+		t.Errorf("Expected hits in index order with rank and snippet, got %+v", hits)
+	}
+}
+
+func TestSearchDocuments(t *testing.T) {
+	svc := NewService(newMockRepository())
+	var ids []uint
+	for i := range 5 {
+		status := StatusPublished
+		if i == 2 {
+			status = StatusDraft
+		}
+		article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: fmt.Sprintf("Article %d", i), Content: "Valid content for test", Status: status})
+		if err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+		ids = append(ids, article.ID)
+	}
+
+	docs, err := svc.GetSearchDocuments(context.Background(), []uint{ids[0], ids[2]})
+	if err != nil || len(docs) != 1 || docs[0].ID != ids[0] {
+		t.Errorf("Expected only the published article, got %v (%v)", docs, err)
+	}
+
+	var batches []int
+	seen := make(map[uint]bool)
+	err = svc.ScanSearchDocuments(context.Background(), 2, func(articles []Article) error {
+		batches = append(batches, len(articles))
+		for _, article := range articles {
+			seen[article.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanSearchDocuments() unexpected error: %v", err)
+	}
+	if !slices.Equal(batches, []int{2, 2}) || len(seen) != 4 || seen[ids[2]] {
+		t.Errorf("Expected 4 published articles in batches of 2, got batches %v and %v", batches, seen)
+	}
+}
+
+func TestRestoreArticle(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	if _, err := svc.RestoreArticle(context.Background(), 1, article.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for live article, got %v", err)
+	}
+
+	if err := svc.DeleteArticle(context.Background(), 1, article.ID); err != nil {
+		t.Fatalf("DeleteArticle() unexpected error: %v", err)
+	}
+
+	if _, err := svc.RestoreArticle(context.Background(), 2, article.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for non-owner, got %v", err)
+	}
+
+	restored, err := svc.RestoreArticle(context.Background(), 1, article.ID)
+	if err != nil {
+		t.Fatalf("RestoreArticle() unexpected error: %v", err)
+	}
+	if restored.ID != article.ID || restored.Content != article.Content {
+		t.Errorf("Expected restored article %d with its content, got %+v", article.ID, restored)
+	}
+	if _, err := svc.GetArticleByID(context.Background(), Viewer{}, article.ID); err != nil {
+		t.Errorf("Expected restored article to be visible, got %v", err)
+	}
+}
+
+func TestGetTrash(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	for _, userID := range []uint{1, 1, 2, 1} {
+		if _, err := svc.CreateArticle(context.Background(), userID, CreateInput{Title: "Article", Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	if _, err := svc.AddAuthor(context.Background(), 2, 3, AuthorInput{UserID: 1, Role: AuthorRoleOwner}); err != nil {
+		t.Fatalf("AddAuthor() unexpected error: %v", err)
+	}
+	for _, del := range []struct{ userID, id uint }{{1, 1}, {1, 2}, {2, 3}} {
+		if err := svc.DeleteArticle(context.Background(), del.userID, del.id); err != nil {
+			t.Fatalf("DeleteArticle() unexpected error: %v", err)
+		}
+	}
+	repo.deleted[1].DeletedAt.Time = time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		userID    uint
+		page      int
+		limit     int
+		wantIDs   []uint
+		wantTotal int64
+	}{
+		{name: "Own and co-owned articles, newest first", userID: 1, page: 1, limit: 10, wantIDs: []uint{3, 2, 1}, wantTotal: 3},
+		{name: "Second page", userID: 1, page: 2, limit: 2, wantIDs: []uint{1}, wantTotal: 3},
+		{name: "Other owner", userID: 2, page: 1, limit: 10, wantIDs: []uint{3}, wantTotal: 1},
+		{name: "Nothing deleted", userID: 3, page: 1, limit: 10, wantIDs: []uint{}, wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trash, total, err := svc.GetTrash(context.Background(), tt.userID, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("GetTrash() unexpected error: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			ids := make([]uint, 0, len(trash))
+			for _, item := range trash {
+				ids = append(ids, item.ID)
+				if item.DeletedAt.IsZero() || item.Content == "" {
+					t.Errorf("Expected article %d with deleted_at and content", item.ID)
+				}
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("Expected articles %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+
+	if _, err := svc.RestoreArticle(context.Background(), 1, 2); err != nil {
+		t.Fatalf("RestoreArticle() unexpected error: %v", err)
+	}
+	if _, total, _ := svc.GetTrash(context.Background(), 1, 1, 10); total != 2 {
+		t.Errorf("Expected restored article to leave the trash, got total %d", total)
+	}
+}
+
+func TestGetOwnArticles(t *testing.T) {
+	svc := NewService(newMockRepository())
+	inputs := []struct {
+		userID uint
+		status string
+	}{{1, StatusDraft}, {1, StatusPublished}, {2, StatusPublished}, {1, StatusPublished}, {2, StatusDraft}}
+	for _, input := range inputs {
+		if _, err := svc.CreateArticle(context.Background(), input.userID, CreateInput{Title: "Article", Content: "Valid content for test", Status: input.status}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	if _, err := svc.AddAuthor(context.Background(), 2, 3, AuthorInput{UserID: 1, Role: AuthorRoleEditor}); err != nil {
+		t.Fatalf("AddAuthor() unexpected error: %v", err)
+	}
+	if err := svc.DeleteArticle(context.Background(), 1, 4); err != nil {
+		t.Fatalf("DeleteArticle() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		userID      uint
+		filter      OwnFilter
+		limit       int
+		wantIDs     []uint
+		wantTotal   int64
+		wantDeleted []uint
+		wantErr     error
+	}{
+		{name: "All articles including deleted", userID: 1, limit: 10, wantIDs: []uint{4, 3, 2, 1}, wantTotal: 4, wantDeleted: []uint{4}},
+		{name: "Drafts only", userID: 1, filter: OwnFilter{Statuses: []string{StatusDraft}}, limit: 10, wantIDs: []uint{1}, wantTotal: 1},
+		{name: "Published leaves out deleted", userID: 1, filter: OwnFilter{Statuses: []string{StatusPublished}}, limit: 10, wantIDs: []uint{3, 2}, wantTotal: 2},
+		{name: "Deleted only", userID: 1, filter: OwnFilter{Deleted: true}, limit: 10, wantIDs: []uint{4}, wantTotal: 1, wantDeleted: []uint{4}},
+		{name: "Paginated", userID: 1, limit: 3, wantIDs: []uint{4, 3, 2}, wantTotal: 4, wantDeleted: []uint{4}},
+		{name: "Other author", userID: 2, limit: 10, wantIDs: []uint{5, 3}, wantTotal: 2},
+		{name: "Invalid status", userID: 1, filter: OwnFilter{Statuses: []string{"archived"}}, limit: 10, wantErr: ErrValidation},
+		{name: "Invalid sort", userID: 1, filter: OwnFilter{Sort: "views"}, limit: 10, wantErr: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, total, err := svc.GetOwnArticles(context.Background(), tt.userID, tt.filter, 1, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, total)
+			}
+			ids := make([]uint, 0, len(articles))
+			var deleted []uint
+			for _, item := range articles {
+				ids = append(ids, item.ID)
+				if item.Deleted != (item.DeletedAt != nil) {
+					t.Errorf("Expected article %d to carry deleted_at only when deleted", item.ID)
+				}
+				if item.Deleted {
+					deleted = append(deleted, item.ID)
+				}
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("Expected articles %v, got %v", tt.wantIDs, ids)
+			}
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("Expected deleted articles %v, got %v", tt.wantDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestAdminArticleOperations(t *testing.T) {
+	svc := NewService(newMockRepository())
+	inputs := []struct {
+		userID uint
+		status string
+	}{{1, StatusDraft}, {2, StatusPublished}, {3, StatusDraft}}
+	for _, input := range inputs {
+		if _, err := svc.CreateArticle(context.Background(), input.userID, CreateInput{Title: "Article", Content: "Valid content for test", Status: input.status}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	articles, total, err := svc.ListAllArticles(context.Background(), ListFilter{Statuses: []string{StatusDraft}}, 1, 10)
+	if err != nil {
+		t.Fatalf("ListAllArticles() unexpected error: %v", err)
+	}
+	if total != 2 || len(articles) != 2 {
+		t.Errorf("Expected 2 drafts across all owners, got total %d and %d articles", total, len(articles))
+	}
+	if _, _, err := svc.ListAllArticles(context.Background(), ListFilter{Statuses: []string{"archived"}}, 1, 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for unknown status, got %v", err)
+	}
+
+	if _, err := svc.ReassignArticle(context.Background(), 9, 1, 0); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for empty user_id, got %v", err)
+	}
+	if _, err := svc.ReassignArticle(context.Background(), 9, 99, 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for missing article, got %v", err)
+	}
+	reassigned, err := svc.ReassignArticle(context.Background(), 9, 1, 2)
+	if err != nil {
+		t.Fatalf("ReassignArticle() unexpected error: %v", err)
+	}
+	if reassigned.UserID != 2 {
+		t.Errorf("Expected owner 2, got %d", reassigned.UserID)
+	}
+	if err := svc.DeleteArticle(context.Background(), 1, 1); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected previous owner to lose access, got %v", err)
+	}
+	title := "Reassigned"
+	if _, err := svc.UpdateArticle(context.Background(), 2, 1, UpdateInput{Title: &title}); err != nil {
+		t.Errorf("Expected new owner to edit the article, got %v", err)
+	}
+
+	if err := svc.DeleteAnyArticle(context.Background(), 9, 3); err != nil {
+		t.Fatalf("DeleteAnyArticle() unexpected error: %v", err)
+	}
+	if err := svc.DeleteAnyArticle(context.Background(), 9, 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+
+	counts, err := svc.CountArticles(context.Background())
+	if err != nil {
+		t.Fatalf("CountArticles() unexpected error: %v", err)
+	}
+	want := map[string]int64{StatusDraft: 1, StatusPublished: 1, StatusDeleted: 1}
+	for status, count := range want {
+		if counts[status] != count {
+			t.Errorf("Expected %d %s articles, got %d", count, status, counts[status])
+		}
+	}
+}
+
+func TestPurgeArticles(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	for i := 0; i < 3; i++ {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	if err := svc.PurgeArticle(context.Background(), 1); err != nil {
+		t.Fatalf("PurgeArticle() unexpected error: %v", err)
+	}
+	if err := svc.PurgeArticle(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for purged article, got %v", err)
+	}
+	if len(repo.revisions[1]) != 0 {
+		t.Errorf("Expected revisions to be purged, got %d", len(repo.revisions[1]))
+	}
+
+	for _, id := range []uint{2, 3} {
+		if err := svc.DeleteArticle(context.Background(), 1, id); err != nil {
+			t.Fatalf("DeleteArticle() unexpected error: %v", err)
+		}
+	}
+	repo.deleted[2].DeletedAt.Time = time.Now().Add(-48 * time.Hour)
+
+	purged, err := svc.PurgeDeleted(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeleted() unexpected error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("Expected 1 purged article, got %d", purged)
+	}
+	if _, err := svc.RestoreArticle(context.Background(), 1, 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected old deletion to be gone, got %v", err)
+	}
+	if _, err := svc.RestoreArticle(context.Background(), 1, 3); err != nil {
+		t.Errorf("Expected recent deletion to be restorable, got %v", err)
+	}
+}
+
+func TestGetArticlesAfter(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo, WithCursorCodec(cursor.NewCodec("test-secret")))
+
+	batch := make([]Article, 5)
+	for i := range batch {
+		batch[i] = Article{UserID: 1, Title: fmt.Sprintf("Article %d", i+1), Content: "Valid content for test", Status: StatusPublished}
+	}
+	if err := repo.CreateBatch(context.Background(), batch); err != nil {
+		t.Fatalf("Failed to create test articles: %v", err)
+	}
+
+	var seen []uint
+	after := ""
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatalf("Cursor pagination did not terminate")
+		}
+		articles, next, err := svc.GetArticlesAfter(context.Background(), Viewer{}, ListFilter{}, after, 2)
+		if err != nil {
+			t.Fatalf("GetArticlesAfter() unexpected error: %v", err)
+		}
+		for _, article := range articles {
+			seen = append(seen, article.ID)
+		}
+		if next == "" {
+			break
+		}
+		after = next
+	}
+
+	if want := []uint{5, 4, 3, 2, 1}; !slices.Equal(seen, want) {
+		t.Errorf("Expected articles %v across pages, got %v", want, seen)
+	}
+
+	if _, _, err := svc.GetArticlesAfter(context.Background(), Viewer{}, ListFilter{}, "bogus.cursor", 2); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for tampered cursor, got %v", err)
+	}
+
+	foreign, err := cursor.NewCodec("other-secret").Encode(ListCursor{ID: 3})
+	if err != nil {
+		t.Fatalf("Failed to encode cursor: %v", err)
+	}
+	if _, _, err := svc.GetArticlesAfter(context.Background(), Viewer{}, ListFilter{}, foreign, 2); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for cursor signed with another secret, got %v", err)
+	}
+}
+
+func TestArticleLikes(t *testing.T) {
+	svc := NewService(newMockRepository())
+	published, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Published", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Valid content for test", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	steps := []struct {
+		name      string
+		like      bool
+		userID    uint
+		articleID uint
+		wantLikes int64
+		wantErr   error
+	}{
+		{name: "First like", like: true, userID: 2, articleID: published.ID, wantLikes: 1},
+		{name: "Repeated like is ignored", like: true, userID: 2, articleID: published.ID, wantLikes: 1},
+		{name: "Second user", like: true, userID: 3, articleID: published.ID, wantLikes: 2},
+		{name: "Unlike", like: false, userID: 2, articleID: published.ID, wantLikes: 1},
+		{name: "Repeated unlike is ignored", like: false, userID: 2, articleID: published.ID, wantLikes: 1},
+		{name: "Someone else's draft", like: true, userID: 2, articleID: draft.ID, wantErr: ErrNotFound},
+		{name: "Own draft", like: true, userID: 1, articleID: draft.ID, wantLikes: 1},
+		{name: "Missing article", like: true, userID: 2, articleID: 99, wantErr: ErrNotFound},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			apply := svc.UnlikeArticle
+			if step.like {
+				apply = svc.LikeArticle
+			}
+			likes, err := apply(context.Background(), Viewer{UserID: step.userID}, step.articleID)
+			if step.wantErr != nil {
+				if !errors.Is(err, step.wantErr) {
+					t.Errorf("Expected error %v, got %v", step.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if likes != step.wantLikes {
+				t.Errorf("Expected %d likes, got %d", step.wantLikes, likes)
+			}
+		})
+	}
+
+	article, err := svc.GetArticleByID(context.Background(), Viewer{}, published.ID)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	if article.LikesCount != 1 {
+		t.Errorf("Expected likes_count 1 on the article, got %d", article.LikesCount)
+	}
+}
+
+func TestListFilterSortAndDates(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	for _, title := range []string{"Banana", "Apple", "Cherry"} {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: title, Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for id, article := range repo.articles {
+		article.CreatedAt = base.AddDate(0, 0, int(id))
+		article.UpdatedAt = base.AddDate(0, 0, 10-int(id))
+	}
+
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name    string
+		filter  ListFilter
+		want    []string
+		wantErr bool
+	}{
+		{name: "Default newest first", filter: ListFilter{}, want: []string{"Cherry", "Apple", "Banana"}},
+		{name: "Oldest first", filter: ListFilter{Sort: SortCreatedAt, Order: OrderAsc}, want: []string{"Banana", "Apple", "Cherry"}},
+		{name: "Recently updated", filter: ListFilter{Sort: SortUpdatedAt}, want: []string{"Banana", "Apple", "Cherry"}},
+		{name: "Title ascending", filter: ListFilter{Sort: SortTitle, Order: OrderAsc}, want: []string{"Apple", "Banana", "Cherry"}},
+		{name: "Created after", filter: ListFilter{CreatedAfter: timePtr(base.AddDate(0, 0, 2))}, want: []string{"Cherry", "Apple"}},
+		{name: "Created before", filter: ListFilter{CreatedBefore: timePtr(base.AddDate(0, 0, 2))}, want: []string{"Banana"}},
+		{name: "Unknown sort", filter: ListFilter{Sort: "views"}, wantErr: true},
+		{name: "Unknown order", filter: ListFilter{Order: "up"}, wantErr: true},
+		{name: "Empty range", filter: ListFilter{CreatedAfter: timePtr(base.AddDate(0, 0, 2)), CreatedBefore: timePtr(base.AddDate(0, 0, 2))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, _, err := svc.GetAllArticles(context.Background(), Viewer{}, tt.filter, 1, 10)
+			if tt.wantErr {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("Expected ErrValidation, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAllArticles() unexpected error: %v", err)
+			}
+			got := make([]string, 0, len(articles))
+			for _, article := range articles {
+				got = append(got, article.Title)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, _, err := svc.GetArticlesAfter(context.Background(), Viewer{}, ListFilter{Sort: SortTitle}, "", 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for sorted cursor pagination, got %v", err)
+	}
+}
+
+func TestBulkCreateArticles(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+
+	results, err := svc.BulkCreateArticles(context.Background(), 1, []CreateInput{
+		{Title: "Same Title", Content: "Valid content for test"},
+		{Title: "", Content: "Valid content for test"},
+		{Title: "Same Title", Content: "Valid content for test", Status: StatusDraft},
+		{Title: "Bad status", Content: "Valid content for test", Status: "archived"},
+	})
+	if err != nil {
+		t.Fatalf("BulkCreateArticles() unexpected error: %v", err)
+	}
+
+	wantOK := []bool{true, false, true, false}
+	for i, result := range results {
+		if ok := result.Err == nil; ok != wantOK[i] {
+			t.Errorf("Item %d: expected success=%v, got error %v", i, wantOK[i], result.Err)
+		}
+		if result.Err != nil && !errors.Is(result.Err, ErrValidation) {
+			t.Errorf("Item %d: expected ErrValidation, got %v", i, result.Err)
+		}
+	}
+
+	first, err := repo.GetByID(context.Background(), results[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to load created article: %v", err)
+	}
+	second, err := repo.GetByID(context.Background(), results[2].ID)
+	if err != nil {
+		t.Fatalf("Failed to load created article: %v", err)
+	}
+	if first.Slug == second.Slug {
+		t.Errorf("Expected distinct slugs within a batch, both got %q", first.Slug)
+	}
+	if !first.CreatedAt.Equal(second.CreatedAt) {
+		t.Errorf("Expected batch items to share a timestamp")
+	}
+
+	if _, err := svc.BulkCreateArticles(context.Background(), 1, nil); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for empty batch, got %v", err)
+	}
+	if _, err := svc.BulkCreateArticles(context.Background(), 1, make([]CreateInput, MaxBulkItems+1)); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for oversized batch, got %v", err)
+	}
+}
+
+func TestBulkCreateArticlesWarnings(t *testing.T) {
+	svc := NewService(newMockRepository(), WithMinContentLength(20))
+
+	results, err := svc.BulkCreateArticles(context.Background(), 1, []CreateInput{
+		{Title: "Short draft", Content: "short", Status: StatusDraft, Lenient: true},
+		{Title: "Long draft", Content: "Valid content for test", Status: StatusDraft, Lenient: true},
+	})
+	if err != nil {
+		t.Fatalf("BulkCreateArticles() unexpected error: %v", err)
+	}
+	if results[0].Err != nil || results[0].Warning == "" {
+		t.Errorf("Expected the short draft to be created with a warning, got %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Warning != "" {
+		t.Errorf("Expected the long draft to be created without a warning, got %+v", results[1])
+	}
+}
+
+func TestBulkDeleteArticles(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	for _, userID := range []uint{1, 1, 2} {
+		if _, err := svc.CreateArticle(context.Background(), userID, CreateInput{Title: "Article", Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	results, err := svc.BulkDeleteArticles(context.Background(), 1, []uint{1, 3, 99, 1, 2})
+	if err != nil {
+		t.Fatalf("BulkDeleteArticles() unexpected error: %v", err)
+	}
+
+	wantErrs := []error{nil, ErrForbidden, ErrNotFound, ErrValidation, nil}
+	for i, result := range results {
+		if wantErrs[i] == nil {
+			if result.Err != nil {
+				t.Errorf("Item %d: unexpected error %v", i, result.Err)
+			}
+			continue
+		}
+		if !errors.Is(result.Err, wantErrs[i]) {
+			t.Errorf("Item %d: expected %v, got %v", i, wantErrs[i], result.Err)
+		}
+	}
+
+	if _, ok := repo.articles[1]; ok {
+		t.Errorf("Expected article 1 to be deleted")
+	}
+	if _, ok := repo.articles[3]; !ok {
+		t.Errorf("Expected another user's article to be kept")
+	}
+
+	repo.err = errors.New("database unavailable")
+	results, err = svc.BulkDeleteArticles(context.Background(), 2, []uint{3})
+	repo.err = nil
+	if err != nil {
+		t.Fatalf("BulkDeleteArticles() unexpected error: %v", err)
+	}
+	if results[0].Err == nil {
+		t.Errorf("Expected transaction failure to be reported per item")
+	}
+}
+
+type fakeMediaCollector struct {
+	collected [][]uint
+	err       error
+}
+
+func (f *fakeMediaCollector) CollectArticleMedia(ctx context.Context, articleIDs []uint) error {
+	f.collected = append(f.collected, articleIDs)
+	return f.err
+}
+
+func TestPurgeArticleCollectsMedia(t *testing.T) {
+	repo := newMockRepository()
+	collector := &fakeMediaCollector{}
+	svc := NewService(repo, WithMediaCollector(collector))
+	for range 4 {
+		if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Article", Content: "Valid content for test"}); err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+	}
+
+	if err := svc.DeleteArticle(context.Background(), 1, 1); err != nil {
+		t.Fatalf("DeleteArticle() unexpected error: %v", err)
+	}
+	if _, err := svc.BulkDeleteArticles(context.Background(), 1, []uint{2, 3}); err != nil {
+		t.Fatalf("BulkDeleteArticles() unexpected error: %v", err)
+	}
+	if len(collector.collected) != 0 {
+		t.Fatalf("Expected no collection for soft deletes, got %v", collector.collected)
+	}
+
+	collector.err = errors.New("media unavailable")
+	if err := svc.PurgeArticle(context.Background(), 1); err == nil {
+		t.Fatal("Expected the purge to fail when its media cannot be detached")
+	}
+	collector.err = nil
+	if err := svc.PurgeArticle(context.Background(), 1); err != nil {
+		t.Fatalf("PurgeArticle() unexpected error: %v", err)
+	}
+	repo.deleted[2].DeletedAt = gorm.DeletedAt{Time: time.Now().Add(-48 * time.Hour), Valid: true}
+	if purged, err := svc.PurgeDeleted(context.Background(), 24*time.Hour); err != nil || purged != 1 {
+		t.Fatalf("Expected 1 purged article, got %d (%v)", purged, err)
+	}
+
+	want := [][]uint{{1}, {1}, {2}}
+	if !slices.EqualFunc(collector.collected, want, slices.Equal[[]uint]) {
+		t.Errorf("Expected collections %v, got %v", want, collector.collected)
+	}
+	if _, err := repo.GetDeletedByID(context.Background(), 3); err != nil {
+		t.Errorf("Expected the recently deleted article to stay in the trash, got %v", err)
+	}
+}
+
+type fakeCovers map[uint]uint
+
+func (f fakeCovers) CoverImageURL(ctx context.Context, userID, mediaID uint) (string, bool, error) {
+	if owner, ok := f[mediaID]; !ok || owner != userID {
+		return "", false, nil
+	}
+	return fmt.Sprintf("/api/media/%d", mediaID), true, nil
+}
+
+func TestArticleCover(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo, WithCovers(fakeCovers{7: 1, 8: 2}))
+	mediaID := func(id uint) *uint { return &id }
+
+	tests := []struct {
+		name      string
+		coverURL  string
+		mediaID   *uint
+		wantErr   error
+		wantURL   string
+		wantMedia *uint
+	}{
+		{name: "No cover"},
+		{name: "External URL", coverURL: "https://cdn.example.com/cover.jpg", wantURL: "https://cdn.example.com/cover.jpg"},
+		{name: "Own media", mediaID: mediaID(7), wantURL: "/api/media/7", wantMedia: mediaID(7)},
+		{name: "Relative URL", coverURL: "/images/cover.jpg", wantErr: ErrValidation},
+		{name: "Non-HTTP URL", coverURL: "javascript:alert(1)", wantErr: ErrValidation},
+		{name: "URL too long", coverURL: "https://example.com/" + strings.Repeat("a", MaxCoverURLLength), wantErr: ErrValidation},
+		{name: "Missing media", mediaID: mediaID(9), wantErr: ErrValidation},
+		{name: "Someone else's media", mediaID: mediaID(8), wantErr: ErrValidation},
+		{name: "Both", coverURL: "https://example.com/a.jpg", mediaID: mediaID(7), wantErr: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Cover", Content: "Content", CoverImageURL: tt.coverURL, CoverMediaID: tt.mediaID})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if article.CoverImageURL != tt.wantURL {
+				t.Errorf("Expected cover %q, got %q", tt.wantURL, article.CoverImageURL)
+			}
+			if (article.CoverMediaID == nil) != (tt.wantMedia == nil) || (tt.wantMedia != nil && *article.CoverMediaID != *tt.wantMedia) {
+				t.Errorf("Expected cover media %v, got %v", tt.wantMedia, article.CoverMediaID)
+			}
+		})
+	}
+
+	withCover := true
+	articles, total, err := svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{HasCover: &withCover}, 1, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 2 || len(articles) != 2 {
+		t.Errorf("Expected 2 articles with a cover, got %d", total)
+	}
+
+	media, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Media cover", Content: "Content", CoverMediaID: mediaID(7)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	external := "https://cdn.example.com/new.jpg"
+	updated, err := svc.UpdateArticle(context.Background(), 1, media.ID, UpdateInput{CoverImageURL: &external})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.CoverImageURL != external || updated.CoverMediaID != nil || repo.articles[media.ID].CoverMediaID != nil {
+		t.Errorf("Expected the external URL to replace the media cover, got %q (%v)", updated.CoverImageURL, updated.CoverMediaID)
+	}
+
+	cleared, err := svc.UpdateArticle(context.Background(), 1, media.ID, UpdateInput{CoverMediaID: mediaID(0)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cleared.CoverImageURL != "" || repo.articles[media.ID].CoverImageURL != "" {
+		t.Errorf("Expected the cover to be cleared, got %q", cleared.CoverImageURL)
+	}
+
+	withoutCover := false
+	_, total, err = svc.GetAllArticles(context.Background(), Viewer{}, ListFilter{HasCover: &withoutCover}, 1, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 2 {
+		t.Errorf("Expected 2 articles without a cover, got %d", total)
+	}
+}
+
+type fakeEventPublisher struct {
+	events []string
+	actors []uint
+}
+
+func (f *fakeEventPublisher) Publish(event events.Event) {
+	f.events = append(f.events, fmt.Sprintf("%s:%d", event.Name, event.Payload.(Article).ID))
+	f.actors = append(f.actors, event.ActorID)
+}
+
+func TestArticleEvents(t *testing.T) {
+	events := &fakeEventPublisher{}
+	repo := newMockRepository()
+	svc := NewService(repo, WithEvents(events), WithModeration(true, []string{"trusted"}))
+
+	expect := func(t *testing.T, want ...string) {
+		t.Helper()
+		if !slices.Equal(events.events, want) {
+			t.Errorf("Expected events %v, got %v", want, events.events)
+		}
+		if !slices.Equal(repo.recorded, want) {
+			t.Errorf("Expected outbox events %v, got %v", want, repo.recorded)
+		}
+		events.events, events.actors = nil, nil
+		repo.recorded = nil
+	}
+
+	draft, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Draft", Content: "Content", Status: StatusDraft})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.created:1")
+
+	published := StatusPublished
+	if _, err := svc.UpdateArticle(context.Background(), 1, draft.ID, UpdateInput{Status: &published, Role: "trusted"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1", "article.published:1")
+
+	title := "Renamed"
+	if _, err := svc.UpdateArticle(context.Background(), 1, draft.ID, UpdateInput{Title: &title}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1")
+
+	if _, err := svc.RegenerateSlug(context.Background(), 1, draft.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1")
+	if _, err := svc.RegenerateSlug(context.Background(), 1, draft.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t)
+
+	pending, err := svc.CreateArticle(context.Background(), 2, CreateInput{Title: "Untrusted", Content: "Content"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.created:2")
+	if _, err := svc.ApproveArticle(context.Background(), 9, pending.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(events.actors, []uint{9}) {
+		t.Errorf("Expected the approval published by moderator 9, got actors %v", events.actors)
+	}
+	expect(t, "article.published:2")
+
+	if _, err := svc.BulkCreateArticles(context.Background(), 1, []CreateInput{{Title: "Bulk", Content: "Content", Role: "trusted"}, {Title: ""}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.created:3", "article.published:3")
+
+	if _, err := svc.SaveTranslation(context.Background(), 1, draft.ID, "de", TranslationInput{Title: "Titel", Content: "Inhalt", Role: "trusted"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1")
+	if err := svc.DeleteTranslation(context.Background(), 1, draft.ID, "de"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1")
+	if err := svc.DeleteTranslation(context.Background(), 1, draft.ID, "de"); !errors.Is(err, ErrNoTranslation) {
+		t.Errorf("Expected ErrNoTranslation, got %v", err)
+	}
+	expect(t)
+
+	if err := svc.DeleteArticle(context.Background(), 1, draft.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.deleted:1")
+	if _, err := svc.RestoreArticle(context.Background(), 1, draft.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.updated:1")
+	if err := svc.DeleteArticle(context.Background(), 1, draft.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.deleted:1")
+
+	if _, err := svc.BulkDeleteArticles(context.Background(), 1, []uint{3, 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expect(t, "article.deleted:3")
+
+	if _, err := svc.ReassignArticle(context.Background(), 9, pending.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(events.actors, []uint{9}) {
+		t.Errorf("Expected the reassignment published by admin 9, got actors %v", events.actors)
+	}
+	expect(t, "article.updated:2")
+	if err := svc.DeleteAnyArticle(context.Background(), 9, pending.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(events.actors, []uint{9}) {
+		t.Errorf("Expected the deletion published by admin 9, got actors %v", events.actors)
+	}
+	expect(t, "article.deleted:2")
+}
+
+type retryingRepository struct {
+	Repository
+	inserted []Article
+}
+
+func (r *retryingRepository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+func (r *retryingRepository) Create(ctx context.Context, article *Article) error {
+	r.inserted = append(r.inserted, article.detached())
+	return r.Repository.Create(ctx, article)
+}
+
+func (r *retryingRepository) CreateBatch(ctx context.Context, articles []Article) error {
+	r.inserted = append(r.inserted, detachedBatch(articles)...)
+	return r.Repository.CreateBatch(ctx, articles)
+}
+
+func TestCreateRetriesStartFresh(t *testing.T) {
+	repo := &retryingRepository{Repository: newMockRepository()}
+	svc := NewService(repo)
+
+	if _, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Retried", Content: "Valid content for test", Tags: []string{"go"}}); err != nil {
+		t.Fatalf("CreateArticle() unexpected error: %v", err)
+	}
+	if _, err := svc.BulkCreateArticles(context.Background(), 1, []CreateInput{{Title: "Bulk", Content: "Valid content for test"}}); err != nil {
+		t.Fatalf("BulkCreateArticles() unexpected error: %v", err)
+	}
+
+	if len(repo.inserted) != 4 {
+		t.Fatalf("Expected 4 insert attempts, got %d", len(repo.inserted))
+	}
+	for i, article := range repo.inserted {
+		if article.ID != 0 || !article.CreatedAt.IsZero() {
+			t.Errorf("Attempt %d: expected a fresh article, got ID %d created at %v", i+1, article.ID, article.CreatedAt)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+)
+
+func Slugify(title string) string {
+	var b strings.Builder
+	runes := 0
+	pendingDash := false
+
+	for _, r := range strings.ToLower(title) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingDash = true
+			continue
+		}
+		if runes >= MaxSlugLength {
+			break
+		}
+		if pendingDash && runes > 0 {
+			if runes+1 >= MaxSlugLength {
+				break
+			}
+			b.WriteByte('-')
+			runes++
+		}
+		pendingDash = false
+		b.WriteRune(r)
+		runes++
+	}
+
+	if b.Len() == 0 {
+		return DefaultSlug
+	}
+	return b.String()
+}
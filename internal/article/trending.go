@@ -0,0 +1,103 @@
+package article
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+type TrendingCandidate struct {
+	ID         uint
+	Views      int64
+	LikesCount int64
+	CreatedAt  time.Time
+}
+
+type TrendingScore struct {
+	ArticleID uint
+	Score     float64
+}
+
+type TrendingArticle struct {
+	Article
+	Score float64 `json:"trending_score"`
+}
+
+type Trending struct {
+	repo       Repository
+	cfg        config.TrendingConfig
+	mu         sync.RWMutex
+	scores     []TrendingScore
+	computedAt time.Time
+}
+
+func NewTrending(repo Repository, cfg config.TrendingConfig) *Trending {
+	return &Trending{repo: repo, cfg: cfg}
+}
+
+func (trending *Trending) score(candidate TrendingCandidate, now time.Time) float64 {
+	ageHours := max(now.Sub(candidate.CreatedAt).Hours(), 0)
+	points := float64(candidate.Views) + trending.cfg.LikeWeight*float64(candidate.LikesCount)
+	return points / math.Pow(ageHours+TrendingAgeOffsetHours, trending.cfg.Gravity)
+}
+
+func (trending *Trending) Refresh(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := trending.repo.TrendingCandidates(ctx, now.Add(-trending.cfg.Window))
+	if err != nil {
+		return 0, err
+	}
+
+	scores := make([]TrendingScore, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score := trending.score(candidate, now); score > 0 {
+			scores = append(scores, TrendingScore{ArticleID: candidate.ID, Score: score})
+		}
+	}
+	slices.SortFunc(scores, func(a, b TrendingScore) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.ArticleID, a.ArticleID))
+	})
+	scores = scores[:min(len(scores), trending.cfg.Size)]
+
+	trending.mu.Lock()
+	trending.scores = scores
+	trending.computedAt = now
+	trending.mu.Unlock()
+	return len(scores), nil
+}
+
+func (trending *Trending) Page(offset, limit int) ([]TrendingScore, int64, time.Time) {
+	trending.mu.RLock()
+	defer trending.mu.RUnlock()
+
+	total := int64(len(trending.scores))
+	if offset >= len(trending.scores) {
+		return []TrendingScore{}, total, trending.computedAt
+	}
+	return slices.Clone(trending.scores[offset:min(offset+limit, len(trending.scores))]), total, trending.computedAt
+}
+
+func (trending *Trending) Run(ctx context.Context) {
+	ticker := time.NewTicker(trending.cfg.Refresh)
+	defer ticker.Stop()
+
+	for {
+		if n, err := trending.Refresh(ctx, time.Now()); err != nil {
+			log.Error().Err(err).Msg("Failed to refresh trending articles")
+		} else {
+			log.Debug().Int("articles", n).Msg("Refreshed trending articles")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package article
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+func TestTrendingArticles(t *testing.T) {
+	repo := newMockRepository()
+	trending := NewTrending(repo, config.TrendingConfig{Window: 72 * time.Hour, Gravity: 1.5, LikeWeight: 5, Size: 3})
+	svc := NewService(repo, WithTrending(trending))
+	now := time.Now()
+
+	articles := []struct {
+		title  string
+		status string
+		age    time.Duration
+		views  int64
+		likes  int64
+	}{
+		{title: "Old and popular", status: StatusPublished, age: 100 * time.Hour, views: 10000},
+		{title: "Fresh", status: StatusPublished, age: time.Hour, views: 40},
+		{title: "Liked", status: StatusPublished, age: 10 * time.Hour, views: 100, likes: 50},
+		{title: "Viewed", status: StatusPublished, age: 10 * time.Hour, views: 150},
+		{title: "Draft", status: StatusDraft, age: time.Hour, views: 1000},
+		{title: "Unseen", status: StatusPublished, age: time.Hour},
+		{title: "Day old", status: StatusPublished, age: 24 * time.Hour, views: 60},
+	}
+	ids := make(map[string]uint)
+	for _, a := range articles {
+		created, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: a.title, Content: "Valid content for test", Status: a.status})
+		if err != nil {
+			t.Fatalf("Failed to create test article: %v", err)
+		}
+		stored := repo.articles[created.ID]
+		stored.CreatedAt = now.Add(-a.age)
+		stored.Views = a.views
+		stored.LikesCount = a.likes
+		ids[a.title] = created.ID
+	}
+
+	if got, _, _, err := svc.GetTrendingArticles(context.Background(), Viewer{}, 1, 10); err != nil || len(got) != 0 {
+		t.Fatalf("Expected no trending articles before the first refresh, got %d (%v)", len(got), err)
+	}
+
+	n, err := trending.Refresh(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected the ranking to be capped at 3 articles, got %d", n)
+	}
+
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		wantOrder []string
+	}{
+		{name: "First page", page: 1, limit: 2, wantOrder: []string{"Liked", "Fresh"}},
+		{name: "Second page", page: 2, limit: 2, wantOrder: []string{"Viewed"}},
+		{name: "Past the end", page: 3, limit: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, computedAt, err := svc.GetTrendingArticles(context.Background(), Viewer{}, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("GetTrendingArticles() unexpected error: %v", err)
+			}
+			if total != 3 || !computedAt.Equal(now) {
+				t.Errorf("Expected total 3 computed at %v, got %d at %v", now, total, computedAt)
+			}
+			if len(got) != len(tt.wantOrder) {
+				t.Fatalf("Expected %d articles, got %d", len(tt.wantOrder), len(got))
+			}
+			for i, title := range tt.wantOrder {
+				if got[i].ID != ids[title] {
+					t.Errorf("Expected %q at position %d, got %q", title, i, got[i].Title)
+				}
+				if i > 0 && got[i].Score > got[i-1].Score {
+					t.Errorf("Expected descending scores, got %v after %v", got[i].Score, got[i-1].Score)
+				}
+			}
+		})
+	}
+
+	if err := svc.DeleteArticle(context.Background(), 1, ids["Liked"]); err != nil {
+		t.Fatalf("DeleteArticle() unexpected error: %v", err)
+	}
+	got, _, _, err := svc.GetTrendingArticles(context.Background(), Viewer{}, 1, 2)
+	if err != nil {
+		t.Fatalf("GetTrendingArticles() unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != ids["Fresh"] {
+		t.Errorf("Expected articles deleted since the refresh to be skipped, got %v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package article
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ViewCounter struct {
+	repo    Repository
+	mu      sync.Mutex
+	pending map[uint]int64
+}
+
+func NewViewCounter(repo Repository) *ViewCounter {
+	return &ViewCounter{
+		repo:    repo,
+		pending: make(map[uint]int64),
+	}
+}
+
+func (counter *ViewCounter) Record(id uint) int64 {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	counter.pending[id]++
+	return counter.pending[id]
+}
+
+func (counter *ViewCounter) Pending(id uint) int64 {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	return counter.pending[id]
+}
+
+func (counter *ViewCounter) Flush(ctx context.Context) error {
+	counter.mu.Lock()
+	batch := counter.pending
+	counter.pending = make(map[uint]int64)
+	counter.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := counter.repo.IncrementViews(ctx, batch); err != nil {
+		counter.mu.Lock()
+		for id, views := range batch {
+			counter.pending[id] += views
+		}
+		counter.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (counter *ViewCounter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := counter.Flush(ctx); err != nil {
+				log.Error().Err(err).Msg("Failed to flush article views")
+			}
+		}
+	}
+}
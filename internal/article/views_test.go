@@ -0,0 +1,113 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestViewCounterBatchesWrites(t *testing.T) {
+	repo := newMockRepository()
+	counter := NewViewCounter(repo)
+	svc := NewService(repo, WithViewCounter(counter))
+
+	first, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "First", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+	second, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Second", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		article, err := svc.GetArticleByID(context.Background(), Viewer{}, first.ID)
+		if err != nil {
+			t.Fatalf("GetArticleByID() unexpected error: %v", err)
+		}
+		if article.Views != int64(i) {
+			t.Errorf("Expected %d views including pending ones, got %d", i, article.Views)
+		}
+		svc.RecordView(article)
+		if article.Views != int64(i+1) {
+			t.Errorf("Expected %d views after recording, got %d", i+1, article.Views)
+		}
+	}
+	article, err := svc.GetArticleByID(context.Background(), Viewer{}, second.ID)
+	if err != nil {
+		t.Fatalf("GetArticleByID() unexpected error: %v", err)
+	}
+	svc.RecordView(article)
+	if _, err := svc.ListRevisions(context.Background(), Viewer{}, second.ID); err != nil {
+		t.Fatalf("ListRevisions() unexpected error: %v", err)
+	}
+
+	if repo.articles[first.ID].Views != 0 {
+		t.Errorf("Expected no writes before flush, got %d stored views", repo.articles[first.ID].Views)
+	}
+
+	if err := counter.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+	if repo.articles[first.ID].Views != 3 || repo.articles[second.ID].Views != 1 {
+		t.Errorf("Expected stored views 3 and 1, got %d and %d", repo.articles[first.ID].Views, repo.articles[second.ID].Views)
+	}
+	if counter.Pending(first.ID) != 0 {
+		t.Errorf("Expected pending views to be cleared, got %d", counter.Pending(first.ID))
+	}
+
+	popular, err := svc.GetPopularArticles(context.Background(), Viewer{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetPopularArticles() unexpected error: %v", err)
+	}
+	if len(popular) != 2 || popular[0].ID != first.ID {
+		t.Errorf("Expected most viewed article first, got %v", popular)
+	}
+}
+
+func TestGetArticleRecordsViews(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := newMockRepository()
+	counter := NewViewCounter(repo)
+	svc := NewService(repo, WithViewCounter(counter))
+	article, err := svc.CreateArticle(context.Background(), 1, CreateInput{Title: "Viewed", Content: "Valid content for test"})
+	if err != nil {
+		t.Fatalf("Failed to create test article: %v", err)
+	}
+
+	handler := NewHandler(svc)
+	router := gin.New()
+	router.GET("/api/articles/:id", handler.GetArticleByID)
+	router.HEAD("/api/articles/:id", handler.GetArticleByID)
+	router.GET("/api/articles/:id/revisions", handler.ListRevisions)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodGet} {
+		if w := performRequest(router, method, "/api/articles/1"); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d for %s, got %d", http.StatusOK, method, w.Code)
+		}
+	}
+	if w := performRequest(router, http.MethodGet, "/api/articles/1/revisions"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d for revisions, got %d", http.StatusOK, w.Code)
+	}
+	if counter.Pending(article.ID) != 2 {
+		t.Errorf("Expected only the 2 GET requests to count as views, got %d", counter.Pending(article.ID))
+	}
+}
+
+func TestViewCounterKeepsViewsOnFailedFlush(t *testing.T) {
+	repo := newMockRepository()
+	counter := NewViewCounter(repo)
+	counter.Record(1)
+	counter.Record(1)
+
+	repo.err = errors.New("database unavailable")
+	if err := counter.Flush(context.Background()); err == nil {
+		t.Fatalf("Expected flush error")
+	}
+	if counter.Pending(1) != 2 {
+		t.Errorf("Expected 2 pending views after failed flush, got %d", counter.Pending(1))
+	}
+}
@@ -0,0 +1,5 @@
+package category
+
+const (
+	MaxNameLength = 100
+)
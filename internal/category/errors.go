@@ -0,0 +1,9 @@
+package category
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("category not found")
+	ErrHasChildren = errors.New("category has child categories")
+	ErrValidation  = errors.New("validation error")
+)
@@ -0,0 +1,150 @@
+package category
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type CreateCategoryRequest struct {
+	Name     string `json:"name" validate:"required,min=1,max=100"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type UpdateCategoryRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100"`
+	ParentID *uint   `json:"parent_id"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:    http.StatusNotFound,
+	ErrHasChildren: http.StatusConflict,
+	ErrValidation:  http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) CreateCategory(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	category, err := handler.service.CreateCategory(c.Request.Context(), CreateInput{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+func (handler *Handler) GetCategoryTree(c *gin.Context) {
+	tree, err := handler.service.GetTree(c.Request.Context())
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tree})
+}
+
+func (handler *Handler) GetCategory(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		return
+	}
+
+	category, err := handler.service.GetCategory(c.Request.Context(), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (handler *Handler) UpdateCategory(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if req.Name == nil && req.ParentID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one field (name or parent_id) must be provided"})
+		return
+	}
+
+	category, err := handler.service.UpdateCategory(c.Request.Context(), id, UpdateInput{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+func (handler *Handler) DeleteCategory(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		return
+	}
+
+	if err := handler.service.DeleteCategory(c.Request.Context(), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
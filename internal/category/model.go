@@ -0,0 +1,16 @@
+package category
+
+import "time"
+
+type Category struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	Name      string      `gorm:"type:varchar(100);not null" json:"name"`
+	ParentID  *uint       `gorm:"index" json:"parent_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Children  []*Category `gorm:"-" json:"children,omitempty"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
@@ -0,0 +1,116 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, category *Category) error
+	GetByID(ctx context.Context, id uint) (*Category, error)
+	GetAll(ctx context.Context) ([]Category, error)
+	SubtreeIDs(ctx context.Context, id uint) ([]uint, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) ([]uint, error)
+	HasChildren(ctx context.Context, id uint) (bool, error)
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &categoryRepository{db: db}
+}
+
+func (repo *categoryRepository) Create(ctx context.Context, category *Category) error {
+	if err := database.Conn(ctx, repo.db).Create(category).Error; err != nil {
+		return fmt.Errorf("repo: failed to create category: %w", err)
+	}
+	return nil
+}
+
+func (repo *categoryRepository) GetByID(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+	if err := database.Conn(ctx, repo.db).First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get category by id %d: %w", id, err)
+	}
+	return &category, nil
+}
+
+func (repo *categoryRepository) GetAll(ctx context.Context) ([]Category, error) {
+	var categories []Category
+	if err := database.Conn(ctx, repo.db).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to get categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (repo *categoryRepository) SubtreeIDs(ctx context.Context, id uint) ([]uint, error) {
+	var ids []uint
+	err := database.Conn(ctx, repo.db).Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree`, id).Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to get subtree of category %d: %w", id, err)
+	}
+	return ids, nil
+}
+
+func (repo *categoryRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	updateResult := database.Conn(ctx, repo.db).Model(&Category{}).Where("id = ?", id).Updates(updates)
+	if updateResult.Error != nil {
+		return fmt.Errorf("repo: failed to update category %d: %w", id, updateResult.Error)
+	}
+	if updateResult.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (repo *categoryRepository) Delete(ctx context.Context, id uint) ([]uint, error) {
+	var detached []uint
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("articles").Where("category_id = ?", id).Pluck("id", &detached).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("articles").Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		deleteResult := tx.Delete(&Category{}, id)
+		if deleteResult.Error != nil {
+			return deleteResult.Error
+		}
+		if deleteResult.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to delete category %d: %w", id, err)
+	}
+	return detached, nil
+}
+
+func (repo *categoryRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	if err := database.Conn(ctx, repo.db).Model(&Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("repo: failed to count children of category %d: %w", id, err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,219 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type CreateInput struct {
+	Name     string
+	ParentID *uint
+}
+
+type UpdateInput struct {
+	Name     *string
+	ParentID *uint
+}
+
+type Service interface {
+	CreateCategory(ctx context.Context, input CreateInput) (*Category, error)
+	GetCategory(ctx context.Context, id uint) (*Category, error)
+	GetTree(ctx context.Context) ([]*Category, error)
+	UpdateCategory(ctx context.Context, id uint, input UpdateInput) (*Category, error)
+	DeleteCategory(ctx context.Context, id uint) error
+	CategoryExists(ctx context.Context, id uint) (bool, error)
+	SubtreeIDs(ctx context.Context, id uint) ([]uint, error)
+}
+
+type ArticleCache interface {
+	Invalidate(ctx context.Context, ids ...uint)
+}
+
+type categoryService struct {
+	repo     Repository
+	articles ArticleCache
+}
+
+type Option func(*categoryService)
+
+func WithArticleCache(articles ArticleCache) Option {
+	return func(svc *categoryService) {
+		svc.articles = articles
+	}
+}
+
+func NewService(repo Repository, opts ...Option) Service {
+	svc := &categoryService{repo: repo}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: name cannot exceed %d characters", ErrValidation, MaxNameLength)
+	}
+	return name, nil
+}
+
+func (svc *categoryService) checkParent(ctx context.Context, parentID uint) error {
+	if _, err := svc.repo.GetByID(ctx, parentID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("%w: parent category %d does not exist", ErrValidation, parentID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (svc *categoryService) CreateCategory(ctx context.Context, input CreateInput) (*Category, error) {
+	name, err := validateName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		if err := svc.checkParent(ctx, *input.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	category := &Category{Name: name, ParentID: input.ParentID}
+	if err := svc.repo.Create(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+	return category, nil
+}
+
+func (svc *categoryService) GetCategory(ctx context.Context, id uint) (*Category, error) {
+	return svc.repo.GetByID(ctx, id)
+}
+
+func (svc *categoryService) GetTree(ctx context.Context) ([]*Category, error) {
+	categories, err := svc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return buildTree(categories), nil
+}
+
+func buildTree(categories []Category) []*Category {
+	nodes := make(map[uint]*Category, len(categories))
+	for i := range categories {
+		nodes[categories[i].ID] = &categories[i]
+	}
+
+	roots := make([]*Category, 0)
+	for i := range categories {
+		node := &categories[i]
+		if node.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[*node.ParentID]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
+func (svc *categoryService) UpdateCategory(ctx context.Context, id uint, input UpdateInput) (*Category, error) {
+	category, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if input.Name != nil {
+		name, err := validateName(*input.Name)
+		if err != nil {
+			return nil, err
+		}
+		updates["name"] = name
+		category.Name = name
+	}
+
+	if input.ParentID != nil {
+		if *input.ParentID == 0 {
+			updates["parent_id"] = nil
+			category.ParentID = nil
+		} else {
+			if err := svc.checkParent(ctx, *input.ParentID); err != nil {
+				return nil, err
+			}
+			subtree, err := svc.repo.SubtreeIDs(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check category tree: %w", err)
+			}
+			if slices.Contains(subtree, *input.ParentID) {
+				return nil, fmt.Errorf("%w: a category cannot be moved under itself or its descendants", ErrValidation)
+			}
+			parentID := *input.ParentID
+			updates["parent_id"] = parentID
+			category.ParentID = &parentID
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+	}
+
+	if err := svc.repo.Update(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+	return category, nil
+}
+
+func (svc *categoryService) DeleteCategory(ctx context.Context, id uint) error {
+	if _, err := svc.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	hasChildren, err := svc.repo.HasChildren(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check child categories: %w", err)
+	}
+	if hasChildren {
+		return ErrHasChildren
+	}
+
+	detached, err := svc.repo.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	if svc.articles != nil && len(detached) > 0 {
+		svc.articles.Invalidate(ctx, detached...)
+	}
+	return nil
+}
+
+func (svc *categoryService) CategoryExists(ctx context.Context, id uint) (bool, error) {
+	if _, err := svc.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (svc *categoryService) SubtreeIDs(ctx context.Context, id uint) ([]uint, error) {
+	ids, err := svc.repo.SubtreeIDs(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category subtree: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,267 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+)
+
+type mockRepository struct {
+	categories map[uint]*Category
+	articles   map[uint][]uint
+	nextID     uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		categories: make(map[uint]*Category),
+		nextID:     1,
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, category *Category) error {
+	category.ID = m.nextID
+	m.nextID++
+	stored := *category
+	m.categories[category.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Category, error) {
+	category, ok := m.categories[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *category
+	return &copied, nil
+}
+
+func (m *mockRepository) GetAll(ctx context.Context) ([]Category, error) {
+	categories := make([]Category, 0, len(m.categories))
+	for _, category := range m.categories {
+		categories = append(categories, *category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+func (m *mockRepository) SubtreeIDs(ctx context.Context, id uint) ([]uint, error) {
+	if _, ok := m.categories[id]; !ok {
+		return nil, nil
+	}
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		for _, category := range m.categories {
+			if category.ParentID != nil && *category.ParentID == ids[i] {
+				ids = append(ids, category.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	category, ok := m.categories[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if name, ok := updates["name"].(string); ok {
+		category.Name = name
+	}
+	if value, ok := updates["parent_id"]; ok {
+		if parentID, ok := value.(uint); ok {
+			category.ParentID = &parentID
+		} else {
+			category.ParentID = nil
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uint) ([]uint, error) {
+	if _, ok := m.categories[id]; !ok {
+		return nil, ErrNotFound
+	}
+	delete(m.categories, id)
+	return m.articles[id], nil
+}
+
+func (m *mockRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	for _, category := range m.categories {
+		if category.ParentID != nil && *category.ParentID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func uintPtr(n uint) *uint {
+	return &n
+}
+
+func seedTree(t *testing.T, svc Service) {
+	t.Helper()
+	inputs := []CreateInput{
+		{Name: "Programming"},
+		{Name: "Go", ParentID: uintPtr(1)},
+		{Name: "Generics", ParentID: uintPtr(2)},
+		{Name: "Cooking"},
+	}
+	for _, input := range inputs {
+		if _, err := svc.CreateCategory(context.Background(), input); err != nil {
+			t.Fatalf("Failed to create test category: %v", err)
+		}
+	}
+}
+
+func TestCreateCategory(t *testing.T) {
+	svc := NewService(newMockRepository())
+	seedTree(t, svc)
+
+	tests := []struct {
+		name    string
+		input   CreateInput
+		wantErr error
+	}{
+		{name: "Root category", input: CreateInput{Name: "Travel"}},
+		{name: "Child category", input: CreateInput{Name: "Rust", ParentID: uintPtr(1)}},
+		{name: "Name is trimmed", input: CreateInput{Name: "  Baking  ", ParentID: uintPtr(4)}},
+		{name: "Empty name", input: CreateInput{Name: "   "}, wantErr: ErrValidation},
+		{name: "Unknown parent", input: CreateInput{Name: "Orphan", ParentID: uintPtr(99)}, wantErr: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := svc.CreateCategory(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateCategory() unexpected error: %v", err)
+			}
+			if category.ID == 0 {
+				t.Errorf("Expected category ID to be set")
+			}
+			if category.Name == "" || category.Name[0] == ' ' {
+				t.Errorf("Expected trimmed name, got %q", category.Name)
+			}
+		})
+	}
+}
+
+func TestGetTree(t *testing.T) {
+	svc := NewService(newMockRepository())
+	seedTree(t, svc)
+
+	tree, err := svc.GetTree(context.Background())
+	if err != nil {
+		t.Fatalf("GetTree() unexpected error: %v", err)
+	}
+
+	if len(tree) != 2 || tree[0].Name != "Cooking" || tree[1].Name != "Programming" {
+		t.Fatalf("Expected roots [Cooking Programming], got %v", tree)
+	}
+	programming := tree[1]
+	if len(programming.Children) != 1 || programming.Children[0].Name != "Go" {
+		t.Fatalf("Expected Programming to contain Go, got %v", programming.Children)
+	}
+	if len(programming.Children[0].Children) != 1 || programming.Children[0].Children[0].Name != "Generics" {
+		t.Errorf("Expected Go to contain Generics, got %v", programming.Children[0].Children)
+	}
+}
+
+func TestUpdateCategoryParent(t *testing.T) {
+	svc := NewService(newMockRepository())
+	seedTree(t, svc)
+
+	tests := []struct {
+		name       string
+		id         uint
+		parentID   uint
+		wantErr    error
+		wantParent *uint
+	}{
+		{name: "Move under sibling tree", id: 2, parentID: 4, wantParent: uintPtr(4)},
+		{name: "Move to root", id: 2, parentID: 0, wantParent: nil},
+		{name: "Under itself", id: 1, parentID: 1, wantErr: ErrValidation},
+		{name: "Under own descendant", id: 2, parentID: 3, wantErr: ErrValidation},
+		{name: "Unknown parent", id: 2, parentID: 99, wantErr: ErrValidation},
+		{name: "Unknown category", id: 99, parentID: 1, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := svc.UpdateCategory(context.Background(), tt.id, UpdateInput{ParentID: uintPtr(tt.parentID)})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateCategory() unexpected error: %v", err)
+			}
+			if (category.ParentID == nil) != (tt.wantParent == nil) || (tt.wantParent != nil && *category.ParentID != *tt.wantParent) {
+				t.Errorf("Expected parent %v, got %v", tt.wantParent, category.ParentID)
+			}
+		})
+	}
+}
+
+type fakeArticleCache struct {
+	invalidated []uint
+}
+
+func (f *fakeArticleCache) Invalidate(ctx context.Context, ids ...uint) {
+	f.invalidated = append(f.invalidated, ids...)
+}
+
+func TestDeleteCategory(t *testing.T) {
+	repo := newMockRepository()
+	repo.articles = map[uint][]uint{3: {7, 8}}
+	articles := &fakeArticleCache{}
+	svc := NewService(repo, WithArticleCache(articles))
+	seedTree(t, svc)
+
+	if err := svc.DeleteCategory(context.Background(), 2); !errors.Is(err, ErrHasChildren) {
+		t.Errorf("Expected ErrHasChildren, got %v", err)
+	}
+	if err := svc.DeleteCategory(context.Background(), 3); err != nil {
+		t.Fatalf("DeleteCategory() unexpected error: %v", err)
+	}
+	if !slices.Equal(articles.invalidated, []uint{7, 8}) {
+		t.Errorf("Expected the detached articles 7 and 8 to be invalidated, got %v", articles.invalidated)
+	}
+	if err := svc.DeleteCategory(context.Background(), 2); err != nil {
+		t.Errorf("Expected leaf category to be deletable, got %v", err)
+	}
+	if err := svc.DeleteCategory(context.Background(), 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSubtreeIDs(t *testing.T) {
+	svc := NewService(newMockRepository())
+	seedTree(t, svc)
+
+	ids, err := svc.SubtreeIDs(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("SubtreeIDs() unexpected error: %v", err)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", ids)
+	}
+
+	exists, err := svc.CategoryExists(context.Background(), 99)
+	if err != nil || exists {
+		t.Errorf("Expected unknown category to not exist, got %v (%v)", exists, err)
+	}
+}
@@ -0,0 +1,7 @@
+package feed
+
+import "errors"
+
+var (
+	ErrSitemapNotFound = errors.New("sitemap not found")
+)
@@ -0,0 +1,202 @@
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+)
+
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+
+	ContentTypeRSS  = "application/rss+xml; charset=utf-8"
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+)
+
+type feedMeta struct {
+	Title       string
+	Description string
+	SiteURL     string
+	SelfURL     string
+	Updated     time.Time
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func articleURL(cfg config.FeedConfig, slug string) string {
+	return strings.ReplaceAll(cfg.ArticleURL, "{slug}", slug)
+}
+
+func tagNames(a *article.Article) []string {
+	names := make([]string, 0, len(a.Tags))
+	for _, tag := range a.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func renderRSS(cfg config.FeedConfig, meta feedMeta, articles []article.Article) ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       meta.Title,
+			Link:        meta.SiteURL,
+			Description: meta.Description,
+			Self:        atomLink{Href: meta.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(articles)),
+		},
+	}
+	if !meta.Updated.IsZero() {
+		feed.Channel.LastBuildDate = meta.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for i := range articles {
+		a := &articles[i]
+		link := articleURL(cfg, a.Slug)
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       a.Title,
+			Link:        link,
+			GUID:        rssGUID{Value: link, IsPermaLink: true},
+			Description: a.Excerpt,
+			PubDate:     a.CreatedAt.UTC().Format(time.RFC1123Z),
+			Categories:  tagNames(a),
+		})
+	}
+	return marshal(feed)
+}
+
+func renderAtom(cfg config.FeedConfig, meta feedMeta, articles []article.Article) ([]byte, error) {
+	updated := meta.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	feed := atomFeed{
+		Title:    meta.Title,
+		Subtitle: meta.Description,
+		ID:       meta.SelfURL,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Author:   atomAuthor{Name: cfg.Title},
+		Links: []atomLink{
+			{Href: meta.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: meta.SiteURL, Rel: "alternate"},
+		},
+		Entries: make([]atomEntry, 0, len(articles)),
+	}
+
+	for i := range articles {
+		a := &articles[i]
+		link := articleURL(cfg, a.Slug)
+		categories := make([]atomCategory, 0, len(a.Tags))
+		for _, name := range tagNames(a) {
+			categories = append(categories, atomCategory{Term: name})
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:      a.Title,
+			ID:         link,
+			Link:       atomLink{Href: link, Rel: "alternate"},
+			Published:  a.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:    a.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary:    a.Excerpt,
+			Categories: categories,
+		})
+	}
+	return marshal(feed)
+}
+
+func marshal(feed any) ([]byte, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+func lastModified(articles []article.Article) time.Time {
+	var latest time.Time
+	for i := range articles {
+		if articles[i].UpdatedAt.After(latest) {
+			latest = articles[i].UpdatedAt
+		}
+	}
+	return latest.UTC().Truncate(time.Second)
+}
+
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		return article.MatchETag(match, etag)
+	}
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.IsZero() {
+		return !modified.After(since)
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/textutil"
+	"content-service/internal/shared/tracking"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Service interface {
+	GetArticlesPage(ctx context.Context, viewer article.Viewer, filter article.ListFilter, page, limit int) ([]article.Article, bool, error)
+	GetSitemapEntries(ctx context.Context, page, limit int) ([]article.SitemapEntry, int64, error)
+}
+
+type Handler struct {
+	service  Service
+	cfg      config.FeedConfig
+	sitemaps sitemapCache
+}
+
+func NewHandler(service Service, cfg config.FeedConfig) *Handler {
+	return &Handler{
+		service:  service,
+		cfg:      cfg,
+		sitemaps: sitemapCache{pages: make(map[int]cachedSitemap)},
+	}
+}
+
+func (handler *Handler) ArticlesRSS(c *gin.Context) {
+	handler.serve(c, FormatRSS, "")
+}
+
+func (handler *Handler) ArticlesAtom(c *gin.Context) {
+	handler.serve(c, FormatAtom, "")
+}
+
+func (handler *Handler) TagFeed(c *gin.Context) {
+	file := c.Param("file")
+	format := strings.TrimPrefix(path.Ext(file), ".")
+	tag := strings.TrimSuffix(file, path.Ext(file))
+	if tag == "" || (format != FormatRSS && format != FormatAtom) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "feed not found"})
+		return
+	}
+
+	handler.serve(c, format, tag)
+}
+
+func (handler *Handler) serve(c *gin.Context, format, tag string) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	viewer := article.Viewer{Language: textutil.PreferredLanguage(c.GetHeader("Accept-Language"))}
+	articles, _, err := handler.service.GetArticlesPage(c.Request.Context(), viewer, article.ListFilter{Tag: tag}, article.DefaultPage, handler.cfg.Items)
+	if err != nil {
+		if errors.Is(err, article.ErrValidation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+		tracking.CaptureError(c.Request.Context(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	modified := lastModified(articles)
+	etag := feedETag(format, tag, articles)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(handler.cfg.MaxAge.Seconds())))
+	c.Header("ETag", etag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.Format(http.TimeFormat))
+	}
+	if notModified(c.Request, etag, modified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	meta := feedMeta{
+		Title:       handler.cfg.Title,
+		Description: handler.cfg.Description,
+		SiteURL:     handler.cfg.SiteURL,
+		SelfURL:     handler.cfg.SiteURL + c.Request.URL.Path,
+		Updated:     modified,
+	}
+	if tag != "" {
+		meta.Title = fmt.Sprintf("%s: %s", handler.cfg.Title, tag)
+		meta.Description = fmt.Sprintf("%s tagged %q", handler.cfg.Description, tag)
+	}
+
+	var body []byte
+	contentType := ContentTypeRSS
+	if format == FormatAtom {
+		contentType = ContentTypeAtom
+		body, err = renderAtom(handler.cfg, meta, articles)
+	} else {
+		body, err = renderRSS(handler.cfg, meta, articles)
+	}
+	if err != nil {
+		log.Ctx(c.Request.Context()).Error().Err(err).Str("format", format).Msg("Failed to render feed")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+func (handler *Handler) Sitemap(c *gin.Context) {
+	handler.serveSitemap(c, 0)
+}
+
+func (handler *Handler) SitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("file"), ".xml"))
+	if err != nil || page < 1 || !strings.HasSuffix(c.Param("file"), ".xml") {
+		c.JSON(http.StatusNotFound, gin.H{"error": ErrSitemapNotFound.Error()})
+		return
+	}
+
+	handler.serveSitemap(c, page)
+}
+
+func (handler *Handler) serveSitemap(c *gin.Context, page int) {
+	body, err := handler.sitemap(c.Request.Context(), page)
+	if err != nil {
+		if errors.Is(err, ErrSitemapNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		log.Ctx(c.Request.Context()).Error().Err(err).Int("page", page).Msg("Failed to build sitemap")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(handler.cfg.SitemapCacheTTL.Seconds())))
+	c.Data(http.StatusOK, ContentTypeSitemap, body)
+}
+
+func feedETag(format, tag string, articles []article.Article) string {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s|%s", format, tag)
+	for i := range articles {
+		fmt.Fprintf(hash, "|%s", articles[i].ETag())
+	}
+	return fmt.Sprintf(`W/"%x"`, hash.Sum64())
+}
@@ -0,0 +1,269 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeService struct {
+	articles     []article.Article
+	sitemapCalls int
+	language     string
+}
+
+func (f *fakeService) GetSitemapEntries(ctx context.Context, page, limit int) ([]article.SitemapEntry, int64, error) {
+	f.sitemapCalls++
+	var entries []article.SitemapEntry
+	for _, a := range f.articles {
+		entries = append(entries, article.SitemapEntry{Slug: a.Slug, UpdatedAt: a.UpdatedAt})
+	}
+	total := int64(len(entries))
+	offset := (page - 1) * limit
+	if offset >= len(entries) {
+		return nil, total, nil
+	}
+	return entries[offset:min(offset+limit, len(entries))], total, nil
+}
+
+func (f *fakeService) GetArticlesPage(ctx context.Context, viewer article.Viewer, filter article.ListFilter, page, limit int) ([]article.Article, bool, error) {
+	f.language = viewer.Language
+	if len(filter.Tag) > article.MaxTagLength {
+		return nil, false, fmt.Errorf("%w: tag too long", article.ErrValidation)
+	}
+	var result []article.Article
+	for _, a := range f.articles {
+		if filter.Tag != "" && !slices.ContainsFunc(a.Tags, func(tag article.Tag) bool { return tag.Name == filter.Tag }) {
+			continue
+		}
+		result = append(result, a)
+	}
+	hasNext := len(result) > limit
+	if hasNext {
+		result = result[:limit]
+	}
+	return result, hasNext, nil
+}
+
+func TestFeedHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := &fakeService{articles: []article.Article{
+		{ID: 2, Title: "Go & you", Slug: "go-and-you", Excerpt: "All about Go", CreatedAt: updated, UpdatedAt: updated, Tags: []article.Tag{{Name: "go"}}},
+		{ID: 1, Title: "Rust", Slug: "rust", Excerpt: "All about Rust", CreatedAt: updated.Add(-time.Hour), UpdatedAt: updated.Add(-time.Hour), Tags: []article.Tag{{Name: "rust"}}},
+	}}
+	handler := NewHandler(service, config.FeedConfig{
+		Title:       "Blog",
+		Description: "Latest articles",
+		SiteURL:     "https://blog.example.com",
+		ArticleURL:  "https://blog.example.com/posts/{slug}",
+		Items:       20,
+		MaxAge:      5 * time.Minute,
+	})
+
+	router := gin.New()
+	router.GET("/feeds/articles.rss", handler.ArticlesRSS)
+	router.GET("/feeds/articles.atom", handler.ArticlesAtom)
+	router.GET("/feeds/tags/:file", handler.TagFeed)
+
+	send := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range header {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/feeds/articles.rss", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentTypeRSS {
+		t.Errorf("Expected Content-Type %q, got %q", ContentTypeRSS, got)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("Expected Cache-Control public, max-age=300, got %q", got)
+	}
+	if got := w.Header().Get("Last-Modified"); got != updated.Format(http.TimeFormat) {
+		t.Errorf("Expected Last-Modified %q, got %q", updated.Format(http.TimeFormat), got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Language" {
+		t.Errorf("Expected Vary Accept-Language, got %q", got)
+	}
+
+	var rss rssFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &rss); err != nil {
+		t.Fatalf("Failed to parse RSS: %v", err)
+	}
+	if rss.Channel.Title != "Blog" || len(rss.Channel.Items) != 2 {
+		t.Fatalf("Expected channel Blog with 2 items, got %q with %d", rss.Channel.Title, len(rss.Channel.Items))
+	}
+	if item := rss.Channel.Items[0]; item.Title != "Go & you" || item.Link != "https://blog.example.com/posts/go-and-you" {
+		t.Errorf("Unexpected first item: %+v", item)
+	}
+
+	etag := w.Header().Get("ETag")
+	if w := send("/feeds/articles.rss", map[string]string{"If-None-Match": etag}); w.Code != http.StatusNotModified {
+		t.Errorf("Expected status %d for a matching ETag, got %d", http.StatusNotModified, w.Code)
+	}
+	if w := send("/feeds/articles.rss", map[string]string{"If-Modified-Since": updated.Format(http.TimeFormat)}); w.Code != http.StatusNotModified {
+		t.Errorf("Expected status %d when not modified since, got %d", http.StatusNotModified, w.Code)
+	}
+	if w := send("/feeds/articles.atom", map[string]string{"If-None-Match": etag}); w.Code != http.StatusOK {
+		t.Errorf("Expected the RSS ETag not to match the Atom feed, got %d", w.Code)
+	}
+
+	if send("/feeds/articles.rss", map[string]string{"Accept-Language": "de-DE,de;q=0.9"}); service.language != "de-DE" {
+		t.Errorf("Expected the feed to be read in the Accept-Language locale, got %q", service.language)
+	}
+
+	w = send("/feeds/tags/rust.atom", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentTypeAtom {
+		t.Errorf("Expected Content-Type %q, got %q", ContentTypeAtom, got)
+	}
+	var atom atomFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &atom); err != nil {
+		t.Fatalf("Failed to parse Atom: %v", err)
+	}
+	if atom.Title != "Blog: rust" || len(atom.Entries) != 1 || atom.Entries[0].ID != "https://blog.example.com/posts/rust" {
+		t.Errorf("Unexpected tag feed: %q with %d entries", atom.Title, len(atom.Entries))
+	}
+	if atom.ID != "https://blog.example.com/feeds/tags/rust.atom" {
+		t.Errorf("Expected the feed ID to be its own URL, got %q", atom.ID)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "Tag RSS", path: "/feeds/tags/go.rss", wantStatus: http.StatusOK},
+		{name: "Unknown format", path: "/feeds/tags/go.json", wantStatus: http.StatusNotFound},
+		{name: "Missing tag", path: "/feeds/tags/.rss", wantStatus: http.StatusNotFound},
+		{name: "Tag too long", path: "/feeds/tags/" + strings.Repeat("a", article.MaxTagLength+1) + ".rss", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := send(tt.path, nil); w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestSitemap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := &fakeService{}
+	for i := 1; i <= 3; i++ {
+		service.articles = append(service.articles, article.Article{ID: uint(i), Slug: fmt.Sprintf("article-%d", i), UpdatedAt: updated})
+	}
+	cfg := config.FeedConfig{
+		SiteURL:         "https://blog.example.com",
+		ArticleURL:      "https://blog.example.com/posts/{slug}",
+		SitemapURLs:     3,
+		SitemapCacheTTL: time.Minute,
+	}
+
+	newRouter := func(handler *Handler) *gin.Engine {
+		router := gin.New()
+		router.GET("/sitemap.xml", handler.Sitemap)
+		router.GET("/sitemaps/:file", handler.SitemapPage)
+		return router
+	}
+	send := func(router *gin.Engine, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	handler := NewHandler(service, cfg)
+	router := newRouter(handler)
+	w := send(router, "/sitemap.xml")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentTypeSitemap {
+		t.Errorf("Expected Content-Type %q, got %q", ContentTypeSitemap, got)
+	}
+	var set urlSet
+	if err := xml.Unmarshal(w.Body.Bytes(), &set); err != nil {
+		t.Fatalf("Failed to parse sitemap: %v", err)
+	}
+	if len(set.URLs) != 3 || set.URLs[0].Loc != "https://blog.example.com/posts/article-1" || set.URLs[0].LastMod != "2024-03-01T12:00:00Z" {
+		t.Errorf("Unexpected sitemap: %+v", set.URLs)
+	}
+
+	send(router, "/sitemap.xml")
+	if service.sitemapCalls != 1 {
+		t.Errorf("Expected the cached sitemap to be served, got %d builds", service.sitemapCalls)
+	}
+
+	if err := handler.InvalidateSitemaps(events.Event{Name: article.EventArticlePublished}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	send(router, "/sitemap.xml")
+	if service.sitemapCalls != 2 {
+		t.Errorf("Expected the sitemap to be rebuilt after invalidation, got %d builds", service.sitemapCalls)
+	}
+
+	cfg.SitemapURLs = 2
+	router = newRouter(NewHandler(service, cfg))
+	var index sitemapIndex
+	if err := xml.Unmarshal(send(router, "/sitemap.xml").Body.Bytes(), &index); err != nil {
+		t.Fatalf("Failed to parse sitemap index: %v", err)
+	}
+	if len(index.Sitemaps) != 2 || index.Sitemaps[1].Loc != "https://blog.example.com/sitemaps/2.xml" {
+		t.Errorf("Unexpected sitemap index: %+v", index.Sitemaps)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantURLs   int
+	}{
+		{name: "First page", path: "/sitemaps/1.xml", wantStatus: http.StatusOK, wantURLs: 2},
+		{name: "Last page", path: "/sitemaps/2.xml", wantStatus: http.StatusOK, wantURLs: 1},
+		{name: "Past the end", path: "/sitemaps/3.xml", wantStatus: http.StatusNotFound},
+		{name: "Not a page", path: "/sitemaps/zero.xml", wantStatus: http.StatusNotFound},
+		{name: "Wrong extension", path: "/sitemaps/1.txt", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := send(router, tt.path)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var set urlSet
+			if err := xml.Unmarshal(w.Body.Bytes(), &set); err != nil {
+				t.Fatalf("Failed to parse sitemap: %v", err)
+			}
+			if len(set.URLs) != tt.wantURLs {
+				t.Errorf("Expected %d URLs, got %d", tt.wantURLs, len(set.URLs))
+			}
+		})
+	}
+}
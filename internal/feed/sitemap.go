@@ -0,0 +1,96 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sync"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/events"
+)
+
+const ContentTypeSitemap = "application/xml; charset=utf-8"
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type cachedSitemap struct {
+	body    []byte
+	expires time.Time
+}
+
+type sitemapCache struct {
+	mu    sync.Mutex
+	pages map[int]cachedSitemap
+}
+
+func (handler *Handler) sitemap(ctx context.Context, page int) ([]byte, error) {
+	handler.sitemaps.mu.Lock()
+	defer handler.sitemaps.mu.Unlock()
+
+	now := time.Now()
+	if cached, ok := handler.sitemaps.pages[page]; ok && now.Before(cached.expires) {
+		return cached.body, nil
+	}
+
+	body, err := handler.buildSitemap(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	handler.sitemaps.pages[page] = cachedSitemap{body: body, expires: now.Add(handler.cfg.SitemapCacheTTL)}
+	return body, nil
+}
+
+func (handler *Handler) InvalidateSitemaps(events.Event) error {
+	handler.sitemaps.mu.Lock()
+	defer handler.sitemaps.mu.Unlock()
+	clear(handler.sitemaps.pages)
+	return nil
+}
+
+func (handler *Handler) buildSitemap(ctx context.Context, page int) ([]byte, error) {
+	perPage := handler.cfg.SitemapURLs
+	entries, total, err := handler.service.GetSitemapEntries(ctx, max(page, 1), perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	if page == 0 && total > int64(perPage) {
+		pages := int((total + int64(perPage) - 1) / int64(perPage))
+		index := sitemapIndex{Sitemaps: make([]sitemapURL, 0, pages)}
+		for n := 1; n <= pages; n++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapURL{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", handler.cfg.SiteURL, n)})
+		}
+		return marshal(index)
+	}
+	if page > 0 && len(entries) == 0 {
+		return nil, ErrSitemapNotFound
+	}
+
+	return marshal(handler.urlSet(entries))
+}
+
+func (handler *Handler) urlSet(entries []article.SitemapEntry) urlSet {
+	set := urlSet{URLs: make([]sitemapURL, 0, len(entries))}
+	for _, entry := range entries {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     articleURL(handler.cfg, entry.Slug),
+			LastMod: entry.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return set
+}
@@ -0,0 +1,11 @@
+package graphql
+
+const (
+	Endpoint = "/api/v1/graphql"
+
+	CodeBadRequest      = "BAD_REQUEST"
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotFound        = "NOT_FOUND"
+	CodeInternal        = "INTERNAL_SERVER_ERROR"
+)
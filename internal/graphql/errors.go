@@ -0,0 +1,8 @@
+package graphql
+
+import "errors"
+
+var (
+	ErrUnauthenticated   = errors.New("authentication required")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
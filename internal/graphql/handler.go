@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/textutil"
+	"content-service/internal/shared/tracking"
+
+	gqlgraphql "github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type viewerKey struct{}
+
+func withViewer(ctx context.Context, viewer article.Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+func viewerFrom(ctx context.Context) article.Viewer {
+	viewer, _ := ctx.Value(viewerKey{}).(article.Viewer)
+	return viewer
+}
+
+type scopesKey struct{}
+
+func withScopes(ctx context.Context, c *gin.Context) context.Context {
+	if _, scoped := c.Get(middleware.UserScopesKey); !scoped {
+		return ctx
+	}
+	return context.WithValue(ctx, scopesKey{}, middleware.GetUserScopes(c))
+}
+
+func requireScope(ctx context.Context, scope string) error {
+	granted, scoped := ctx.Value(scopesKey{}).([]string)
+	if scoped && !slices.Contains(granted, scope) {
+		return fmt.Errorf("%w: %s required", ErrInsufficientScope, scope)
+	}
+	return nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID:   userID,
+		Role:     middleware.GetUserRole(c),
+		Language: textutil.PreferredLanguage(c.GetHeader("Accept-Language")),
+	}
+}
+
+var errorToCode = map[error]string{
+	article.ErrNotFound:      CodeNotFound,
+	article.ErrNoTranslation: CodeNotFound,
+	article.ErrForbidden:     CodeForbidden,
+	article.ErrValidation:    CodeBadRequest,
+	ErrUnauthenticated:       CodeUnauthenticated,
+	ErrInsufficientScope:     CodeForbidden,
+}
+
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := gqlgraphql.DefaultErrorPresenter(ctx, err)
+	if presented.Err == nil {
+		return presented
+	}
+
+	for target, code := range errorToCode {
+		if errors.Is(err, target) {
+			presented.Extensions = map[string]any{"code": code}
+			return presented
+		}
+	}
+
+	log.Ctx(ctx).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(ctx, err)
+	presented.Message = "internal server error"
+	presented.Extensions = map[string]any{"code": CodeInternal}
+	return presented
+}
+
+type Handler struct {
+	server     *handler.Server
+	playground http.HandlerFunc
+}
+
+func NewHandler(resolver *Resolver, cfg config.GraphQLConfig) *Handler {
+	server := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.Use(extension.FixedComplexityLimit(cfg.ComplexityLimit))
+	server.SetErrorPresenter(presentError)
+
+	h := &Handler{server: server}
+	if cfg.Playground {
+		h.playground = playground.Handler("GraphQL", Endpoint)
+	}
+	return h
+}
+
+func (h *Handler) Serve(c *gin.Context) {
+	ctx := withScopes(withViewer(c.Request.Context(), getViewer(c)), c)
+	h.server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+func (h *Handler) Playground(c *gin.Context) {
+	if h.playground == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "GraphQL playground is disabled"})
+		return
+	}
+	h.playground(c.Writer, c.Request)
+}
@@ -0,0 +1,189 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"content-service/internal/article"
+	"content-service/internal/category"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeArticles struct {
+	article.Service
+	articles map[uint]*article.Article
+	created  *article.CreateInput
+	author   uint
+}
+
+func (f *fakeArticles) GetAllArticles(ctx context.Context, viewer article.Viewer, filter article.ListFilter, page, limit int) ([]article.Article, int64, error) {
+	var found []article.Article
+	for id := uint(1); id <= uint(len(f.articles)); id++ {
+		if a := f.articles[id]; filter.Tag == "" || (len(a.Tags) > 0 && a.Tags[0].Name == filter.Tag) {
+			found = append(found, *a)
+		}
+	}
+	return found, int64(len(found)), nil
+}
+
+func (f *fakeArticles) GetArticleByID(ctx context.Context, viewer article.Viewer, id uint) (*article.Article, error) {
+	if id == 500 {
+		return nil, errors.New("connection refused")
+	}
+	a, ok := f.articles[id]
+	if !ok {
+		return nil, article.ErrNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeArticles) GetArticleBySlug(ctx context.Context, viewer article.Viewer, slug string) (*article.Article, bool, error) {
+	for _, a := range f.articles {
+		if a.Slug == slug {
+			return a, false, nil
+		}
+	}
+	return nil, false, article.ErrNotFound
+}
+
+func (f *fakeArticles) ListAuthors(ctx context.Context, viewer article.Viewer, id uint) ([]article.Author, error) {
+	return []article.Author{{ArticleID: id, UserID: f.articles[id].UserID, Role: article.AuthorRoleOwner}}, nil
+}
+
+func (f *fakeArticles) CreateArticle(ctx context.Context, userID uint, input article.CreateInput) (*article.Article, error) {
+	f.created = &input
+	f.author = userID
+	return &article.Article{ID: 3, UserID: userID, Title: input.Title, Status: input.Status}, nil
+}
+
+type fakeCategories struct {
+	category.Service
+}
+
+func (fakeCategories) GetCategory(ctx context.Context, id uint) (*category.Category, error) {
+	if id != 7 {
+		return nil, category.ErrNotFound
+	}
+	return &category.Category{ID: 7, Name: "Go"}, nil
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	categoryID := uint(7)
+	articles := &fakeArticles{articles: map[uint]*article.Article{
+		1: {ID: 1, UserID: 4, Title: "First", Slug: "first", Status: article.StatusPublished, CategoryID: &categoryID, Tags: []article.Tag{{Name: "go"}}},
+		2: {ID: 2, UserID: 5, Title: "Second", Slug: "second", Status: article.StatusPublished},
+	}}
+	handler := NewHandler(NewResolver(articles, fakeCategories{}), config.GraphQLConfig{ComplexityLimit: 100})
+
+	router := gin.New()
+	router.POST(Endpoint, func(c *gin.Context) {
+		if id := c.GetHeader("X-Test-User"); id != "" {
+			userID, _ := strconv.ParseUint(id, 10, 32)
+			c.Set(middleware.UserIDKey, uint(userID))
+		}
+		if scopes := c.GetHeader("X-Test-Scopes"); scopes != "" {
+			c.Set(middleware.UserScopesKey, strings.Fields(scopes))
+		}
+	}, handler.Serve)
+
+	sendScoped := func(userID, scopes, query string) response {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"query": query})
+		req := httptest.NewRequest(http.MethodPost, Endpoint, strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", userID)
+		req.Header.Set("X-Test-Scopes", scopes)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var resp response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+	send := func(userID, query string) response {
+		t.Helper()
+		return sendScoped(userID, "", query)
+	}
+
+	resp := send("", `{ articles(filter: {tag: "go"}) { data { id title tags category { name } authors { userId role } } meta { total totalPages } } }`)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %+v", resp.Errors)
+	}
+	want := `{"data":[{"id":"1","title":"First","tags":["go"],"category":{"name":"Go"},"authors":[{"userId":"4","role":"owner"}]}],"meta":{"total":1,"totalPages":1}}`
+	if got := string(resp.Data["articles"]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	resp = send("", `{ article(slug: "second") { id category { name } } }`)
+	if got := string(resp.Data["article"]); got != `{"id":"2","category":null}` {
+		t.Errorf("Unexpected article by slug: %s", got)
+	}
+
+	errorTests := []struct {
+		name        string
+		userID      string
+		scopes      string
+		query       string
+		wantCode    string
+		wantMessage string
+	}{
+		{name: "Not found", query: `{ article(id: 9) { id } }`, wantCode: CodeNotFound, wantMessage: "article not found"},
+		{name: "Neither id nor slug", query: `{ article { id } }`, wantCode: CodeBadRequest},
+		{name: "Internal error is hidden", query: `{ article(id: 500) { id } }`, wantCode: CodeInternal, wantMessage: "internal server error"},
+		{name: "Anonymous mutation", query: `mutation { deleteArticle(id: 1) }`, wantCode: CodeUnauthenticated, wantMessage: "authentication required"},
+		{name: "Mutation without write scope", userID: "4", scopes: "articles:read", query: `mutation { deleteArticle(id: 1) }`, wantCode: CodeForbidden, wantMessage: "insufficient scope: articles:write required"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := sendScoped(tt.userID, tt.scopes, tt.query)
+			if len(resp.Errors) != 1 {
+				t.Fatalf("Expected 1 error, got %+v", resp.Errors)
+			}
+			if code := resp.Errors[0].Extensions["code"]; code != tt.wantCode {
+				t.Errorf("Expected code %s, got %v", tt.wantCode, code)
+			}
+			if tt.wantMessage != "" && resp.Errors[0].Message != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, resp.Errors[0].Message)
+			}
+		})
+	}
+
+	resp = sendScoped("4", "articles:read", `{ article(id: 1) { id } }`)
+	if len(resp.Errors) != 0 {
+		t.Errorf("Expected queries to need only the read scope, got %+v", resp.Errors)
+	}
+
+	resp = sendScoped("4", "articles:read articles:write", `mutation { createArticle(input: {title: "Third", content: "Body", status: "draft", tags: ["go"]}) { id userId status } }`)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %+v", resp.Errors)
+	}
+	if articles.author != 4 || articles.created.Status != article.StatusDraft || len(articles.created.Tags) != 1 {
+		t.Errorf("Expected article created by 4 as a draft with one tag, got %d %+v", articles.author, articles.created)
+	}
+	if got := string(resp.Data["createArticle"]); got != `{"id":"3","userId":"4","status":"draft"}` {
+		t.Errorf("Unexpected created article: %s", got)
+	}
+}
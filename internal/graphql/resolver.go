@@ -0,0 +1,62 @@
+package graphql
+
+//go:generate go tool gqlgen generate
+
+import (
+	"content-service/internal/article"
+	"content-service/internal/category"
+)
+
+type Resolver struct {
+	articles   article.Service
+	categories category.Service
+}
+
+func NewResolver(articles article.Service, categories category.Service) *Resolver {
+	return &Resolver{articles: articles, categories: categories}
+}
+
+func value[T any](ptr *T) T {
+	var zero T
+	if ptr == nil {
+		return zero
+	}
+	return *ptr
+}
+
+func pagination(page, limit *int) (int, int) {
+	p, l := article.DefaultPage, article.DefaultLimit
+	if page != nil && *page > 0 {
+		p = *page
+	}
+	if limit != nil && *limit > 0 {
+		l = min(*limit, article.MaxLimit)
+	}
+	return p, l
+}
+
+func pageInfo(page, limit int, total int64) *PageInfo {
+	return &PageInfo{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
+
+func (filter *ArticleFilter) toListFilter() article.ListFilter {
+	if filter == nil {
+		return article.ListFilter{}
+	}
+	return article.ListFilter{
+		UserID:        filter.UserID,
+		CategoryID:    filter.CategoryID,
+		Tag:           value(filter.Tag),
+		Featured:      filter.Featured,
+		HasCover:      filter.HasCover,
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+		Sort:          value(filter.Sort),
+		Order:         value(filter.Order),
+	}
+}
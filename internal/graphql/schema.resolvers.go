@@ -0,0 +1,157 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"content-service/internal/article"
+	"content-service/internal/category"
+	"content-service/internal/shared/middleware"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Category is the resolver for the category field.
+func (r *articleResolver) Category(ctx context.Context, obj *article.Article) (*category.Category, error) {
+	if obj.CategoryID == nil {
+		return nil, nil
+	}
+	found, err := r.categories.GetCategory(ctx, *obj.CategoryID)
+	if errors.Is(err, category.ErrNotFound) {
+		return nil, nil
+	}
+	return found, err
+}
+
+// Tags is the resolver for the tags field.
+func (r *articleResolver) Tags(ctx context.Context, obj *article.Article) ([]string, error) {
+	tags := make([]string, len(obj.Tags))
+	for i, tag := range obj.Tags {
+		tags[i] = tag.Name
+	}
+	return tags, nil
+}
+
+// Authors is the resolver for the authors field.
+func (r *articleResolver) Authors(ctx context.Context, obj *article.Article) ([]article.Author, error) {
+	return r.articles.ListAuthors(ctx, viewerFrom(ctx), obj.ID)
+}
+
+// Translations is the resolver for the translations field.
+func (r *articleResolver) Translations(ctx context.Context, obj *article.Article) ([]article.Translation, error) {
+	return r.articles.ListTranslations(ctx, viewerFrom(ctx), obj.ID)
+}
+
+// CreateArticle is the resolver for the createArticle field.
+func (r *mutationResolver) CreateArticle(ctx context.Context, input CreateArticleInput) (*article.Article, error) {
+	viewer := viewerFrom(ctx)
+	if viewer.UserID == 0 {
+		return nil, ErrUnauthenticated
+	}
+	if err := requireScope(ctx, middleware.ScopeArticlesWrite); err != nil {
+		return nil, err
+	}
+	return r.articles.CreateArticle(ctx, viewer.UserID, article.CreateInput{
+		Title:         input.Title,
+		Content:       input.Content,
+		Status:        value(input.Status),
+		Language:      value(input.Language),
+		Tags:          input.Tags,
+		CategoryID:    input.CategoryID,
+		Excerpt:       value(input.Excerpt),
+		CoverImageURL: value(input.CoverImageURL),
+		CoverMediaID:  input.CoverMediaID,
+		Role:          viewer.Role,
+	})
+}
+
+// UpdateArticle is the resolver for the updateArticle field.
+func (r *mutationResolver) UpdateArticle(ctx context.Context, id uint, input UpdateArticleInput) (*article.Article, error) {
+	viewer := viewerFrom(ctx)
+	if viewer.UserID == 0 {
+		return nil, ErrUnauthenticated
+	}
+	if err := requireScope(ctx, middleware.ScopeArticlesWrite); err != nil {
+		return nil, err
+	}
+	update := article.UpdateInput{
+		Title:         input.Title,
+		Content:       input.Content,
+		Status:        input.Status,
+		Language:      input.Language,
+		CategoryID:    input.CategoryID,
+		Excerpt:       input.Excerpt,
+		CoverImageURL: input.CoverImageURL,
+		CoverMediaID:  input.CoverMediaID,
+		Role:          viewer.Role,
+	}
+	if input.Tags != nil {
+		update.Tags = &input.Tags
+	}
+	return r.articles.UpdateArticle(ctx, viewer.UserID, id, update)
+}
+
+// DeleteArticle is the resolver for the deleteArticle field.
+func (r *mutationResolver) DeleteArticle(ctx context.Context, id uint) (bool, error) {
+	viewer := viewerFrom(ctx)
+	if viewer.UserID == 0 {
+		return false, ErrUnauthenticated
+	}
+	if err := requireScope(ctx, middleware.ScopeArticlesWrite); err != nil {
+		return false, err
+	}
+	if err := r.articles.DeleteArticle(ctx, viewer.UserID, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Articles is the resolver for the articles field.
+func (r *queryResolver) Articles(ctx context.Context, filter *ArticleFilter, page *int, limit *int) (*ArticleList, error) {
+	p, l := pagination(page, limit)
+	articles, total, err := r.articles.GetAllArticles(ctx, viewerFrom(ctx), filter.toListFilter(), p, l)
+	if err != nil {
+		return nil, err
+	}
+	return &ArticleList{Data: articles, Meta: pageInfo(p, l, total)}, nil
+}
+
+// Article is the resolver for the article field.
+func (r *queryResolver) Article(ctx context.Context, id *uint, slug *string) (*article.Article, error) {
+	viewer := viewerFrom(ctx)
+	switch {
+	case id != nil && slug == nil:
+		return r.articles.GetArticleByID(ctx, viewer, *id)
+	case slug != nil && id == nil:
+		found, _, err := r.articles.GetArticleBySlug(ctx, viewer, *slug)
+		return found, err
+	default:
+		return nil, fmt.Errorf("%w: exactly one of id or slug is required", article.ErrValidation)
+	}
+}
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query string, page *int, limit *int) (*SearchResult, error) {
+	p, l := pagination(page, limit)
+	hits, total, err := r.articles.SearchArticles(ctx, viewerFrom(ctx), query, p, l)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResult{Data: hits, Meta: pageInfo(p, l, total)}, nil
+}
+
+// Article returns ArticleResolver implementation.
+func (r *Resolver) Article() ArticleResolver { return &articleResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type articleResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,173 @@
+package grpcserver
+
+import (
+	"context"
+
+	contentv1 "content-service/api/proto/content/v1"
+	"content-service/internal/article"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type articleServer struct {
+	contentv1.UnimplementedArticleServiceServer
+	service article.Service
+}
+
+func toProto(a *article.Article) *contentv1.Article {
+	msg := &contentv1.Article{
+		Id:                 uint64(a.ID),
+		Title:              a.Title,
+		Slug:               a.Slug,
+		Content:            a.Content,
+		Excerpt:            a.Excerpt,
+		Status:             a.Status,
+		Language:           a.Language,
+		UserId:             uint64(a.UserID),
+		LikesCount:         a.LikesCount,
+		Views:              a.Views,
+		IsFeatured:         a.IsFeatured,
+		ReadingTimeMinutes: int32(a.ReadingTimeMinutes),
+		CoverImageUrl:      a.CoverImageURL,
+		CreatedAt:          timestamppb.New(a.CreatedAt),
+		UpdatedAt:          timestamppb.New(a.UpdatedAt),
+	}
+	if a.CategoryID != nil {
+		id := uint64(*a.CategoryID)
+		msg.CategoryId = &id
+	}
+	for _, tag := range a.Tags {
+		msg.Tags = append(msg.Tags, tag.Name)
+	}
+	return msg
+}
+
+func optionalID(id *uint64) *uint {
+	if id == nil {
+		return nil
+	}
+	v := uint(*id)
+	return &v
+}
+
+func pagination(page, limit int32) (int, int) {
+	p, l := article.DefaultPage, article.DefaultLimit
+	if page > 0 {
+		p = int(page)
+	}
+	if limit > 0 {
+		l = min(int(limit), article.MaxLimit)
+	}
+	return p, l
+}
+
+func (server *articleServer) GetArticle(ctx context.Context, req *contentv1.GetArticleRequest) (*contentv1.GetArticleResponse, error) {
+	found, err := server.service.GetArticleByID(ctx, viewerFrom(ctx), uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &contentv1.GetArticleResponse{Article: toProto(found)}, nil
+}
+
+func (server *articleServer) GetArticleBySlug(ctx context.Context, req *contentv1.GetArticleBySlugRequest) (*contentv1.GetArticleBySlugResponse, error) {
+	found, _, err := server.service.GetArticleBySlug(ctx, viewerFrom(ctx), req.GetSlug())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &contentv1.GetArticleBySlugResponse{Article: toProto(found)}, nil
+}
+
+func (server *articleServer) ListArticles(ctx context.Context, req *contentv1.ListArticlesRequest) (*contentv1.ListArticlesResponse, error) {
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+	filter := article.ListFilter{
+		UserID:     optionalID(req.UserId),
+		CategoryID: optionalID(req.CategoryId),
+		Tag:        req.GetTag(),
+		Featured:   req.Featured,
+		Sort:       req.GetSort(),
+		Order:      req.GetOrder(),
+	}
+
+	articles, total, err := server.service.GetAllArticles(ctx, viewerFrom(ctx), filter, page, limit)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	resp := &contentv1.ListArticlesResponse{
+		Articles:   make([]*contentv1.Article, len(articles)),
+		Page:       int32(page),
+		Limit:      int32(limit),
+		Total:      total,
+		TotalPages: int32((total + int64(limit) - 1) / int64(limit)),
+	}
+	for i := range articles {
+		resp.Articles[i] = toProto(&articles[i])
+	}
+	return resp, nil
+}
+
+func (server *articleServer) CreateArticle(ctx context.Context, req *contentv1.CreateArticleRequest) (*contentv1.CreateArticleResponse, error) {
+	viewer, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := server.service.CreateArticle(ctx, viewer.UserID, article.CreateInput{
+		Title:         req.GetTitle(),
+		Content:       req.GetContent(),
+		Status:        req.GetStatus(),
+		Language:      req.GetLanguage(),
+		Tags:          req.GetTags(),
+		CategoryID:    optionalID(req.CategoryId),
+		Excerpt:       req.GetExcerpt(),
+		CoverImageURL: req.GetCoverImageUrl(),
+		Role:          viewer.Role,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &contentv1.CreateArticleResponse{Article: toProto(created)}, nil
+}
+
+func (server *articleServer) UpdateArticle(ctx context.Context, req *contentv1.UpdateArticleRequest) (*contentv1.UpdateArticleResponse, error) {
+	viewer, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input := article.UpdateInput{
+		Title:         req.Title,
+		Content:       req.Content,
+		Status:        req.Status,
+		Language:      req.Language,
+		CategoryID:    optionalID(req.CategoryId),
+		Excerpt:       req.Excerpt,
+		CoverImageURL: req.CoverImageUrl,
+		Role:          viewer.Role,
+	}
+	if req.Tags != nil {
+		tags := req.Tags.GetNames()
+		if tags == nil {
+			tags = []string{}
+		}
+		input.Tags = &tags
+	}
+
+	updated, err := server.service.UpdateArticle(ctx, viewer.UserID, uint(req.GetId()), input)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &contentv1.UpdateArticleResponse{Article: toProto(updated)}, nil
+}
+
+func (server *articleServer) DeleteArticle(ctx context.Context, req *contentv1.DeleteArticleRequest) (*contentv1.DeleteArticleResponse, error) {
+	viewer, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.service.DeleteArticle(ctx, viewer.UserID, uint(req.GetId())); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &contentv1.DeleteArticleResponse{}, nil
+}
@@ -0,0 +1,6 @@
+package grpcserver
+
+const (
+	AuthorizationMetadata = "authorization"
+	RequestIDMetadata     = "x-request-id"
+)
@@ -0,0 +1,31 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/tracking"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errorToCode = map[error]codes.Code{
+	article.ErrNotFound:   codes.NotFound,
+	article.ErrForbidden:  codes.PermissionDenied,
+	article.ErrValidation: codes.InvalidArgument,
+}
+
+func toStatus(ctx context.Context, err error) error {
+	for target, code := range errorToCode {
+		if errors.Is(err, target) {
+			return status.Error(code, err.Error())
+		}
+	}
+
+	log.Ctx(ctx).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(ctx, err)
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,112 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type viewerKey struct{}
+
+func viewerFrom(ctx context.Context) article.Viewer {
+	viewer, _ := ctx.Value(viewerKey{}).(article.Viewer)
+	return viewer
+}
+
+func firstValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		startedAt := time.Now()
+		requestID := middleware.ResolveRequestID(firstValue(ctx, RequestIDMetadata))
+		logger := log.With().Str(middleware.RequestIDKey, requestID).Logger()
+		ctx = logger.WithContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.Error().Interface("panic", recovered).Str("method", info.FullMethod).Msg("Recovered from panic")
+				err = status.Error(codes.Internal, "internal server error")
+			}
+
+			code := status.Code(err)
+			event := logger.Info()
+			if code == codes.Internal || code == codes.Unknown {
+				event = logger.Error()
+			}
+			event.
+				Str("method", info.FullMethod).
+				Str("code", code.String()).
+				Dur("duration", time.Since(startedAt)).
+				Msg("gRPC request")
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func AuthInterceptor(verifier *middleware.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		header := firstValue(ctx, AuthorizationMetadata)
+		if header == "" {
+			return handler(ctx, req)
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+		claims, err := verifier.Parse(token)
+		if errors.Is(err, middleware.ErrIntrospectionUnavailable) {
+			log.Ctx(ctx).Error().Err(err).Msg("Token introspection failed")
+			return nil, status.Error(codes.Unavailable, "authorization server unavailable")
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("Error parsing JWT token")
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+
+		if scope := methodScope(info.FullMethod); !claims.HasScope(scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "insufficient scope: %s required", scope)
+		}
+
+		viewer := article.Viewer{UserID: claims.UserID, Role: claims.Role}
+		return handler(context.WithValue(ctx, viewerKey{}, viewer), req)
+	}
+}
+
+func methodScope(fullMethod string) string {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List") {
+		return middleware.ScopeArticlesRead
+	}
+	return middleware.ScopeArticlesWrite
+}
+
+func requireUser(ctx context.Context) (article.Viewer, error) {
+	viewer := viewerFrom(ctx)
+	if viewer.UserID == 0 {
+		return viewer, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+	return viewer, nil
+}
@@ -0,0 +1,43 @@
+package grpcserver
+
+import (
+	"context"
+
+	contentv1 "content-service/api/proto/content/v1"
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func NewServer(cfg *config.Config, verifier *middleware.Verifier, articles article.Service) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			LoggingInterceptor(),
+			AuthInterceptor(verifier),
+		),
+	)
+	contentv1.RegisterArticleServiceServer(server, &articleServer{service: articles})
+	if cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+	return server
+}
+
+func Shutdown(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
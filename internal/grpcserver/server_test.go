@@ -0,0 +1,192 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	contentv1 "content-service/api/proto/content/v1"
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSecret = "test-secret-key-for-grpc-server-tests"
+
+type fakeArticles struct {
+	article.Service
+	articles map[uint]*article.Article
+	updated  *article.UpdateInput
+}
+
+func (f *fakeArticles) GetArticleByID(ctx context.Context, viewer article.Viewer, id uint) (*article.Article, error) {
+	a, ok := f.articles[id]
+	if !ok {
+		return nil, article.ErrNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeArticles) GetAllArticles(ctx context.Context, viewer article.Viewer, filter article.ListFilter, page, limit int) ([]article.Article, int64, error) {
+	var found []article.Article
+	for id := uint(1); id <= uint(len(f.articles)); id++ {
+		if filter.UserID == nil || f.articles[id].UserID == *filter.UserID {
+			found = append(found, *f.articles[id])
+		}
+	}
+	return found, int64(len(found)), nil
+}
+
+func (f *fakeArticles) CreateArticle(ctx context.Context, userID uint, input article.CreateInput) (*article.Article, error) {
+	if input.Title == "" {
+		return nil, article.ErrValidation
+	}
+	return &article.Article{ID: 3, UserID: userID, Title: input.Title, Status: article.StatusPublished}, nil
+}
+
+func (f *fakeArticles) UpdateArticle(ctx context.Context, userID, id uint, input article.UpdateInput) (*article.Article, error) {
+	a, ok := f.articles[id]
+	if !ok {
+		return nil, article.ErrNotFound
+	}
+	if a.UserID != userID {
+		return nil, article.ErrForbidden
+	}
+	f.updated = &input
+	return a, nil
+}
+
+func newTestClient(t *testing.T, articles article.Service) contentv1.ArticleServiceClient {
+	t.Helper()
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: testSecret}}
+	verifier, err := middleware.NewVerifier(cfg)
+	if err != nil {
+		t.Fatalf("NewVerifier() unexpected error: %v", err)
+	}
+	server := NewServer(cfg, verifier, articles)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return contentv1.NewArticleServiceClient(conn)
+}
+
+func withToken(t *testing.T, userID uint, scopes ...string) context.Context {
+	t.Helper()
+	token, err := middleware.CreateTestToken(userID, "", testSecret, scopes...)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(t.Context(), AuthorizationMetadata, "Bearer "+token)
+}
+
+func TestArticleService(t *testing.T) {
+	articles := &fakeArticles{articles: map[uint]*article.Article{
+		1: {ID: 1, UserID: 4, Title: "First", Tags: []article.Tag{{Name: "go"}}},
+		2: {ID: 2, UserID: 5, Title: "Second"},
+	}}
+	client := newTestClient(t, articles)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(t.Context(), RequestIDMetadata, "req-42")
+	resp, err := client.GetArticle(ctx, &contentv1.GetArticleRequest{Id: 1}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("GetArticle() unexpected error: %v", err)
+	}
+	if resp.GetArticle().GetTitle() != "First" || len(resp.GetArticle().GetTags()) != 1 {
+		t.Errorf("Unexpected article: %v", resp.GetArticle())
+	}
+	if got := header.Get(RequestIDMetadata); len(got) != 1 || got[0] != "req-42" {
+		t.Errorf("Expected request ID req-42 in response header, got %v", got)
+	}
+
+	userID := uint64(5)
+	list, err := client.ListArticles(t.Context(), &contentv1.ListArticlesRequest{UserId: &userID, Limit: 500})
+	if err != nil {
+		t.Fatalf("ListArticles() unexpected error: %v", err)
+	}
+	if len(list.GetArticles()) != 1 || list.GetTotal() != 1 || list.GetLimit() != int32(article.MaxLimit) {
+		t.Errorf("Expected 1 article with limit %d, got %d (total %d, limit %d)", article.MaxLimit, len(list.GetArticles()), list.GetTotal(), list.GetLimit())
+	}
+
+	title := "Renamed"
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{name: "Missing article", call: func() error {
+			_, err := client.GetArticle(t.Context(), &contentv1.GetArticleRequest{Id: 9})
+			return err
+		}, wantCode: codes.NotFound},
+		{name: "Create without token", call: func() error {
+			_, err := client.CreateArticle(t.Context(), &contentv1.CreateArticleRequest{Title: "New"})
+			return err
+		}, wantCode: codes.Unauthenticated},
+		{name: "Invalid token", call: func() error {
+			ctx := metadata.AppendToOutgoingContext(t.Context(), AuthorizationMetadata, "Bearer nope")
+			_, err := client.GetArticle(ctx, &contentv1.GetArticleRequest{Id: 1})
+			return err
+		}, wantCode: codes.Unauthenticated},
+		{name: "Invalid create", call: func() error {
+			_, err := client.CreateArticle(withToken(t, 4), &contentv1.CreateArticleRequest{})
+			return err
+		}, wantCode: codes.InvalidArgument},
+		{name: "Update someone else's article", call: func() error {
+			_, err := client.UpdateArticle(withToken(t, 4), &contentv1.UpdateArticleRequest{Id: 2, Title: &title})
+			return err
+		}, wantCode: codes.PermissionDenied},
+		{name: "Create with a read-only token", call: func() error {
+			_, err := client.CreateArticle(withToken(t, 4, middleware.ScopeArticlesRead), &contentv1.CreateArticleRequest{Title: "New"})
+			return err
+		}, wantCode: codes.PermissionDenied},
+		{name: "Get with a read-only token", call: func() error {
+			_, err := client.GetArticle(withToken(t, 4, middleware.ScopeArticlesRead), &contentv1.GetArticleRequest{Id: 1})
+			return err
+		}, wantCode: codes.OK},
+		{name: "Create", call: func() error {
+			resp, err := client.CreateArticle(withToken(t, 4), &contentv1.CreateArticleRequest{Title: "New"})
+			if err == nil && resp.GetArticle().GetUserId() != 4 {
+				t.Errorf("Expected article owned by 4, got %d", resp.GetArticle().GetUserId())
+			}
+			return err
+		}, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, code)
+			}
+		})
+	}
+
+	if _, err := client.UpdateArticle(withToken(t, 4), &contentv1.UpdateArticleRequest{Id: 1, Title: &title}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if articles.updated.Title == nil || *articles.updated.Title != title || articles.updated.Tags != nil {
+		t.Errorf("Expected only the title to change, got %+v", articles.updated)
+	}
+	if _, err := client.UpdateArticle(withToken(t, 4), &contentv1.UpdateArticleRequest{Id: 1, Tags: &contentv1.Tags{}}); err != nil {
+		t.Fatalf("UpdateArticle() unexpected error: %v", err)
+	}
+	if articles.updated.Tags == nil || len(*articles.updated.Tags) != 0 {
+		t.Errorf("Expected empty tags to clear the tags, got %v", articles.updated.Tags)
+	}
+}
@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/buildinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+
+	ComponentUp   = "up"
+	ComponentDown = "down"
+)
+
+type Probe func(ctx context.Context) error
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Check struct {
+	Name     string
+	Critical bool
+	Probe    Probe
+}
+
+type Component struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     string               `json:"status"`
+	Service    string               `json:"service"`
+	Version    string               `json:"version"`
+	Commit     string               `json:"commit,omitempty"`
+	BuildDate  string               `json:"build_date,omitempty"`
+	Components map[string]Component `json:"components"`
+}
+
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+func (checker *Checker) Add(check Check) {
+	checker.checks = append(checker.checks, check)
+}
+
+func (checker *Checker) Run(ctx context.Context) Report {
+	build := buildinfo.Get()
+	report := Report{
+		Status:     StatusOK,
+		Service:    "content-service",
+		Version:    build.APIVersion,
+		Commit:     build.Commit,
+		BuildDate:  build.BuildDate,
+		Components: make(map[string]Component, len(checker.checks)),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, check := range checker.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			component := checker.probe(ctx, check)
+			mu.Lock()
+			report.Components[check.Name] = component
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status == ComponentUp {
+			continue
+		}
+		if component.Critical {
+			report.Status = StatusUnavailable
+			break
+		}
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+func (checker *Checker) probe(ctx context.Context, check Check) Component {
+	ctx, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := check.Probe(ctx)
+	component := Component{
+		Status:    ComponentUp,
+		Critical:  check.Critical,
+		LatencyMS: time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		component.Status = ComponentDown
+		component.Error = err.Error()
+	}
+	return component
+}
+
+func Liveness(c *gin.Context) {
+	build := buildinfo.Get()
+	body := gin.H{
+		"status":  StatusOK,
+		"service": "content-service",
+		"version": build.APIVersion,
+	}
+	if build.Commit != "" {
+		body["commit"] = build.Commit
+	}
+	if build.BuildDate != "" {
+		body["build_date"] = build.BuildDate
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+func Version(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
+
+func (checker *Checker) Readiness(c *gin.Context) {
+	report := checker.Run(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
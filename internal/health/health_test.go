@@ -0,0 +1,149 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/buildinfo"
+
+	"github.com/gin-gonic/gin"
+)
+
+func up(context.Context) error {
+	return nil
+}
+
+func down(context.Context) error {
+	return errors.New("connection refused")
+}
+
+func hang(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		checks     []Check
+		wantCode   int
+		wantStatus string
+		wantDown   []string
+	}{
+		{
+			name:       "All components up",
+			checks:     []Check{{Name: "database", Critical: true, Probe: up}, {Name: "cache", Probe: up}},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "Optional component down",
+			checks:     []Check{{Name: "database", Critical: true, Probe: up}, {Name: "cache", Probe: down}},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusDegraded,
+			wantDown:   []string{"cache"},
+		},
+		{
+			name:       "Critical component down",
+			checks:     []Check{{Name: "database", Critical: true, Probe: down}, {Name: "cache", Probe: down}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnavailable,
+			wantDown:   []string{"database", "cache"},
+		},
+		{
+			name:       "Critical component times out",
+			checks:     []Check{{Name: "database", Critical: true, Probe: hang}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusUnavailable,
+			wantDown:   []string{"database"},
+		},
+		{
+			name:       "No checks",
+			wantCode:   http.StatusOK,
+			wantStatus: StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(50*time.Millisecond, tt.checks...)
+			router := gin.New()
+			router.GET("/readyz", checker.Readiness)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			var report Report
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Failed to decode report: %v", err)
+			}
+			if report.Status != tt.wantStatus {
+				t.Errorf("Expected report status %q, got %q", tt.wantStatus, report.Status)
+			}
+			if len(report.Components) != len(tt.checks) {
+				t.Errorf("Expected %d components, got %d", len(tt.checks), len(report.Components))
+			}
+			for _, name := range tt.wantDown {
+				component := report.Components[name]
+				if component.Status != ComponentDown || component.Error == "" {
+					t.Errorf("Expected %s to be down with an error, got %+v", name, component)
+				}
+			}
+		})
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/healthz", Liveness)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	previous := buildinfo.BuildDate
+	buildinfo.BuildDate = "2026-01-02T03:04:05Z"
+	defer func() { buildinfo.BuildDate = previous }()
+
+	router := gin.New()
+	router.GET("/version", Version)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var info buildinfo.Info
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to decode build info: %v", err)
+	}
+	if info.Version != buildinfo.Version {
+		t.Errorf("Expected version %q, got %q", buildinfo.Version, info.Version)
+	}
+	if info.BuildDate != "2026-01-02T03:04:05Z" {
+		t.Errorf("Expected build date %q, got %q", "2026-01-02T03:04:05Z", info.BuildDate)
+	}
+	if info.GoVersion == "" {
+		t.Error("Expected go version to be set")
+	}
+}
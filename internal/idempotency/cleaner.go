@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func RunCleaner(ctx context.Context, repo Repository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := repo.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to delete expired idempotency keys")
+		} else if deleted > 0 {
+			log.Info().Int64("deleted", deleted).Msg("Deleted expired idempotency keys")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package idempotency
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	MaxKeyLength = 255
+)
+
+var ReplayedHeaders = []string{"Content-Type", "ETag", "Location"}
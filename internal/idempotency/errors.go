@@ -0,0 +1,8 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInProgress = errors.New("a request with this idempotency key is still being processed")
+	ErrMismatch   = errors.New("idempotency key was already used for a different request")
+)
@@ -0,0 +1,144 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"content-service/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.RequestURI())
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayedHeaders(header http.Header) string {
+	selected := make(map[string]string, len(ReplayedHeaders))
+	for _, name := range ReplayedHeaders {
+		if value := header.Get(name); value != "" {
+			selected[name] = value
+		}
+	}
+	encoded, _ := json.Marshal(selected)
+	return string(encoded)
+}
+
+func replay(c *gin.Context, record *Record, hash string) {
+	switch {
+	case record.RequestHash != hash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": ErrMismatch.Error()})
+		return
+	case !record.Completed():
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": ErrInProgress.Error()})
+		return
+	}
+
+	var headers map[string]string
+	_ = json.Unmarshal([]byte(record.Headers), &headers)
+	for name, value := range headers {
+		c.Header(name, value)
+	}
+	c.Header(HeaderReplayed, "true")
+	c.Status(record.StatusCode)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+func Middleware(repo Repository, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > MaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s cannot exceed %d characters", HeaderKey, MaxKeyLength)})
+			return
+		}
+
+		userID, err := middleware.GetUserID(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &Record{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+		existing, err := repo.Claim(c.Request.Context(), record)
+		if err != nil {
+			log.Ctx(c.Request.Context()).Error().Err(err).Msg("Failed to claim idempotency key")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if existing != nil {
+			replay(c, existing, record.RequestHash)
+			return
+		}
+
+		ctx := context.WithoutCancel(c.Request.Context())
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		stored := false
+		defer func() {
+			c.Writer = recorder.ResponseWriter
+			if stored {
+				return
+			}
+			if err := repo.Release(ctx, userID, key); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to release idempotency key")
+			}
+		}()
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		record.StatusCode = recorder.Status()
+		record.Headers = replayedHeaders(recorder.Header())
+		record.Body = recorder.body.Bytes()
+		if err := repo.Complete(ctx, record); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to store idempotent response")
+			return
+		}
+		stored = true
+	}
+}
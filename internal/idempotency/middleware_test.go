@@ -0,0 +1,189 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mockRepository struct {
+	mu      sync.Mutex
+	records map[string]*Record
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{records: make(map[string]*Record)}
+}
+
+func recordKey(userID uint, key string) string {
+	return fmt.Sprintf("%d:%s", userID, key)
+}
+
+func (m *mockRepository) Claim(ctx context.Context, record *Record) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.records[recordKey(record.UserID, record.Key)]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	m.records[recordKey(record.UserID, record.Key)] = &copied
+	return nil, nil
+}
+
+func (m *mockRepository) Complete(ctx context.Context, record *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.records[recordKey(record.UserID, record.Key)]
+	if !ok {
+		return errors.New("record not found")
+	}
+	existing.StatusCode = record.StatusCode
+	existing.Headers = record.Headers
+	existing.Body = record.Body
+	return nil
+}
+
+func (m *mockRepository) Release(ctx context.Context, userID uint, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.records[recordKey(userID, key)]; ok && !existing.Completed() {
+		delete(m.records, recordKey(userID, key))
+	}
+	return nil
+}
+
+func (m *mockRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for key, record := range m.records {
+		if !record.ExpiresAt.After(now) {
+			delete(m.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestMiddleware(t *testing.T) {
+	repo := newMockRepository()
+	created := 0
+	failures := 1
+	release := make(chan struct{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	authenticated := func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(1))
+		if c.GetHeader("X-User") == "2" {
+			c.Set(middleware.UserIDKey, uint(2))
+		}
+	}
+	router.POST("/articles", authenticated, Middleware(repo, time.Hour), func(c *gin.Context) {
+		created++
+		c.Header("Location", "/articles/1")
+		c.JSON(http.StatusCreated, gin.H{"id": created})
+	})
+	router.POST("/flaky", authenticated, Middleware(repo, time.Hour), func(c *gin.Context) {
+		if failures > 0 {
+			failures--
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+	router.POST("/slow", authenticated, Middleware(repo, time.Hour), func(c *gin.Context) {
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	request := func(path, key, body string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(HeaderKey, key)
+		}
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		key          string
+		body         string
+		headers      []string
+		wantStatus   int
+		wantBody     string
+		wantReplayed bool
+		wantCreated  int
+	}{
+		{name: "First request", path: "/articles", key: "a", body: `{"title":"A"}`, wantStatus: http.StatusCreated, wantBody: `{"id":1}`, wantCreated: 1},
+		{name: "Retry is replayed", path: "/articles", key: "a", body: `{"title":"A"}`, wantStatus: http.StatusCreated, wantBody: `{"id":1}`, wantReplayed: true, wantCreated: 1},
+		{name: "Different body", path: "/articles", key: "a", body: `{"title":"B"}`, wantStatus: http.StatusUnprocessableEntity, wantCreated: 1},
+		{name: "Same key of another user", path: "/articles", key: "a", body: `{"title":"A"}`, headers: []string{"X-User", "2"}, wantStatus: http.StatusCreated, wantBody: `{"id":2}`, wantCreated: 2},
+		{name: "Without key", path: "/articles", body: `{"title":"A"}`, wantStatus: http.StatusCreated, wantBody: `{"id":3}`, wantCreated: 3},
+		{name: "Key too long", path: "/articles", key: strings.Repeat("k", MaxKeyLength+1), wantStatus: http.StatusBadRequest, wantCreated: 3},
+		{name: "Server error is not stored", path: "/flaky", key: "b", wantStatus: http.StatusInternalServerError, wantCreated: 3},
+		{name: "Retry after server error", path: "/flaky", key: "b", wantStatus: http.StatusCreated, wantBody: `{"ok":true}`, wantCreated: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(tt.path, tt.key, tt.body, tt.headers...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %s, got %s", tt.wantBody, w.Body.String())
+			}
+			if replayed := w.Header().Get(HeaderReplayed) == "true"; replayed != tt.wantReplayed {
+				t.Errorf("Expected replayed = %v, got %v", tt.wantReplayed, replayed)
+			}
+			if tt.wantReplayed && (w.Header().Get("Location") != "/articles/1" || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")) {
+				t.Errorf("Expected stored headers to be replayed, got %v", w.Header())
+			}
+			if created != tt.wantCreated {
+				t.Errorf("Expected %d articles created, got %d", tt.wantCreated, created)
+			}
+		})
+	}
+
+	t.Run("Concurrent retry", func(t *testing.T) {
+		done := make(chan *httptest.ResponseRecorder)
+		go func() {
+			done <- request("/slow", "c", "")
+		}()
+		for {
+			repo.mu.Lock()
+			_, claimed := repo.records[recordKey(1, "c")]
+			repo.mu.Unlock()
+			if claimed {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		if w := request("/slow", "c", ""); w.Code != http.StatusConflict {
+			t.Errorf("Expected status %d while the first request runs, got %d", http.StatusConflict, w.Code)
+		}
+		close(release)
+		if w := <-done; w.Code != http.StatusCreated {
+			t.Errorf("Expected status %d for the first request, got %d", http.StatusCreated, w.Code)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package idempotency
+
+import "time"
+
+type Record struct {
+	UserID      uint      `gorm:"primaryKey;autoIncrement:false"`
+	Key         string    `gorm:"column:idempotency_key;primaryKey;type:varchar(255)"`
+	RequestHash string    `gorm:"type:varchar(64);not null"`
+	StatusCode  int       `gorm:"not null;default:0"`
+	Headers     string    `gorm:"type:text;not null;default:''"`
+	Body        []byte    `gorm:"type:bytea"`
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,80 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Claim(ctx context.Context, record *Record) (*Record, error)
+	Complete(ctx context.Context, record *Record) error
+	Release(ctx context.Context, userID uint, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &idempotencyRepository{db: db}
+}
+
+func (repo *idempotencyRepository) Claim(ctx context.Context, record *Record) (*Record, error) {
+	var existing *Record
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND idempotency_key = ? AND expires_at <= ?", record.UserID, record.Key, record.CreatedAt).
+			Delete(&Record{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+
+		existing = &Record{}
+		return tx.Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).First(existing).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to claim idempotency key for user %d: %w", record.UserID, err)
+	}
+	return existing, nil
+}
+
+func (repo *idempotencyRepository) Complete(ctx context.Context, record *Record) error {
+	err := database.Conn(ctx, repo.db).Model(&Record{}).
+		Where("user_id = ? AND idempotency_key = ?", record.UserID, record.Key).
+		Updates(map[string]interface{}{
+			"status_code": record.StatusCode,
+			"headers":     record.Headers,
+			"body":        record.Body,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to store response for idempotency key of user %d: %w", record.UserID, err)
+	}
+	return nil
+}
+
+func (repo *idempotencyRepository) Release(ctx context.Context, userID uint, key string) error {
+	err := database.Conn(ctx, repo.db).Where("user_id = ? AND idempotency_key = ? AND status_code = 0", userID, key).Delete(&Record{}).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to release idempotency key of user %d: %w", userID, err)
+	}
+	return nil
+}
+
+func (repo *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := database.Conn(ctx, repo.db).Where("expires_at <= ?", now).Delete(&Record{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo: failed to delete expired idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
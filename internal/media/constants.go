@@ -0,0 +1,11 @@
+package media
+
+const (
+	MaxFilenameLength = 255
+	DefaultFilename   = "upload"
+	KeyPrefix         = "media/"
+	URLPrefix         = "/api/v1/media/"
+
+	MaxThumbnailSourcePixels = 25_000_000
+	ThumbnailJPEGQuality     = 85
+)
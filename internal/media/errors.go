@@ -0,0 +1,15 @@
+package media
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("media not found")
+	ErrArticleNotFound  = errors.New("article not found")
+	ErrLinkNotFound     = errors.New("media is not attached to this article")
+	ErrInUse            = errors.New("media is attached to one or more articles")
+	ErrForbidden        = errors.New("forbidden: you can only manage your own media")
+	ErrTooLarge         = errors.New("file is too large")
+	ErrUnsupportedType  = errors.New("unsupported media type")
+	ErrNotThumbnailable = errors.New("media cannot be thumbnailed")
+	ErrValidation       = errors.New("validation error")
+)
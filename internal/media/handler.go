@@ -0,0 +1,221 @@
+package media
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const MultipartOverhead = 1 << 20
+
+type Handler struct {
+	service  Service
+	maxBytes int64
+}
+
+func NewHandler(service Service, maxBytes int64) *Handler {
+	return &Handler{service: service, maxBytes: maxBytes}
+}
+
+type AttachMediaRequest struct {
+	MediaID uint `json:"media_id" validate:"required,min=1"`
+}
+
+func parseID(raw string) (uint, error) {
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:        http.StatusNotFound,
+	ErrArticleNotFound: http.StatusNotFound,
+	ErrLinkNotFound:    http.StatusNotFound,
+	ErrInUse:           http.StatusConflict,
+	ErrForbidden:       http.StatusForbidden,
+	ErrTooLarge:        http.StatusRequestEntityTooLarge,
+	ErrUnsupportedType: http.StatusUnsupportedMediaType,
+	ErrValidation:      http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) UploadMedia(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, handler.maxBytes+MultipartOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handler.handleError(c, ErrTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart field 'file' is required"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, handler.maxBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+
+	media, err := handler.service.Upload(c.Request.Context(), userID, UploadInput{
+		Filename: header.Filename,
+		Data:     data,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, media)
+}
+
+func (handler *Handler) GetMedia(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media ID"})
+		return
+	}
+
+	var size int
+	if raw := c.Query("size"); raw != "" {
+		size, err = strconv.Atoi(raw)
+		if err != nil || size < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "size must be a positive integer"})
+			return
+		}
+	}
+
+	content, err := handler.service.Open(c.Request.Context(), id, size)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	if content.Pending {
+		c.Header("Cache-Control", "no-cache")
+	} else {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	}
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": content.Filename}))
+	c.Data(http.StatusOK, content.ContentType, content.Data)
+}
+
+func (handler *Handler) DeleteMedia(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media ID"})
+		return
+	}
+
+	if err := handler.service.DeleteMedia(c.Request.Context(), getViewer(c), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ListArticleMedia(c *gin.Context) {
+	articleID, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	media, err := handler.service.ListArticleMedia(c.Request.Context(), getViewer(c), articleID)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": media})
+}
+
+func (handler *Handler) AttachMedia(c *gin.Context) {
+	articleID, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req AttachMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+	if req.MediaID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "media_id is required"})
+		return
+	}
+
+	media, err := handler.service.AttachMedia(c.Request.Context(), getViewer(c), articleID, req.MediaID)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, media)
+}
+
+func (handler *Handler) DetachMedia(c *gin.Context) {
+	articleID, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	mediaID, err := parseID(c.Param("media_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media ID"})
+		return
+	}
+
+	if err := handler.service.DetachMedia(c.Request.Context(), getViewer(c), articleID, mediaID); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
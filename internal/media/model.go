@@ -0,0 +1,36 @@
+package media
+
+import "time"
+
+type Media struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	UserID      uint              `gorm:"not null;index" json:"user_id"`
+	Filename    string            `gorm:"type:varchar(255);not null" json:"filename"`
+	ContentType string            `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64             `gorm:"not null" json:"size"`
+	StorageKey  string            `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	CreatedAt   time.Time         `json:"created_at"`
+	URL         string            `gorm:"-" json:"url"`
+	Thumbnails  map[string]string `gorm:"-" json:"thumbnails,omitempty"`
+}
+
+type Content struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+	Pending     bool
+}
+
+func (Media) TableName() string {
+	return "media"
+}
+
+type Link struct {
+	MediaID   uint `gorm:"primaryKey;autoIncrement:false"`
+	ArticleID uint `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+}
+
+func (Link) TableName() string {
+	return "article_media"
+}
@@ -0,0 +1,177 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, media *Media) error
+	GetByID(ctx context.Context, id uint) (*Media, error)
+	Delete(ctx context.Context, id uint) error
+	IsLinked(ctx context.Context, id uint) (bool, error)
+	ListForArticle(ctx context.Context, articleID uint) ([]Media, error)
+	Link(ctx context.Context, mediaID, articleID uint) error
+	Unlink(ctx context.Context, mediaID, articleID uint) error
+	UnlinkArticles(ctx context.Context, articleIDs []uint) ([]uint, error)
+	ArticleExists(ctx context.Context, articleID uint) (bool, error)
+	CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error)
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &mediaRepository{db: db}
+}
+
+func (repo *mediaRepository) Create(ctx context.Context, media *Media) error {
+	if err := database.Conn(ctx, repo.db).Create(media).Error; err != nil {
+		return fmt.Errorf("repo: failed to create media: %w", err)
+	}
+	return nil
+}
+
+func (repo *mediaRepository) GetByID(ctx context.Context, id uint) (*Media, error) {
+	var media Media
+	if err := database.Conn(ctx, repo.db).First(&media, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get media by id %d: %w", id, err)
+	}
+	return &media, nil
+}
+
+func (repo *mediaRepository) Delete(ctx context.Context, id uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", id).Delete(&Link{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Media{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to delete media %d: %w", id, err)
+	}
+	return nil
+}
+
+func (repo *mediaRepository) IsLinked(ctx context.Context, id uint) (bool, error) {
+	var count int64
+	if err := database.Conn(ctx, repo.db).Model(&Link{}).Where("media_id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("repo: failed to check links of media %d: %w", id, err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := database.Conn(ctx, repo.db).Unscoped().Model(&article.Article{}).Where("cover_media_id = ?", id).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("repo: failed to check covers using media %d: %w", id, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *mediaRepository) ListForArticle(ctx context.Context, articleID uint) ([]Media, error) {
+	var media []Media
+	err := database.Conn(ctx, repo.db).
+		Joins("JOIN article_media am ON am.media_id = media.id").
+		Where("am.article_id = ?", articleID).
+		Order("am.created_at ASC, media.id ASC").
+		Find(&media).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to list media of article %d: %w", articleID, err)
+	}
+	return media, nil
+}
+
+func (repo *mediaRepository) Link(ctx context.Context, mediaID, articleID uint) error {
+	link := Link{MediaID: mediaID, ArticleID: articleID}
+	if err := database.Conn(ctx, repo.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&link).Error; err != nil {
+		return fmt.Errorf("repo: failed to attach media %d to article %d: %w", mediaID, articleID, err)
+	}
+	return nil
+}
+
+func (repo *mediaRepository) Unlink(ctx context.Context, mediaID, articleID uint) error {
+	result := database.Conn(ctx, repo.db).Where("media_id = ? AND article_id = ?", mediaID, articleID).Delete(&Link{})
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to detach media %d from article %d: %w", mediaID, articleID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+func (repo *mediaRepository) UnlinkArticles(ctx context.Context, articleIDs []uint) ([]uint, error) {
+	var orphaned []uint
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var linked, covers []uint
+		if err := tx.Model(&Link{}).Distinct("media_id").Where("article_id IN ?", articleIDs).Pluck("media_id", &linked).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&article.Article{}).Where("id IN ? AND cover_media_id IS NOT NULL", articleIDs).Pluck("cover_media_id", &covers).Error; err != nil {
+			return err
+		}
+		linked = append(linked, covers...)
+		if len(linked) == 0 {
+			return nil
+		}
+		if err := tx.Where("article_id IN ?", articleIDs).Delete(&Link{}).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&Media{}).
+			Where("id IN ?", linked).
+			Where("NOT EXISTS (SELECT 1 FROM article_media am WHERE am.media_id = media.id)").
+			Where("NOT EXISTS (SELECT 1 FROM articles a WHERE a.cover_media_id = media.id AND a.id NOT IN ?)", articleIDs).
+			Pluck("id", &orphaned).Error
+		if err != nil || len(orphaned) == 0 {
+			return err
+		}
+		return tx.Table("articles").
+			Where("cover_media_id IN ?", orphaned).
+			Updates(map[string]interface{}{"cover_image_url": "", "cover_media_id": nil}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to detach media from articles: %w", err)
+	}
+	return orphaned, nil
+}
+
+func (repo *mediaRepository) ArticleExists(ctx context.Context, articleID uint) (bool, error) {
+	var count int64
+	if err := database.Conn(ctx, repo.db).Model(&article.Article{}).Where("id = ?", articleID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("repo: failed to check article %d: %w", articleID, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *mediaRepository) CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Table("articles a").
+		Joins("LEFT JOIN article_authors aa ON aa.article_id = a.id AND aa.user_id = ?", userID).
+		Where("a.id = ? AND a.deleted_at IS NULL", articleID).
+		Where("aa.role IN ? OR (aa.role IS NULL AND a.user_id = ?)", []string{article.AuthorRoleOwner, article.AuthorRoleEditor}, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to check access to article %d: %w", articleID, err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,347 @@
+package media
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+	"content-service/internal/shared/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UploadInput struct {
+	Filename string
+	Data     []byte
+}
+
+type Service interface {
+	Upload(ctx context.Context, userID uint, input UploadInput) (*Media, error)
+	Open(ctx context.Context, id uint, size int) (*Content, error)
+	DeleteMedia(ctx context.Context, viewer article.Viewer, id uint) error
+	ListArticleMedia(ctx context.Context, viewer article.Viewer, articleID uint) ([]Media, error)
+	AttachMedia(ctx context.Context, viewer article.Viewer, articleID, mediaID uint) (*Media, error)
+	DetachMedia(ctx context.Context, viewer article.Viewer, articleID, mediaID uint) error
+	CollectArticleMedia(ctx context.Context, articleIDs []uint) error
+	CoverImageURL(ctx context.Context, userID, mediaID uint) (string, bool, error)
+}
+
+type mediaService struct {
+	repo           Repository
+	store          storage.ObjectStore
+	maxBytes       int64
+	allowedTypes   []string
+	thumbnailSizes []int
+	thumbnails     *Thumbnailer
+	articles       ArticleCache
+}
+
+type ArticleCache interface {
+	Invalidate(ctx context.Context, ids ...uint)
+}
+
+type Option func(*mediaService)
+
+func WithThumbnailer(thumbnailer *Thumbnailer) Option {
+	return func(svc *mediaService) {
+		svc.thumbnails = thumbnailer
+	}
+}
+
+func WithArticleCache(articles ArticleCache) Option {
+	return func(svc *mediaService) {
+		svc.articles = articles
+	}
+}
+
+func NewService(repo Repository, store storage.ObjectStore, cfg config.MediaConfig, opts ...Option) Service {
+	svc := &mediaService{
+		repo:           repo,
+		store:          store,
+		maxBytes:       cfg.MaxBytes,
+		allowedTypes:   cfg.AllowedTypes,
+		thumbnailSizes: cfg.ThumbnailSizes,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func (svc *mediaService) withURL(media *Media) *Media {
+	media.URL = URLPrefix + strconv.FormatUint(uint64(media.ID), 10)
+	if _, ok := thumbnailType(media.ContentType); ok && svc.thumbnails != nil {
+		media.Thumbnails = make(map[string]string, len(svc.thumbnailSizes))
+		for _, size := range svc.thumbnailSizes {
+			media.Thumbnails[strconv.Itoa(size)] = media.URL + "?size=" + strconv.Itoa(size)
+		}
+	}
+	return media
+}
+
+func sanitizeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return DefaultFilename
+	}
+	if runes := []rune(name); len(runes) > MaxFilenameLength {
+		name = string(runes[:MaxFilenameLength])
+	}
+	return name
+}
+
+func detectContentType(data []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+func newStorageKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return KeyPrefix + hex.EncodeToString(buf), nil
+}
+
+func (svc *mediaService) Upload(ctx context.Context, userID uint, input UploadInput) (*Media, error) {
+	size := int64(len(input.Data))
+	if size == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrValidation)
+	}
+	if size > svc.maxBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, svc.maxBytes)
+	}
+
+	contentType := detectContentType(input.Data)
+	if !slices.Contains(svc.allowedTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	key, err := newStorageKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	if err := svc.store.PutObject(key, input.Data, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store media: %w", err)
+	}
+
+	media := &Media{
+		UserID:      userID,
+		Filename:    sanitizeFilename(input.Filename),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+	if err := svc.repo.Create(ctx, media); err != nil {
+		_ = svc.store.DeleteObject(key)
+		return nil, fmt.Errorf("failed to create media: %w", err)
+	}
+
+	if svc.thumbnails != nil && !svc.thumbnails.Enqueue(key, contentType) {
+		log.Warn().Uint("media_id", media.ID).Msg("Thumbnail queue is full, serving originals")
+	}
+	return svc.withURL(media), nil
+}
+
+func (svc *mediaService) Open(ctx context.Context, id uint, size int) (*Content, error) {
+	if size != 0 && (svc.thumbnails == nil || !slices.Contains(svc.thumbnailSizes, size)) {
+		return nil, fmt.Errorf("%w: size must be one of %v", ErrValidation, svc.thumbnailSizes)
+	}
+
+	media, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &Content{Filename: media.Filename, ContentType: media.ContentType}
+	if format, ok := thumbnailType(media.ContentType); ok && size != 0 {
+		data, err := svc.store.GetObject(ThumbnailKey(media.StorageKey, size))
+		switch {
+		case err == nil:
+			content.ContentType = format
+			content.Data = data
+			return content, nil
+		case !errors.Is(err, storage.ErrNotFound):
+			return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+		}
+		content.Pending = true
+	}
+
+	data, err := svc.store.GetObject(media.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read media: %w", err)
+	}
+	content.Data = data
+	return content, nil
+}
+
+func canManage(viewer article.Viewer, media *Media) bool {
+	return viewer.IsAdmin() || (viewer.UserID != 0 && viewer.UserID == media.UserID)
+}
+
+func (svc *mediaService) DeleteMedia(ctx context.Context, viewer article.Viewer, id uint) error {
+	media, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !canManage(viewer, media) {
+		return ErrForbidden
+	}
+
+	linked, err := svc.repo.IsLinked(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check media links: %w", err)
+	}
+	if linked {
+		return ErrInUse
+	}
+
+	return svc.remove(ctx, media)
+}
+
+func (svc *mediaService) remove(ctx context.Context, media *Media) error {
+	if err := svc.repo.Delete(ctx, media.ID); err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+	if err := svc.store.DeleteObject(media.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete media object: %w", err)
+	}
+	if _, ok := thumbnailType(media.ContentType); ok {
+		for _, size := range svc.thumbnailSizes {
+			if err := svc.store.DeleteObject(ThumbnailKey(media.StorageKey, size)); err != nil {
+				return fmt.Errorf("failed to delete thumbnail: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (svc *mediaService) checkArticle(ctx context.Context, viewer article.Viewer, articleID uint) error {
+	exists, err := svc.repo.ArticleExists(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to check article: %w", err)
+	}
+	if !exists {
+		return ErrArticleNotFound
+	}
+	if viewer.IsAdmin() {
+		return nil
+	}
+
+	allowed, err := svc.repo.CanEditArticle(ctx, articleID, viewer.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to check article: %w", err)
+	}
+	if !allowed {
+		return fmt.Errorf("%w: you cannot edit article %d", ErrForbidden, articleID)
+	}
+	return nil
+}
+
+func (svc *mediaService) ListArticleMedia(ctx context.Context, viewer article.Viewer, articleID uint) ([]Media, error) {
+	if err := svc.checkArticle(ctx, viewer, articleID); err != nil {
+		return nil, err
+	}
+
+	media, err := svc.repo.ListForArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article media: %w", err)
+	}
+	for i := range media {
+		svc.withURL(&media[i])
+	}
+	return media, nil
+}
+
+func (svc *mediaService) AttachMedia(ctx context.Context, viewer article.Viewer, articleID, mediaID uint) (*Media, error) {
+	if err := svc.checkArticle(ctx, viewer, articleID); err != nil {
+		return nil, err
+	}
+
+	media, err := svc.repo.GetByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(viewer, media) {
+		return nil, ErrForbidden
+	}
+
+	if err := svc.repo.Link(ctx, mediaID, articleID); err != nil {
+		return nil, fmt.Errorf("failed to attach media: %w", err)
+	}
+	return svc.withURL(media), nil
+}
+
+func (svc *mediaService) DetachMedia(ctx context.Context, viewer article.Viewer, articleID, mediaID uint) error {
+	if err := svc.checkArticle(ctx, viewer, articleID); err != nil {
+		return err
+	}
+	return svc.repo.Unlink(ctx, mediaID, articleID)
+}
+
+func (svc *mediaService) CoverImageURL(ctx context.Context, userID, mediaID uint) (string, bool, error) {
+	media, err := svc.repo.GetByID(ctx, mediaID)
+	if errors.Is(err, ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if media.UserID != userID || !strings.HasPrefix(media.ContentType, "image/") {
+		return "", false, nil
+	}
+	return svc.withURL(media).URL, true, nil
+}
+
+func (svc *mediaService) CollectArticleMedia(ctx context.Context, articleIDs []uint) error {
+	if len(articleIDs) == 0 {
+		return nil
+	}
+
+	orphaned, err := svc.repo.UnlinkArticles(ctx, articleIDs)
+	if err != nil {
+		return fmt.Errorf("failed to detach article media: %w", err)
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+	if svc.articles != nil {
+		svc.articles.Invalidate(ctx, articleIDs...)
+	}
+
+	database.AfterCommit(ctx, func() {
+		for _, id := range orphaned {
+			media, err := svc.repo.GetByID(ctx, id)
+			if err == nil {
+				err = svc.remove(ctx, media)
+			}
+			if err != nil {
+				log.Warn().Err(err).Uint("media_id", id).Msg("Failed to delete media of purged articles")
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,532 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/storage"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type linkKey struct {
+	mediaID   uint
+	articleID uint
+}
+
+type mockRepository struct {
+	media    map[uint]*Media
+	links    map[linkKey]bool
+	articles map[uint]uint
+	editors  map[uint][]uint
+	covers   map[uint]uint
+	deleted  map[uint]bool
+	nextID   uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		media:    make(map[uint]*Media),
+		links:    make(map[linkKey]bool),
+		articles: make(map[uint]uint),
+		editors:  make(map[uint][]uint),
+		covers:   make(map[uint]uint),
+		deleted:  make(map[uint]bool),
+		nextID:   1,
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, media *Media) error {
+	media.ID = m.nextID
+	m.nextID++
+	stored := *media
+	m.media[media.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Media, error) {
+	media, ok := m.media[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *media
+	return &copied, nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uint) error {
+	if _, ok := m.media[id]; !ok {
+		return ErrNotFound
+	}
+	for key := range m.links {
+		if key.mediaID == id {
+			delete(m.links, key)
+		}
+	}
+	delete(m.media, id)
+	return nil
+}
+
+func (m *mockRepository) IsLinked(ctx context.Context, id uint) (bool, error) {
+	for key := range m.links {
+		if key.mediaID == id {
+			return true, nil
+		}
+	}
+	for _, mediaID := range m.covers {
+		if mediaID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRepository) ListForArticle(ctx context.Context, articleID uint) ([]Media, error) {
+	var list []Media
+	for key := range m.links {
+		if key.articleID == articleID {
+			list = append(list, *m.media[key.mediaID])
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (m *mockRepository) Link(ctx context.Context, mediaID, articleID uint) error {
+	m.links[linkKey{mediaID, articleID}] = true
+	return nil
+}
+
+func (m *mockRepository) Unlink(ctx context.Context, mediaID, articleID uint) error {
+	key := linkKey{mediaID, articleID}
+	if !m.links[key] {
+		return ErrLinkNotFound
+	}
+	delete(m.links, key)
+	return nil
+}
+
+func (m *mockRepository) UnlinkArticles(ctx context.Context, articleIDs []uint) ([]uint, error) {
+	affected := make(map[uint]bool)
+	for _, articleID := range articleIDs {
+		m.deleted[articleID] = true
+		for key := range m.links {
+			if key.articleID == articleID {
+				affected[key.mediaID] = true
+				delete(m.links, key)
+			}
+		}
+		if mediaID, ok := m.covers[articleID]; ok {
+			affected[mediaID] = true
+		}
+	}
+
+	var orphaned []uint
+	for id := range affected {
+		if !m.usedByLiveArticle(id) {
+			orphaned = append(orphaned, id)
+		}
+	}
+	for articleID, mediaID := range m.covers {
+		if slices.Contains(orphaned, mediaID) {
+			delete(m.covers, articleID)
+		}
+	}
+	return orphaned, nil
+}
+
+func (m *mockRepository) usedByLiveArticle(id uint) bool {
+	for key := range m.links {
+		if key.mediaID == id {
+			return true
+		}
+	}
+	for articleID, mediaID := range m.covers {
+		if mediaID == id && !m.deleted[articleID] {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockRepository) ArticleExists(ctx context.Context, articleID uint) (bool, error) {
+	_, ok := m.articles[articleID]
+	return ok, nil
+}
+
+func (m *mockRepository) CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error) {
+	owner, ok := m.articles[articleID]
+	if !ok {
+		return false, nil
+	}
+	if owner == userID {
+		return true, nil
+	}
+	for _, editor := range m.editors[articleID] {
+		if editor == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newTestService(repo Repository, store storage.ObjectStore) Service {
+	return NewService(repo, store, config.MediaConfig{
+		MaxBytes:     64,
+		AllowedTypes: []string{"image/png", "application/pdf"},
+	})
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		wantErr  error
+		wantName string
+		wantType string
+	}{
+		{name: "PNG", filename: "diagram.png", data: pngHeader, wantName: "diagram.png", wantType: "image/png"},
+		{name: "PDF with path", filename: `C:\docs\report.pdf`, data: []byte("%PDF-1.7\n"), wantName: "report.pdf", wantType: "application/pdf"},
+		{name: "Missing filename", filename: "", data: pngHeader, wantName: DefaultFilename, wantType: "image/png"},
+		{name: "Empty file", filename: "empty.png", data: nil, wantErr: ErrValidation},
+		{name: "Too large", filename: "big.png", data: append(append([]byte{}, pngHeader...), make([]byte, 64)...), wantErr: ErrTooLarge},
+		{name: "Type from contents", filename: "fake.png", data: []byte("<html><body>hi</body></html>"), wantErr: ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			store := storage.NewMemoryObjectStore()
+			svc := newTestService(repo, store)
+
+			media, err := svc.Upload(context.Background(), 5, UploadInput{Filename: tt.filename, Data: tt.data})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				if store.Len() != 0 {
+					t.Errorf("Expected nothing stored, got %d objects", store.Len())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if media.Filename != tt.wantName || media.ContentType != tt.wantType {
+				t.Errorf("Expected %q (%s), got %q (%s)", tt.wantName, tt.wantType, media.Filename, media.ContentType)
+			}
+			if media.URL != "/api/v1/media/1" || media.Size != int64(len(tt.data)) || media.UserID != 5 {
+				t.Errorf("Unexpected media %+v", media)
+			}
+			if !strings.HasPrefix(media.StorageKey, KeyPrefix) {
+				t.Errorf("Expected storage key under %q, got %q", KeyPrefix, media.StorageKey)
+			}
+
+			content, err := svc.Open(context.Background(), media.ID, 0)
+			if err != nil {
+				t.Fatalf("Unexpected error opening media: %v", err)
+			}
+			if string(content.Data) != string(tt.data) || content.ContentType != tt.wantType {
+				t.Errorf("Expected stored contents to round-trip")
+			}
+		})
+	}
+}
+
+func TestAttachAndDeleteMedia(t *testing.T) {
+	repo := newMockRepository()
+	repo.articles[10] = 1
+	repo.articles[11] = 2
+	repo.editors[10] = []uint{3}
+	store := storage.NewMemoryObjectStore()
+	svc := newTestService(repo, store)
+
+	owner := article.Viewer{UserID: 1}
+	editor := article.Viewer{UserID: 3}
+	stranger := article.Viewer{UserID: 2}
+	admin := article.Viewer{UserID: 99, Role: auth.RoleAdmin}
+
+	uploaded, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "a.png", Data: pngHeader})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	editorUpload, err := svc.Upload(context.Background(), 3, UploadInput{Filename: "b.png", Data: pngHeader})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := svc.AttachMedia(context.Background(), stranger, 10, uploaded.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for a user who cannot edit the article, got %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), owner, 11, uploaded.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for someone else's article, got %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), owner, 10, editorUpload.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden for someone else's media, got %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), owner, 404, uploaded.ID); !errors.Is(err, ErrArticleNotFound) {
+		t.Errorf("Expected ErrArticleNotFound, got %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), owner, 10, uploaded.ID); err != nil {
+		t.Fatalf("Unexpected error attaching as owner: %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), editor, 10, editorUpload.ID); err != nil {
+		t.Fatalf("Unexpected error attaching as editor: %v", err)
+	}
+	if _, err := svc.AttachMedia(context.Background(), admin, 11, uploaded.ID); err != nil {
+		t.Fatalf("Unexpected error attaching as admin: %v", err)
+	}
+
+	list, err := svc.ListArticleMedia(context.Background(), editor, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].URL == "" {
+		t.Errorf("Expected 2 attached files with URLs, got %+v", list)
+	}
+
+	if err := svc.DeleteMedia(context.Background(), stranger, uploaded.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden deleting someone else's media, got %v", err)
+	}
+	if err := svc.DeleteMedia(context.Background(), owner, uploaded.ID); !errors.Is(err, ErrInUse) {
+		t.Errorf("Expected ErrInUse while attached, got %v", err)
+	}
+
+	if err := svc.DetachMedia(context.Background(), owner, 10, uploaded.ID); err != nil {
+		t.Fatalf("Unexpected error detaching: %v", err)
+	}
+	if err := svc.DetachMedia(context.Background(), owner, 10, uploaded.ID); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("Expected ErrLinkNotFound detaching twice, got %v", err)
+	}
+	if err := svc.DetachMedia(context.Background(), admin, 11, uploaded.ID); err != nil {
+		t.Fatalf("Unexpected error detaching as admin: %v", err)
+	}
+
+	if err := svc.DeleteMedia(context.Background(), owner, uploaded.ID); err != nil {
+		t.Fatalf("Unexpected error deleting: %v", err)
+	}
+	if _, err := svc.Open(context.Background(), uploaded.ID, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected 1 stored object left, got %d", store.Len())
+	}
+}
+
+func TestCollectArticleMedia(t *testing.T) {
+	repo := newMockRepository()
+	repo.articles[10] = 1
+	repo.articles[11] = 1
+	store := storage.NewMemoryObjectStore()
+	svc := newTestService(repo, store)
+	owner := article.Viewer{UserID: 1}
+
+	upload := func() uint {
+		media, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "a.png", Data: pngHeader})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return media.ID
+	}
+	only := upload()
+	shared := upload()
+	unattached := upload()
+
+	for _, link := range []linkKey{{only, 10}, {shared, 10}, {shared, 11}} {
+		if _, err := svc.AttachMedia(context.Background(), owner, link.articleID, link.mediaID); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if err := svc.CollectArticleMedia(context.Background(), []uint{10}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := repo.GetByID(context.Background(), only); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected media %d to be collected, got %v", only, err)
+	}
+	for _, id := range []uint{shared, unattached} {
+		if _, err := svc.Open(context.Background(), id, 0); err != nil {
+			t.Errorf("Expected media %d to be kept, got %v", id, err)
+		}
+	}
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 stored objects, got %d", store.Len())
+	}
+
+	list, err := svc.ListArticleMedia(context.Background(), owner, 11)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != shared {
+		t.Errorf("Expected the shared file to stay attached to article 11, got %+v", list)
+	}
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnails(t *testing.T) {
+	repo := newMockRepository()
+	store := storage.NewMemoryObjectStore()
+	thumbnailer := NewThumbnailer(store, []int{32, 500}, 10)
+	svc := NewService(repo, store, config.MediaConfig{
+		MaxBytes:       1 << 20,
+		AllowedTypes:   []string{"image/png", "application/pdf"},
+		ThumbnailSizes: []int{32, 500},
+	}, WithThumbnailer(thumbnailer))
+
+	photo, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "photo.png", Data: encodePNG(t, 200, 100)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if photo.Thumbnails["32"] != "/api/v1/media/1?size=32" || len(photo.Thumbnails) != 2 {
+		t.Errorf("Expected thumbnail URLs for both sizes, got %v", photo.Thumbnails)
+	}
+	doc, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "doc.pdf", Data: []byte("%PDF-1.7\n")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Thumbnails != nil {
+		t.Errorf("Expected no thumbnails for a PDF, got %v", doc.Thumbnails)
+	}
+
+	pending, err := svc.Open(context.Background(), photo.ID, 32)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !pending.Pending || len(pending.Data) != int(photo.Size) {
+		t.Errorf("Expected the original while the thumbnail is pending")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	thumbnailer.Start(ctx, 2)
+	cancel()
+	thumbnailer.Wait()
+
+	tests := []struct {
+		size       int
+		wantWidth  int
+		wantHeight int
+	}{
+		{size: 32, wantWidth: 32, wantHeight: 16},
+		{size: 500, wantWidth: 200, wantHeight: 100},
+	}
+	for _, tt := range tests {
+		content, err := svc.Open(ctx, photo.ID, tt.size)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if content.Pending || content.ContentType != "image/png" {
+			t.Fatalf("Expected a ready PNG thumbnail, got pending=%v type=%s", content.Pending, content.ContentType)
+		}
+		cfg, err := png.DecodeConfig(bytes.NewReader(content.Data))
+		if err != nil {
+			t.Fatalf("Failed to decode thumbnail: %v", err)
+		}
+		if cfg.Width != tt.wantWidth || cfg.Height != tt.wantHeight {
+			t.Errorf("Size %d: expected %dx%d, got %dx%d", tt.size, tt.wantWidth, tt.wantHeight, cfg.Width, cfg.Height)
+		}
+	}
+
+	if _, err := svc.Open(ctx, photo.ID, 64); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an unconfigured size, got %v", err)
+	}
+	content, err := svc.Open(ctx, doc.ID, 32)
+	if err != nil || content.Pending || content.ContentType != "application/pdf" {
+		t.Errorf("Expected the original PDF for a thumbnail request, got %v", err)
+	}
+
+	if err := svc.DeleteMedia(ctx, article.Viewer{UserID: 1}, photo.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected thumbnails to be deleted with the original, got %d objects", store.Len())
+	}
+}
+
+func TestCoverImages(t *testing.T) {
+	repo := newMockRepository()
+	repo.articles[10] = 1
+	repo.articles[11] = 1
+	store := storage.NewMemoryObjectStore()
+	svc := newTestService(repo, store)
+
+	image, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "cover.png", Data: pngHeader})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	doc, err := svc.Upload(context.Background(), 1, UploadInput{Filename: "doc.pdf", Data: []byte("%PDF-1.7\n")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  uint
+		mediaID uint
+		wantOK  bool
+	}{
+		{name: "Own image", userID: 1, mediaID: image.ID, wantOK: true},
+		{name: "Someone else's image", userID: 2, mediaID: image.ID},
+		{name: "Not an image", userID: 1, mediaID: doc.ID},
+		{name: "Missing", userID: 1, mediaID: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, ok, err := svc.CoverImageURL(context.Background(), tt.userID, tt.mediaID)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if ok != tt.wantOK || (ok && url != image.URL) {
+				t.Errorf("Expected ok=%v, got ok=%v url=%q", tt.wantOK, ok, url)
+			}
+		})
+	}
+
+	repo.covers[10] = image.ID
+	repo.covers[11] = image.ID
+	if err := svc.DeleteMedia(context.Background(), article.Viewer{UserID: 1}, image.ID); !errors.Is(err, ErrInUse) {
+		t.Errorf("Expected ErrInUse for a cover image, got %v", err)
+	}
+
+	if err := svc.CollectArticleMedia(context.Background(), []uint{10}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := repo.GetByID(context.Background(), image.ID); err != nil {
+		t.Errorf("Expected a cover still used by article 11 to be kept, got %v", err)
+	}
+	if err := svc.CollectArticleMedia(context.Background(), []uint{11}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := repo.GetByID(context.Background(), image.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the unused cover to be collected, got %v", err)
+	}
+	if len(repo.covers) != 0 {
+		t.Errorf("Expected covers of collected media to be cleared, got %v", repo.covers)
+	}
+}
@@ -0,0 +1,191 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"strconv"
+	"sync"
+
+	"content-service/internal/shared/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+func ThumbnailKey(key string, size int) string {
+	return key + "_" + strconv.Itoa(size)
+}
+
+func thumbnailType(contentType string) (string, bool) {
+	switch contentType {
+	case "image/jpeg":
+		return "image/jpeg", true
+	case "image/png", "image/gif":
+		return "image/png", true
+	default:
+		return "", false
+	}
+}
+
+type thumbnailJob struct {
+	key         string
+	contentType string
+}
+
+type Thumbnailer struct {
+	store storage.ObjectStore
+	sizes []int
+	jobs  chan thumbnailJob
+	wg    sync.WaitGroup
+}
+
+func NewThumbnailer(store storage.ObjectStore, sizes []int, queueSize int) *Thumbnailer {
+	return &Thumbnailer{
+		store: store,
+		sizes: sizes,
+		jobs:  make(chan thumbnailJob, queueSize),
+	}
+}
+
+func (thumbnailer *Thumbnailer) Enqueue(key, contentType string) bool {
+	if _, ok := thumbnailType(contentType); !ok || len(thumbnailer.sizes) == 0 {
+		return true
+	}
+
+	select {
+	case thumbnailer.jobs <- thumbnailJob{key: key, contentType: contentType}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (thumbnailer *Thumbnailer) Start(ctx context.Context, workers int) {
+	for range workers {
+		thumbnailer.wg.Add(1)
+		go thumbnailer.work(ctx)
+	}
+}
+
+func (thumbnailer *Thumbnailer) Wait() {
+	thumbnailer.wg.Wait()
+}
+
+func (thumbnailer *Thumbnailer) work(ctx context.Context) {
+	defer thumbnailer.wg.Done()
+
+	for {
+		select {
+		case job := <-thumbnailer.jobs:
+			thumbnailer.process(job)
+		case <-ctx.Done():
+			for {
+				select {
+				case job := <-thumbnailer.jobs:
+					thumbnailer.process(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (thumbnailer *Thumbnailer) process(job thumbnailJob) {
+	if err := thumbnailer.Generate(job.key, job.contentType); err != nil {
+		log.Error().Err(err).Str("key", job.key).Msg("Failed to generate thumbnails")
+	}
+}
+
+func (thumbnailer *Thumbnailer) Generate(key, contentType string) error {
+	format, ok := thumbnailType(contentType)
+	if !ok {
+		return ErrNotThumbnailable
+	}
+
+	data, err := thumbnailer.store.GetObject(key)
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotThumbnailable, err)
+	}
+	if config.Width*config.Height > MaxThumbnailSourcePixels {
+		return fmt.Errorf("%w: image is %dx%d", ErrNotThumbnailable, config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotThumbnailable, err)
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	for _, size := range thumbnailer.sizes {
+		thumb := downscale(rgba, size)
+
+		var buf bytes.Buffer
+		if format == "image/jpeg" {
+			err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: ThumbnailJPEGQuality})
+		} else {
+			err = png.Encode(&buf, thumb)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode %dpx thumbnail: %w", size, err)
+		}
+
+		if err := thumbnailer.store.PutObject(ThumbnailKey(key, size), buf.Bytes(), format); err != nil {
+			return fmt.Errorf("failed to store %dpx thumbnail: %w", size, err)
+		}
+	}
+	return nil
+}
+
+func downscale(src *image.RGBA, size int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	if srcW <= size && srcH <= size {
+		return src
+	}
+
+	dstW, dstH := size, size
+	if srcW >= srcH {
+		dstH = max(1, srcH*size/srcW)
+	} else {
+		dstW = max(1, srcW*size/srcH)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := range dstH {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := range dstW {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,145 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Service interface {
+	ModerationQueue(ctx context.Context, page, limit int) ([]article.Article, int64, error)
+	ApproveArticle(ctx context.Context, moderatorID, id uint) (*article.Article, error)
+	RejectArticle(ctx context.Context, moderatorID, id uint, reason string) (*article.Article, error)
+}
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type RejectRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=1000"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func parsePagination(c *gin.Context) (page, limit int) {
+	page = article.DefaultPage
+	limit = article.DefaultLimit
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = min(l, article.MaxLimit)
+	}
+	return page, limit
+}
+
+var errorToStatus = map[error]int{
+	article.ErrNotFound:   http.StatusNotFound,
+	article.ErrNotPending: http.StatusConflict,
+	article.ErrValidation: http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) GetQueue(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	articles, total, err := handler.service.ModerationQueue(c.Request.Context(), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": articles,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		},
+	})
+}
+
+func (handler *Handler) ApproveArticle(c *gin.Context) {
+	moderatorID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	approved, err := handler.service.ApproveArticle(c.Request.Context(), moderatorID, id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approved)
+}
+
+func (handler *Handler) RejectArticle(c *gin.Context) {
+	moderatorID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req RejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	rejected, err := handler.service.RejectArticle(c.Request.Context(), moderatorID, id, req.Reason)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rejected)
+}
@@ -0,0 +1,135 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeService struct {
+	pending map[uint]*article.Article
+}
+
+func (f *fakeService) ModerationQueue(ctx context.Context, page, limit int) ([]article.Article, int64, error) {
+	queue := make([]article.Article, 0, len(f.pending))
+	for _, pending := range f.pending {
+		queue = append(queue, *pending)
+	}
+	return queue, int64(len(queue)), nil
+}
+
+func (f *fakeService) review(moderatorID, id uint, status, note string) (*article.Article, error) {
+	pending, ok := f.pending[id]
+	if !ok {
+		return nil, article.ErrNotPending
+	}
+	delete(f.pending, id)
+	pending.Status = status
+	pending.ReviewNote = note
+	pending.ReviewedBy = &moderatorID
+	return pending, nil
+}
+
+func (f *fakeService) ApproveArticle(ctx context.Context, moderatorID, id uint) (*article.Article, error) {
+	return f.review(moderatorID, id, article.StatusPublished, "")
+}
+
+func (f *fakeService) RejectArticle(ctx context.Context, moderatorID, id uint, reason string) (*article.Article, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", article.ErrValidation)
+	}
+	return f.review(moderatorID, id, article.StatusRejected, reason)
+}
+
+func TestModerationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	service := &fakeService{pending: map[uint]*article.Article{
+		1: {ID: 1, Title: "First", Status: article.StatusPendingReview},
+		2: {ID: 2, Title: "Second", Status: article.StatusPendingReview},
+	}}
+	handler := NewHandler(service)
+
+	router := gin.New()
+	group := router.Group("/api/moderation", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uint(9))
+		c.Set(middleware.UserRoleKey, c.GetHeader("X-Test-Role"))
+	}, middleware.RequireRole(auth.RoleModerator, auth.RoleAdmin))
+	group.GET("/queue", handler.GetQueue)
+	group.POST("/:id/approve", handler.ApproveArticle)
+	group.POST("/:id/reject", handler.RejectArticle)
+
+	send := func(method, path, role, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-Role", role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodGet, "/api/moderation/queue", "", ""); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a regular user, got %d", http.StatusForbidden, w.Code)
+	}
+
+	w := send(http.MethodGet, "/api/moderation/queue?limit=500", auth.RoleModerator, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	var queue struct {
+		Data []article.Article `json:"data"`
+		Meta struct {
+			Limit int   `json:"limit"`
+			Total int64 `json:"total"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &queue); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(queue.Data) != 2 || queue.Meta.Total != 2 || queue.Meta.Limit != article.MaxLimit {
+		t.Errorf("Expected 2 queued articles with limit %d, got %d (total %d, limit %d)", article.MaxLimit, len(queue.Data), queue.Meta.Total, queue.Meta.Limit)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantNote   string
+	}{
+		{name: "Reject without reason", path: "/api/moderation/1/reject", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "Reject with reason", path: "/api/moderation/1/reject", body: `{"reason": "Off topic"}`, wantStatus: http.StatusOK, wantNote: "Off topic"},
+		{name: "Already reviewed", path: "/api/moderation/1/approve", wantStatus: http.StatusConflict},
+		{name: "Approve", path: "/api/moderation/2/approve", wantStatus: http.StatusOK},
+		{name: "Invalid ID", path: "/api/moderation/abc/approve", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := send(http.MethodPost, tt.path, auth.RoleAdmin, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var reviewed article.Article
+			if err := json.Unmarshal(w.Body.Bytes(), &reviewed); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if reviewed.ReviewNote != tt.wantNote || reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != 9 {
+				t.Errorf("Expected review by 9 with note %q, got %q", tt.wantNote, reviewed.ReviewNote)
+			}
+		})
+	}
+}
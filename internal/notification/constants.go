@@ -0,0 +1,24 @@
+package notification
+
+import "content-service/internal/article"
+
+const (
+	ProviderNone     = "none"
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+
+	MaxEmailLength   = 254
+	MaxResponseBytes = 64 << 10
+	MaxErrorBytes    = 1024
+
+	TemplateSubject = "subject"
+	TemplateText    = "text"
+	TemplateHTML    = "html"
+)
+
+var Events = []string{
+	article.EventArticleCreated,
+	article.EventArticleUpdated,
+	article.EventArticleDeleted,
+	article.EventArticlePublished,
+}
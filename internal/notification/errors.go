@@ -0,0 +1,9 @@
+package notification
+
+import "errors"
+
+var (
+	ErrValidation      = errors.New("validation error")
+	ErrTemplateMissing = errors.New("email template not found")
+	ErrSend            = errors.New("failed to send email")
+)
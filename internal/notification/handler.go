@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type UpdatePreferencesRequest struct {
+	Email        *string   `json:"email"`
+	EmailEnabled *bool     `json:"email_enabled"`
+	MutedEvents  *[]string `json:"muted_events"`
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, ErrValidation) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) GetPreferences(c *gin.Context) {
+	preference, err := handler.service.GetPreferences(c.Request.Context(), getViewer(c))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+func (handler *Handler) UpdatePreferences(c *gin.Context) {
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if req.Email != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email cannot be set, it is taken from the verified email of your token"})
+		return
+	}
+
+	preference, err := handler.service.UpdatePreferences(c.Request.Context(), getViewer(c), UpdateInput{
+		Email:        middleware.GetUserEmail(c),
+		EmailEnabled: req.EmailEnabled,
+		MutedEvents:  req.MutedEvents,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"slices"
+	"time"
+
+	"content-service/internal/article"
+)
+
+type Preference struct {
+	UserID       uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Email        string    `gorm:"type:varchar(254);not null;default:''" json:"email"`
+	EmailEnabled bool      `gorm:"not null;default:true" json:"email_enabled"`
+	MutedEvents  []string  `gorm:"type:text;serializer:json;not null" json:"muted_events"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (Preference) TableName() string {
+	return "notification_preferences"
+}
+
+func (p *Preference) Wants(event string) bool {
+	if p.Email == "" || !p.EmailEnabled {
+		return false
+	}
+	return !slices.Contains(p.MutedEvents, event)
+}
+
+type Message struct {
+	To      string
+	Event   string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type TemplateData struct {
+	Event      string
+	Article    article.EventData
+	ArticleURL string
+	SiteTitle  string
+	SiteURL    string
+	OccurredAt time.Time
+}
@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Queue struct {
+	mailer      Mailer
+	maxAttempts int
+	retryDelay  time.Duration
+	mu          sync.RWMutex
+	closed      bool
+	jobs        chan Message
+	wg          sync.WaitGroup
+}
+
+func NewQueue(mailer Mailer, cfg config.NotificationConfig) *Queue {
+	return &Queue{
+		mailer:      mailer,
+		maxAttempts: cfg.MaxAttempts,
+		retryDelay:  cfg.RetryDelay,
+		jobs:        make(chan Message, cfg.QueueSize),
+	}
+}
+
+func (queue *Queue) Enqueue(msg Message) bool {
+	queue.mu.RLock()
+	defer queue.mu.RUnlock()
+	if queue.closed {
+		return false
+	}
+
+	select {
+	case queue.jobs <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (queue *Queue) Start(workers int) {
+	for range workers {
+		queue.wg.Add(1)
+		go queue.work()
+	}
+}
+
+func (queue *Queue) Close(ctx context.Context) error {
+	queue.mu.Lock()
+	if !queue.closed {
+		queue.closed = true
+		close(queue.jobs)
+	}
+	queue.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		queue.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("notification: %d queued emails not sent: %w", len(queue.jobs), ctx.Err())
+	}
+}
+
+func (queue *Queue) work() {
+	defer queue.wg.Done()
+	for msg := range queue.jobs {
+		queue.deliver(msg)
+	}
+}
+
+func (queue *Queue) deliver(msg Message) {
+	delay := queue.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := queue.mailer.Send(context.Background(), msg)
+		if err == nil {
+			log.Info().Str("event", msg.Event).Str("to", msg.To).Int("attempt", attempt).Msg("Email sent")
+			return
+		}
+		if attempt >= queue.maxAttempts {
+			log.Error().Err(err).Str("event", msg.Event).Str("to", msg.To).Int("attempts", attempt).Msg("Failed to send email, giving up")
+			return
+		}
+		log.Warn().Err(err).Str("event", msg.Event).Str("to", msg.To).Int("attempt", attempt).Dur("retry_in", delay).Msg("Failed to send email, retrying")
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetPreference(ctx context.Context, userID uint) (*Preference, error)
+	SavePreference(ctx context.Context, preference *Preference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &notificationRepository{db: db}
+}
+
+func (repo *notificationRepository) GetPreference(ctx context.Context, userID uint) (*Preference, error) {
+	var preference Preference
+	if err := database.Conn(ctx, repo.db).First(&preference, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("repo: failed to get notification preferences of user %d: %w", userID, err)
+	}
+	return &preference, nil
+}
+
+func (repo *notificationRepository) SavePreference(ctx context.Context, preference *Preference) error {
+	err := database.Conn(ctx, repo.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "email_enabled", "muted_events", "updated_at"}),
+	}).Create(preference).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to save notification preferences of user %d: %w", preference.UserID, err)
+	}
+	return nil
+}
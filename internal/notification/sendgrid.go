@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+type SendGridMailer struct {
+	client *http.Client
+	cfg    config.SendGridConfig
+	from   *mail.Address
+}
+
+func NewSendGridMailer(cfg config.SendGridConfig, from *mail.Address, timeout time.Duration) *SendGridMailer {
+	return &SendGridMailer{client: &http.Client{Timeout: timeout}, cfg: cfg, from: from}
+}
+
+func (mailer *SendGridMailer) Send(ctx context.Context, msg Message) error {
+	request := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: mailer.from.Address, Name: mailer.from.Name},
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: msg.Text}},
+	}
+	if msg.HTML != "" {
+		request.Content = append(request.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, mailer.cfg.URL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+mailer.cfg.APIKey)
+
+	resp, err := mailer.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSend, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBytes))
+		return fmt.Errorf("%w: mail API returned %d: %s", ErrSend, resp.StatusCode, detail)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, MaxResponseBytes))
+	return nil
+}
@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/events"
+)
+
+type UpdateInput struct {
+	Email        string
+	EmailEnabled *bool
+	MutedEvents  *[]string
+}
+
+type Service interface {
+	GetPreferences(ctx context.Context, viewer article.Viewer) (*Preference, error)
+	UpdatePreferences(ctx context.Context, viewer article.Viewer, input UpdateInput) (*Preference, error)
+	HandleArticleEvent(event events.Event) error
+}
+
+type Sender interface {
+	Enqueue(msg Message) bool
+}
+
+type notificationService struct {
+	repo      Repository
+	templates *Templates
+	sender    Sender
+	site      config.FeedConfig
+}
+
+func NewService(repo Repository, templates *Templates, sender Sender, site config.FeedConfig) Service {
+	return &notificationService{repo: repo, templates: templates, sender: sender, site: site}
+}
+
+func validateEmail(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	if len(raw) > MaxEmailLength {
+		return "", fmt.Errorf("%w: email cannot exceed %d characters", ErrValidation, MaxEmailLength)
+	}
+	parsed, err := mail.ParseAddress(raw)
+	if err != nil || parsed.Address != raw {
+		return "", fmt.Errorf("%w: email must be a plain address like name@example.com", ErrValidation)
+	}
+	return raw, nil
+}
+
+func validateMutedEvents(muted []string) ([]string, error) {
+	normalized := make([]string, 0, len(muted))
+	for _, event := range muted {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("%w: muted event must be one of: %s", ErrValidation, strings.Join(Events, ", "))
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+func (svc *notificationService) GetPreferences(ctx context.Context, viewer article.Viewer) (*Preference, error) {
+	preference, err := svc.repo.GetPreference(ctx, viewer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if preference == nil {
+		preference = &Preference{UserID: viewer.UserID, EmailEnabled: true, MutedEvents: []string{}}
+	}
+	return preference, nil
+}
+
+func (svc *notificationService) UpdatePreferences(ctx context.Context, viewer article.Viewer, input UpdateInput) (*Preference, error) {
+	if input.EmailEnabled == nil && input.MutedEvents == nil {
+		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+	}
+	preference, err := svc.GetPreferences(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if preference.Email, err = validateEmail(input.Email); err != nil {
+		return nil, err
+	}
+	if input.EmailEnabled != nil {
+		preference.EmailEnabled = *input.EmailEnabled
+	}
+	if input.MutedEvents != nil {
+		if preference.MutedEvents, err = validateMutedEvents(*input.MutedEvents); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.repo.SavePreference(ctx, preference); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return preference, nil
+}
+
+func (svc *notificationService) HandleArticleEvent(event events.Event) error {
+	a, ok := event.Payload.(article.Article)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for event %s", event.Payload, event.Name)
+	}
+
+	preference, err := svc.repo.GetPreference(context.Background(), a.UserID)
+	if err != nil {
+		return err
+	}
+	if preference == nil || !preference.Wants(event.Name) {
+		return nil
+	}
+
+	msg, err := svc.templates.Render(TemplateData{
+		Event:      event.Name,
+		Article:    article.NewEventData(&a),
+		ArticleURL: strings.ReplaceAll(svc.site.ArticleURL, "{slug}", a.Slug),
+		SiteTitle:  svc.site.Title,
+		SiteURL:    svc.site.SiteURL,
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = preference.Email
+
+	if !svc.sender.Enqueue(msg) {
+		return fmt.Errorf("email queue is full or closed, dropped %s email for user %d", event.Name, a.UserID)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/events"
+)
+
+type mockRepository struct {
+	preferences map[uint]Preference
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{preferences: make(map[uint]Preference)}
+}
+
+func (m *mockRepository) GetPreference(ctx context.Context, userID uint) (*Preference, error) {
+	preference, ok := m.preferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &preference, nil
+}
+
+func (m *mockRepository) SavePreference(ctx context.Context, preference *Preference) error {
+	m.preferences[preference.UserID] = *preference
+	return nil
+}
+
+type recordingSender struct {
+	messages []Message
+}
+
+func (s *recordingSender) Enqueue(msg Message) bool {
+	s.messages = append(s.messages, msg)
+	return true
+}
+
+var testSite = config.FeedConfig{
+	Title:      "Example Blog",
+	SiteURL:    "https://blog.example.com",
+	ArticleURL: "https://blog.example.com/posts/{slug}",
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	service := NewService(newMockRepository(), nil, nil, testSite)
+	viewer := article.Viewer{UserID: 7}
+
+	current, err := service.GetPreferences(context.Background(), viewer)
+	if err != nil {
+		t.Fatalf("GetPreferences() unexpected error: %v", err)
+	}
+	if current.Email != "" || !current.EmailEnabled || current.MutedEvents == nil {
+		t.Errorf("Expected default preferences, got %+v", current)
+	}
+
+	tests := []struct {
+		name      string
+		input     UpdateInput
+		wantErr   error
+		wantEmail string
+		wantMuted []string
+	}{
+		{name: "No fields", input: UpdateInput{Email: "jane@example.com"}, wantErr: ErrValidation},
+		{name: "Email with display name", input: UpdateInput{Email: "Jane <jane@example.com>", EmailEnabled: boolPtr(true)}, wantErr: ErrValidation},
+		{name: "Invalid email", input: UpdateInput{Email: "not-an-email", EmailEnabled: boolPtr(true)}, wantErr: ErrValidation},
+		{name: "Unknown muted event", input: UpdateInput{MutedEvents: &[]string{"article.liked"}}, wantErr: ErrValidation},
+		{name: "Email is taken from the identity", input: UpdateInput{Email: " jane@example.com ", EmailEnabled: boolPtr(true)}, wantEmail: "jane@example.com", wantMuted: []string{}},
+		{
+			name:      "Muted events are normalized",
+			input:     UpdateInput{Email: "jane@example.com", MutedEvents: &[]string{"Article.Updated", article.EventArticleDeleted, article.EventArticleDeleted}},
+			wantEmail: "jane@example.com",
+			wantMuted: []string{article.EventArticleDeleted, article.EventArticleUpdated},
+		},
+		{name: "Identity without a verified email clears it", input: UpdateInput{EmailEnabled: boolPtr(true)}, wantMuted: []string{article.EventArticleDeleted, article.EventArticleUpdated}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preference, err := service.UpdatePreferences(context.Background(), viewer, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if preference.Email != tt.wantEmail || !slices.Equal(preference.MutedEvents, tt.wantMuted) {
+				t.Errorf("Expected email %q and muted %v, got %+v", tt.wantEmail, tt.wantMuted, preference)
+			}
+		})
+	}
+}
+
+func TestHandleArticleEvent(t *testing.T) {
+	templates, err := LoadTemplates("", Events)
+	if err != nil {
+		t.Fatalf("LoadTemplates() unexpected error: %v", err)
+	}
+	repo := newMockRepository()
+	repo.preferences[1] = Preference{UserID: 1, Email: "author@example.com", EmailEnabled: true, MutedEvents: []string{article.EventArticleDeleted}}
+	repo.preferences[2] = Preference{UserID: 2, Email: "off@example.com", EmailEnabled: false}
+	repo.preferences[3] = Preference{UserID: 3, EmailEnabled: true}
+	sender := &recordingSender{}
+	service := NewService(repo, templates, sender, testSite)
+
+	tests := []struct {
+		name     string
+		event    string
+		userID   uint
+		wantSent bool
+	}{
+		{name: "Published article", event: article.EventArticlePublished, userID: 1, wantSent: true},
+		{name: "Muted event", event: article.EventArticleDeleted, userID: 1},
+		{name: "Email disabled", event: article.EventArticlePublished, userID: 2},
+		{name: "No email address", event: article.EventArticlePublished, userID: 3},
+		{name: "No preferences", event: article.EventArticlePublished, userID: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender.messages = nil
+			payload := article.Article{ID: 9, UserID: tt.userID, Title: "Go <generics>", Slug: "go-generics", Status: article.StatusPublished}
+			if err := service.HandleArticleEvent(events.Event{Name: tt.event, Payload: payload, OccurredAt: time.Now()}); err != nil {
+				t.Fatalf("HandleArticleEvent() unexpected error: %v", err)
+			}
+			if (len(sender.messages) == 1) != tt.wantSent {
+				t.Fatalf("Expected sent = %v, got %d messages", tt.wantSent, len(sender.messages))
+			}
+			if !tt.wantSent {
+				return
+			}
+
+			msg := sender.messages[0]
+			if msg.To != "author@example.com" || msg.Subject != `Your article "Go <generics>" is published` {
+				t.Errorf("Unexpected recipient or subject: %q, %q", msg.To, msg.Subject)
+			}
+			if !strings.Contains(msg.Text, "https://blog.example.com/posts/go-generics") || !strings.Contains(msg.Text, "Go <generics>") {
+				t.Errorf("Expected the link and raw title in the text body, got %q", msg.Text)
+			}
+			if !strings.Contains(msg.HTML, "Go &lt;generics&gt;") {
+				t.Errorf("Expected an escaped title in the HTML body, got %q", msg.HTML)
+			}
+		})
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{{define "subject"}}Live: {{.Article.Title}}{{end}}{{define "text"}}{{.ArticleURL}}{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, article.EventArticlePublished+".tmpl"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken := `{{define "subject"}}Only a subject{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, article.EventArticleCreated+".tmpl"), []byte(broken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := LoadTemplates(dir, []string{article.EventArticlePublished, article.EventArticleDeleted})
+	if err != nil {
+		t.Fatalf("LoadTemplates() unexpected error: %v", err)
+	}
+	msg, err := templates.Render(TemplateData{Event: article.EventArticlePublished, Article: article.EventData{Title: "Hi"}, ArticleURL: "https://x/hi"})
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	if msg.Subject != "Live: Hi" || msg.Text != "https://x/hi\n" || msg.HTML != "" {
+		t.Errorf("Expected the override template to be used, got %+v", msg)
+	}
+	if msg, err := templates.Render(TemplateData{Event: article.EventArticleDeleted, Article: article.EventData{Title: "Hi"}}); err != nil || !strings.Contains(msg.Subject, "deleted") {
+		t.Errorf("Expected the built-in template as fallback, got %+v (%v)", msg, err)
+	}
+	if _, err := templates.Render(TemplateData{Event: article.EventArticleUpdated}); !errors.Is(err, ErrTemplateMissing) {
+		t.Errorf("Expected ErrTemplateMissing for an unloaded event, got %v", err)
+	}
+
+	if _, err := LoadTemplates(dir, []string{article.EventArticleCreated}); !errors.Is(err, ErrTemplateMissing) {
+		t.Errorf("Expected ErrTemplateMissing for a template without a text body, got %v", err)
+	}
+	if _, err := LoadTemplates("", []string{"article.liked"}); err == nil {
+		t.Error("Expected an error for an unknown event")
+	}
+}
+
+func TestSendGridMailer(t *testing.T) {
+	var got sendGridRequest
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.Subject == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	from := &mail.Address{Name: "Blog", Address: "no-reply@example.com"}
+	mailer := NewSendGridMailer(config.SendGridConfig{URL: server.URL, APIKey: "key"}, from, time.Second)
+
+	err := mailer.Send(context.Background(), Message{To: "jane@example.com", Subject: "Hello", Text: "text", HTML: "<p>html</p>"})
+	if err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if auth != "Bearer key" {
+		t.Errorf("Expected bearer authorization, got %q", auth)
+	}
+	if got.From.Email != "no-reply@example.com" || got.Personalizations[0].To[0].Email != "jane@example.com" || len(got.Content) != 2 {
+		t.Errorf("Unexpected request: %+v", got)
+	}
+
+	if err := mailer.Send(context.Background(), Message{To: "jane@example.com", Subject: "fail"}); !errors.Is(err, ErrSend) {
+		t.Errorf("Expected ErrSend, got %v", err)
+	}
+}
+
+type flakyMailer struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (m *flakyMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts++
+	if m.attempts <= m.failures {
+		return ErrSend
+	}
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		wantAttempts int
+	}{
+		{name: "Sent on the first attempt", wantAttempts: 1},
+		{name: "Retried until sent", failures: 2, wantAttempts: 3},
+		{name: "Gives up after max attempts", failures: 5, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailer := &flakyMailer{failures: tt.failures}
+			queue := NewQueue(mailer, config.NotificationConfig{QueueSize: 1, MaxAttempts: 3, RetryDelay: time.Millisecond})
+			queue.Start(1)
+			if !queue.Enqueue(Message{To: "jane@example.com"}) {
+				t.Fatal("Expected the message to be queued")
+			}
+			if err := queue.Close(context.Background()); err != nil {
+				t.Fatalf("Close() unexpected error: %v", err)
+			}
+			if mailer.attempts != tt.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, mailer.attempts)
+			}
+			if queue.Enqueue(Message{To: "jane@example.com"}) {
+				t.Error("Expected a closed queue to reject messages")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+const smtpsPort = 465
+
+type SMTPMailer struct {
+	cfg     config.SMTPConfig
+	from    *mail.Address
+	timeout time.Duration
+}
+
+func NewSMTPMailer(cfg config.SMTPConfig, from *mail.Address, timeout time.Duration) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg, from: from, timeout: timeout}
+}
+
+func (mailer *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	body, err := buildMIME(mailer.from, msg, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	addr := net.JoinHostPort(mailer.cfg.Host, strconv.Itoa(mailer.cfg.Port))
+	dialer := net.Dialer{Timeout: mailer.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%w: failed to connect to %s: %v", ErrSend, addr, err)
+	}
+	tlsConfig := &tls.Config{ServerName: mailer.cfg.Host}
+	if mailer.cfg.Port == smtpsPort {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	_ = conn.SetDeadline(time.Now().Add(mailer.timeout))
+
+	client, err := smtp.NewClient(conn, mailer.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("%w: %s: %v", ErrSend, addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && mailer.cfg.Port != smtpsPort {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("%w: STARTTLS: %v", ErrSend, err)
+		}
+	}
+	if mailer.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", mailer.cfg.Username, mailer.cfg.Password, mailer.cfg.Host)); err != nil {
+			return fmt.Errorf("%w: authentication failed: %v", ErrSend, err)
+		}
+	}
+	if err := client.Mail(mailer.from.Address); err != nil {
+		return fmt.Errorf("%w: MAIL FROM: %v", ErrSend, err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("%w: RCPT TO: %v", ErrSend, err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("%w: DATA: %v", ErrSend, err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("%w: DATA: %v", ErrSend, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("%w: DATA: %v", ErrSend, err)
+	}
+	return client.Quit()
+}
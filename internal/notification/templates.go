@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+type eventTemplates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type Templates struct {
+	events map[string]eventTemplates
+}
+
+func LoadTemplates(dir string, events []string) (*Templates, error) {
+	templates := &Templates{events: make(map[string]eventTemplates, len(events))}
+	for _, event := range events {
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("unknown event %q: must be one of: %s", event, strings.Join(Events, ", "))
+		}
+		source, err := readTemplate(dir, event+".tmpl")
+		if err != nil {
+			return nil, err
+		}
+
+		text, err := texttemplate.New(event).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of %s: %w", event, err)
+		}
+		if text.Lookup(TemplateSubject) == nil || text.Lookup(TemplateText) == nil {
+			return nil, fmt.Errorf("%w: template of %s must define %q and %q", ErrTemplateMissing, event, TemplateSubject, TemplateText)
+		}
+		loaded := eventTemplates{text: text}
+		if text.Lookup(TemplateHTML) != nil {
+			if loaded.html, err = htmltemplate.New(event).Option("missingkey=error").Parse(source); err != nil {
+				return nil, fmt.Errorf("failed to parse HTML template of %s: %w", event, err)
+			}
+		}
+		templates.events[event] = loaded
+	}
+	return templates, nil
+}
+
+func readTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		source, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(source), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read template %s: %w", name, err)
+		}
+	}
+	source, err := defaultTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrTemplateMissing, name)
+	}
+	return string(source), nil
+}
+
+func (templates *Templates) Render(data TemplateData) (Message, error) {
+	loaded, ok := templates.events[data.Event]
+	if !ok {
+		return Message{}, fmt.Errorf("%w: %s", ErrTemplateMissing, data.Event)
+	}
+
+	var subject, text bytes.Buffer
+	if err := loaded.text.ExecuteTemplate(&subject, TemplateSubject, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render subject of %s: %w", data.Event, err)
+	}
+	if err := loaded.text.ExecuteTemplate(&text, TemplateText, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render text of %s: %w", data.Event, err)
+	}
+	msg := Message{
+		Event:   data.Event,
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}
+
+	if loaded.html != nil {
+		var html bytes.Buffer
+		if err := loaded.html.ExecuteTemplate(&html, TemplateHTML, data); err != nil {
+			return Message{}, fmt.Errorf("failed to render HTML of %s: %w", data.Event, err)
+		}
+		msg.HTML = strings.TrimSpace(html.String())
+	}
+	return msg, nil
+}
@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/hamba/avro/v2"
+)
+
+const articleEventSchema = `{
+  "type": "record",
+  "name": "ArticleEvent",
+  "namespace": "content_service",
+  "fields": [
+    {"name": "event_id", "type": "string"},
+    {"name": "event", "type": "string"},
+    {"name": "occurred_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "article", "type": {
+      "type": "record",
+      "name": "Article",
+      "fields": [
+        {"name": "id", "type": "long"},
+        {"name": "user_id", "type": "long"},
+        {"name": "title", "type": "string"},
+        {"name": "slug", "type": "string"},
+        {"name": "status", "type": "string"},
+        {"name": "language", "type": "string"},
+        {"name": "category_id", "type": ["null", "long"], "default": null},
+        {"name": "tags", "type": {"type": "array", "items": "string"}},
+        {"name": "excerpt", "type": "string"},
+        {"name": "created_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+        {"name": "updated_at", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+      ]
+    }}
+  ]
+}`
+
+var ArticleEventSchema = avro.MustParse(articleEventSchema)
+
+type avroArticle struct {
+	ID         int64     `avro:"id"`
+	UserID     int64     `avro:"user_id"`
+	Title      string    `avro:"title"`
+	Slug       string    `avro:"slug"`
+	Status     string    `avro:"status"`
+	Language   string    `avro:"language"`
+	CategoryID *int64    `avro:"category_id"`
+	Tags       []string  `avro:"tags"`
+	Excerpt    string    `avro:"excerpt"`
+	CreatedAt  time.Time `avro:"created_at"`
+	UpdatedAt  time.Time `avro:"updated_at"`
+}
+
+type avroEvent struct {
+	EventID    string      `avro:"event_id"`
+	Event      string      `avro:"event"`
+	OccurredAt time.Time   `avro:"occurred_at"`
+	Article    avroArticle `avro:"article"`
+}
+
+func encodeAvro(event Event) ([]byte, error) {
+	a := event.Article
+	record := avroEvent{
+		EventID:    event.EventID,
+		Event:      event.Event,
+		OccurredAt: event.OccurredAt,
+		Article: avroArticle{
+			ID:        int64(a.ID),
+			UserID:    int64(a.UserID),
+			Title:     a.Title,
+			Slug:      a.Slug,
+			Status:    a.Status,
+			Language:  a.Language,
+			Tags:      make([]string, 0, len(a.Tags)),
+			Excerpt:   a.Excerpt,
+			CreatedAt: a.CreatedAt,
+			UpdatedAt: a.UpdatedAt,
+		},
+	}
+	if a.CategoryID != nil {
+		categoryID := int64(*a.CategoryID)
+		record.Article.CategoryID = &categoryID
+	}
+	for _, tag := range a.Tags {
+		record.Article.Tags = append(record.Article.Tags, tag.Name)
+	}
+	return avro.Marshal(ArticleEventSchema, record)
+}
@@ -0,0 +1,28 @@
+package outbox
+
+import "time"
+
+const (
+	BusNone  = "none"
+	BusKafka = "kafka"
+	BusNATS  = "nats"
+
+	FormatJSON = "json"
+	FormatAvro = "avro"
+
+	ContentTypeJSON = "application/json"
+	ContentTypeAvro = "application/avro"
+
+	HeaderEvent       = "event"
+	HeaderEventID     = "event_id"
+	HeaderContentType = "content-type"
+	HeaderNATSMsgID   = "Nats-Msg-Id"
+
+	EventIDBytes = 16
+
+	RelayLockKey    = 0x6f7574626f78
+	RelayMaxBackoff = 5 * time.Minute
+
+	NATSClientName   = "content-service-outbox-relay"
+	NATSDrainTimeout = 30 * time.Second
+)
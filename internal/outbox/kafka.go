@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"context"
+
+	"content-service/internal/shared/config"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type KafkaPublisher struct {
+	writer kafkaWriter
+}
+
+func NewKafkaPublisher(cfg config.KafkaConfig) *KafkaPublisher {
+	return &KafkaPublisher{writer: &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}}
+}
+
+func (publisher *KafkaPublisher) Publish(ctx context.Context, records []Record) error {
+	messages := make([]kafka.Message, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, kafka.Message{
+			Topic: record.Topic,
+			Key:   []byte(record.Key),
+			Value: record.Value,
+			Headers: []kafka.Header{
+				{Key: HeaderEvent, Value: []byte(record.Event)},
+				{Key: HeaderEventID, Value: []byte(record.EventID)},
+				{Key: HeaderContentType, Value: []byte(record.ContentType)},
+			},
+			Time: record.Time,
+		})
+	}
+	return publisher.writer.WriteMessages(ctx, messages...)
+}
+
+func (publisher *KafkaPublisher) Health() error {
+	return nil
+}
+
+func (publisher *KafkaPublisher) Close() error {
+	return publisher.writer.Close()
+}
@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"time"
+
+	"content-service/internal/article"
+)
+
+type Message struct {
+	ID        uint      `gorm:"primaryKey"`
+	EventID   string    `gorm:"type:varchar(64);not null"`
+	Topic     string    `gorm:"type:varchar(255);not null"`
+	Key       string    `gorm:"type:varchar(255);not null"`
+	Event     string    `gorm:"type:varchar(64);not null"`
+	Payload   string    `gorm:"type:text;not null"`
+	Attempts  int       `gorm:"not null;default:0"`
+	LastError string    `gorm:"type:text;not null;default:''"`
+	CreatedAt time.Time `gorm:"not null"`
+	DeadAt    *time.Time
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+type Event struct {
+	EventID    string            `json:"event_id"`
+	Event      string            `json:"event"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Article    article.EventData `json:"article"`
+}
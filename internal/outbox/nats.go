@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/rs/zerolog/log"
+)
+
+type NATSPublisher struct {
+	conn   *nats.Conn
+	js     jetstream.JetStream
+	cfg    config.NATSConfig
+	closed chan struct{}
+
+	mu          sync.Mutex
+	streamReady bool
+}
+
+func NewNATSPublisher(cfg config.NATSConfig) (*NATSPublisher, error) {
+	closed := make(chan struct{})
+	conn, err := nats.Connect(cfg.URL,
+		nats.Name(NATSClientName),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(cfg.ReconnectWait),
+		nats.DrainTimeout(NATSDrainTimeout),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			log.Warn().Err(err).Msg("Disconnected from NATS")
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			log.Info().Str("url", conn.ConnectedUrl()).Msg("Reconnected to NATS")
+		}),
+		nats.ClosedHandler(func(*nats.Conn) {
+			close(closed)
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return &NATSPublisher{conn: conn, js: js, cfg: cfg, closed: closed}, nil
+}
+
+func (publisher *NATSPublisher) ensureStream(ctx context.Context) error {
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	if publisher.streamReady {
+		return nil
+	}
+
+	_, err := publisher.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     publisher.cfg.Stream,
+		Subjects: []string{publisher.cfg.Subject + ".>"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set up stream %s: %w", publisher.cfg.Stream, err)
+	}
+	publisher.streamReady = true
+	return nil
+}
+
+func natsMessage(record Record) *nats.Msg {
+	msg := nats.NewMsg(record.Topic + "." + record.Event)
+	msg.Data = record.Value
+	msg.Header.Set(HeaderNATSMsgID, record.EventID)
+	msg.Header.Set(HeaderEvent, record.Event)
+	msg.Header.Set(HeaderEventID, record.EventID)
+	msg.Header.Set(HeaderContentType, record.ContentType)
+	return msg
+}
+
+func (publisher *NATSPublisher) Publish(ctx context.Context, records []Record) error {
+	if err := publisher.ensureStream(ctx); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if _, err := publisher.js.PublishMsg(ctx, natsMessage(record)); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", record.EventID, err)
+		}
+	}
+	return nil
+}
+
+func (publisher *NATSPublisher) Health() error {
+	if status := publisher.conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("nats connection is %s", status)
+	}
+	return nil
+}
+
+func (publisher *NATSPublisher) Close() error {
+	if err := publisher.conn.Drain(); err != nil {
+		publisher.conn.Close()
+	}
+	select {
+	case <-publisher.closed:
+		return nil
+	case <-time.After(NATSDrainTimeout + time.Second):
+		return fmt.Errorf("timed out draining the NATS connection")
+	}
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+type Record struct {
+	Topic       string
+	Key         string
+	Event       string
+	EventID     string
+	ContentType string
+	Value       []byte
+	Time        time.Time
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, records []Record) error
+	Health() error
+	Close() error
+}
+
+func NewPublisher(cfg config.OutboxConfig) (Publisher, error) {
+	switch cfg.Bus {
+	case BusKafka:
+		return NewKafkaPublisher(cfg.Kafka), nil
+	case BusNATS:
+		return NewNATSPublisher(cfg.NATS)
+	default:
+		return nil, fmt.Errorf("no publisher for event bus %q", cfg.Bus)
+	}
+}
@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrUnavailable = errors.New("event bus unavailable")
+
+type Status struct {
+	Bus         string     `json:"bus"`
+	Healthy     bool       `json:"healthy"`
+	Error       string     `json:"error,omitempty"`
+	LastRelayAt *time.Time `json:"last_relay_at,omitempty"`
+}
+
+type Relay struct {
+	repo      Repository
+	publisher Publisher
+	cfg       config.OutboxConfig
+
+	mu          sync.Mutex
+	lastErr     error
+	lastRelayAt *time.Time
+}
+
+func NewRelay(repo Repository, publisher Publisher, cfg config.OutboxConfig) *Relay {
+	return &Relay{repo: repo, publisher: publisher, cfg: cfg}
+}
+
+func (relay *Relay) Run(ctx context.Context) {
+	failures := 0
+	for {
+		for {
+			n, err := relay.RelayOnce(context.WithoutCancel(ctx))
+			if err != nil {
+				failures++
+				log.Error().Err(err).Int("failures", failures).Msg("Failed to relay outbox messages")
+			} else {
+				failures = 0
+			}
+			if err != nil || n < relay.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relay.backoff(failures)):
+		}
+	}
+}
+
+func (relay *Relay) backoff(failures int) time.Duration {
+	delay := relay.cfg.PollInterval
+	for i := 1; i < failures && delay < RelayMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, RelayMaxBackoff)
+}
+
+func (relay *Relay) RelayOnce(ctx context.Context) (int, error) {
+	n, err := relay.repo.Process(ctx, relay.cfg.BatchSize, func(messages []Message) (int, error) {
+		return relay.send(ctx, messages)
+	})
+
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+	relay.lastErr = err
+	if err == nil {
+		now := time.Now()
+		relay.lastRelayAt = &now
+	}
+	return n, err
+}
+
+func (relay *Relay) Status() Status {
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+
+	status := Status{Bus: relay.cfg.Bus, Healthy: true, LastRelayAt: relay.lastRelayAt}
+	err := relay.publisher.Health()
+	if err == nil {
+		err = relay.lastErr
+	}
+	if err != nil {
+		status.Healthy = false
+		status.Error = err.Error()
+	}
+	return status
+}
+
+func (relay *Relay) Check(ctx context.Context) error {
+	status := relay.Status()
+	if !status.Healthy {
+		return errors.New(status.Error)
+	}
+	return nil
+}
+
+func (relay *Relay) send(ctx context.Context, messages []Message) (int, error) {
+	records := make([]Record, 0, len(messages))
+	var encodeErr error
+	for _, message := range messages {
+		record, err := relay.encode(message)
+		if err != nil {
+			encodeErr = fmt.Errorf("message %d: %w", message.ID, err)
+			break
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return 0, encodeErr
+	}
+
+	err := relay.publisher.Publish(ctx, records)
+	if err == nil {
+		return len(records), encodeErr
+	}
+	if healthErr := relay.publisher.Health(); healthErr != nil {
+		return 0, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	if len(records) == 1 {
+		return 0, fmt.Errorf("message %d: %w", messages[0].ID, err)
+	}
+	for i := range records {
+		if err := relay.publisher.Publish(ctx, records[i:i+1]); err != nil {
+			return i, fmt.Errorf("message %d: %w", messages[i].ID, err)
+		}
+	}
+	return len(records), encodeErr
+}
+
+func (relay *Relay) encode(message Message) (Record, error) {
+	record := Record{
+		Topic:       message.Topic,
+		Key:         message.Key,
+		Event:       message.Event,
+		EventID:     message.EventID,
+		ContentType: ContentTypeJSON,
+		Value:       []byte(message.Payload),
+		Time:        message.CreatedAt,
+	}
+	if relay.cfg.Format == FormatAvro {
+		var event Event
+		if err := json.Unmarshal(record.Value, &event); err != nil {
+			return Record{}, fmt.Errorf("failed to decode payload: %w", err)
+		}
+		encoded, err := encodeAvro(event)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to encode avro: %w", err)
+		}
+		record.Value = encoded
+		record.ContentType = ContentTypeAvro
+	}
+	return record, nil
+}
@@ -0,0 +1,389 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/database"
+
+	"github.com/hamba/avro/v2"
+	"github.com/segmentio/kafka-go"
+)
+
+type mockRepository struct {
+	messages []Message
+}
+
+func (m *mockRepository) Process(ctx context.Context, limit int, fn func(messages []Message) (int, error)) (int, error) {
+	batch := m.messages[:min(limit, len(m.messages))]
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	n, err := fn(slices.Clone(batch))
+	m.messages = m.messages[n:]
+	return n, err
+}
+
+type fakePublisher struct {
+	published []Record
+	err       error
+	healthErr error
+	rejected  uint
+}
+
+func (f *fakePublisher) Publish(ctx context.Context, records []Record) error {
+	if f.err != nil {
+		return f.err
+	}
+	for _, record := range records {
+		if record.Key == strconv.FormatUint(uint64(f.rejected), 10) {
+			return errors.New("message too large")
+		}
+	}
+	f.published = append(f.published, records...)
+	return nil
+}
+
+func (f *fakePublisher) Health() error {
+	return f.healthErr
+}
+
+func (f *fakePublisher) Close() error {
+	return nil
+}
+
+type fakeKafkaWriter struct {
+	written []kafka.Message
+}
+
+func (f *fakeKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	f.written = append(f.written, msgs...)
+	return nil
+}
+
+func (f *fakeKafkaWriter) Close() error {
+	return nil
+}
+
+func newMessage(t *testing.T, id uint, event string) Message {
+	t.Helper()
+	categoryID := uint(4)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	payload, err := json.Marshal(Event{
+		EventID:    "evt-1",
+		Event:      event,
+		OccurredAt: created,
+		Article: article.NewEventData(&article.Article{
+			ID: 7, UserID: 1, Title: "Hello", Slug: "hello", Status: article.StatusPublished,
+			CategoryID: &categoryID, Tags: []article.Tag{{Name: "go"}}, CreatedAt: created, UpdatedAt: created,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode event: %v", err)
+	}
+	return Message{ID: id, EventID: "evt-1", Topic: "articles", Key: "7", Event: event, Payload: string(payload), CreatedAt: created}
+}
+
+func kafkaHeader(message kafka.Message, key string) string {
+	for _, h := range message.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestRelayOnce(t *testing.T) {
+	repo := &mockRepository{}
+	for i := uint(1); i <= 3; i++ {
+		repo.messages = append(repo.messages, newMessage(t, i, article.EventArticleCreated))
+	}
+	publisher := &fakePublisher{err: errors.New("broker unavailable")}
+	relay := NewRelay(repo, publisher, config.OutboxConfig{Bus: BusKafka, Format: FormatJSON, BatchSize: 2})
+
+	if _, err := relay.RelayOnce(context.Background()); err == nil {
+		t.Fatal("Expected an error when the broker is unavailable")
+	}
+	if len(repo.messages) != 3 {
+		t.Errorf("Expected messages to stay in the outbox after a failed write, got %d", len(repo.messages))
+	}
+	if status := relay.Status(); status.Healthy || status.Error == "" {
+		t.Errorf("Expected an unhealthy status after a failed write, got %+v", status)
+	}
+
+	publisher.err = nil
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("Expected 2 relayed messages, got %d (%v)", n, err)
+	}
+	if n, _ := relay.RelayOnce(context.Background()); n != 1 {
+		t.Errorf("Expected 1 relayed message, got %d", n)
+	}
+	if len(repo.messages) != 0 || len(publisher.published) != 3 {
+		t.Fatalf("Expected all messages relayed, got %d left and %d published", len(repo.messages), len(publisher.published))
+	}
+	if status := relay.Status(); !status.Healthy || status.LastRelayAt == nil {
+		t.Errorf("Expected a healthy status after relaying, got %+v", status)
+	}
+
+	record := publisher.published[0]
+	if record.Topic != "articles" || record.Key != "7" || record.EventID != "evt-1" {
+		t.Errorf("Unexpected topic %q, key %q or event ID %q", record.Topic, record.Key, record.EventID)
+	}
+	if record.Event != article.EventArticleCreated || record.ContentType != ContentTypeJSON {
+		t.Errorf("Unexpected event %q or content type %q", record.Event, record.ContentType)
+	}
+	var event Event
+	if err := json.Unmarshal(record.Value, &event); err != nil || event.Article.Slug != "hello" {
+		t.Errorf("Expected the JSON payload to be sent as-is, got %s (%v)", record.Value, err)
+	}
+}
+
+func TestRelayIsolatesFailingMessages(t *testing.T) {
+	broken := newMessage(t, 3, article.EventArticleCreated)
+	broken.Payload = "not json"
+
+	tests := []struct {
+		name      string
+		format    string
+		messages  []Message
+		publisher *fakePublisher
+		wantSent  int
+		wantErr   error
+		wantLeft  uint
+	}{
+		{name: "Rejected message", format: FormatJSON, publisher: &fakePublisher{rejected: 2}, wantSent: 1, wantLeft: 2},
+		{name: "Undecodable payload", format: FormatAvro, publisher: &fakePublisher{}, wantSent: 2, wantLeft: 3},
+		{name: "Unreachable broker", format: FormatJSON, publisher: &fakePublisher{err: errors.New("dial tcp: connection refused"), healthErr: errors.New("nats connection is RECONNECTING")}, wantErr: ErrUnavailable, wantLeft: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{}
+			for i := uint(1); i <= 2; i++ {
+				message := newMessage(t, i, article.EventArticleCreated)
+				message.Key = strconv.FormatUint(uint64(i), 10)
+				repo.messages = append(repo.messages, message)
+			}
+			repo.messages = append(repo.messages, broken)
+			relay := NewRelay(repo, tt.publisher, config.OutboxConfig{Bus: BusNATS, Format: tt.format, BatchSize: 10})
+
+			n, err := relay.RelayOnce(context.Background())
+			if n != tt.wantSent || err == nil {
+				t.Fatalf("Expected %d sent messages and an error, got %d (%v)", tt.wantSent, n, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if repo.messages[0].ID != tt.wantLeft {
+				t.Errorf("Expected message %d to be left at the head of the outbox, got %d", tt.wantLeft, repo.messages[0].ID)
+			}
+		})
+	}
+}
+
+func TestRepositoryDeadLetters(t *testing.T) {
+	db, err := database.ConnectDB(&config.Config{
+		Environment: "test",
+		DB: config.DBConfig{
+			Driver:          database.DriverSQLite,
+			SQLitePath:      ":memory:",
+			ConnectAttempts: 1,
+			RetryAttempts:   1,
+			RetryBackoffMin: time.Millisecond,
+			RetryBackoffMax: time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to open SQLite: %v", err)
+	}
+	if err := db.AutoMigrate(&Message{}); err != nil {
+		t.Fatalf("Failed to migrate SQLite: %v", err)
+	}
+	for i := uint(1); i <= 3; i++ {
+		message := newMessage(t, i, article.EventArticleCreated)
+		if err := db.Create(&message).Error; err != nil {
+			t.Fatalf("Failed to create message: %v", err)
+		}
+	}
+
+	repo := NewRepository(db, 2)
+	poison := errors.New("message too large")
+	var seen [][]uint
+	process := func(sent int, cause error) {
+		t.Helper()
+		_, err := repo.Process(context.Background(), 10, func(messages []Message) (int, error) {
+			ids := make([]uint, 0, len(messages))
+			for _, message := range messages {
+				ids = append(ids, message.ID)
+			}
+			seen = append(seen, ids)
+			return sent, cause
+		})
+		if !errors.Is(err, cause) {
+			t.Fatalf("Expected %v, got %v", cause, err)
+		}
+	}
+
+	process(1, poison)
+	process(0, fmt.Errorf("%w: connection refused", ErrUnavailable))
+	process(0, poison)
+	process(1, nil)
+
+	want := [][]uint{{1, 2, 3}, {2, 3}, {2, 3}, {3}}
+	if !slices.EqualFunc(seen, want, slices.Equal) {
+		t.Errorf("Expected batches %v, got %v", want, seen)
+	}
+
+	var dead Message
+	if err := db.First(&dead, 2).Error; err != nil {
+		t.Fatalf("Expected message 2 to be kept as a dead letter: %v", err)
+	}
+	if dead.Attempts != 2 || dead.DeadAt == nil || dead.LastError != poison.Error() {
+		t.Errorf("Expected 2 attempts, a dead letter timestamp and the last error, got %+v", dead)
+	}
+	var left int64
+	db.Model(&Message{}).Count(&left)
+	if left != 1 {
+		t.Errorf("Expected only the dead letter to stay in the outbox, got %d messages", left)
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(&mockRepository{}, &fakePublisher{}, config.OutboxConfig{PollInterval: time.Second})
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: time.Second},
+		{failures: 1, want: time.Second},
+		{failures: 3, want: 4 * time.Second},
+		{failures: 30, want: RelayMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := relay.backoff(tt.failures); got != tt.want {
+			t.Errorf("backoff(%d): expected %v, got %v", tt.failures, tt.want, got)
+		}
+	}
+}
+
+func TestRelayAvro(t *testing.T) {
+	repo := &mockRepository{messages: []Message{newMessage(t, 1, article.EventArticlePublished)}}
+	publisher := &fakePublisher{}
+	relay := NewRelay(repo, publisher, config.OutboxConfig{Bus: BusNATS, Format: FormatAvro, BatchSize: 10})
+
+	if _, err := relay.RelayOnce(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	record := publisher.published[0]
+	if record.ContentType != ContentTypeAvro {
+		t.Errorf("Expected content type %q, got %q", ContentTypeAvro, record.ContentType)
+	}
+
+	var decoded avroEvent
+	if err := avro.Unmarshal(ArticleEventSchema, record.Value, &decoded); err != nil {
+		t.Fatalf("Failed to decode avro: %v", err)
+	}
+	if decoded.Event != article.EventArticlePublished || decoded.Article.ID != 7 || decoded.Article.Slug != "hello" {
+		t.Errorf("Unexpected decoded event: %+v", decoded)
+	}
+	if decoded.Article.CategoryID == nil || *decoded.Article.CategoryID != 4 || !slices.Equal(decoded.Article.Tags, []string{"go"}) {
+		t.Errorf("Unexpected category or tags: %+v", decoded.Article)
+	}
+	if !decoded.OccurredAt.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected occurred_at: %v", decoded.OccurredAt)
+	}
+}
+
+func TestPublisherMessages(t *testing.T) {
+	record := Record{
+		Topic:       "articles",
+		Key:         "7",
+		Event:       article.EventArticleDeleted,
+		EventID:     "evt-1",
+		ContentType: ContentTypeJSON,
+		Value:       []byte(`{}`),
+	}
+
+	writer := &fakeKafkaWriter{}
+	kafkaPublisher := &KafkaPublisher{writer: writer}
+	if err := kafkaPublisher.Publish(context.Background(), []Record{record}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	message := writer.written[0]
+	if message.Topic != "articles" || string(message.Key) != "7" || string(message.Value) != "{}" {
+		t.Errorf("Unexpected Kafka message: %+v", message)
+	}
+	if kafkaHeader(message, HeaderEvent) != article.EventArticleDeleted || kafkaHeader(message, HeaderEventID) != "evt-1" {
+		t.Errorf("Unexpected Kafka headers: %v", message.Headers)
+	}
+
+	msg := natsMessage(record)
+	if msg.Subject != "articles.article.deleted" {
+		t.Errorf("Expected subject articles.article.deleted, got %q", msg.Subject)
+	}
+	if msg.Header.Get(HeaderNATSMsgID) != "evt-1" || msg.Header.Get(HeaderContentType) != ContentTypeJSON {
+		t.Errorf("Unexpected NATS headers: %v", msg.Header)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	publisher := &fakePublisher{}
+	relay := NewRelay(&mockRepository{}, publisher, config.OutboxConfig{Bus: BusNATS, BatchSize: 10})
+	handler := HealthHandler(relay)
+
+	tests := []struct {
+		name       string
+		healthErr  error
+		wantStatus int
+	}{
+		{name: "Connected", wantStatus: http.StatusOK},
+		{name: "Disconnected", healthErr: errors.New("nats connection is RECONNECTING"), wantStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher.healthErr = tt.healthErr
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			var status Status
+			if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil || status.Bus != BusNATS {
+				t.Errorf("Unexpected body %s (%v)", w.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestRelayCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		publisher *fakePublisher
+		wantErr   bool
+	}{
+		{name: "Healthy", publisher: &fakePublisher{}},
+		{name: "Publisher disconnected", publisher: &fakePublisher{healthErr: errors.New("kafka unavailable")}, wantErr: true},
+		{name: "Last relay failed", publisher: &fakePublisher{err: errors.New("broker unavailable")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepository{messages: []Message{newMessage(t, 1, "article.created")}}
+			relay := NewRelay(repo, tt.publisher, config.OutboxConfig{Bus: BusKafka, BatchSize: 10})
+			_, _ = relay.RelayOnce(context.Background())
+
+			err := relay.Check(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
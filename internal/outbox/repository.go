@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Process(ctx context.Context, limit int, fn func(messages []Message) (int, error)) (int, error)
+}
+
+type outboxRepository struct {
+	db          *gorm.DB
+	maxAttempts int
+}
+
+func NewRepository(db *gorm.DB, maxAttempts int) Repository {
+	return &outboxRepository{db: db, maxAttempts: maxAttempts}
+}
+
+func (repo *outboxRepository) Process(ctx context.Context, limit int, fn func(messages []Message) (int, error)) (int, error) {
+	var sent int
+	var failed error
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if !database.IsSQLite(tx) {
+			var locked bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", RelayLockKey).Scan(&locked).Error; err != nil || !locked {
+				return err
+			}
+		}
+
+		var messages []Message
+		err := tx.Where("dead_at IS NULL").
+			Order("id ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		sent, failed = fn(messages)
+		if sent > 0 {
+			ids := make([]uint, 0, sent)
+			for _, message := range messages[:sent] {
+				ids = append(ids, message.ID)
+			}
+			if err := tx.Delete(&Message{}, ids).Error; err != nil {
+				return err
+			}
+		}
+		if failed == nil || sent >= len(messages) || errors.Is(failed, ErrUnavailable) {
+			return nil
+		}
+		return repo.fail(tx, &messages[sent], failed)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to relay outbox messages: %w", err)
+	}
+	return sent, failed
+}
+
+func (repo *outboxRepository) fail(tx *gorm.DB, message *Message, cause error) error {
+	updates := map[string]interface{}{
+		"attempts":   message.Attempts + 1,
+		"last_error": cause.Error(),
+	}
+	if message.Attempts+1 >= repo.maxAttempts {
+		updates["dead_at"] = time.Now()
+	}
+	return tx.Model(&Message{}).Where("id = ?", message.ID).Updates(updates).Error
+}
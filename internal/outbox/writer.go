@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"content-service/internal/article"
+
+	"gorm.io/gorm"
+)
+
+type Writer struct {
+	topic string
+}
+
+func NewWriter(topic string) *Writer {
+	return &Writer{topic: topic}
+}
+
+func (writer *Writer) Add(tx *gorm.DB, event string, a *article.Article) error {
+	buf := make([]byte, EventIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate event id: %w", err)
+	}
+	eventID := hex.EncodeToString(buf)
+
+	payload, err := json.Marshal(Event{
+		EventID:    eventID,
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Article:    article.NewEventData(a),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	return tx.Create(&Message{
+		EventID: eventID,
+		Topic:   writer.topic,
+		Key:     strconv.FormatUint(uint64(a.ID), 10),
+		Event:   event,
+		Payload: string(payload),
+	}).Error
+}
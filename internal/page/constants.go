@@ -0,0 +1,7 @@
+package page
+
+const (
+	MaxTitleLength   = 255
+	MaxSlugLength    = 100
+	MaxContentLength = 200000
+)
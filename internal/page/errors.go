@@ -0,0 +1,10 @@
+package page
+
+import "errors"
+
+var (
+	ErrNotFound   = errors.New("page not found")
+	ErrSlugTaken  = errors.New("slug already in use")
+	ErrForbidden  = errors.New("forbidden: only admins can manage pages")
+	ErrValidation = errors.New("validation error")
+)
@@ -0,0 +1,158 @@
+package page
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type CreatePageRequest struct {
+	Title   string `json:"title" validate:"required,min=1,max=255"`
+	Slug    string `json:"slug" validate:"omitempty,max=100"`
+	Content string `json:"content" validate:"required,min=1"`
+	Status  string `json:"status" validate:"omitempty,oneof=draft published"`
+}
+
+type UpdatePageRequest struct {
+	Title   *string `json:"title" validate:"omitempty,min=1,max=255"`
+	Slug    *string `json:"slug" validate:"omitempty,min=1,max=100"`
+	Content *string `json:"content" validate:"omitempty,min=1"`
+	Status  *string `json:"status" validate:"omitempty,oneof=draft published"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:   http.StatusNotFound,
+	ErrSlugTaken:  http.StatusConflict,
+	ErrForbidden:  http.StatusForbidden,
+	ErrValidation: http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) CreatePage(c *gin.Context) {
+	var req CreatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	page, err := handler.service.CreatePage(c.Request.Context(), getViewer(c), CreateInput{
+		Title:   req.Title,
+		Slug:    req.Slug,
+		Content: req.Content,
+		Status:  req.Status,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, page)
+}
+
+func (handler *Handler) GetPage(c *gin.Context) {
+	page, err := handler.service.GetPage(c.Request.Context(), getViewer(c), c.Param("slug"))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (handler *Handler) ListPages(c *gin.Context) {
+	pages, err := handler.service.ListPages(c.Request.Context(), getViewer(c), c.Query("status"))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": pages})
+}
+
+func (handler *Handler) UpdatePage(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page ID"})
+		return
+	}
+
+	var req UpdatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	page, err := handler.service.UpdatePage(c.Request.Context(), getViewer(c), id, UpdateInput{
+		Title:   req.Title,
+		Slug:    req.Slug,
+		Content: req.Content,
+		Status:  req.Status,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (handler *Handler) DeletePage(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page ID"})
+		return
+	}
+
+	if err := handler.service.DeletePage(c.Request.Context(), getViewer(c), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,20 @@
+package page
+
+import "time"
+
+type Page struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	Slug        string     `gorm:"type:varchar(100);not null;uniqueIndex" json:"slug"`
+	Content     string     `gorm:"type:text;not null" json:"content,omitempty"`
+	Status      string     `gorm:"type:varchar(20);not null;default:draft;index" json:"status"`
+	CreatedBy   uint       `gorm:"not null" json:"created_by"`
+	UpdatedBy   uint       `gorm:"not null" json:"updated_by"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (Page) TableName() string {
+	return "pages"
+}
@@ -0,0 +1,103 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, page *Page) error
+	GetByID(ctx context.Context, id uint) (*Page, error)
+	GetBySlug(ctx context.Context, slug string) (*Page, error)
+	List(ctx context.Context, statuses []string) ([]Page, error)
+	SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error)
+	Update(ctx context.Context, page *Page) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type pageRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &pageRepository{db: db}
+}
+
+func (repo *pageRepository) Create(ctx context.Context, page *Page) error {
+	if err := database.Conn(ctx, repo.db).Create(page).Error; err != nil {
+		return fmt.Errorf("repo: failed to create page: %w", err)
+	}
+	return nil
+}
+
+func (repo *pageRepository) GetByID(ctx context.Context, id uint) (*Page, error) {
+	var page Page
+	if err := database.Conn(ctx, repo.db).First(&page, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get page by id %d: %w", id, err)
+	}
+	return &page, nil
+}
+
+func (repo *pageRepository) GetBySlug(ctx context.Context, slug string) (*Page, error) {
+	var page Page
+	if err := database.Conn(ctx, repo.db).Where("slug = ?", slug).First(&page).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get page by slug %q: %w", slug, err)
+	}
+	return &page, nil
+}
+
+func (repo *pageRepository) List(ctx context.Context, statuses []string) ([]Page, error) {
+	var pages []Page
+	query := database.Conn(ctx, repo.db).Omit("content").Order("title ASC, id ASC")
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to list pages: %w", err)
+	}
+	return pages, nil
+}
+
+func (repo *pageRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Model(&Page{}).Where("slug = ? AND id <> ?", slug, excludeID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to check slug %q: %w", slug, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *pageRepository) Update(ctx context.Context, page *Page) error {
+	result := database.Conn(ctx, repo.db).Model(page).
+		Select("title", "slug", "content", "status", "updated_by", "published_at").
+		Updates(page)
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to update page %d: %w", page.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (repo *pageRepository) Delete(ctx context.Context, id uint) error {
+	result := database.Conn(ctx, repo.db).Delete(&Page{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to delete page %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+)
+
+type CreateInput struct {
+	Title   string
+	Slug    string
+	Content string
+	Status  string
+}
+
+type UpdateInput struct {
+	Title   *string
+	Slug    *string
+	Content *string
+	Status  *string
+}
+
+type Service interface {
+	CreatePage(ctx context.Context, viewer article.Viewer, input CreateInput) (*Page, error)
+	GetPage(ctx context.Context, viewer article.Viewer, slug string) (*Page, error)
+	ListPages(ctx context.Context, viewer article.Viewer, status string) ([]Page, error)
+	UpdatePage(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Page, error)
+	DeletePage(ctx context.Context, viewer article.Viewer, id uint) error
+}
+
+type pageService struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func NewService(repo Repository) Service {
+	return &pageService{repo: repo, now: time.Now}
+}
+
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("%w: title is required", ErrValidation)
+	}
+	if len(title) > MaxTitleLength {
+		return "", fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
+	}
+	return title, nil
+}
+
+func validateSlug(slug string) error {
+	if slug == "" {
+		return fmt.Errorf("%w: slug is required", ErrValidation)
+	}
+	if article.Slugify(slug) != slug {
+		return fmt.Errorf("%w: slug must contain only lowercase letters, digits and single dashes", ErrValidation)
+	}
+	return nil
+}
+
+func validateContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("%w: content is required", ErrValidation)
+	}
+	if len(content) > MaxContentLength {
+		return fmt.Errorf("%w: content cannot exceed %d bytes", ErrValidation, MaxContentLength)
+	}
+	return nil
+}
+
+func validateStatus(status string) error {
+	switch status {
+	case article.StatusDraft, article.StatusPublished:
+		return nil
+	default:
+		return fmt.Errorf("%w: status must be one of: %s, %s", ErrValidation, article.StatusDraft, article.StatusPublished)
+	}
+}
+
+func (svc *pageService) checkSlug(ctx context.Context, slug string, excludeID uint) error {
+	taken, err := svc.repo.SlugTaken(ctx, slug, excludeID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("%w: %s", ErrSlugTaken, slug)
+	}
+	return nil
+}
+
+func (svc *pageService) markPublished(page *Page) {
+	if page.Status == article.StatusPublished && page.PublishedAt == nil {
+		now := svc.now()
+		page.PublishedAt = &now
+	}
+}
+
+func (svc *pageService) CreatePage(ctx context.Context, viewer article.Viewer, input CreateInput) (*Page, error) {
+	if !viewer.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	title, err := validateTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+	slug := input.Slug
+	if slug == "" {
+		slug = article.Slugify(title)
+	}
+	if err := validateSlug(slug); err != nil {
+		return nil, err
+	}
+	if err := validateContent(input.Content); err != nil {
+		return nil, err
+	}
+	status := input.Status
+	if status == "" {
+		status = article.StatusDraft
+	}
+	if err := validateStatus(status); err != nil {
+		return nil, err
+	}
+	if err := svc.checkSlug(ctx, slug, 0); err != nil {
+		return nil, err
+	}
+
+	page := &Page{
+		Title:     title,
+		Slug:      slug,
+		Content:   input.Content,
+		Status:    status,
+		CreatedBy: viewer.UserID,
+		UpdatedBy: viewer.UserID,
+	}
+	svc.markPublished(page)
+	if err := svc.repo.Create(ctx, page); err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+	return page, nil
+}
+
+func (svc *pageService) GetPage(ctx context.Context, viewer article.Viewer, slug string) (*Page, error) {
+	page, err := svc.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if page.Status != article.StatusPublished && !viewer.IsAdmin() {
+		return nil, ErrNotFound
+	}
+	return page, nil
+}
+
+func (svc *pageService) ListPages(ctx context.Context, viewer article.Viewer, status string) ([]Page, error) {
+	statuses := []string{article.StatusPublished}
+	if viewer.IsAdmin() {
+		switch status {
+		case "":
+			statuses = nil
+		default:
+			if err := validateStatus(status); err != nil {
+				return nil, err
+			}
+			statuses = []string{status}
+		}
+	}
+
+	pages, err := svc.repo.List(ctx, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	return pages, nil
+}
+
+func (svc *pageService) UpdatePage(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Page, error) {
+	if !viewer.IsAdmin() {
+		return nil, ErrForbidden
+	}
+
+	page, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		title, err := validateTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		page.Title = title
+	}
+	if input.Slug != nil && *input.Slug != page.Slug {
+		if err := validateSlug(*input.Slug); err != nil {
+			return nil, err
+		}
+		if err := svc.checkSlug(ctx, *input.Slug, page.ID); err != nil {
+			return nil, err
+		}
+		page.Slug = *input.Slug
+	}
+	if input.Content != nil {
+		if err := validateContent(*input.Content); err != nil {
+			return nil, err
+		}
+		page.Content = *input.Content
+	}
+	if input.Status != nil {
+		if err := validateStatus(*input.Status); err != nil {
+			return nil, err
+		}
+		page.Status = *input.Status
+	}
+	page.UpdatedBy = viewer.UserID
+	svc.markPublished(page)
+
+	if err := svc.repo.Update(ctx, page); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update page: %w", err)
+	}
+	return svc.repo.GetByID(ctx, id)
+}
+
+func (svc *pageService) DeletePage(ctx context.Context, viewer article.Viewer, id uint) error {
+	if !viewer.IsAdmin() {
+		return ErrForbidden
+	}
+	return svc.repo.Delete(ctx, id)
+}
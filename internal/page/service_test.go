@@ -0,0 +1,271 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+)
+
+type mockRepository struct {
+	pages  map[uint]*Page
+	nextID uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		pages:  make(map[uint]*Page),
+		nextID: 1,
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, page *Page) error {
+	page.ID = m.nextID
+	m.nextID++
+	stored := *page
+	m.pages[page.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Page, error) {
+	page, ok := m.pages[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *page
+	return &copied, nil
+}
+
+func (m *mockRepository) GetBySlug(ctx context.Context, slug string) (*Page, error) {
+	for _, page := range m.pages {
+		if page.Slug == slug {
+			copied := *page
+			return &copied, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) List(ctx context.Context, statuses []string) ([]Page, error) {
+	pages := make([]Page, 0, len(m.pages))
+	for _, page := range m.pages {
+		if len(statuses) > 0 && !slices.Contains(statuses, page.Status) {
+			continue
+		}
+		pages = append(pages, *page)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].Title < pages[j].Title
+	})
+	return pages, nil
+}
+
+func (m *mockRepository) SlugTaken(ctx context.Context, slug string, excludeID uint) (bool, error) {
+	for _, page := range m.pages {
+		if page.Slug == slug && page.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, page *Page) error {
+	if _, ok := m.pages[page.ID]; !ok {
+		return ErrNotFound
+	}
+	stored := *page
+	m.pages[page.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uint) error {
+	if _, ok := m.pages[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.pages, id)
+	return nil
+}
+
+var (
+	admin  = article.Viewer{UserID: 1, Role: auth.RoleAdmin}
+	author = article.Viewer{UserID: 2, Role: "user"}
+	guest  = article.Viewer{}
+)
+
+func TestCreatePage(t *testing.T) {
+	tests := []struct {
+		name      string
+		viewer    article.Viewer
+		input     CreateInput
+		wantSlug  string
+		wantError error
+	}{
+		{name: "Slug generated from title", viewer: admin, input: CreateInput{Title: "About Us", Content: "Hello"}, wantSlug: "about-us"},
+		{name: "Explicit slug", viewer: admin, input: CreateInput{Title: "Terms of Service", Slug: "terms", Content: "Rules"}, wantSlug: "terms"},
+		{name: "Slug already taken", viewer: admin, input: CreateInput{Title: "About", Slug: "about-us", Content: "Again"}, wantError: ErrSlugTaken},
+		{name: "Malformed slug", viewer: admin, input: CreateInput{Title: "Privacy", Slug: "Privacy Policy", Content: "Data"}, wantError: ErrValidation},
+		{name: "Missing title", viewer: admin, input: CreateInput{Title: "  ", Content: "Body"}, wantError: ErrValidation},
+		{name: "Missing content", viewer: admin, input: CreateInput{Title: "Contact"}, wantError: ErrValidation},
+		{name: "Invalid status", viewer: admin, input: CreateInput{Title: "Contact", Content: "Mail", Status: "archived"}, wantError: ErrValidation},
+		{name: "Author cannot create", viewer: author, input: CreateInput{Title: "Contact", Content: "Mail"}, wantError: ErrForbidden},
+	}
+
+	svc := NewService(newMockRepository())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := svc.CreatePage(context.Background(), tt.viewer, tt.input)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("Expected error %v, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if page.Slug != tt.wantSlug {
+				t.Errorf("Expected slug %q, got %q", tt.wantSlug, page.Slug)
+			}
+			if page.Status != article.StatusDraft || page.PublishedAt != nil {
+				t.Errorf("Expected an unpublished draft, got status %q", page.Status)
+			}
+		})
+	}
+}
+
+func TestGetPageVisibility(t *testing.T) {
+	svc := NewService(newMockRepository())
+	if _, err := svc.CreatePage(context.Background(), admin, CreateInput{Title: "About", Content: "Hello", Status: article.StatusPublished}); err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+	if _, err := svc.CreatePage(context.Background(), admin, CreateInput{Title: "Terms", Content: "Rules"}); err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		viewer    article.Viewer
+		slug      string
+		wantError error
+	}{
+		{name: "Published page for guest", viewer: guest, slug: "about"},
+		{name: "Draft hidden from guest", viewer: guest, slug: "terms", wantError: ErrNotFound},
+		{name: "Draft hidden from author", viewer: author, slug: "terms", wantError: ErrNotFound},
+		{name: "Draft visible to admin", viewer: admin, slug: "terms"},
+		{name: "Unknown slug", viewer: admin, slug: "missing", wantError: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetPage(context.Background(), tt.viewer, tt.slug)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("Expected error %v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestListPages(t *testing.T) {
+	svc := NewService(newMockRepository())
+	for _, input := range []CreateInput{
+		{Title: "About", Content: "Hello", Status: article.StatusPublished},
+		{Title: "Terms", Content: "Rules"},
+	} {
+		if _, err := svc.CreatePage(context.Background(), admin, input); err != nil {
+			t.Fatalf("Failed to create page: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		viewer    article.Viewer
+		status    string
+		wantCount int
+		wantError error
+	}{
+		{name: "Guest sees published only", viewer: guest, wantCount: 1},
+		{name: "Guest status filter ignored", viewer: guest, status: article.StatusDraft, wantCount: 1},
+		{name: "Admin sees all", viewer: admin, wantCount: 2},
+		{name: "Admin filters drafts", viewer: admin, status: article.StatusDraft, wantCount: 1},
+		{name: "Admin invalid status", viewer: admin, status: "archived", wantError: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, err := svc.ListPages(context.Background(), tt.viewer, tt.status)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if len(pages) != tt.wantCount {
+				t.Errorf("Expected %d pages, got %d", tt.wantCount, len(pages))
+			}
+		})
+	}
+}
+
+func TestUpdatePage(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	about, err := svc.CreatePage(context.Background(), admin, CreateInput{Title: "About", Content: "Hello"})
+	if err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+	if _, err := svc.CreatePage(context.Background(), admin, CreateInput{Title: "Terms", Content: "Rules"}); err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+
+	published := article.StatusPublished
+	taken := "terms"
+	renamed := "about-us"
+	tests := []struct {
+		name      string
+		viewer    article.Viewer
+		id        uint
+		input     UpdateInput
+		wantError error
+	}{
+		{name: "Author cannot update", viewer: author, id: about.ID, input: UpdateInput{Status: &published}, wantError: ErrForbidden},
+		{name: "Slug already taken", viewer: admin, id: about.ID, input: UpdateInput{Slug: &taken}, wantError: ErrSlugTaken},
+		{name: "Unknown page", viewer: admin, id: 99, input: UpdateInput{Status: &published}, wantError: ErrNotFound},
+		{name: "Publish and rename", viewer: admin, id: about.ID, input: UpdateInput{Slug: &renamed, Status: &published}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdatePage(context.Background(), tt.viewer, tt.id, tt.input)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("Expected error %v, got %v", tt.wantError, err)
+			}
+		})
+	}
+
+	page, err := svc.GetPage(context.Background(), guest, renamed)
+	if err != nil {
+		t.Fatalf("Expected renamed page to be public, got %v", err)
+	}
+	if page.PublishedAt == nil || page.UpdatedBy != admin.UserID {
+		t.Errorf("Expected published_at and updated_by to be set, got %+v", page)
+	}
+}
+
+func TestDeletePage(t *testing.T) {
+	svc := NewService(newMockRepository())
+	page, err := svc.CreatePage(context.Background(), admin, CreateInput{Title: "About", Content: "Hello"})
+	if err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+
+	if err := svc.DeletePage(context.Background(), author, page.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected error %v, got %v", ErrForbidden, err)
+	}
+	if err := svc.DeletePage(context.Background(), admin, page.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := svc.DeletePage(context.Background(), admin, page.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error %v, got %v", ErrNotFound, err)
+	}
+}
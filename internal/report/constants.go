@@ -0,0 +1,16 @@
+package report
+
+const (
+	ReasonSpam      = "spam"
+	ReasonAbuse     = "abuse"
+	ReasonCopyright = "copyright"
+
+	StatusOpen      = "open"
+	StatusResolved  = "resolved"
+	StatusDismissed = "dismissed"
+
+	MaxDetailsLength = 1000
+	MaxNoteLength    = 1000
+)
+
+var Reasons = []string{ReasonSpam, ReasonAbuse, ReasonCopyright}
@@ -0,0 +1,11 @@
+package report
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("report not found")
+	ErrAlreadyReported = errors.New("article already reported by this user")
+	ErrAlreadyResolved = errors.New("report is already resolved")
+	ErrRateLimited     = errors.New("too many reports, please try again later")
+	ErrValidation      = errors.New("validation error")
+)
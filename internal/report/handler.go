@@ -0,0 +1,173 @@
+package report
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type ReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam abuse copyright"`
+	Details string `json:"details" validate:"max=1000"`
+}
+
+type ResolveRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=resolved dismissed"`
+	Note   string `json:"note" validate:"max=1000"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+func parsePagination(c *gin.Context) (page, limit int) {
+	page = article.DefaultPage
+	limit = article.DefaultLimit
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = min(l, article.MaxLimit)
+	}
+	return page, limit
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:         http.StatusNotFound,
+	article.ErrNotFound: http.StatusNotFound,
+	ErrAlreadyReported:  http.StatusConflict,
+	ErrAlreadyResolved:  http.StatusConflict,
+	ErrRateLimited:      http.StatusTooManyRequests,
+	ErrValidation:       http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) ReportArticle(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	var req ReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	report, err := handler.service.ReportArticle(c.Request.Context(), getViewer(c), id, CreateInput{
+		Reason:  req.Reason,
+		Details: req.Details,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (handler *Handler) ListReports(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	filter := Filter{Status: c.Query("status")}
+	if raw := c.Query("article_id"); raw != "" {
+		articleID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article_id"})
+			return
+		}
+		filter.ArticleID = uint(articleID)
+	}
+
+	reports, total, err := handler.service.ListReports(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reports,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		},
+	})
+}
+
+func (handler *Handler) ResolveReport(c *gin.Context) {
+	moderatorID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		return
+	}
+
+	var req ResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	report, err := handler.service.ResolveReport(c.Request.Context(), moderatorID, id, ResolveInput{
+		Status: req.Status,
+		Note:   req.Note,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
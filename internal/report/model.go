@@ -0,0 +1,25 @@
+package report
+
+import "time"
+
+type Report struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	ArticleID  uint       `gorm:"not null;uniqueIndex:idx_reports_article_reporter" json:"article_id"`
+	ReporterID uint       `gorm:"not null;uniqueIndex:idx_reports_article_reporter;index:idx_reports_reporter_created" json:"reporter_id"`
+	Reason     string     `gorm:"type:varchar(20);not null" json:"reason"`
+	Details    string     `gorm:"type:text" json:"details,omitempty"`
+	Status     string     `gorm:"type:varchar(20);not null;default:open;index" json:"status"`
+	Note       string     `gorm:"type:text" json:"note,omitempty"`
+	ResolvedBy *uint      `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"index:idx_reports_reporter_created" json:"created_at"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
+
+type Filter struct {
+	Status    string
+	ArticleID uint
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, report *Report) error
+	GetByID(ctx context.Context, id uint) (*Report, error)
+	Exists(ctx context.Context, articleID, reporterID uint) (bool, error)
+	CountByReporterSince(ctx context.Context, reporterID uint, since time.Time) (int64, error)
+	CountOpen(ctx context.Context, articleID uint, since time.Time) (int64, error)
+	List(ctx context.Context, filter Filter, page, limit int) ([]Report, int64, error)
+	Resolve(ctx context.Context, id uint, status string, moderatorID uint, note string, at time.Time) error
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &reportRepository{db: db}
+}
+
+func (repo *reportRepository) Create(ctx context.Context, report *Report) error {
+	result := database.Conn(ctx, repo.db).Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to create report: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyReported
+	}
+	return nil
+}
+
+func (repo *reportRepository) GetByID(ctx context.Context, id uint) (*Report, error) {
+	var report Report
+	if err := database.Conn(ctx, repo.db).First(&report, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get report by id %d: %w", id, err)
+	}
+	return &report, nil
+}
+
+func (repo *reportRepository) Exists(ctx context.Context, articleID, reporterID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Model(&Report{}).Where("article_id = ? AND reporter_id = ?", articleID, reporterID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to check report for article %d: %w", articleID, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *reportRepository) CountByReporterSince(ctx context.Context, reporterID uint, since time.Time) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Model(&Report{}).Where("reporter_id = ? AND created_at >= ?", reporterID, since).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to count reports by user %d: %w", reporterID, err)
+	}
+	return count, nil
+}
+
+func (repo *reportRepository) CountOpen(ctx context.Context, articleID uint, since time.Time) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Model(&Report{}).Where("article_id = ? AND status = ? AND created_at > ?", articleID, StatusOpen, since).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("repo: failed to count open reports for article %d: %w", articleID, err)
+	}
+	return count, nil
+}
+
+func (repo *reportRepository) List(ctx context.Context, filter Filter, page, limit int) ([]Report, int64, error) {
+	query := database.Conn(ctx, repo.db).Model(&Report{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ArticleID != 0 {
+		query = query.Where("article_id = ?", filter.ArticleID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count reports: %w", err)
+	}
+
+	var reports []Report
+	offset := (page - 1) * limit
+	if err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(limit).Find(&reports).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to list reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+func (repo *reportRepository) Resolve(ctx context.Context, id uint, status string, moderatorID uint, note string, at time.Time) error {
+	result := database.Conn(ctx, repo.db).Model(&Report{}).
+		Where("id = ? AND status = ?", id, StatusOpen).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"note":        note,
+			"resolved_by": moderatorID,
+			"resolved_at": at,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to resolve report %d: %w", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyResolved
+	}
+	return nil
+}
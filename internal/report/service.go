@@ -0,0 +1,180 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateInput struct {
+	Reason  string
+	Details string
+}
+
+type ResolveInput struct {
+	Status string
+	Note   string
+}
+
+type Articles interface {
+	GetVisibleArticle(ctx context.Context, viewer article.Viewer, id uint) (*article.Article, error)
+	HoldForReview(ctx context.Context, id uint, note string) (*article.Article, error)
+}
+
+type Service interface {
+	ReportArticle(ctx context.Context, viewer article.Viewer, articleID uint, input CreateInput) (*Report, error)
+	ListReports(ctx context.Context, filter Filter, page, limit int) ([]Report, int64, error)
+	ResolveReport(ctx context.Context, moderatorID, id uint, input ResolveInput) (*Report, error)
+}
+
+type reportService struct {
+	repo     Repository
+	articles Articles
+	cfg      config.ReportConfig
+	now      func() time.Time
+}
+
+func NewService(repo Repository, articles Articles, cfg config.ReportConfig) Service {
+	return &reportService{repo: repo, articles: articles, cfg: cfg, now: time.Now}
+}
+
+func validateReason(reason string) error {
+	if !slices.Contains(Reasons, reason) {
+		return fmt.Errorf("%w: reason must be one of: %s", ErrValidation, strings.Join(Reasons, ", "))
+	}
+	return nil
+}
+
+func validateText(field, value string, max int) (string, error) {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > max {
+		return "", fmt.Errorf("%w: %s cannot exceed %d characters", ErrValidation, field, max)
+	}
+	return value, nil
+}
+
+func (svc *reportService) ReportArticle(ctx context.Context, viewer article.Viewer, articleID uint, input CreateInput) (*Report, error) {
+	if viewer.UserID == 0 {
+		return nil, fmt.Errorf("%w: reporter_id cannot be empty", ErrValidation)
+	}
+	if err := validateReason(input.Reason); err != nil {
+		return nil, err
+	}
+	details, err := validateText("details", input.Details, MaxDetailsLength)
+	if err != nil {
+		return nil, err
+	}
+
+	reported, err := svc.articles.GetVisibleArticle(ctx, viewer, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if reported.UserID == viewer.UserID {
+		return nil, fmt.Errorf("%w: you cannot report your own article", ErrValidation)
+	}
+
+	exists, err := svc.repo.Exists(ctx, articleID, viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadyReported
+	}
+
+	recent, err := svc.repo.CountByReporterSince(ctx, viewer.UserID, svc.now().Add(-svc.cfg.RateWindow))
+	if err != nil {
+		return nil, err
+	}
+	if recent >= int64(svc.cfg.RateLimit) {
+		return nil, ErrRateLimited
+	}
+
+	report := &Report{
+		ArticleID:  articleID,
+		ReporterID: viewer.UserID,
+		Reason:     input.Reason,
+		Details:    details,
+		Status:     StatusOpen,
+	}
+	if err := svc.repo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	svc.checkThreshold(ctx, reported)
+	return report, nil
+}
+
+func (svc *reportService) checkThreshold(ctx context.Context, reported *article.Article) {
+	if svc.cfg.UnpublishThreshold == 0 || reported.Status != article.StatusPublished {
+		return
+	}
+
+	var since time.Time
+	if reported.ReviewedAt != nil {
+		since = *reported.ReviewedAt
+	}
+	open, err := svc.repo.CountOpen(ctx, reported.ID, since)
+	if err != nil {
+		log.Error().Err(err).Uint("article_id", reported.ID).Msg("Failed to count open reports")
+		return
+	}
+	if open < int64(svc.cfg.UnpublishThreshold) {
+		return
+	}
+
+	note := fmt.Sprintf("Automatically unpublished after %d reports", open)
+	if _, err := svc.articles.HoldForReview(ctx, reported.ID, note); err != nil {
+		log.Error().Err(err).Uint("article_id", reported.ID).Msg("Failed to unpublish reported article")
+		return
+	}
+	log.Warn().Uint("article_id", reported.ID).Int64("reports", open).Msg("Reported article held for review")
+}
+
+func (svc *reportService) ListReports(ctx context.Context, filter Filter, page, limit int) ([]Report, int64, error) {
+	switch filter.Status {
+	case "", StatusOpen, StatusResolved, StatusDismissed:
+	default:
+		return nil, 0, fmt.Errorf("%w: status must be one of: %s, %s, %s", ErrValidation, StatusOpen, StatusResolved, StatusDismissed)
+	}
+
+	reports, total, err := svc.repo.List(ctx, filter, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+func (svc *reportService) ResolveReport(ctx context.Context, moderatorID, id uint, input ResolveInput) (*Report, error) {
+	status := input.Status
+	if status == "" {
+		status = StatusResolved
+	}
+	if status != StatusResolved && status != StatusDismissed {
+		return nil, fmt.Errorf("%w: status must be one of: %s, %s", ErrValidation, StatusResolved, StatusDismissed)
+	}
+	note, err := validateText("note", input.Note, MaxNoteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != StatusOpen {
+		return nil, ErrAlreadyResolved
+	}
+
+	if err := svc.repo.Resolve(ctx, id, status, moderatorID, note, svc.now()); err != nil {
+		return nil, err
+	}
+	return svc.repo.GetByID(ctx, id)
+}
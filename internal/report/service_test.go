@@ -0,0 +1,281 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+)
+
+type mockRepository struct {
+	reports map[uint]*Report
+	nextID  uint
+	now     time.Time
+}
+
+func newMockRepository(now time.Time) *mockRepository {
+	return &mockRepository{
+		reports: make(map[uint]*Report),
+		nextID:  1,
+		now:     now,
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, report *Report) error {
+	if exists, _ := m.Exists(ctx, report.ArticleID, report.ReporterID); exists {
+		return ErrAlreadyReported
+	}
+	report.ID = m.nextID
+	report.CreatedAt = m.now
+	m.nextID++
+	stored := *report
+	m.reports[report.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Report, error) {
+	report, ok := m.reports[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *report
+	return &copied, nil
+}
+
+func (m *mockRepository) Exists(ctx context.Context, articleID, reporterID uint) (bool, error) {
+	for _, report := range m.reports {
+		if report.ArticleID == articleID && report.ReporterID == reporterID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRepository) CountByReporterSince(ctx context.Context, reporterID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, report := range m.reports {
+		if report.ReporterID == reporterID && !report.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockRepository) CountOpen(ctx context.Context, articleID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, report := range m.reports {
+		if report.ArticleID == articleID && report.Status == StatusOpen && report.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockRepository) List(ctx context.Context, filter Filter, page, limit int) ([]Report, int64, error) {
+	var reports []Report
+	for id := uint(1); id < m.nextID; id++ {
+		report, ok := m.reports[id]
+		if !ok {
+			continue
+		}
+		if filter.Status != "" && report.Status != filter.Status {
+			continue
+		}
+		if filter.ArticleID != 0 && report.ArticleID != filter.ArticleID {
+			continue
+		}
+		reports = append(reports, *report)
+	}
+	total := int64(len(reports))
+	start := min((page-1)*limit, len(reports))
+	end := min(start+limit, len(reports))
+	return reports[start:end], total, nil
+}
+
+func (m *mockRepository) Resolve(ctx context.Context, id uint, status string, moderatorID uint, note string, at time.Time) error {
+	report, ok := m.reports[id]
+	if !ok || report.Status != StatusOpen {
+		return ErrAlreadyResolved
+	}
+	report.Status = status
+	report.Note = note
+	report.ResolvedBy = &moderatorID
+	report.ResolvedAt = &at
+	return nil
+}
+
+type fakeArticles struct {
+	articles map[uint]*article.Article
+	held     []uint
+}
+
+func (f *fakeArticles) GetVisibleArticle(ctx context.Context, viewer article.Viewer, id uint) (*article.Article, error) {
+	a, ok := f.articles[id]
+	if !ok || (a.Status != article.StatusPublished && a.UserID != viewer.UserID) {
+		return nil, article.ErrNotFound
+	}
+	copied := *a
+	return &copied, nil
+}
+
+func (f *fakeArticles) HoldForReview(ctx context.Context, id uint, note string) (*article.Article, error) {
+	a := f.articles[id]
+	a.Status = article.StatusPendingReview
+	a.ReviewNote = note
+	f.held = append(f.held, id)
+	return a, nil
+}
+
+func newFakeArticles() *fakeArticles {
+	return &fakeArticles{articles: map[uint]*article.Article{
+		1: {ID: 1, UserID: 10, Status: article.StatusPublished},
+		2: {ID: 2, UserID: 10, Status: article.StatusDraft},
+		3: {ID: 3, UserID: 10, Status: article.StatusPublished},
+		4: {ID: 4, UserID: 10, Status: article.StatusPublished},
+	}}
+}
+
+var testConfig = config.ReportConfig{RateLimit: 2, RateWindow: time.Hour, UnpublishThreshold: 2}
+
+func TestReportArticle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewService(newMockRepository(now), newFakeArticles(), testConfig)
+	svc.(*reportService).now = func() time.Time { return now }
+
+	tests := []struct {
+		name      string
+		reporter  uint
+		articleID uint
+		input     CreateInput
+		wantError error
+	}{
+		{name: "Valid report", reporter: 1, articleID: 1, input: CreateInput{Reason: ReasonSpam, Details: " Link farm "}},
+		{name: "Same article twice", reporter: 1, articleID: 1, input: CreateInput{Reason: ReasonAbuse}, wantError: ErrAlreadyReported},
+		{name: "Unknown reason", reporter: 1, articleID: 3, input: CreateInput{Reason: "boring"}, wantError: ErrValidation},
+		{name: "Anonymous reporter", reporter: 0, articleID: 3, input: CreateInput{Reason: ReasonSpam}, wantError: ErrValidation},
+		{name: "Own article", reporter: 10, articleID: 3, input: CreateInput{Reason: ReasonSpam}, wantError: ErrValidation},
+		{name: "Hidden draft", reporter: 1, articleID: 2, input: CreateInput{Reason: ReasonSpam}, wantError: article.ErrNotFound},
+		{name: "Second report within the limit", reporter: 1, articleID: 3, input: CreateInput{Reason: ReasonCopyright}},
+		{name: "Rate limited", reporter: 1, articleID: 4, input: CreateInput{Reason: ReasonSpam}, wantError: ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := svc.ReportArticle(context.Background(), article.Viewer{UserID: tt.reporter}, tt.articleID, tt.input)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if err == nil && (report.Status != StatusOpen || report.ReporterID != tt.reporter) {
+				t.Errorf("Expected an open report by %d, got %+v", tt.reporter, report)
+			}
+		})
+	}
+
+	svc.(*reportService).now = func() time.Time { return now.Add(2 * time.Hour) }
+	if _, err := svc.ReportArticle(context.Background(), article.Viewer{UserID: 1}, 4, CreateInput{Reason: ReasonSpam}); err != nil {
+		t.Errorf("Expected the rate limit to reset after the window, got %v", err)
+	}
+}
+
+func TestReportThreshold(t *testing.T) {
+	articles := newFakeArticles()
+	svc := NewService(newMockRepository(time.Now()), articles, testConfig)
+
+	if _, err := svc.ReportArticle(context.Background(), article.Viewer{UserID: 1}, 1, CreateInput{Reason: ReasonSpam}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(articles.held) != 0 {
+		t.Fatalf("Expected article to stay published below the threshold, held %v", articles.held)
+	}
+	if _, err := svc.ReportArticle(context.Background(), article.Viewer{UserID: 2}, 1, CreateInput{Reason: ReasonAbuse}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(articles.held) != 1 || articles.articles[1].Status != article.StatusPendingReview {
+		t.Fatalf("Expected article 1 to be held for review, held %v", articles.held)
+	}
+	if articles.articles[1].ReviewNote != "Automatically unpublished after 2 reports" {
+		t.Errorf("Unexpected review note %q", articles.articles[1].ReviewNote)
+	}
+
+	repo := newMockRepository(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	reviewedAt := repo.now.Add(time.Hour)
+	articles = newFakeArticles()
+	articles.articles[3].ReviewedAt = &reviewedAt
+	approved := NewService(repo, articles, testConfig)
+	if _, err := approved.ReportArticle(context.Background(), article.Viewer{UserID: 1}, 3, CreateInput{Reason: ReasonSpam}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	repo.now = reviewedAt.Add(time.Hour)
+	if _, err := approved.ReportArticle(context.Background(), article.Viewer{UserID: 2}, 3, CreateInput{Reason: ReasonSpam}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(articles.held) != 0 {
+		t.Errorf("Expected reports from before the last approval not to count, held %v", articles.held)
+	}
+	if _, err := approved.ReportArticle(context.Background(), article.Viewer{UserID: 3}, 3, CreateInput{Reason: ReasonSpam}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(articles.held) != 1 {
+		t.Errorf("Expected 2 reports since the last approval to hold the article, held %v", articles.held)
+	}
+
+	disabled := NewService(newMockRepository(time.Now()), newFakeArticles(), config.ReportConfig{RateLimit: 5, RateWindow: time.Hour})
+	for reporter := uint(1); reporter <= 3; reporter++ {
+		if _, err := disabled.ReportArticle(context.Background(), article.Viewer{UserID: reporter}, 3, CreateInput{Reason: ReasonSpam}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if held := disabled.(*reportService).articles.(*fakeArticles).held; len(held) != 0 {
+		t.Errorf("Expected no article to be held with the threshold disabled, held %v", held)
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	svc := NewService(newMockRepository(time.Now()), newFakeArticles(), testConfig)
+	report, err := svc.ReportArticle(context.Background(), article.Viewer{UserID: 1}, 1, CreateInput{Reason: ReasonSpam})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         uint
+		input      ResolveInput
+		wantStatus string
+		wantError  error
+	}{
+		{name: "Invalid status", id: report.ID, input: ResolveInput{Status: StatusOpen}, wantError: ErrValidation},
+		{name: "Unknown report", id: 99, wantError: ErrNotFound},
+		{name: "Dismiss", id: report.ID, input: ResolveInput{Status: StatusDismissed, Note: "Not spam"}, wantStatus: StatusDismissed},
+		{name: "Already resolved", id: report.ID, wantError: ErrAlreadyResolved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := svc.ResolveReport(context.Background(), 9, tt.id, tt.input)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if err != nil {
+				return
+			}
+			if resolved.Status != tt.wantStatus || resolved.ResolvedBy == nil || *resolved.ResolvedBy != 9 || resolved.Note != tt.input.Note {
+				t.Errorf("Expected report %s by 9, got %+v", tt.wantStatus, resolved)
+			}
+		})
+	}
+
+	open, total, err := svc.ListReports(context.Background(), Filter{Status: StatusOpen}, 1, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 0 || len(open) != 0 {
+		t.Errorf("Expected no open reports, got %d", total)
+	}
+	if _, _, err := svc.ListReports(context.Background(), Filter{Status: "closed"}, 1, 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for unknown status, got %v", err)
+	}
+}
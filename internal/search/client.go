@@ -0,0 +1,250 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+)
+
+type Client struct {
+	http *http.Client
+	cfg  config.SearchConfig
+}
+
+func NewClient(cfg config.SearchConfig) *Client {
+	return &Client{http: &http.Client{Timeout: cfg.Timeout}, cfg: cfg}
+}
+
+func (client *Client) do(ctx context.Context, method, path string, body any, out any) error {
+	var reader io.Reader
+	contentType := "application/json"
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+		contentType = "application/x-ndjson"
+	case string:
+		reader = strings.NewReader(b)
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, client.cfg.URL+path, reader)
+	if err != nil {
+		return err
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if client.cfg.Username != "" {
+		req.SetBasicAuth(client.cfg.Username, client.cfg.Password)
+	}
+
+	resp, err := client.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrRequest, method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%w: %s %s", ErrNotFound, method, path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBytes))
+		return fmt.Errorf("%w: %s %s returned %d: %s", ErrRequest, method, path, resp.StatusCode, detail)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func (client *Client) Ping(ctx context.Context) error {
+	return client.do(ctx, http.MethodGet, "/", nil, nil)
+}
+
+func (client *Client) EnsureIndex(ctx context.Context) error {
+	err := client.do(ctx, http.MethodHead, "/"+url.PathEscape(client.cfg.Index), nil, nil)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	name := client.newIndexName()
+	if err := client.createIndex(ctx, name); err != nil {
+		return err
+	}
+	return client.swapAlias(ctx, name)
+}
+
+func (client *Client) newIndexName() string {
+	return fmt.Sprintf("%s_%d", client.cfg.Index, time.Now().UnixNano())
+}
+
+func (client *Client) createIndex(ctx context.Context, name string) error {
+	if err := client.do(ctx, http.MethodPut, "/"+url.PathEscape(name), indexDefinition, nil); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", name, err)
+	}
+	return nil
+}
+
+func (client *Client) deleteIndex(ctx context.Context, name string) error {
+	if err := client.do(ctx, http.MethodDelete, "/"+url.PathEscape(name), nil, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to delete index %s: %w", name, err)
+	}
+	return nil
+}
+
+func (client *Client) aliasedIndices(ctx context.Context) ([]string, error) {
+	var aliases map[string]json.RawMessage
+	err := client.do(ctx, http.MethodGet, "/_alias/"+url.PathEscape(client.cfg.Index), nil, &aliases)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get alias %s: %w", client.cfg.Index, err)
+	}
+	indices := make([]string, 0, len(aliases))
+	for name := range aliases {
+		indices = append(indices, name)
+	}
+	return indices, nil
+}
+
+func (client *Client) swapAlias(ctx context.Context, name string) error {
+	previous, err := client.aliasedIndices(ctx)
+	if err != nil {
+		return err
+	}
+
+	actions := make([]map[string]any, 0, len(previous)+1)
+	for _, index := range previous {
+		if index != name {
+			actions = append(actions, map[string]any{"remove": map[string]string{"index": index, "alias": client.cfg.Index}})
+		}
+	}
+	actions = append(actions, map[string]any{"add": map[string]string{"index": name, "alias": client.cfg.Index}})
+	if err := client.do(ctx, http.MethodPost, "/_aliases", map[string]any{"actions": actions}, nil); err != nil {
+		return fmt.Errorf("failed to point alias %s at %s: %w", client.cfg.Index, name, err)
+	}
+	return nil
+}
+
+func (client *Client) Index(ctx context.Context, doc Document) error {
+	path := fmt.Sprintf("/%s/_doc/%d", url.PathEscape(client.cfg.Index), doc.ID)
+	if err := client.do(ctx, http.MethodPut, path, doc, nil); err != nil {
+		return fmt.Errorf("failed to index article %d: %w", doc.ID, err)
+	}
+	return nil
+}
+
+func (client *Client) Delete(ctx context.Context, id uint) error {
+	path := fmt.Sprintf("/%s/_doc/%d", url.PathEscape(client.cfg.Index), id)
+	if err := client.do(ctx, http.MethodDelete, path, nil, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to remove article %d from the index: %w", id, err)
+	}
+	return nil
+}
+
+func (client *Client) bulkIndex(ctx context.Context, index string, docs []Document) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]map[string]string{"index": {"_id": strconv.FormatUint(uint64(doc.ID), 10)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	var resp bulkResponse
+	if err := client.do(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_bulk", body.Bytes(), &resp); err != nil {
+		return fmt.Errorf("failed to bulk index %d articles: %w", len(docs), err)
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status < 200 || result.Status > 299 {
+				return fmt.Errorf("%w: failed to index article %s: %s", ErrRequest, result.ID, result.Error)
+			}
+		}
+	}
+	return fmt.Errorf("%w: bulk request reported errors", ErrRequest)
+}
+
+func (client *Client) refresh(ctx context.Context, index string) error {
+	return client.do(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_refresh", nil, nil)
+}
+
+func (client *Client) Search(query string, page, limit int) ([]article.SearchMatch, int64, error) {
+	request := map[string]any{
+		"from":             (page - 1) * limit,
+		"size":             limit,
+		"track_total_hits": true,
+		"track_scores":     true,
+		"_source":          false,
+		"query": map[string]any{
+			"simple_query_string": map[string]any{
+				"query":            query,
+				"fields":           searchFields,
+				"default_operator": "and",
+			},
+		},
+		"sort": []any{"_score", map[string]string{"created_at": "desc"}},
+		"highlight": map[string]any{
+			"pre_tags":  []string{HighlightPreTag},
+			"post_tags": []string{HighlightPostTag},
+			"fields": map[string]any{
+				"content": map[string]int{"fragment_size": HighlightFragment, "number_of_fragments": HighlightCount},
+				"title":   map[string]int{"number_of_fragments": 0},
+			},
+		},
+	}
+
+	var resp searchResponse
+	path := "/" + url.PathEscape(client.cfg.Index) + "/_search"
+	if err := client.do(context.Background(), http.MethodPost, path, request, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	matches := make([]article.SearchMatch, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		id, err := strconv.ParseUint(hit.ID, 10, 32)
+		if err != nil {
+			continue
+		}
+		snippet := hit.Highlight["content"]
+		if len(snippet) == 0 {
+			snippet = hit.Highlight["title"]
+		}
+		matches = append(matches, article.SearchMatch{
+			ArticleID: uint(id),
+			Rank:      hit.Score,
+			Snippet:   strings.Join(snippet, SnippetSeparator),
+		})
+	}
+	return matches, resp.Hits.Total.Value, nil
+}
@@ -0,0 +1,12 @@
+package search
+
+const (
+	HighlightPreTag   = "<mark>"
+	HighlightPostTag  = "</mark>"
+	HighlightFragment = 200
+	HighlightCount    = 2
+	SnippetSeparator  = " ... "
+	MaxErrorBytes     = 4 << 10
+)
+
+var searchFields = []string{"title^3", "tags^2", "excerpt^2", "content"}
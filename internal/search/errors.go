@@ -0,0 +1,8 @@
+package search
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("search: not found")
+	ErrRequest  = errors.New("search: request failed")
+)
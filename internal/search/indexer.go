@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+var Events = []string{
+	article.EventArticleCreated,
+	article.EventArticleUpdated,
+	article.EventArticleDeleted,
+	article.EventArticlePublished,
+}
+
+type Source interface {
+	GetSearchDocuments(ctx context.Context, ids []uint) ([]article.Article, error)
+	ScanSearchDocuments(ctx context.Context, batchSize int, fn func(articles []article.Article) error) error
+}
+
+type Indexer struct {
+	client *Client
+	source Source
+}
+
+func NewIndexer(client *Client, source Source) *Indexer {
+	return &Indexer{client: client, source: source}
+}
+
+func (indexer *Indexer) HandleArticleEvent(event events.Event) error {
+	a, ok := event.Payload.(article.Article)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for event %s", event.Payload, event.Name)
+	}
+	ctx := context.Background()
+
+	if event.Name == article.EventArticleDeleted || a.Status != article.StatusPublished {
+		return indexer.client.Delete(ctx, a.ID)
+	}
+
+	docs, err := indexer.source.GetSearchDocuments(ctx, []uint{a.ID})
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return indexer.client.Delete(ctx, a.ID)
+	}
+	return indexer.client.Index(ctx, NewDocument(&docs[0]))
+}
+
+func (indexer *Indexer) Reindex(ctx context.Context, batchSize int) (int, error) {
+	previous, err := indexer.client.aliasedIndices(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	name := indexer.client.newIndexName()
+	if err := indexer.client.createIndex(ctx, name); err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	err = indexer.source.ScanSearchDocuments(ctx, batchSize, func(articles []article.Article) error {
+		docs := make([]Document, len(articles))
+		for i := range articles {
+			docs[i] = NewDocument(&articles[i])
+		}
+		if err := indexer.client.bulkIndex(ctx, name, docs); err != nil {
+			return err
+		}
+		indexed += len(docs)
+		log.Info().Int("indexed", indexed).Msg("Reindexing articles")
+		return nil
+	})
+	if err == nil {
+		err = indexer.client.refresh(ctx, name)
+	}
+	if err == nil {
+		err = indexer.client.swapAlias(ctx, name)
+	}
+	if err != nil {
+		if cleanupErr := indexer.client.deleteIndex(ctx, name); cleanupErr != nil {
+			log.Error().Err(cleanupErr).Str("index", name).Msg("Failed to remove incomplete index")
+		}
+		return indexed, err
+	}
+
+	for _, index := range previous {
+		if err := indexer.client.deleteIndex(ctx, index); err != nil {
+			log.Error().Err(err).Str("index", index).Msg("Failed to remove previous index")
+		}
+	}
+	return indexed, nil
+}
@@ -0,0 +1,244 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/events"
+)
+
+type fakeCluster struct {
+	mu      sync.Mutex
+	indices map[string]map[string]Document
+	alias   map[string]string
+	search  map[string]any
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{indices: make(map[string]map[string]Document), alias: make(map[string]string)}
+}
+
+func (f *fakeCluster) resolve(name string) (map[string]Document, bool) {
+	if index, ok := f.alias[name]; ok {
+		name = index
+	}
+	docs, ok := f.indices[name]
+	return docs, ok
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodPost && parts[0] == "_aliases":
+		var body struct {
+			Actions []map[string]map[string]string `json:"actions"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, action := range body.Actions {
+			if add, ok := action["add"]; ok {
+				f.alias[add["alias"]] = add["index"]
+			}
+		}
+	case r.Method == http.MethodGet && parts[0] == "_alias":
+		index, ok := f.alias[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{index: map[string]any{}})
+	case len(parts) == 1 && r.Method == http.MethodHead:
+		if _, ok := f.resolve(parts[0]); !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		f.indices[parts[0]] = make(map[string]Document)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(f.indices, parts[0])
+	case len(parts) == 2 && parts[1] == "_refresh":
+	case len(parts) == 2 && parts[1] == "_bulk":
+		docs, _ := f.resolve(parts[0])
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]map[string]string
+			_ = json.Unmarshal(scanner.Bytes(), &action)
+			scanner.Scan()
+			var doc Document
+			_ = json.Unmarshal(scanner.Bytes(), &doc)
+			docs[action["index"]["_id"]] = doc
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": false})
+	case len(parts) == 2 && parts[1] == "_search":
+		_ = json.NewDecoder(r.Body).Decode(&f.search)
+		docs, _ := f.resolve(parts[0])
+		hits := make([]map[string]any, 0, len(docs))
+		for id := range docs {
+			hits = append(hits, map[string]any{
+				"_id":       id,
+				"_score":    1.5,
+				"highlight": map[string][]string{"content": {"<mark>go</mark> one", "<mark>go</mark> two"}},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"hits": map[string]any{"total": map[string]int{"value": len(hits)}, "hits": hits}})
+	case len(parts) == 3 && parts[1] == "_doc":
+		docs, ok := f.resolve(parts[0])
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			if _, ok := docs[parts[2]]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(docs, parts[2])
+			return
+		}
+		var doc Document
+		_ = json.NewDecoder(r.Body).Decode(&doc)
+		docs[parts[2]] = doc
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeCluster) documents(name string) map[string]Document {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	docs, _ := f.resolve(name)
+	return docs
+}
+
+type fakeSource struct {
+	articles []article.Article
+}
+
+func (f *fakeSource) GetSearchDocuments(ctx context.Context, ids []uint) ([]article.Article, error) {
+	var found []article.Article
+	for _, a := range f.articles {
+		if slices.Contains(ids, a.ID) && a.Status == article.StatusPublished {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSource) ScanSearchDocuments(ctx context.Context, batchSize int, fn func(articles []article.Article) error) error {
+	for batch := range slices.Chunk(f.articles, batchSize) {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestIndexer(t *testing.T, source Source) (*Indexer, *fakeCluster) {
+	t.Helper()
+	cluster := newFakeCluster()
+	server := httptest.NewServer(cluster)
+	t.Cleanup(server.Close)
+
+	client := NewClient(config.SearchConfig{URL: server.URL, Index: "articles", Timeout: time.Second})
+	if err := client.EnsureIndex(t.Context()); err != nil {
+		t.Fatalf("EnsureIndex() unexpected error: %v", err)
+	}
+	return NewIndexer(client, source), cluster
+}
+
+func TestHandleArticleEvent(t *testing.T) {
+	published := article.Article{ID: 1, Title: "Go", Content: "Go content", Status: article.StatusPublished, Tags: []article.Tag{{Name: "go"}}}
+	source := &fakeSource{articles: []article.Article{published}}
+	indexer, cluster := newTestIndexer(t, source)
+
+	tests := []struct {
+		name    string
+		event   string
+		payload article.Article
+		wantDoc bool
+	}{
+		{name: "Published article is indexed", event: article.EventArticlePublished, payload: published, wantDoc: true},
+		{name: "Unpublished article is removed", event: article.EventArticleUpdated, payload: article.Article{ID: 1, Status: article.StatusDraft}},
+		{name: "Updated article is indexed again", event: article.EventArticleUpdated, payload: published, wantDoc: true},
+		{name: "Deleted article is removed", event: article.EventArticleDeleted, payload: published},
+		{name: "Missing document is ignored", event: article.EventArticleDeleted, payload: published},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := indexer.HandleArticleEvent(events.Event{Name: tt.event, Payload: tt.payload}); err != nil {
+				t.Fatalf("HandleArticleEvent() unexpected error: %v", err)
+			}
+			doc, ok := cluster.documents("articles")["1"]
+			if ok != tt.wantDoc {
+				t.Fatalf("Expected document present = %v, got %v", tt.wantDoc, ok)
+			}
+			if ok && (doc.Content != "Go content" || !slices.Equal(doc.Tags, []string{"go"})) {
+				t.Errorf("Unexpected document: %+v", doc)
+			}
+		})
+	}
+}
+
+func TestReindex(t *testing.T) {
+	source := &fakeSource{}
+	for id := uint(1); id <= 5; id++ {
+		source.articles = append(source.articles, article.Article{ID: id, Title: "Article", Status: article.StatusPublished})
+	}
+	indexer, cluster := newTestIndexer(t, source)
+	cluster.mu.Lock()
+	previous := cluster.alias["articles"]
+	cluster.mu.Unlock()
+
+	indexed, err := indexer.Reindex(t.Context(), 2)
+	if err != nil {
+		t.Fatalf("Reindex() unexpected error: %v", err)
+	}
+	if indexed != 5 || len(cluster.documents("articles")) != 5 {
+		t.Errorf("Expected 5 documents behind the alias, got %d (%d indexed)", len(cluster.documents("articles")), indexed)
+	}
+
+	cluster.mu.Lock()
+	defer cluster.mu.Unlock()
+	if cluster.alias["articles"] == previous {
+		t.Error("Expected the alias to point at the new index")
+	}
+	if _, ok := cluster.indices[previous]; ok {
+		t.Error("Expected the previous index to be deleted")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	source := &fakeSource{articles: []article.Article{{ID: 3, Status: article.StatusPublished}}}
+	indexer, cluster := newTestIndexer(t, source)
+	if _, err := indexer.Reindex(t.Context(), 10); err != nil {
+		t.Fatalf("Reindex() unexpected error: %v", err)
+	}
+
+	matches, total, err := indexer.client.Search("go", 2, 5)
+	if err != nil {
+		t.Fatalf("Search() unexpected error: %v", err)
+	}
+	if total != 1 || len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d (total %d)", len(matches), total)
+	}
+	if matches[0].ArticleID != 3 || matches[0].Rank != 1.5 || matches[0].Snippet != "<mark>go</mark> one ... <mark>go</mark> two" {
+		t.Errorf("Unexpected match: %+v", matches[0])
+	}
+
+	cluster.mu.Lock()
+	defer cluster.mu.Unlock()
+	if cluster.search["from"] != float64(5) || cluster.search["size"] != float64(5) {
+		t.Errorf("Expected from=5 and size=5, got %v and %v", cluster.search["from"], cluster.search["size"])
+	}
+}
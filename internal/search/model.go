@@ -0,0 +1,83 @@
+package search
+
+import (
+	"encoding/json"
+	"time"
+
+	"content-service/internal/article"
+)
+
+const indexDefinition = `{
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "id": {"type": "long"},
+      "user_id": {"type": "long"},
+      "title": {"type": "text"},
+      "slug": {"type": "keyword"},
+      "content": {"type": "text"},
+      "excerpt": {"type": "text"},
+      "tags": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+      "category_id": {"type": "long"},
+      "language": {"type": "keyword"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`
+
+type Document struct {
+	ID         uint      `json:"id"`
+	UserID     uint      `json:"user_id"`
+	Title      string    `json:"title"`
+	Slug       string    `json:"slug"`
+	Content    string    `json:"content"`
+	Excerpt    string    `json:"excerpt"`
+	Tags       []string  `json:"tags"`
+	CategoryID *uint     `json:"category_id,omitempty"`
+	Language   string    `json:"language,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewDocument(a *article.Article) Document {
+	tags := make([]string, len(a.Tags))
+	for i, tag := range a.Tags {
+		tags[i] = tag.Name
+	}
+	return Document{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Title:      a.Title,
+		Slug:       a.Slug,
+		Content:    a.Content,
+		Excerpt:    a.Excerpt,
+		Tags:       tags,
+		CategoryID: a.CategoryID,
+		Language:   a.Language,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID        string              `json:"_id"`
+			Score     float64             `json:"_score"`
+			Highlight map[string][]string `json:"highlight"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string          `json:"_id"`
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"items"`
+}
@@ -0,0 +1,7 @@
+package series
+
+const (
+	MaxTitleLength       = 255
+	MaxDescriptionLength = 2000
+	MaxEntries           = 500
+)
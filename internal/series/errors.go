@@ -0,0 +1,11 @@
+package series
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("series not found")
+	ErrEntryNotFound = errors.New("article is not part of this series")
+	ErrInOtherSeries = errors.New("article already belongs to another series")
+	ErrForbidden     = errors.New("forbidden: you can only manage your own series")
+	ErrValidation    = errors.New("validation error")
+)
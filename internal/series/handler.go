@@ -0,0 +1,255 @@
+package series
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type CreateSeriesRequest struct {
+	Title       string `json:"title" validate:"required,min=1,max=255"`
+	Slug        string `json:"slug" validate:"omitempty,max=100"`
+	Description string `json:"description" validate:"omitempty,max=2000"`
+}
+
+type UpdateSeriesRequest struct {
+	Title       *string `json:"title" validate:"omitempty,min=1,max=255"`
+	Slug        *string `json:"slug" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+}
+
+type AddEntryRequest struct {
+	ArticleID uint `json:"article_id" validate:"required,min=1"`
+	Position  int  `json:"position" validate:"omitempty,min=1"`
+}
+
+type ReorderEntriesRequest struct {
+	ArticleIDs []uint `json:"article_ids" validate:"required,min=1,max=500"`
+}
+
+func getID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:      http.StatusNotFound,
+	ErrEntryNotFound: http.StatusNotFound,
+	ErrInOtherSeries: http.StatusConflict,
+	ErrForbidden:     http.StatusForbidden,
+	ErrValidation:    http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) CreateSeries(c *gin.Context) {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	series, err := handler.service.CreateSeries(c.Request.Context(), userID, CreateInput{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+func (handler *Handler) ListSeries(c *gin.Context) {
+	var userID uint
+	if raw := c.Query("user_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || parsed == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user_id must be a positive integer"})
+			return
+		}
+		userID = uint(parsed)
+	}
+
+	series, err := handler.service.ListSeries(c.Request.Context(), userID)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": series})
+}
+
+func (handler *Handler) GetSeries(c *gin.Context) {
+	series, err := handler.service.GetSeries(c.Request.Context(), getViewer(c), c.Param("id"))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (handler *Handler) UpdateSeries(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID"})
+		return
+	}
+
+	var req UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	if req.Title == nil && req.Slug == nil && req.Description == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one field (title, slug, or description) must be provided"})
+		return
+	}
+
+	series, err := handler.service.UpdateSeries(c.Request.Context(), getViewer(c), id, UpdateInput{
+		Title:       req.Title,
+		Slug:        req.Slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (handler *Handler) DeleteSeries(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID"})
+		return
+	}
+
+	if err := handler.service.DeleteSeries(c.Request.Context(), getViewer(c), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) AddEntry(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID"})
+		return
+	}
+
+	var req AddEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	series, err := handler.service.AddEntry(c.Request.Context(), getViewer(c), id, EntryInput{
+		ArticleID: req.ArticleID,
+		Position:  req.Position,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (handler *Handler) ReorderEntries(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID"})
+		return
+	}
+
+	var req ReorderEntriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	series, err := handler.service.ReorderEntries(c.Request.Context(), getViewer(c), id, req.ArticleIDs)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (handler *Handler) RemoveEntry(c *gin.Context) {
+	id, err := getID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid series ID"})
+		return
+	}
+
+	articleID, err := strconv.ParseUint(c.Param("article_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article ID"})
+		return
+	}
+
+	if err := handler.service.RemoveEntry(c.Request.Context(), getViewer(c), id, uint(articleID)); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
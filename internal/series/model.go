@@ -0,0 +1,37 @@
+package series
+
+import "time"
+
+type Series struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Slug        string    `gorm:"type:varchar(255);uniqueIndex" json:"slug"`
+	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
+	Description string    `gorm:"type:text;not null;default:''" json:"description"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Entries     []Item    `gorm:"-" json:"entries,omitempty"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+type Entry struct {
+	ArticleID uint `gorm:"primaryKey;autoIncrement:false"`
+	SeriesID  uint `gorm:"not null;index"`
+	Position  int  `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (Entry) TableName() string {
+	return "series_entries"
+}
+
+type Item struct {
+	ArticleID uint   `json:"article_id"`
+	Position  int    `json:"position"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Status    string `json:"status"`
+}
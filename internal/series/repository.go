@@ -0,0 +1,242 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, series *Series) error
+	GetByID(ctx context.Context, id uint) (*Series, error)
+	GetBySlug(ctx context.Context, slug string) (*Series, error)
+	List(ctx context.Context, userID uint) ([]Series, error)
+	SlugOwner(ctx context.Context, slug string) (uint, error)
+	Update(ctx context.Context, id uint, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uint) error
+	Items(ctx context.Context, seriesID uint) ([]Item, error)
+	EntryFor(ctx context.Context, articleID uint) (*Entry, error)
+	ArticleExists(ctx context.Context, articleID uint) (bool, error)
+	CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error)
+	AddEntry(ctx context.Context, entry *Entry) error
+	RemoveEntry(ctx context.Context, seriesID, articleID uint) error
+	Reorder(ctx context.Context, seriesID uint, articleIDs []uint) error
+}
+
+type seriesRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &seriesRepository{db: db}
+}
+
+func (repo *seriesRepository) Create(ctx context.Context, series *Series) error {
+	if err := database.Conn(ctx, repo.db).Create(series).Error; err != nil {
+		return fmt.Errorf("repo: failed to create series: %w", err)
+	}
+	return nil
+}
+
+func (repo *seriesRepository) GetByID(ctx context.Context, id uint) (*Series, error) {
+	var series Series
+	if err := database.Conn(ctx, repo.db).First(&series, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get series by id %d: %w", id, err)
+	}
+	return &series, nil
+}
+
+func (repo *seriesRepository) GetBySlug(ctx context.Context, slug string) (*Series, error) {
+	var series Series
+	if err := database.Conn(ctx, repo.db).Where("slug = ?", slug).First(&series).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get series by slug %q: %w", slug, err)
+	}
+	return &series, nil
+}
+
+func (repo *seriesRepository) List(ctx context.Context, userID uint) ([]Series, error) {
+	query := database.Conn(ctx, repo.db).Order("created_at DESC, id DESC")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	var series []Series
+	if err := query.Find(&series).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to list series: %w", err)
+	}
+	return series, nil
+}
+
+func (repo *seriesRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	var ids []uint
+	if err := database.Conn(ctx, repo.db).Model(&Series{}).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("repo: failed to check series slug %q: %w", slug, err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+func (repo *seriesRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	updateResult := database.Conn(ctx, repo.db).Model(&Series{}).Where("id = ?", id).Updates(updates)
+	if updateResult.Error != nil {
+		return fmt.Errorf("repo: failed to update series %d: %w", id, updateResult.Error)
+	}
+	if updateResult.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (repo *seriesRepository) Delete(ctx context.Context, id uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&Entry{}).Error; err != nil {
+			return err
+		}
+		deleteResult := tx.Delete(&Series{}, id)
+		if deleteResult.Error != nil {
+			return deleteResult.Error
+		}
+		if deleteResult.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to delete series %d: %w", id, err)
+	}
+	return nil
+}
+
+func (repo *seriesRepository) Items(ctx context.Context, seriesID uint) ([]Item, error) {
+	var items []Item
+	err := database.Conn(ctx, repo.db).Table("series_entries e").
+		Select("e.article_id, e.position, a.title, a.slug, a.status").
+		Joins("JOIN articles a ON a.id = e.article_id AND a.deleted_at IS NULL").
+		Where("e.series_id = ?", seriesID).
+		Order("e.position ASC, e.article_id ASC").
+		Scan(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to get entries of series %d: %w", seriesID, err)
+	}
+	return items, nil
+}
+
+func (repo *seriesRepository) EntryFor(ctx context.Context, articleID uint) (*Entry, error) {
+	var entry Entry
+	if err := database.Conn(ctx, repo.db).Where("article_id = ?", articleID).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("repo: failed to get series entry of article %d: %w", articleID, err)
+	}
+	return &entry, nil
+}
+
+func (repo *seriesRepository) ArticleExists(ctx context.Context, articleID uint) (bool, error) {
+	var count int64
+	if err := database.Conn(ctx, repo.db).Model(&article.Article{}).Where("id = ?", articleID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("repo: failed to check article %d: %w", articleID, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *seriesRepository) CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, repo.db).Table("articles a").
+		Joins("LEFT JOIN article_authors aa ON aa.article_id = a.id AND aa.user_id = ?", userID).
+		Where("a.id = ? AND a.deleted_at IS NULL", articleID).
+		Where("aa.role IN ? OR (aa.role IS NULL AND a.user_id = ?)", []string{article.AuthorRoleOwner, article.AuthorRoleEditor}, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("repo: failed to check access to article %d: %w", articleID, err)
+	}
+	return count > 0, nil
+}
+
+func (repo *seriesRepository) AddEntry(ctx context.Context, entry *Entry) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if entry.Position == 0 {
+			var last int
+			if err := tx.Model(&Entry{}).Where("series_id = ?", entry.SeriesID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+				return err
+			}
+			entry.Position = last + 1
+		} else {
+			err := tx.Model(&Entry{}).
+				Where("series_id = ? AND position >= ?", entry.SeriesID, entry.Position).
+				UpdateColumn("position", gorm.Expr("position + 1")).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Create(entry).Error
+	})
+	if err != nil {
+		return fmt.Errorf("repo: failed to add article %d to series %d: %w", entry.ArticleID, entry.SeriesID, err)
+	}
+	return nil
+}
+
+func (repo *seriesRepository) RemoveEntry(ctx context.Context, seriesID, articleID uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		var entry Entry
+		if err := tx.Where("series_id = ? AND article_id = ?", seriesID, articleID).First(&entry).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrEntryNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&entry).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Entry{}).
+			Where("series_id = ? AND position > ?", seriesID, entry.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+	})
+	if errors.Is(err, ErrEntryNotFound) {
+		return ErrEntryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to remove article %d from series %d: %w", articleID, seriesID, err)
+	}
+	return nil
+}
+
+func (repo *seriesRepository) Reorder(ctx context.Context, seriesID uint, articleIDs []uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Entry{}).
+			Where("series_id = ? AND article_id NOT IN ?", seriesID, articleIDs).
+			UpdateColumn("position", gorm.Expr("position + ?", len(articleIDs))).Error
+		if err != nil {
+			return err
+		}
+		for i, articleID := range articleIDs {
+			err := tx.Model(&Entry{}).
+				Where("series_id = ? AND article_id = ?", seriesID, articleID).
+				UpdateColumn("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repo: failed to reorder series %d: %w", seriesID, err)
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"content-service/internal/article"
+)
+
+type CreateInput struct {
+	Title       string
+	Slug        string
+	Description string
+}
+
+type UpdateInput struct {
+	Title       *string
+	Slug        *string
+	Description *string
+}
+
+type EntryInput struct {
+	ArticleID uint
+	Position  int
+}
+
+type Service interface {
+	CreateSeries(ctx context.Context, userID uint, input CreateInput) (*Series, error)
+	GetSeries(ctx context.Context, viewer article.Viewer, idOrSlug string) (*Series, error)
+	ListSeries(ctx context.Context, userID uint) ([]Series, error)
+	UpdateSeries(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Series, error)
+	DeleteSeries(ctx context.Context, viewer article.Viewer, id uint) error
+	AddEntry(ctx context.Context, viewer article.Viewer, id uint, input EntryInput) (*Series, error)
+	RemoveEntry(ctx context.Context, viewer article.Viewer, id, articleID uint) error
+	ReorderEntries(ctx context.Context, viewer article.Viewer, id uint, articleIDs []uint) (*Series, error)
+	ArticleSeries(ctx context.Context, articleID uint) (*article.SeriesMembership, error)
+}
+
+type seriesService struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &seriesService{repo: repo}
+}
+
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("%w: title is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", fmt.Errorf("%w: title cannot exceed %d characters", ErrValidation, MaxTitleLength)
+	}
+	return title, nil
+}
+
+func validateDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return "", fmt.Errorf("%w: description cannot exceed %d characters", ErrValidation, MaxDescriptionLength)
+	}
+	return description, nil
+}
+
+func (svc *seriesService) uniqueSlug(ctx context.Context, source string, seriesID uint) (string, error) {
+	base := article.Slugify(source)
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		owner, err := svc.repo.SlugOwner(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if owner == 0 || owner == seriesID {
+			return candidate, nil
+		}
+	}
+}
+
+func (svc *seriesService) CreateSeries(ctx context.Context, userID uint, input CreateInput) (*Series, error) {
+	title, err := validateTitle(input.Title)
+	if err != nil {
+		return nil, err
+	}
+	description, err := validateDescription(input.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	source := strings.TrimSpace(input.Slug)
+	if source == "" {
+		source = title
+	}
+	slug, err := svc.uniqueSlug(ctx, source, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &Series{
+		Slug:        slug,
+		Title:       title,
+		Description: description,
+		UserID:      userID,
+	}
+	if err := svc.repo.Create(ctx, series); err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+	series.Entries = []Item{}
+	return series, nil
+}
+
+func (svc *seriesService) lookup(ctx context.Context, idOrSlug string) (*Series, error) {
+	if id, err := strconv.ParseUint(idOrSlug, 10, 32); err == nil {
+		return svc.repo.GetByID(ctx, uint(id))
+	}
+	return svc.repo.GetBySlug(ctx, idOrSlug)
+}
+
+func canManage(viewer article.Viewer, series *Series) bool {
+	return viewer.IsAdmin() || (viewer.UserID != 0 && viewer.UserID == series.UserID)
+}
+
+func (svc *seriesService) visibleItems(ctx context.Context, viewer article.Viewer, series *Series) ([]Item, error) {
+	items, err := svc.repo.Items(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series entries: %w", err)
+	}
+
+	showDrafts := canManage(viewer, series)
+	visible := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.Status != article.StatusPublished && !showDrafts {
+			continue
+		}
+		item.Position = len(visible) + 1
+		visible = append(visible, item)
+	}
+	return visible, nil
+}
+
+func (svc *seriesService) withEntries(ctx context.Context, viewer article.Viewer, series *Series) (*Series, error) {
+	items, err := svc.visibleItems(ctx, viewer, series)
+	if err != nil {
+		return nil, err
+	}
+	series.Entries = items
+	return series, nil
+}
+
+func (svc *seriesService) GetSeries(ctx context.Context, viewer article.Viewer, idOrSlug string) (*Series, error) {
+	series, err := svc.lookup(ctx, idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	return svc.withEntries(ctx, viewer, series)
+}
+
+func (svc *seriesService) ListSeries(ctx context.Context, userID uint) ([]Series, error) {
+	series, err := svc.repo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	return series, nil
+}
+
+func (svc *seriesService) managedSeries(ctx context.Context, viewer article.Viewer, id uint) (*Series, error) {
+	series, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(viewer, series) {
+		return nil, ErrForbidden
+	}
+	return series, nil
+}
+
+func (svc *seriesService) UpdateSeries(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Series, error) {
+	series, err := svc.managedSeries(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if input.Title != nil {
+		title, err := validateTitle(*input.Title)
+		if err != nil {
+			return nil, err
+		}
+		updates["title"] = title
+		series.Title = title
+	}
+
+	if input.Slug != nil {
+		source := strings.TrimSpace(*input.Slug)
+		if source == "" {
+			return nil, fmt.Errorf("%w: slug cannot be empty", ErrValidation)
+		}
+		slug, err := svc.uniqueSlug(ctx, source, series.ID)
+		if err != nil {
+			return nil, err
+		}
+		updates["slug"] = slug
+		series.Slug = slug
+	}
+
+	if input.Description != nil {
+		description, err := validateDescription(*input.Description)
+		if err != nil {
+			return nil, err
+		}
+		updates["description"] = description
+		series.Description = description
+	}
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+	}
+
+	if err := svc.repo.Update(ctx, id, updates); err != nil {
+		return nil, fmt.Errorf("failed to update series: %w", err)
+	}
+	return svc.withEntries(ctx, viewer, series)
+}
+
+func (svc *seriesService) DeleteSeries(ctx context.Context, viewer article.Viewer, id uint) error {
+	if _, err := svc.managedSeries(ctx, viewer, id); err != nil {
+		return err
+	}
+	if err := svc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+	return nil
+}
+
+func (svc *seriesService) AddEntry(ctx context.Context, viewer article.Viewer, id uint, input EntryInput) (*Series, error) {
+	series, err := svc.managedSeries(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.ArticleID == 0 {
+		return nil, fmt.Errorf("%w: article_id is required", ErrValidation)
+	}
+
+	exists, err := svc.repo.ArticleExists(ctx, input.ArticleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check article: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: article %d does not exist", ErrValidation, input.ArticleID)
+	}
+	if !viewer.IsAdmin() {
+		allowed, err := svc.repo.CanEditArticle(ctx, input.ArticleID, viewer.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check article: %w", err)
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: you cannot edit article %d", ErrValidation, input.ArticleID)
+		}
+	}
+
+	existing, err := svc.repo.EntryFor(ctx, input.ArticleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check article series: %w", err)
+	}
+	if existing != nil {
+		if existing.SeriesID == series.ID {
+			return nil, fmt.Errorf("%w: article %d is already part of this series", ErrValidation, input.ArticleID)
+		}
+		return nil, ErrInOtherSeries
+	}
+
+	items, err := svc.repo.Items(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series entries: %w", err)
+	}
+	if len(items) >= MaxEntries {
+		return nil, fmt.Errorf("%w: a series cannot have more than %d articles", ErrValidation, MaxEntries)
+	}
+
+	entry := &Entry{SeriesID: series.ID, ArticleID: input.ArticleID}
+	if input.Position != 0 {
+		if input.Position < 1 || input.Position > len(items)+1 {
+			return nil, fmt.Errorf("%w: position must be between 1 and %d", ErrValidation, len(items)+1)
+		}
+		if input.Position <= len(items) {
+			entry.Position = items[input.Position-1].Position
+		}
+	}
+
+	if err := svc.repo.AddEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to add series entry: %w", err)
+	}
+	return svc.withEntries(ctx, viewer, series)
+}
+
+func (svc *seriesService) RemoveEntry(ctx context.Context, viewer article.Viewer, id, articleID uint) error {
+	series, err := svc.managedSeries(ctx, viewer, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.repo.RemoveEntry(ctx, series.ID, articleID); err != nil {
+		if errors.Is(err, ErrEntryNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove series entry: %w", err)
+	}
+	return nil
+}
+
+func (svc *seriesService) ReorderEntries(ctx context.Context, viewer article.Viewer, id uint, articleIDs []uint) (*Series, error) {
+	series, err := svc.managedSeries(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := svc.repo.Items(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series entries: %w", err)
+	}
+	if len(articleIDs) != len(items) {
+		return nil, fmt.Errorf("%w: article_ids must list all %d articles of the series", ErrValidation, len(items))
+	}
+
+	members := make(map[uint]bool, len(items))
+	for _, item := range items {
+		members[item.ArticleID] = true
+	}
+	seen := make(map[uint]bool, len(articleIDs))
+	for _, articleID := range articleIDs {
+		if !members[articleID] {
+			return nil, fmt.Errorf("%w: article %d is not part of this series", ErrValidation, articleID)
+		}
+		if seen[articleID] {
+			return nil, fmt.Errorf("%w: article %d is listed more than once", ErrValidation, articleID)
+		}
+		seen[articleID] = true
+	}
+
+	if len(articleIDs) > 0 {
+		if err := svc.repo.Reorder(ctx, series.ID, articleIDs); err != nil {
+			return nil, fmt.Errorf("failed to reorder series: %w", err)
+		}
+	}
+	return svc.withEntries(ctx, viewer, series)
+}
+
+func seriesLink(item Item) *article.SeriesLink {
+	return &article.SeriesLink{ID: item.ArticleID, Slug: item.Slug, Title: item.Title}
+}
+
+func (svc *seriesService) ArticleSeries(ctx context.Context, articleID uint) (*article.SeriesMembership, error) {
+	entry, err := svc.repo.EntryFor(ctx, articleID)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	series, err := svc.repo.GetByID(ctx, entry.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := svc.repo.Items(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]Item, 0, len(items))
+	current := -1
+	for _, item := range items {
+		if item.ArticleID == articleID {
+			current = len(visible)
+		} else if item.Status != article.StatusPublished {
+			continue
+		}
+		visible = append(visible, item)
+	}
+	if current < 0 {
+		return nil, nil
+	}
+
+	membership := &article.SeriesMembership{
+		ID:       series.ID,
+		Slug:     series.Slug,
+		Title:    series.Title,
+		Position: current + 1,
+		Total:    len(visible),
+	}
+	if current > 0 {
+		membership.Prev = seriesLink(visible[current-1])
+	}
+	if current < len(visible)-1 {
+		membership.Next = seriesLink(visible[current+1])
+	}
+	return membership, nil
+}
@@ -0,0 +1,475 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+)
+
+type mockArticle struct {
+	userID  uint
+	title   string
+	slug    string
+	status  string
+	deleted bool
+}
+
+type mockRepository struct {
+	series   map[uint]*Series
+	entries  map[uint]*Entry
+	articles map[uint]*mockArticle
+	nextID   uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		series:   make(map[uint]*Series),
+		entries:  make(map[uint]*Entry),
+		articles: make(map[uint]*mockArticle),
+		nextID:   1,
+	}
+}
+
+func (m *mockRepository) Create(ctx context.Context, series *Series) error {
+	series.ID = m.nextID
+	m.nextID++
+	stored := *series
+	m.series[series.ID] = &stored
+	return nil
+}
+
+func (m *mockRepository) GetByID(ctx context.Context, id uint) (*Series, error) {
+	series, ok := m.series[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *series
+	return &copied, nil
+}
+
+func (m *mockRepository) GetBySlug(ctx context.Context, slug string) (*Series, error) {
+	for _, series := range m.series {
+		if series.Slug == slug {
+			copied := *series
+			return &copied, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *mockRepository) List(ctx context.Context, userID uint) ([]Series, error) {
+	var list []Series
+	for _, series := range m.series {
+		if userID == 0 || series.UserID == userID {
+			list = append(list, *series)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID > list[j].ID })
+	return list, nil
+}
+
+func (m *mockRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	for _, series := range m.series {
+		if series.Slug == slug {
+			return series.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+func (m *mockRepository) Update(ctx context.Context, id uint, updates map[string]interface{}) error {
+	series, ok := m.series[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if title, ok := updates["title"].(string); ok {
+		series.Title = title
+	}
+	if slug, ok := updates["slug"].(string); ok {
+		series.Slug = slug
+	}
+	if description, ok := updates["description"].(string); ok {
+		series.Description = description
+	}
+	return nil
+}
+
+func (m *mockRepository) Delete(ctx context.Context, id uint) error {
+	if _, ok := m.series[id]; !ok {
+		return ErrNotFound
+	}
+	for articleID, entry := range m.entries {
+		if entry.SeriesID == id {
+			delete(m.entries, articleID)
+		}
+	}
+	delete(m.series, id)
+	return nil
+}
+
+func (m *mockRepository) Items(ctx context.Context, seriesID uint) ([]Item, error) {
+	items := make([]Item, 0)
+	for _, entry := range m.entries {
+		a := m.articles[entry.ArticleID]
+		if entry.SeriesID != seriesID || a == nil || a.deleted {
+			continue
+		}
+		items = append(items, Item{ArticleID: entry.ArticleID, Position: entry.Position, Title: a.title, Slug: a.slug, Status: a.status})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ArticleID < items[j].ArticleID
+	})
+	return items, nil
+}
+
+func (m *mockRepository) EntryFor(ctx context.Context, articleID uint) (*Entry, error) {
+	entry, ok := m.entries[articleID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *entry
+	return &copied, nil
+}
+
+func (m *mockRepository) ArticleExists(ctx context.Context, articleID uint) (bool, error) {
+	a, ok := m.articles[articleID]
+	return ok && !a.deleted, nil
+}
+
+func (m *mockRepository) CanEditArticle(ctx context.Context, articleID, userID uint) (bool, error) {
+	a, ok := m.articles[articleID]
+	return ok && !a.deleted && a.userID == userID, nil
+}
+
+func (m *mockRepository) AddEntry(ctx context.Context, entry *Entry) error {
+	if entry.Position == 0 {
+		for _, existing := range m.entries {
+			if existing.SeriesID == entry.SeriesID && existing.Position > entry.Position {
+				entry.Position = existing.Position
+			}
+		}
+		entry.Position++
+	} else {
+		for _, existing := range m.entries {
+			if existing.SeriesID == entry.SeriesID && existing.Position >= entry.Position {
+				existing.Position++
+			}
+		}
+	}
+	stored := *entry
+	m.entries[entry.ArticleID] = &stored
+	return nil
+}
+
+func (m *mockRepository) RemoveEntry(ctx context.Context, seriesID, articleID uint) error {
+	entry, ok := m.entries[articleID]
+	if !ok || entry.SeriesID != seriesID {
+		return ErrEntryNotFound
+	}
+	delete(m.entries, articleID)
+	for _, existing := range m.entries {
+		if existing.SeriesID == seriesID && existing.Position > entry.Position {
+			existing.Position--
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) Reorder(ctx context.Context, seriesID uint, articleIDs []uint) error {
+	for _, existing := range m.entries {
+		if existing.SeriesID == seriesID {
+			existing.Position += len(articleIDs)
+		}
+	}
+	for i, articleID := range articleIDs {
+		m.entries[articleID].Position = i + 1
+	}
+	return nil
+}
+
+var (
+	owner    = article.Viewer{UserID: 1}
+	stranger = article.Viewer{UserID: 2}
+	admin    = article.Viewer{UserID: 3, Role: auth.RoleAdmin}
+)
+
+func seedSeries(t *testing.T, repo *mockRepository, svc Service) *Series {
+	t.Helper()
+	repo.articles[10] = &mockArticle{userID: 1, title: "Part one", slug: "part-one", status: article.StatusPublished}
+	repo.articles[11] = &mockArticle{userID: 1, title: "Part two", slug: "part-two", status: article.StatusDraft}
+	repo.articles[12] = &mockArticle{userID: 1, title: "Part three", slug: "part-three", status: article.StatusPublished}
+	repo.articles[20] = &mockArticle{userID: 2, title: "Someone else", slug: "someone-else", status: article.StatusPublished}
+
+	series, err := svc.CreateSeries(context.Background(), owner.UserID, CreateInput{Title: "Learning Go", Description: "  A short course  "})
+	if err != nil {
+		t.Fatalf("Failed to create test series: %v", err)
+	}
+	for _, articleID := range []uint{10, 11, 12} {
+		if _, err := svc.AddEntry(context.Background(), owner, series.ID, EntryInput{ArticleID: articleID}); err != nil {
+			t.Fatalf("Failed to add test entry: %v", err)
+		}
+	}
+	return series
+}
+
+func entryIDs(series *Series) []uint {
+	ids := make([]uint, 0, len(series.Entries))
+	for _, item := range series.Entries {
+		ids = append(ids, item.ArticleID)
+	}
+	return ids
+}
+
+func sameIDs(got, want []uint) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCreateSeries(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	seedSeries(t, repo, svc)
+
+	tests := []struct {
+		name     string
+		input    CreateInput
+		wantSlug string
+		wantErr  error
+	}{
+		{name: "Slug from title", input: CreateInput{Title: "Web Basics"}, wantSlug: "web-basics"},
+		{name: "Duplicate title gets suffix", input: CreateInput{Title: "Learning Go"}, wantSlug: "learning-go-2"},
+		{name: "Explicit slug is normalized", input: CreateInput{Title: "Other", Slug: "My Custom Slug"}, wantSlug: "my-custom-slug"},
+		{name: "Empty title", input: CreateInput{Title: "   "}, wantErr: ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := svc.CreateSeries(context.Background(), owner.UserID, tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateSeries() unexpected error: %v", err)
+			}
+			if series.Slug != tt.wantSlug {
+				t.Errorf("Expected slug %q, got %q", tt.wantSlug, series.Slug)
+			}
+		})
+	}
+}
+
+func TestGetSeriesVisibility(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	seeded := seedSeries(t, repo, svc)
+
+	if seeded.Description != "A short course" {
+		t.Errorf("Expected trimmed description, got %q", seeded.Description)
+	}
+
+	tests := []struct {
+		name     string
+		viewer   article.Viewer
+		idOrSlug string
+		want     []uint
+	}{
+		{name: "Owner sees drafts", viewer: owner, idOrSlug: "1", want: []uint{10, 11, 12}},
+		{name: "Admin sees drafts", viewer: admin, idOrSlug: "learning-go", want: []uint{10, 11, 12}},
+		{name: "Public sees published only", viewer: article.Viewer{}, idOrSlug: "learning-go", want: []uint{10, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := svc.GetSeries(context.Background(), tt.viewer, tt.idOrSlug)
+			if err != nil {
+				t.Fatalf("GetSeries() unexpected error: %v", err)
+			}
+			if got := entryIDs(series); !sameIDs(got, tt.want) {
+				t.Errorf("Expected entries %v, got %v", tt.want, got)
+			}
+			for i, item := range series.Entries {
+				if item.Position != i+1 {
+					t.Errorf("Expected entry %d at position %d, got %d", item.ArticleID, i+1, item.Position)
+				}
+			}
+		})
+	}
+
+	if _, err := svc.GetSeries(context.Background(), owner, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAddEntry(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	seeded := seedSeries(t, repo, svc)
+	repo.articles[13] = &mockArticle{userID: 1, title: "Intro", slug: "intro", status: article.StatusPublished}
+	other, err := svc.CreateSeries(context.Background(), owner.UserID, CreateInput{Title: "Other"})
+	if err != nil {
+		t.Fatalf("Failed to create second series: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		viewer   article.Viewer
+		seriesID uint
+		input    EntryInput
+		wantErr  error
+	}{
+		{name: "Not the series owner", viewer: stranger, seriesID: seeded.ID, input: EntryInput{ArticleID: 20}, wantErr: ErrForbidden},
+		{name: "Article of another user", viewer: owner, seriesID: seeded.ID, input: EntryInput{ArticleID: 20}, wantErr: ErrValidation},
+		{name: "Unknown article", viewer: admin, seriesID: seeded.ID, input: EntryInput{ArticleID: 99}, wantErr: ErrValidation},
+		{name: "Already in this series", viewer: owner, seriesID: seeded.ID, input: EntryInput{ArticleID: 10}, wantErr: ErrValidation},
+		{name: "Already in another series", viewer: owner, seriesID: other.ID, input: EntryInput{ArticleID: 10}, wantErr: ErrInOtherSeries},
+		{name: "Position out of range", viewer: owner, seriesID: seeded.ID, input: EntryInput{ArticleID: 13, Position: 5}, wantErr: ErrValidation},
+		{name: "Unknown series", viewer: owner, seriesID: 99, input: EntryInput{ArticleID: 13}, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AddEntry(context.Background(), tt.viewer, tt.seriesID, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	series, err := svc.AddEntry(context.Background(), owner, seeded.ID, EntryInput{ArticleID: 13, Position: 1})
+	if err != nil {
+		t.Fatalf("AddEntry() unexpected error: %v", err)
+	}
+	if got, want := entryIDs(series), []uint{13, 10, 11, 12}; !sameIDs(got, want) {
+		t.Errorf("Expected entries %v, got %v", want, got)
+	}
+
+	repo.articles[20].userID = 1
+	series, err = svc.AddEntry(context.Background(), admin, seeded.ID, EntryInput{ArticleID: 20})
+	if err != nil {
+		t.Fatalf("AddEntry() as admin unexpected error: %v", err)
+	}
+	if got, want := entryIDs(series), []uint{13, 10, 11, 12, 20}; !sameIDs(got, want) {
+		t.Errorf("Expected entries %v, got %v", want, got)
+	}
+}
+
+func TestReorderAndRemoveEntries(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	seeded := seedSeries(t, repo, svc)
+
+	tests := []struct {
+		name string
+		ids  []uint
+	}{
+		{name: "Missing entry", ids: []uint{12, 10}},
+		{name: "Duplicate entry", ids: []uint{12, 12, 10}},
+		{name: "Foreign article", ids: []uint{12, 20, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.ReorderEntries(context.Background(), owner, seeded.ID, tt.ids); !errors.Is(err, ErrValidation) {
+				t.Errorf("Expected ErrValidation, got %v", err)
+			}
+		})
+	}
+
+	if _, err := svc.ReorderEntries(context.Background(), stranger, seeded.ID, []uint{12, 11, 10}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+
+	series, err := svc.ReorderEntries(context.Background(), owner, seeded.ID, []uint{12, 11, 10})
+	if err != nil {
+		t.Fatalf("ReorderEntries() unexpected error: %v", err)
+	}
+	if got, want := entryIDs(series), []uint{12, 11, 10}; !sameIDs(got, want) {
+		t.Errorf("Expected entries %v, got %v", want, got)
+	}
+
+	if err := svc.RemoveEntry(context.Background(), owner, seeded.ID, 11); err != nil {
+		t.Fatalf("RemoveEntry() unexpected error: %v", err)
+	}
+	if err := svc.RemoveEntry(context.Background(), owner, seeded.ID, 11); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Expected ErrEntryNotFound, got %v", err)
+	}
+	series, err = svc.GetSeries(context.Background(), owner, "learning-go")
+	if err != nil {
+		t.Fatalf("GetSeries() unexpected error: %v", err)
+	}
+	if got, want := entryIDs(series), []uint{12, 10}; !sameIDs(got, want) {
+		t.Errorf("Expected entries %v, got %v", want, got)
+	}
+}
+
+func TestArticleSeries(t *testing.T) {
+	repo := newMockRepository()
+	svc := NewService(repo)
+	seeded := seedSeries(t, repo, svc)
+
+	tests := []struct {
+		name         string
+		articleID    uint
+		wantPosition int
+		wantTotal    int
+		wantPrev     uint
+		wantNext     uint
+	}{
+		{name: "First article skips draft neighbour", articleID: 10, wantPosition: 1, wantTotal: 2, wantNext: 12},
+		{name: "Last article", articleID: 12, wantPosition: 2, wantTotal: 2, wantPrev: 10},
+		{name: "Draft article sits between published ones", articleID: 11, wantPosition: 2, wantTotal: 3, wantPrev: 10, wantNext: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			membership, err := svc.ArticleSeries(context.Background(), tt.articleID)
+			if err != nil {
+				t.Fatalf("ArticleSeries() unexpected error: %v", err)
+			}
+			if membership == nil || membership.ID != seeded.ID || membership.Slug != "learning-go" {
+				t.Fatalf("Expected membership of series %d, got %+v", seeded.ID, membership)
+			}
+			if membership.Position != tt.wantPosition || membership.Total != tt.wantTotal {
+				t.Errorf("Expected position %d of %d, got %d of %d", tt.wantPosition, tt.wantTotal, membership.Position, membership.Total)
+			}
+			if (membership.Prev == nil) != (tt.wantPrev == 0) || (membership.Prev != nil && membership.Prev.ID != tt.wantPrev) {
+				t.Errorf("Expected prev %d, got %+v", tt.wantPrev, membership.Prev)
+			}
+			if (membership.Next == nil) != (tt.wantNext == 0) || (membership.Next != nil && membership.Next.ID != tt.wantNext) {
+				t.Errorf("Expected next %d, got %+v", tt.wantNext, membership.Next)
+			}
+		})
+	}
+
+	membership, err := svc.ArticleSeries(context.Background(), 20)
+	if err != nil || membership != nil {
+		t.Errorf("Expected no membership for unrelated article, got %+v (%v)", membership, err)
+	}
+
+	if err := svc.DeleteSeries(context.Background(), stranger, seeded.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+	if err := svc.DeleteSeries(context.Background(), owner, seeded.ID); err != nil {
+		t.Fatalf("DeleteSeries() unexpected error: %v", err)
+	}
+	membership, err = svc.ArticleSeries(context.Background(), 10)
+	if err != nil || membership != nil {
+		t.Errorf("Expected no membership after deleting series, got %+v (%v)", membership, err)
+	}
+}
@@ -0,0 +1,6 @@
+package auth
+
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+)
@@ -0,0 +1,63 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+type Info struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	BuildDate  string `json:"build_date,omitempty"`
+	GoVersion  string `json:"go_version"`
+	APIVersion string `json:"api_version"`
+}
+
+var resolveOnce sync.Once
+
+func resolve() {
+	if Commit != "" {
+		if len(Commit) > 12 {
+			Commit = Commit[:12]
+		}
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			Commit = setting.Value
+			if len(Commit) > 12 {
+				Commit = Commit[:12]
+			}
+		}
+	}
+}
+
+func APIVersion() string {
+	resolveOnce.Do(resolve)
+	if Commit == "" {
+		return Version
+	}
+	return Version + "+" + Commit
+}
+
+func Get() Info {
+	resolveOnce.Do(resolve)
+	return Info{
+		Version:    Version,
+		Commit:     Commit,
+		BuildDate:  BuildDate,
+		GoVersion:  runtime.Version(),
+		APIVersion: APIVersion(),
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
+	Close() error
+}
+
+type Flusher interface {
+	Flush(ctx context.Context) (int, error)
+}
+
+func New(cfg config.CacheConfig) (Cache, error) {
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewLRUCache(cfg.MemoryMaxEntries), nil
+	case BackendRedis:
+		return NewRedisCache(cfg.Redis)
+	default:
+		return nil, fmt.Errorf("no cache for backend %q", cfg.Backend)
+	}
+}
+
+type Stats struct {
+	Backend  string  `json:"backend"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Errors   int64   `json:"errors"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+type Metrics struct {
+	backend string
+	hits    atomic.Int64
+	misses  atomic.Int64
+	errors  atomic.Int64
+}
+
+func NewMetrics(backend string) *Metrics {
+	return &Metrics{backend: backend}
+}
+
+func (metrics *Metrics) Hit() {
+	metrics.hits.Add(1)
+}
+
+func (metrics *Metrics) Miss() {
+	metrics.misses.Add(1)
+}
+
+func (metrics *Metrics) Error() {
+	metrics.errors.Add(1)
+}
+
+func (metrics *Metrics) Snapshot() Stats {
+	stats := Stats{
+		Backend: metrics.backend,
+		Hits:    metrics.hits.Load(),
+		Misses:  metrics.misses.Load(),
+		Errors:  metrics.errors.Load(),
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
@@ -0,0 +1,17 @@
+package cache
+
+import "testing"
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics(BackendRedis)
+	metrics.Hit()
+	metrics.Hit()
+	metrics.Hit()
+	metrics.Miss()
+	metrics.Error()
+
+	stats := metrics.Snapshot()
+	if stats.Backend != BackendRedis || stats.Hits != 3 || stats.Misses != 1 || stats.Errors != 1 || stats.HitRatio != 0.75 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
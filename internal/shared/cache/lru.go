@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+type LRUCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	counters   map[string]int64
+	maxEntries int
+	now        func() time.Time
+}
+
+func NewLRUCache(maxEntries int) *LRUCache {
+	return &LRUCache{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		counters:   make(map[string]int64),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+func (cache *LRUCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if n, ok := cache.counters[key]; ok {
+		return []byte(strconv.FormatInt(n, 10)), true, nil
+	}
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !cache.now().Before(entry.expires) {
+		cache.remove(element)
+		return nil, false, nil
+	}
+	cache.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (cache *LRUCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = cache.now().Add(ttl)
+	}
+	if element, ok := cache.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expires = expires
+		cache.order.MoveToFront(element)
+		return nil
+	}
+
+	cache.entries[key] = cache.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for cache.order.Len() > cache.maxEntries {
+		cache.remove(cache.order.Back())
+	}
+	return nil
+}
+
+func (cache *LRUCache) remove(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.entries, element.Value.(*lruEntry).key)
+}
+
+func (cache *LRUCache) Delete(ctx context.Context, keys ...string) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := cache.entries[key]; ok {
+			cache.remove(element)
+		}
+		delete(cache.counters, key)
+	}
+	return nil
+}
+
+func (cache *LRUCache) Incr(ctx context.Context, key string) (int64, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.counters[key]++
+	return cache.counters[key], nil
+}
+
+func (cache *LRUCache) Len() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.order.Len()
+}
+
+func (cache *LRUCache) Flush(ctx context.Context) (int, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	flushed := cache.order.Len()
+	cache.entries = make(map[string]*list.Element)
+	cache.order.Init()
+	for key := range cache.counters {
+		cache.counters[key]++
+	}
+	return flushed, nil
+}
+
+func (cache *LRUCache) Close() error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewLRUCache(2)
+	cache.now = func() time.Time { return now }
+
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+	if _, ok, _ := cache.Get(ctx, "a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	_ = cache.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := cache.Get(ctx, "b"); ok {
+		t.Error("Expected the least recently used key to be evicted")
+	}
+	if value, ok, _ := cache.Get(ctx, "a"); !ok || string(value) != "1" {
+		t.Errorf("Expected the recently used key to survive, got %q (%v)", value, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := cache.Get(ctx, "c"); ok {
+		t.Error("Expected the value to expire after its TTL")
+	}
+
+	_ = cache.Delete(ctx, "a")
+	if _, ok, _ := cache.Get(ctx, "a"); ok {
+		t.Error("Expected the deleted key to be gone")
+	}
+}
+
+func TestLRUCacheCounters(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(1)
+
+	for i := range 2 {
+		if n, _ := cache.Incr(ctx, "version"); n != int64(i+1) {
+			t.Errorf("Expected version %d, got %d", i+1, n)
+		}
+	}
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+	if value, ok, _ := cache.Get(ctx, "version"); !ok || string(value) != "2" {
+		t.Errorf("Expected counters to survive eviction, got %q (%v)", value, ok)
+	}
+
+	flushed, err := cache.Flush(ctx)
+	if err != nil || flushed != 1 || cache.Len() != 0 {
+		t.Errorf("Expected 1 flushed entry and an empty cache, got %d and %d (%v)", flushed, cache.Len(), err)
+	}
+	if value, _, _ := cache.Get(ctx, "version"); string(value) != "3" {
+		t.Errorf("Expected flush to advance counters, got %q", value)
+	}
+}
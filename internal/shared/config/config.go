@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"mime"
+	"net/mail"
+	"net/netip"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -11,33 +15,351 @@ import (
 	"github.com/joho/godotenv"
 )
 
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
 type Config struct {
-	Environment string
-	DB          DBConfig
-	App         AppConfig
-	JWT         JWTConfig
+	Environment  string
+	DB           DBConfig
+	App          AppConfig
+	JWT          JWTConfig
+	Auth         AuthConfig
+	Compression  CompressionConfig
+	Article      ArticleConfig
+	Maintenance  MaintenanceConfig
+	RateLimit    RateLimitConfig
+	Body         BodyConfig
+	Admin        AdminConfig
+	Sentry       SentryConfig
+	ContentStore ContentStoreConfig
+	Moderation   ModerationConfig
+	Media        MediaConfig
+	Feed         FeedConfig
+	Webhook      WebhookConfig
+	Events       EventsConfig
+	Outbox       OutboxConfig
+	Search       SearchConfig
+	Cache        CacheConfig
+	Notification NotificationConfig
+	Trending     TrendingConfig
+	Report       ReportConfig
+	GraphQL      GraphQLConfig
+	GRPC         GRPCConfig
+	Idempotency  IdempotencyConfig
+	Health       HealthConfig
 }
 
 type DBConfig struct {
-	Host            string
-	Port            int
-	User            string
-	Password        string
-	Name            string
-	SSLMode         string
-	MaxOpenConns    int
-	MaxIdleConns    int
-	ConnMaxLifetime time.Duration
-	ConnMaxIdleTime time.Duration
+	Driver             string
+	SQLitePath         string
+	Host               string
+	Port               int
+	User               string
+	Password           string
+	Name               string
+	SSLMode            string
+	MaxOpenConns       int
+	MaxIdleConns       int
+	ConnMaxLifetime    time.Duration
+	ConnMaxIdleTime    time.Duration
+	SlowQueryThreshold time.Duration
+	PoolStatsInterval  time.Duration
+	PoolWaitWarn       time.Duration
+	PoolSaturation     float64
+	QueryTimeout       time.Duration
+	ConnectAttempts    int
+	RetryAttempts      int
+	RetryBackoffMin    time.Duration
+	RetryBackoffMax    time.Duration
+
+	ReplicaDSNs          []string
+	ReplicaCheckInterval time.Duration
+	ReplicaMaxLag        time.Duration
 }
 
 type AppConfig struct {
-	Port    int
-	GinMode string
+	Port           int
+	GinMode        string
+	ClientIPHeader string
+	TrustedProxies []string
+	AllowedMethods []string
+
+	SlowRequestThreshold time.Duration
+
+	LogHTTPBody         bool
+	LogHTTPBodyMaxBytes int
+}
+
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	SampleRate  float64
+}
+
+type AdminConfig struct {
+	AllowedIPs []string
+	DeniedIPs  []string
 }
 
 type JWTConfig struct {
-	Secret string
+	Secret        string
+	RefreshSecret string
+	AccessTTL     time.Duration
+	RefreshTTL    time.Duration
+	JWKSURL       string
+	PublicKeyFile string
+	Issuer        string
+	Audience      string
+	AllowHS256    bool
+	JWKSRefresh   time.Duration
+}
+
+type AuthConfig struct {
+	Mode          string
+	Introspection IntrospectionConfig
+	Signing       SigningConfig
+}
+
+type SigningConfig struct {
+	Secret    string
+	UserID    uint
+	Role      string
+	Scopes    []string
+	Tolerance time.Duration
+}
+
+type IntrospectionConfig struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	CacheTTL     time.Duration
+	CacheSize    int
+}
+
+type ArticleConfig struct {
+	MinContentLength int
+	SeedOnEmpty      bool
+	PurgeAfter       time.Duration
+	PurgeInterval    time.Duration
+	ViewsFlush       time.Duration
+	CountMode        string
+	CountEstimateMin int64
+	CountCacheTTL    time.Duration
+	BatchSize        int
+	CursorSecret     string
+}
+
+type TrendingConfig struct {
+	Window     time.Duration
+	Refresh    time.Duration
+	Gravity    float64
+	LikeWeight float64
+	Size       int
+}
+
+type HealthConfig struct {
+	Timeout time.Duration
+}
+
+type IdempotencyConfig struct {
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
+
+type GRPCConfig struct {
+	Enabled    bool
+	Port       int
+	Reflection bool
+}
+
+type GraphQLConfig struct {
+	Playground      bool
+	ComplexityLimit int
+}
+
+type ReportConfig struct {
+	RateLimit          int
+	RateWindow         time.Duration
+	UnpublishThreshold int
+}
+
+type ModerationConfig struct {
+	Enabled      bool
+	TrustedRoles []string
+}
+
+type MediaConfig struct {
+	Backend          string
+	Dir              string
+	Bucket           string
+	MaxBytes         int64
+	AllowedTypes     []string
+	ThumbnailSizes   []int
+	ThumbnailWorkers int
+	ThumbnailQueue   int
+}
+
+type FeedConfig struct {
+	Title           string
+	Description     string
+	SiteURL         string
+	ArticleURL      string
+	Items           int
+	MaxAge          time.Duration
+	SitemapURLs     int
+	SitemapCacheTTL time.Duration
+}
+
+type OutboxConfig struct {
+	Bus          string
+	Format       string
+	BatchSize    int
+	PollInterval time.Duration
+	MaxAttempts  int
+	HealthAddr   string
+	InProcess    bool
+	Kafka        KafkaConfig
+	NATS         NATSConfig
+}
+
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+}
+
+type NATSConfig struct {
+	URL           string
+	Stream        string
+	Subject       string
+	ReconnectWait time.Duration
+}
+
+type SearchConfig struct {
+	Backend      string
+	URL          string
+	Index        string
+	Username     string
+	Password     string
+	Timeout      time.Duration
+	ReindexBatch int
+}
+
+type CacheConfig struct {
+	Backend          string
+	TTL              time.Duration
+	ListTTL          time.Duration
+	ListPages        int
+	MemoryMaxEntries int
+	Redis            RedisConfig
+}
+
+type NotificationConfig struct {
+	Provider    string
+	From        string
+	Events      []string
+	DryRun      bool
+	TemplateDir string
+	QueueSize   int
+	Workers     int
+	MaxAttempts int
+	RetryDelay  time.Duration
+	Timeout     time.Duration
+	SMTP        SMTPConfig
+	SendGrid    SendGridConfig
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+type SendGridConfig struct {
+	URL    string
+	APIKey string
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+type EventsConfig struct {
+	QueueSize     int
+	Workers       int
+	DrainTimeout  time.Duration
+	LogEvents     []string
+	LogSampleRate float64
+}
+
+type WebhookConfig struct {
+	MaxAttempts         int
+	BackoffBase         time.Duration
+	BackoffMax          time.Duration
+	Timeout             time.Duration
+	PollInterval        time.Duration
+	AllowPrivateTargets bool
+}
+
+type MaintenanceConfig struct {
+	Enabled    bool
+	RetryAfter time.Duration
+}
+
+type BodyConfig struct {
+	MaxBytes     int64
+	ContentTypes []string
+}
+
+type RateLimitConfig struct {
+	Backend        string
+	WarnThreshold  int
+	ReadBurst      int
+	ReadPerSecond  float64
+	WriteBurst     int
+	WritePerSecond float64
+}
+
+type ContentStoreConfig struct {
+	Backend         string
+	InlineThreshold int
+	S3              S3Config
+}
+
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Region    string
+	UseSSL    bool
+}
+
+type CompressionConfig struct {
+	ContentTypes []string
+}
+
+var defaultAllowedMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+var defaultMediaTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+}
+
+var defaultCompressContentTypes = []string{
+	"application/json",
+	"application/vnd.api+json",
+	"application/msgpack",
+	"application/xml",
+	"application/rss+xml",
+	"application/atom+xml",
+	"text/plain",
+	"text/html",
+	"text/xml",
 }
 
 func LoadConfig() (*Config, error) {
@@ -52,6 +374,24 @@ func LoadConfig() (*Config, error) {
 	if env != "production" && len(jwtSecret) < 32 {
 		jwtSecret = "dev-secret-key-min-32-chars------"
 	}
+	refreshSecret := getEnv("JWT_REFRESH_SECRET", "")
+	if env != "production" && len(refreshSecret) < 32 {
+		refreshSecret = "dev-refresh-secret-min-32-chars--"
+	}
+	cursorSecret := getEnv("CURSOR_SECRET", "")
+	if env != "production" && len(cursorSecret) < 32 {
+		cursorSecret = "dev-cursor-secret-min-32-chars---"
+	}
+
+	jwksURL := getEnv("JWT_JWKS_URL", "")
+	jwtPublicKeyFile := getEnv("JWT_PUBLIC_KEY_FILE", "")
+
+	siteURL := strings.TrimSuffix(getEnv("FEED_SITE_URL", "http://localhost:8080"), "/")
+
+	eventLogSampleRate := 1.0
+	if env == "production" {
+		eventLogSampleRate = 0.1
+	}
 
 	if ginMode == "" {
 		if env == "production" {
@@ -64,23 +404,253 @@ func LoadConfig() (*Config, error) {
 	cfg := &Config{
 		Environment: env,
 		DB: DBConfig{
-			Host:            getEnv("DB_HOST", "localhost"),
-			Port:            getEnvInt("DB_PORT", 5432),
-			User:            getEnv("DB_USER", "postgres"),
-			Password:        getEnv("DB_PASSWORD", "postgres"),
-			Name:            getEnv("DB_NAME", "content_db"),
-			SSLMode:         getEnv("DB_SSLMODE", "disable"),
-			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_MIN", 5)) * time.Minute,
-			ConnMaxIdleTime: time.Duration(getEnvInt("DB_CONN_MAX_IDLE_TIME_MIN", 2)) * time.Minute,
+			Driver:             strings.ToLower(getEnv("DB_DRIVER", "postgres")),
+			SQLitePath:         getEnv("DB_SQLITE_PATH", "content.db"),
+			Host:               getEnv("DB_HOST", "localhost"),
+			Port:               getEnvInt("DB_PORT", 5432),
+			User:               getEnv("DB_USER", "postgres"),
+			Password:           getEnv("DB_PASSWORD", "postgres"),
+			Name:               getEnv("DB_NAME", "content_db"),
+			SSLMode:            getEnv("DB_SSLMODE", "disable"),
+			MaxOpenConns:       getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:       getEnvInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime:    time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_MIN", 5)) * time.Minute,
+			ConnMaxIdleTime:    time.Duration(getEnvInt("DB_CONN_MAX_IDLE_TIME_MIN", 2)) * time.Minute,
+			SlowQueryThreshold: time.Duration(getEnvInt("DB_SLOW_QUERY_MS", 200)) * time.Millisecond,
+			PoolStatsInterval:  time.Duration(getEnvInt("DB_POOL_STATS_INTERVAL_SEC", 60)) * time.Second,
+			PoolWaitWarn:       time.Duration(getEnvInt("DB_POOL_WAIT_WARN_MS", 1000)) * time.Millisecond,
+			PoolSaturation:     getEnvFloat("DB_POOL_SATURATION", 0.9),
+			QueryTimeout:       time.Duration(getEnvInt("DB_QUERY_TIMEOUT_MS", 5000)) * time.Millisecond,
+			ConnectAttempts:    getEnvInt("DB_CONNECT_ATTEMPTS", 10),
+			RetryAttempts:      getEnvInt("DB_RETRY_ATTEMPTS", 3),
+			RetryBackoffMin:    time.Duration(getEnvInt("DB_RETRY_BACKOFF_MIN_MS", 100)) * time.Millisecond,
+			RetryBackoffMax:    time.Duration(getEnvInt("DB_RETRY_BACKOFF_MAX_MS", 5000)) * time.Millisecond,
+
+			ReplicaDSNs:          getEnvValues("DB_REPLICA_DSNS"),
+			ReplicaCheckInterval: time.Duration(getEnvInt("DB_REPLICA_CHECK_INTERVAL_SEC", 10)) * time.Second,
+			ReplicaMaxLag:        time.Duration(getEnvInt("DB_REPLICA_MAX_LAG_MS", 10000)) * time.Millisecond,
 		},
 		App: AppConfig{
-			Port:    getEnvInt("PORT", 8080),
-			GinMode: ginMode,
+			Port:           getEnvInt("PORT", 8080),
+			GinMode:        ginMode,
+			ClientIPHeader: getEnv("CLIENT_IP_HEADER", ""),
+			TrustedProxies: getEnvList("TRUSTED_PROXIES", nil),
+			AllowedMethods: getEnvMethods("ALLOWED_METHODS", defaultAllowedMethods),
+
+			SlowRequestThreshold: time.Duration(getEnvInt("SLOW_REQUEST_MS", 1000)) * time.Millisecond,
+
+			LogHTTPBody:         getEnvBool("LOG_HTTP_BODY", false),
+			LogHTTPBodyMaxBytes: getEnvInt("LOG_HTTP_BODY_MAX_BYTES", 4096),
 		},
 		JWT: JWTConfig{
-			Secret: jwtSecret,
+			Secret:        jwtSecret,
+			RefreshSecret: refreshSecret,
+			AccessTTL:     time.Duration(getEnvInt("JWT_ACCESS_TTL_MIN", 15)) * time.Minute,
+			RefreshTTL:    time.Duration(getEnvInt("JWT_REFRESH_TTL_HOURS", 720)) * time.Hour,
+			JWKSURL:       jwksURL,
+			PublicKeyFile: jwtPublicKeyFile,
+			Issuer:        getEnv("JWT_ISSUER", ""),
+			Audience:      getEnv("JWT_AUDIENCE", ""),
+			AllowHS256:    getEnvBool("JWT_ALLOW_HS256", jwksURL == "" && jwtPublicKeyFile == ""),
+			JWKSRefresh:   time.Duration(getEnvInt("JWT_JWKS_REFRESH_MIN", 60)) * time.Minute,
+		},
+		Auth: AuthConfig{
+			Mode: strings.ToLower(getEnv("AUTH_MODE", "jwt")),
+			Introspection: IntrospectionConfig{
+				URL:          getEnv("INTROSPECTION_URL", ""),
+				ClientID:     getEnv("INTROSPECTION_CLIENT_ID", ""),
+				ClientSecret: getEnv("INTROSPECTION_CLIENT_SECRET", ""),
+				CacheTTL:     time.Duration(getEnvInt("INTROSPECTION_CACHE_TTL_SEC", 60)) * time.Second,
+				CacheSize:    getEnvInt("INTROSPECTION_CACHE_SIZE", 10000),
+			},
+			Signing: SigningConfig{
+				Secret:    getEnv("SIGNING_SECRET", ""),
+				UserID:    uint(getEnvInt("SIGNING_USER_ID", 0)),
+				Role:      getEnv("SIGNING_ROLE", ""),
+				Scopes:    getEnvList("SIGNING_SCOPES", nil),
+				Tolerance: time.Duration(getEnvInt("SIGNING_TOLERANCE_SEC", 300)) * time.Second,
+			},
+		},
+		Compression: CompressionConfig{
+			ContentTypes: getEnvList("COMPRESS_CONTENT_TYPES", defaultCompressContentTypes),
+		},
+		Article: ArticleConfig{
+			MinContentLength: getEnvInt("ARTICLE_MIN_CONTENT_LENGTH", 1),
+			SeedOnEmpty:      getEnvBool("SEED_ON_EMPTY", false),
+			PurgeAfter:       time.Duration(getEnvInt("ARTICLE_PURGE_AFTER_DAYS", 0)) * 24 * time.Hour,
+			PurgeInterval:    time.Duration(getEnvInt("ARTICLE_PURGE_INTERVAL_MIN", 60)) * time.Minute,
+			ViewsFlush:       time.Duration(getEnvInt("ARTICLE_VIEWS_FLUSH_SEC", 10)) * time.Second,
+			CountMode:        strings.ToLower(getEnv("ARTICLE_COUNT_MODE", "exact")),
+			CountEstimateMin: int64(getEnvInt("ARTICLE_COUNT_ESTIMATE_MIN", 10000)),
+			CountCacheTTL:    time.Duration(getEnvInt("ARTICLE_COUNT_CACHE_TTL_SEC", 30)) * time.Second,
+			BatchSize:        getEnvInt("ARTICLE_BATCH_SIZE", 100),
+			CursorSecret:     cursorSecret,
+		},
+		Trending: TrendingConfig{
+			Window:     time.Duration(getEnvInt("TRENDING_WINDOW_HOURS", 72)) * time.Hour,
+			Refresh:    time.Duration(getEnvInt("TRENDING_REFRESH_SEC", 300)) * time.Second,
+			Gravity:    getEnvFloat("TRENDING_GRAVITY", 1.5),
+			LikeWeight: getEnvFloat("TRENDING_LIKE_WEIGHT", 5),
+			Size:       getEnvInt("TRENDING_MAX_ARTICLES", 100),
+		},
+		GRPC: GRPCConfig{
+			Enabled:    getEnvBool("GRPC_ENABLED", true),
+			Port:       getEnvInt("GRPC_PORT", 9090),
+			Reflection: getEnvBool("GRPC_REFLECTION", env != "production"),
+		},
+		GraphQL: GraphQLConfig{
+			Playground:      getEnvBool("GRAPHQL_PLAYGROUND", env != "production"),
+			ComplexityLimit: getEnvInt("GRAPHQL_COMPLEXITY_LIMIT", 500),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour,
+			CleanupInterval: time.Duration(getEnvInt("IDEMPOTENCY_CLEANUP_INTERVAL_MIN", 60)) * time.Minute,
+		},
+		Report: ReportConfig{
+			RateLimit:          getEnvInt("REPORT_RATE_LIMIT", 5),
+			RateWindow:         time.Duration(getEnvInt("REPORT_RATE_WINDOW_MIN", 60)) * time.Minute,
+			UnpublishThreshold: getEnvInt("REPORT_UNPUBLISH_THRESHOLD", 0),
+		},
+		Moderation: ModerationConfig{
+			Enabled:      getEnvBool("MODERATION_ENABLED", false),
+			TrustedRoles: getEnvList("MODERATION_TRUSTED_ROLES", nil),
+		},
+		Media: MediaConfig{
+			Backend:          strings.ToLower(getEnv("MEDIA_STORE", "disk")),
+			Dir:              getEnv("MEDIA_DIR", "./data/media"),
+			Bucket:           getEnv("MEDIA_S3_BUCKET", getEnv("S3_BUCKET", "")),
+			MaxBytes:         int64(getEnvInt("MEDIA_MAX_BYTES", 10<<20)),
+			AllowedTypes:     getEnvList("MEDIA_ALLOWED_TYPES", defaultMediaTypes),
+			ThumbnailSizes:   getEnvIntList("MEDIA_THUMBNAIL_SIZES", []int{160, 480, 1024}),
+			ThumbnailWorkers: getEnvInt("MEDIA_THUMBNAIL_WORKERS", 2),
+			ThumbnailQueue:   getEnvInt("MEDIA_THUMBNAIL_QUEUE", 100),
+		},
+		Feed: FeedConfig{
+			Title:           getEnv("FEED_TITLE", "content-service"),
+			Description:     getEnv("FEED_DESCRIPTION", "Latest articles"),
+			SiteURL:         siteURL,
+			ArticleURL:      getEnv("FEED_ARTICLE_URL", siteURL+"/api/v1/articles/slug/{slug}"),
+			Items:           getEnvInt("FEED_ITEMS", 20),
+			MaxAge:          time.Duration(getEnvInt("FEED_MAX_AGE_SEC", 300)) * time.Second,
+			SitemapURLs:     getEnvInt("SITEMAP_MAX_URLS", 50000),
+			SitemapCacheTTL: time.Duration(getEnvInt("SITEMAP_CACHE_TTL_SEC", 60)) * time.Second,
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:         getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BackoffBase:         time.Duration(getEnvInt("WEBHOOK_BACKOFF_BASE_SEC", 30)) * time.Second,
+			BackoffMax:          time.Duration(getEnvInt("WEBHOOK_BACKOFF_MAX_SEC", 21600)) * time.Second,
+			Timeout:             time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SEC", 10)) * time.Second,
+			PollInterval:        time.Duration(getEnvInt("WEBHOOK_POLL_INTERVAL_SEC", 5)) * time.Second,
+			AllowPrivateTargets: getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+		},
+		Events: EventsConfig{
+			QueueSize:     getEnvInt("EVENTS_QUEUE_SIZE", 1000),
+			Workers:       getEnvInt("EVENTS_WORKERS", 4),
+			DrainTimeout:  time.Duration(getEnvInt("EVENTS_DRAIN_TIMEOUT_SEC", 10)) * time.Second,
+			LogEvents:     getEnvList("EVENTS_LOG", []string{"article.created", "article.updated", "article.deleted", "article.published"}),
+			LogSampleRate: getEnvFloat("EVENTS_LOG_SAMPLE_RATE", eventLogSampleRate),
+		},
+		Outbox: OutboxConfig{
+			Bus:          strings.ToLower(getEnv("EVENT_BUS", "none")),
+			Format:       strings.ToLower(getEnv("EVENT_FORMAT", "json")),
+			BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE", 100),
+			PollInterval: time.Duration(getEnvInt("OUTBOX_POLL_INTERVAL_SEC", 1)) * time.Second,
+			MaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+			HealthAddr:   getEnv("OUTBOX_HEALTH_ADDR", ":8081"),
+			InProcess:    getEnvBool("OUTBOX_RELAY_IN_PROCESS", false),
+			Kafka: KafkaConfig{
+				Brokers: getEnvList("KAFKA_BROKERS", nil),
+				Topic:   getEnv("KAFKA_TOPIC", "articles"),
+			},
+			NATS: NATSConfig{
+				URL:           getEnv("NATS_URL", "nats://localhost:4222"),
+				Stream:        getEnv("NATS_STREAM", "ARTICLES"),
+				Subject:       getEnv("NATS_SUBJECT", "articles"),
+				ReconnectWait: time.Duration(getEnvInt("NATS_RECONNECT_WAIT_SEC", 2)) * time.Second,
+			},
+		},
+		Search: SearchConfig{
+			Backend:      strings.ToLower(getEnv("SEARCH_BACKEND", "postgres")),
+			URL:          strings.TrimSuffix(getEnv("ELASTICSEARCH_URL", "http://localhost:9200"), "/"),
+			Index:        getEnv("ELASTICSEARCH_INDEX", "articles"),
+			Username:     getEnv("ELASTICSEARCH_USERNAME", ""),
+			Password:     getEnv("ELASTICSEARCH_PASSWORD", ""),
+			Timeout:      time.Duration(getEnvInt("ELASTICSEARCH_TIMEOUT_SEC", 5)) * time.Second,
+			ReindexBatch: getEnvInt("SEARCH_REINDEX_BATCH_SIZE", 500),
+		},
+		Cache: CacheConfig{
+			Backend:          strings.ToLower(getEnv("CACHE_BACKEND", "none")),
+			TTL:              time.Duration(getEnvInt("CACHE_TTL_SEC", 60)) * time.Second,
+			ListTTL:          time.Duration(getEnvInt("CACHE_LIST_TTL_SEC", 15)) * time.Second,
+			ListPages:        getEnvInt("CACHE_LIST_PAGES", 3),
+			MemoryMaxEntries: getEnvInt("CACHE_MEMORY_MAX_ENTRIES", 10000),
+			Redis: RedisConfig{
+				Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
+				Password: getEnv("REDIS_PASSWORD", ""),
+				DB:       getEnvInt("REDIS_DB", 0),
+			},
+		},
+		Notification: NotificationConfig{
+			Provider:    strings.ToLower(getEnv("EMAIL_PROVIDER", "none")),
+			From:        getEnv("EMAIL_FROM", "content-service <no-reply@localhost>"),
+			Events:      getEnvList("EMAIL_EVENTS", []string{"article.published"}),
+			DryRun:      getEnvBool("EMAIL_DRY_RUN", env != "production"),
+			TemplateDir: getEnv("EMAIL_TEMPLATE_DIR", ""),
+			QueueSize:   getEnvInt("EMAIL_QUEUE_SIZE", 100),
+			Workers:     getEnvInt("EMAIL_WORKERS", 2),
+			MaxAttempts: getEnvInt("EMAIL_MAX_ATTEMPTS", 3),
+			RetryDelay:  time.Duration(getEnvInt("EMAIL_RETRY_DELAY_SEC", 5)) * time.Second,
+			Timeout:     time.Duration(getEnvInt("EMAIL_TIMEOUT_SEC", 10)) * time.Second,
+			SMTP: SMTPConfig{
+				Host:     getEnv("SMTP_HOST", "localhost"),
+				Port:     getEnvInt("SMTP_PORT", 587),
+				Username: getEnv("SMTP_USERNAME", ""),
+				Password: getEnv("SMTP_PASSWORD", ""),
+			},
+			SendGrid: SendGridConfig{
+				URL:    strings.TrimSuffix(getEnv("SENDGRID_URL", "https://api.sendgrid.com"), "/"),
+				APIKey: getEnv("SENDGRID_API_KEY", ""),
+			},
+		},
+		Maintenance: MaintenanceConfig{
+			Enabled:    getEnvBool("MAINTENANCE_MODE", false),
+			RetryAfter: time.Duration(getEnvInt("MAINTENANCE_RETRY_AFTER_SEC", 300)) * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Backend:        strings.ToLower(getEnv("RATE_LIMIT_BACKEND", "memory")),
+			WarnThreshold:  getEnvInt("RATE_LIMIT_WARN_THRESHOLD", 10),
+			ReadBurst:      getEnvInt("RATE_LIMIT_READ_BURST", 100),
+			ReadPerSecond:  getEnvFloat("RATE_LIMIT_READ_PER_SEC", 10),
+			WriteBurst:     getEnvInt("RATE_LIMIT_WRITE_BURST", 20),
+			WritePerSecond: getEnvFloat("RATE_LIMIT_WRITE_PER_SEC", 2),
+		},
+		Health: HealthConfig{
+			Timeout: time.Duration(getEnvInt("HEALTH_CHECK_TIMEOUT_MS", 2000)) * time.Millisecond,
+		},
+		Sentry: SentryConfig{
+			DSN:         getEnv("SENTRY_DSN", ""),
+			Environment: getEnv("SENTRY_ENVIRONMENT", ""),
+			SampleRate:  getEnvFloat("SENTRY_SAMPLE_RATE", 1),
+		},
+		Admin: AdminConfig{
+			AllowedIPs: getEnvList("ADMIN_ALLOWED_IPS", nil),
+			DeniedIPs:  getEnvList("ADMIN_DENIED_IPS", nil),
+		},
+		Body: BodyConfig{
+			MaxBytes:     int64(getEnvInt("REQUEST_MAX_BODY_BYTES", 1<<20)),
+			ContentTypes: getEnvList("REQUEST_CONTENT_TYPES", []string{"application/json"}),
+		},
+		ContentStore: ContentStoreConfig{
+			Backend:         strings.ToLower(getEnv("CONTENT_STORE", "db")),
+			InlineThreshold: getEnvInt("CONTENT_INLINE_THRESHOLD", 65536),
+			S3: S3Config{
+				Endpoint:  getEnv("S3_ENDPOINT", ""),
+				Bucket:    getEnv("S3_BUCKET", ""),
+				AccessKey: getEnv("S3_ACCESS_KEY", ""),
+				SecretKey: getEnv("S3_SECRET_KEY", ""),
+				Region:    getEnv("S3_REGION", ""),
+				UseSSL:    getEnvBool("S3_USE_SSL", true),
+			},
 		},
 	}
 
@@ -106,6 +676,362 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid PORT: must be 1..65535")
 	}
 
+	if c.App.ClientIPHeader != "" && !headerNamePattern.MatchString(c.App.ClientIPHeader) {
+		return fmt.Errorf("invalid CLIENT_IP_HEADER: %q is not a valid header name", c.App.ClientIPHeader)
+	}
+
+	if c.Health.Timeout <= 0 {
+		return fmt.Errorf("invalid HEALTH_CHECK_TIMEOUT_MS: must be > 0")
+	}
+
+	if c.App.SlowRequestThreshold < 0 {
+		return fmt.Errorf("invalid SLOW_REQUEST_MS: must be >= 0")
+	}
+	if c.App.LogHTTPBody && c.IsProduction() {
+		return fmt.Errorf("invalid LOG_HTTP_BODY: cannot be enabled in production")
+	}
+	if c.App.LogHTTPBodyMaxBytes < 1 {
+		return fmt.Errorf("invalid LOG_HTTP_BODY_MAX_BYTES: must be >= 1")
+	}
+
+	if c.DB.SlowQueryThreshold < 0 {
+		return fmt.Errorf("invalid DB_SLOW_QUERY_MS: must be >= 0")
+	}
+	if c.DB.PoolStatsInterval < time.Second {
+		return fmt.Errorf("invalid DB_POOL_STATS_INTERVAL_SEC: must be >= 1")
+	}
+	if c.DB.PoolWaitWarn < 0 {
+		return fmt.Errorf("invalid DB_POOL_WAIT_WARN_MS: must be >= 0")
+	}
+	if c.DB.PoolSaturation <= 0 || c.DB.PoolSaturation > 1 {
+		return fmt.Errorf("invalid DB_POOL_SATURATION: must be > 0 and <= 1")
+	}
+	if c.DB.QueryTimeout < 0 {
+		return fmt.Errorf("invalid DB_QUERY_TIMEOUT_MS: must be >= 0")
+	}
+	if c.DB.ConnectAttempts < 1 {
+		return fmt.Errorf("invalid DB_CONNECT_ATTEMPTS: must be >= 1")
+	}
+	if c.DB.RetryAttempts < 1 {
+		return fmt.Errorf("invalid DB_RETRY_ATTEMPTS: must be >= 1")
+	}
+	if c.DB.RetryBackoffMin < time.Millisecond {
+		return fmt.Errorf("invalid DB_RETRY_BACKOFF_MIN_MS: must be >= 1")
+	}
+	if c.DB.RetryBackoffMax < c.DB.RetryBackoffMin {
+		return fmt.Errorf("invalid DB_RETRY_BACKOFF_MAX_MS: must be >= DB_RETRY_BACKOFF_MIN_MS")
+	}
+	if c.DB.ReplicaCheckInterval < time.Second {
+		return fmt.Errorf("invalid DB_REPLICA_CHECK_INTERVAL_SEC: must be >= 1")
+	}
+	if c.DB.ReplicaMaxLag < 0 {
+		return fmt.Errorf("invalid DB_REPLICA_MAX_LAG_MS: must be >= 0")
+	}
+
+	if c.Sentry.DSN != "" && !isAbsoluteURL(c.Sentry.DSN) {
+		return fmt.Errorf("invalid SENTRY_DSN: must be an absolute http or https URL")
+	}
+	if c.Sentry.SampleRate <= 0 || c.Sentry.SampleRate > 1 {
+		return fmt.Errorf("invalid SENTRY_SAMPLE_RATE: must be > 0 and <= 1")
+	}
+
+	if err := validateIPs("TRUSTED_PROXIES", c.App.TrustedProxies); err != nil {
+		return err
+	}
+	if err := validateIPs("ADMIN_ALLOWED_IPS", c.Admin.AllowedIPs); err != nil {
+		return err
+	}
+	if err := validateIPs("ADMIN_DENIED_IPS", c.Admin.DeniedIPs); err != nil {
+		return err
+	}
+
+	if len(c.App.AllowedMethods) == 0 {
+		return fmt.Errorf("invalid ALLOWED_METHODS: cannot be empty")
+	}
+	for _, method := range c.App.AllowedMethods {
+		if !headerNamePattern.MatchString(method) {
+			return fmt.Errorf("invalid ALLOWED_METHODS: %q is not a valid method", method)
+		}
+	}
+
+	if c.RateLimit.WarnThreshold < 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_WARN_THRESHOLD: must be >= 0")
+	}
+	if c.RateLimit.ReadBurst < 1 {
+		return fmt.Errorf("invalid RATE_LIMIT_READ_BURST: must be >= 1")
+	}
+	if c.RateLimit.ReadPerSecond <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_READ_PER_SEC: must be > 0")
+	}
+	if c.RateLimit.WriteBurst < 1 {
+		return fmt.Errorf("invalid RATE_LIMIT_WRITE_BURST: must be >= 1")
+	}
+	if c.RateLimit.WritePerSecond <= 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_WRITE_PER_SEC: must be > 0")
+	}
+	if c.Body.MaxBytes < 1 {
+		return fmt.Errorf("invalid REQUEST_MAX_BODY_BYTES: must be >= 1")
+	}
+	if len(c.Body.ContentTypes) == 0 {
+		return fmt.Errorf("invalid REQUEST_CONTENT_TYPES: at least one content type is required")
+	}
+	for _, contentType := range c.Body.ContentTypes {
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("invalid REQUEST_CONTENT_TYPES: %q is not a media type", contentType)
+		}
+	}
+	switch c.RateLimit.Backend {
+	case "memory":
+	case "redis":
+		if c.Cache.Redis.Addr == "" {
+			return fmt.Errorf("invalid REDIS_ADDR: cannot be empty when RATE_LIMIT_BACKEND is redis")
+		}
+	default:
+		return fmt.Errorf("invalid RATE_LIMIT_BACKEND: must be memory or redis")
+	}
+
+	switch c.ContentStore.Backend {
+	case "db":
+	case "s3":
+		if c.ContentStore.S3.Endpoint == "" {
+			return fmt.Errorf("invalid S3_ENDPOINT: cannot be empty when CONTENT_STORE=s3")
+		}
+		if c.ContentStore.S3.Bucket == "" {
+			return fmt.Errorf("invalid S3_BUCKET: cannot be empty when CONTENT_STORE=s3")
+		}
+	default:
+		return fmt.Errorf("invalid CONTENT_STORE: must be one of: db, s3")
+	}
+	if c.ContentStore.InlineThreshold < 1 {
+		return fmt.Errorf("invalid CONTENT_INLINE_THRESHOLD: must be >= 1")
+	}
+
+	switch c.Media.Backend {
+	case "disk":
+		if c.Media.Dir == "" {
+			return fmt.Errorf("invalid MEDIA_DIR: cannot be empty when MEDIA_STORE=disk")
+		}
+	case "s3":
+		if c.ContentStore.S3.Endpoint == "" {
+			return fmt.Errorf("invalid S3_ENDPOINT: cannot be empty when MEDIA_STORE=s3")
+		}
+		if c.Media.Bucket == "" {
+			return fmt.Errorf("invalid MEDIA_S3_BUCKET: cannot be empty when MEDIA_STORE=s3")
+		}
+	default:
+		return fmt.Errorf("invalid MEDIA_STORE: must be one of: disk, s3")
+	}
+	if c.Media.MaxBytes < 1 {
+		return fmt.Errorf("invalid MEDIA_MAX_BYTES: must be >= 1")
+	}
+	if len(c.Media.AllowedTypes) == 0 {
+		return fmt.Errorf("invalid MEDIA_ALLOWED_TYPES: cannot be empty")
+	}
+	for _, mediaType := range c.Media.AllowedTypes {
+		if !strings.Contains(mediaType, "/") {
+			return fmt.Errorf("invalid MEDIA_ALLOWED_TYPES: %q is not a media type", mediaType)
+		}
+	}
+	for _, size := range c.Media.ThumbnailSizes {
+		if size < 16 || size > 4096 {
+			return fmt.Errorf("invalid MEDIA_THUMBNAIL_SIZES: %d must be 16..4096", size)
+		}
+	}
+	if c.Media.ThumbnailWorkers < 1 {
+		return fmt.Errorf("invalid MEDIA_THUMBNAIL_WORKERS: must be >= 1")
+	}
+	if c.Media.ThumbnailQueue < 1 {
+		return fmt.Errorf("invalid MEDIA_THUMBNAIL_QUEUE: must be >= 1")
+	}
+
+	if c.Feed.Title == "" {
+		return fmt.Errorf("invalid FEED_TITLE: cannot be empty")
+	}
+	if !isAbsoluteURL(c.Feed.SiteURL) {
+		return fmt.Errorf("invalid FEED_SITE_URL: must be an absolute http or https URL")
+	}
+	if !strings.Contains(c.Feed.ArticleURL, "{slug}") || !isAbsoluteURL(strings.ReplaceAll(c.Feed.ArticleURL, "{slug}", "slug")) {
+		return fmt.Errorf("invalid FEED_ARTICLE_URL: must be an absolute http or https URL containing {slug}")
+	}
+	if c.Feed.Items < 1 || c.Feed.Items > 100 {
+		return fmt.Errorf("invalid FEED_ITEMS: must be 1..100")
+	}
+	if c.Feed.MaxAge < 0 {
+		return fmt.Errorf("invalid FEED_MAX_AGE_SEC: must be >= 0")
+	}
+	if c.Feed.SitemapURLs < 1 || c.Feed.SitemapURLs > 50000 {
+		return fmt.Errorf("invalid SITEMAP_MAX_URLS: must be 1..50000")
+	}
+	if c.Feed.SitemapCacheTTL < 0 {
+		return fmt.Errorf("invalid SITEMAP_CACHE_TTL_SEC: must be >= 0")
+	}
+
+	if c.Webhook.MaxAttempts < 1 {
+		return fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS: must be >= 1")
+	}
+	if c.Webhook.BackoffBase < time.Second {
+		return fmt.Errorf("invalid WEBHOOK_BACKOFF_BASE_SEC: must be >= 1")
+	}
+	if c.Webhook.BackoffMax < c.Webhook.BackoffBase {
+		return fmt.Errorf("invalid WEBHOOK_BACKOFF_MAX_SEC: must be >= WEBHOOK_BACKOFF_BASE_SEC")
+	}
+	if c.Webhook.Timeout < time.Second {
+		return fmt.Errorf("invalid WEBHOOK_TIMEOUT_SEC: must be >= 1")
+	}
+	if c.Webhook.PollInterval < time.Second {
+		return fmt.Errorf("invalid WEBHOOK_POLL_INTERVAL_SEC: must be >= 1")
+	}
+
+	if c.Events.QueueSize < 0 {
+		return fmt.Errorf("invalid EVENTS_QUEUE_SIZE: must be >= 0")
+	}
+	if c.Events.Workers < 1 {
+		return fmt.Errorf("invalid EVENTS_WORKERS: must be >= 1")
+	}
+	if c.Events.DrainTimeout < time.Second {
+		return fmt.Errorf("invalid EVENTS_DRAIN_TIMEOUT_SEC: must be >= 1")
+	}
+	if c.Events.LogSampleRate < 0 || c.Events.LogSampleRate > 1 {
+		return fmt.Errorf("invalid EVENTS_LOG_SAMPLE_RATE: must be >= 0 and <= 1")
+	}
+
+	switch c.Outbox.Bus {
+	case "none":
+	case "kafka":
+		if len(c.Outbox.Kafka.Brokers) == 0 {
+			return fmt.Errorf("invalid KAFKA_BROKERS: cannot be empty when EVENT_BUS is kafka")
+		}
+		if c.Outbox.Kafka.Topic == "" {
+			return fmt.Errorf("invalid KAFKA_TOPIC: cannot be empty")
+		}
+	case "nats":
+		if !strings.HasPrefix(c.Outbox.NATS.URL, "nats://") && !strings.HasPrefix(c.Outbox.NATS.URL, "tls://") {
+			return fmt.Errorf("invalid NATS_URL: must start with nats:// or tls://")
+		}
+		if c.Outbox.NATS.Stream == "" || strings.ContainsAny(c.Outbox.NATS.Stream, ". *>") {
+			return fmt.Errorf("invalid NATS_STREAM: must be a name without spaces, dots or wildcards")
+		}
+		if c.Outbox.NATS.Subject == "" || strings.ContainsAny(c.Outbox.NATS.Subject, " *>") {
+			return fmt.Errorf("invalid NATS_SUBJECT: must be a subject without spaces or wildcards")
+		}
+		if c.Outbox.NATS.ReconnectWait < time.Second {
+			return fmt.Errorf("invalid NATS_RECONNECT_WAIT_SEC: must be >= 1")
+		}
+	default:
+		return fmt.Errorf("invalid EVENT_BUS: must be none, kafka or nats")
+	}
+	if c.Outbox.Format != "json" && c.Outbox.Format != "avro" {
+		return fmt.Errorf("invalid EVENT_FORMAT: must be json or avro")
+	}
+	if c.Outbox.BatchSize < 1 || c.Outbox.BatchSize > 1000 {
+		return fmt.Errorf("invalid OUTBOX_BATCH_SIZE: must be 1..1000")
+	}
+	if c.Outbox.PollInterval < time.Second {
+		return fmt.Errorf("invalid OUTBOX_POLL_INTERVAL_SEC: must be >= 1")
+	}
+	if c.Outbox.MaxAttempts < 1 {
+		return fmt.Errorf("invalid OUTBOX_MAX_ATTEMPTS: must be >= 1")
+	}
+
+	switch c.Search.Backend {
+	case "postgres":
+	case "elasticsearch":
+		if !isAbsoluteURL(c.Search.URL) {
+			return fmt.Errorf("invalid ELASTICSEARCH_URL: must be an absolute http or https URL")
+		}
+		if c.Search.Index == "" || c.Search.Index != strings.ToLower(c.Search.Index) || strings.ContainsAny(c.Search.Index, ` "*,/<>?\|#`) {
+			return fmt.Errorf("invalid ELASTICSEARCH_INDEX: must be a lowercase index name")
+		}
+		if c.Search.Timeout < time.Second {
+			return fmt.Errorf("invalid ELASTICSEARCH_TIMEOUT_SEC: must be >= 1")
+		}
+	default:
+		return fmt.Errorf("invalid SEARCH_BACKEND: must be postgres or elasticsearch")
+	}
+	if c.Search.ReindexBatch < 1 || c.Search.ReindexBatch > 5000 {
+		return fmt.Errorf("invalid SEARCH_REINDEX_BATCH_SIZE: must be 1..5000")
+	}
+
+	switch c.Cache.Backend {
+	case "none":
+	case "memory":
+		if c.Cache.MemoryMaxEntries < 1 {
+			return fmt.Errorf("invalid CACHE_MEMORY_MAX_ENTRIES: must be >= 1")
+		}
+	case "redis":
+		if c.Cache.Redis.Addr == "" {
+			return fmt.Errorf("invalid REDIS_ADDR: cannot be empty when CACHE_BACKEND is redis")
+		}
+		if c.Cache.Redis.DB < 0 {
+			return fmt.Errorf("invalid REDIS_DB: must be >= 0")
+		}
+	default:
+		return fmt.Errorf("invalid CACHE_BACKEND: must be none, memory or redis")
+	}
+	if c.Cache.TTL < time.Second {
+		return fmt.Errorf("invalid CACHE_TTL_SEC: must be >= 1")
+	}
+	if c.Cache.ListTTL < time.Second {
+		return fmt.Errorf("invalid CACHE_LIST_TTL_SEC: must be >= 1")
+	}
+	if c.Cache.ListPages < 0 {
+		return fmt.Errorf("invalid CACHE_LIST_PAGES: must be >= 0")
+	}
+
+	switch c.Notification.Provider {
+	case "none":
+	case "smtp":
+		if c.Notification.SMTP.Host == "" {
+			return fmt.Errorf("invalid SMTP_HOST: cannot be empty when EMAIL_PROVIDER is smtp")
+		}
+		if c.Notification.SMTP.Port < 1 || c.Notification.SMTP.Port > 65535 {
+			return fmt.Errorf("invalid SMTP_PORT: must be 1..65535")
+		}
+	case "sendgrid":
+		if !isAbsoluteURL(c.Notification.SendGrid.URL) {
+			return fmt.Errorf("invalid SENDGRID_URL: must be an absolute http or https URL")
+		}
+		if c.Notification.SendGrid.APIKey == "" && !c.Notification.DryRun {
+			return fmt.Errorf("invalid SENDGRID_API_KEY: cannot be empty when EMAIL_PROVIDER is sendgrid")
+		}
+	default:
+		return fmt.Errorf("invalid EMAIL_PROVIDER: must be none, smtp or sendgrid")
+	}
+	if c.Notification.Provider != "none" {
+		if _, err := mail.ParseAddress(c.Notification.From); err != nil {
+			return fmt.Errorf("invalid EMAIL_FROM: %q is not an email address", c.Notification.From)
+		}
+		if len(c.Notification.Events) == 0 {
+			return fmt.Errorf("invalid EMAIL_EVENTS: cannot be empty")
+		}
+	}
+	if c.Notification.QueueSize < 1 {
+		return fmt.Errorf("invalid EMAIL_QUEUE_SIZE: must be >= 1")
+	}
+	if c.Notification.Workers < 1 {
+		return fmt.Errorf("invalid EMAIL_WORKERS: must be >= 1")
+	}
+	if c.Notification.MaxAttempts < 1 {
+		return fmt.Errorf("invalid EMAIL_MAX_ATTEMPTS: must be >= 1")
+	}
+	if c.Notification.RetryDelay < time.Second {
+		return fmt.Errorf("invalid EMAIL_RETRY_DELAY_SEC: must be >= 1")
+	}
+	if c.Notification.Timeout < time.Second {
+		return fmt.Errorf("invalid EMAIL_TIMEOUT_SEC: must be >= 1")
+	}
+
+	if c.DB.Driver != "postgres" && c.DB.Driver != "sqlite" {
+		return fmt.Errorf("invalid DB_DRIVER: must be one of: postgres, sqlite")
+	}
+	if c.DB.Driver == "sqlite" {
+		if c.DB.SQLitePath == "" {
+			return fmt.Errorf("invalid DB_SQLITE_PATH: cannot be empty")
+		}
+		if len(c.DB.ReplicaDSNs) > 0 {
+			return fmt.Errorf("invalid DB_REPLICA_DSNS: not supported with DB_DRIVER=sqlite")
+		}
+	}
 	if c.DB.Host == "" {
 		return fmt.Errorf("invalid DB_HOST: cannot be empty")
 	}
@@ -139,6 +1065,159 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.JWT.RefreshSecret != "" {
+		if c.Environment == "production" && len(c.JWT.RefreshSecret) < 32 {
+			return fmt.Errorf("invalid JWT_REFRESH_SECRET: must be >= 32 chars in production")
+		}
+		if c.JWT.RefreshSecret == c.JWT.Secret {
+			return fmt.Errorf("invalid JWT_REFRESH_SECRET: must differ from JWT_SECRET")
+		}
+	}
+	if c.JWT.AccessTTL < time.Minute {
+		return fmt.Errorf("invalid JWT_ACCESS_TTL_MIN: must be >= 1")
+	}
+	if c.JWT.RefreshTTL <= c.JWT.AccessTTL {
+		return fmt.Errorf("invalid JWT_REFRESH_TTL_HOURS: must be longer than JWT_ACCESS_TTL_MIN")
+	}
+
+	if c.JWT.JWKSURL != "" && !isAbsoluteURL(c.JWT.JWKSURL) {
+		return fmt.Errorf("invalid JWT_JWKS_URL: must be an absolute http or https URL")
+	}
+	if c.JWT.JWKSURL != "" && c.JWT.PublicKeyFile != "" {
+		return fmt.Errorf("invalid JWT_PUBLIC_KEY_FILE: cannot be combined with JWT_JWKS_URL")
+	}
+	if !c.JWT.AllowHS256 && c.JWT.JWKSURL == "" && c.JWT.PublicKeyFile == "" {
+		return fmt.Errorf("invalid JWT_ALLOW_HS256: set JWT_JWKS_URL or JWT_PUBLIC_KEY_FILE before disabling HS256")
+	}
+	if c.JWT.JWKSRefresh <= 0 {
+		return fmt.Errorf("invalid JWT_JWKS_REFRESH_MIN: must be > 0")
+	}
+
+	switch c.Auth.Mode {
+	case "jwt":
+	case "introspection":
+		if !isAbsoluteURL(c.Auth.Introspection.URL) {
+			return fmt.Errorf("invalid INTROSPECTION_URL: must be an absolute http or https URL when AUTH_MODE=introspection")
+		}
+		if c.Auth.Introspection.CacheTTL < 0 {
+			return fmt.Errorf("invalid INTROSPECTION_CACHE_TTL_SEC: must be >= 0")
+		}
+		if c.Auth.Introspection.CacheSize < 1 {
+			return fmt.Errorf("invalid INTROSPECTION_CACHE_SIZE: must be >= 1")
+		}
+	default:
+		return fmt.Errorf("invalid AUTH_MODE: must be one of: jwt, introspection")
+	}
+	if c.Auth.Signing.Secret != "" {
+		if c.IsProduction() && len(c.Auth.Signing.Secret) < 32 {
+			return fmt.Errorf("invalid SIGNING_SECRET: must be at least 32 characters in production")
+		}
+		if c.Auth.Signing.UserID == 0 {
+			return fmt.Errorf("invalid SIGNING_USER_ID: required when SIGNING_SECRET is set")
+		}
+		if c.Auth.Signing.Tolerance < time.Second {
+			return fmt.Errorf("invalid SIGNING_TOLERANCE_SEC: must be >= 1")
+		}
+	}
+
+	if c.Environment == "production" && len(c.Article.CursorSecret) < 32 {
+		return fmt.Errorf("invalid CURSOR_SECRET: must be >= 32 chars in production")
+	}
+	if c.Article.CursorSecret == "" {
+		return fmt.Errorf("invalid CURSOR_SECRET: cannot be empty")
+	}
+	if c.Article.CursorSecret == c.JWT.Secret || c.Article.CursorSecret == c.JWT.RefreshSecret {
+		return fmt.Errorf("invalid CURSOR_SECRET: must differ from JWT_SECRET and JWT_REFRESH_SECRET")
+	}
+
+	if c.Article.MinContentLength < 1 {
+		return fmt.Errorf("invalid ARTICLE_MIN_CONTENT_LENGTH: must be >= 1")
+	}
+
+	if c.Article.PurgeAfter < 0 {
+		return fmt.Errorf("invalid ARTICLE_PURGE_AFTER_DAYS: must be >= 0")
+	}
+
+	if c.Article.PurgeInterval < time.Minute {
+		return fmt.Errorf("invalid ARTICLE_PURGE_INTERVAL_MIN: must be >= 1")
+	}
+
+	if c.Article.ViewsFlush < time.Second {
+		return fmt.Errorf("invalid ARTICLE_VIEWS_FLUSH_SEC: must be >= 1")
+	}
+
+	if c.Article.CountMode != "exact" && c.Article.CountMode != "estimated" {
+		return fmt.Errorf("invalid ARTICLE_COUNT_MODE: must be one of: exact, estimated")
+	}
+
+	if c.Article.CountEstimateMin < 0 {
+		return fmt.Errorf("invalid ARTICLE_COUNT_ESTIMATE_MIN: must be >= 0")
+	}
+
+	if c.Article.CountCacheTTL < 0 {
+		return fmt.Errorf("invalid ARTICLE_COUNT_CACHE_TTL_SEC: must be >= 0")
+	}
+
+	if c.Article.BatchSize < 1 || c.Article.BatchSize > 1000 {
+		return fmt.Errorf("invalid ARTICLE_BATCH_SIZE: must be 1..1000")
+	}
+
+	if c.Trending.Window < time.Hour {
+		return fmt.Errorf("invalid TRENDING_WINDOW_HOURS: must be >= 1")
+	}
+	if c.Trending.Refresh < time.Second {
+		return fmt.Errorf("invalid TRENDING_REFRESH_SEC: must be >= 1")
+	}
+	if c.Trending.Gravity < 0 || c.Trending.Gravity > 10 {
+		return fmt.Errorf("invalid TRENDING_GRAVITY: must be 0..10")
+	}
+	if c.Trending.LikeWeight < 0 {
+		return fmt.Errorf("invalid TRENDING_LIKE_WEIGHT: must be >= 0")
+	}
+	if c.Trending.Size < 1 || c.Trending.Size > 1000 {
+		return fmt.Errorf("invalid TRENDING_MAX_ARTICLES: must be 1..1000")
+	}
+
+	if c.GRPC.Enabled {
+		if c.GRPC.Port < 1 || c.GRPC.Port > 65535 {
+			return fmt.Errorf("invalid GRPC_PORT: must be 1..65535")
+		}
+		if c.GRPC.Port == c.App.Port {
+			return fmt.Errorf("invalid GRPC_PORT: must differ from PORT")
+		}
+	}
+
+	if c.GraphQL.ComplexityLimit < 1 {
+		return fmt.Errorf("invalid GRAPHQL_COMPLEXITY_LIMIT: must be >= 1")
+	}
+
+	if c.Idempotency.TTL < time.Hour {
+		return fmt.Errorf("invalid IDEMPOTENCY_TTL_HOURS: must be >= 1")
+	}
+	if c.Idempotency.CleanupInterval < time.Minute {
+		return fmt.Errorf("invalid IDEMPOTENCY_CLEANUP_INTERVAL_MIN: must be >= 1")
+	}
+
+	if c.Report.RateLimit < 1 {
+		return fmt.Errorf("invalid REPORT_RATE_LIMIT: must be >= 1")
+	}
+	if c.Report.RateWindow < time.Minute {
+		return fmt.Errorf("invalid REPORT_RATE_WINDOW_MIN: must be >= 1")
+	}
+	if c.Report.UnpublishThreshold < 0 {
+		return fmt.Errorf("invalid REPORT_UNPUBLISH_THRESHOLD: must be >= 0")
+	}
+
+	if c.Maintenance.RetryAfter < time.Second {
+		return fmt.Errorf("invalid MAINTENANCE_RETRY_AFTER_SEC: must be >= 1")
+	}
+
+	for _, contentType := range c.Compression.ContentTypes {
+		if !strings.Contains(contentType, "/") {
+			return fmt.Errorf("invalid COMPRESS_CONTENT_TYPES: %q is not a media type", contentType)
+		}
+	}
+
 	if c.Environment == "production" && c.App.GinMode != "release" {
 		return fmt.Errorf("invalid GIN_MODE: must be 'release' in production")
 	}
@@ -170,10 +1249,26 @@ func (c *Config) GetSafeDSN() string {
 	)
 }
 
+func isAbsoluteURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
 }
 
+func (c OutboxConfig) Enabled() bool {
+	return c.Bus != "none"
+}
+
+func (c OutboxConfig) Topic() string {
+	if c.Bus == "nats" {
+		return c.NATS.Subject
+	}
+	return c.Kafka.Topic
+}
+
 func getEnv(key, defaultValue string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -189,3 +1284,79 @@ func getEnvInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultVal
+}
+
+func getEnvBool(key string, defaultVal bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return parsed
+		}
+	}
+	return defaultVal
+}
+
+func validateIPs(key string, values []string) error {
+	for _, value := range values {
+		if _, err := netip.ParsePrefix(value); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(value); err != nil {
+			return fmt.Errorf("invalid %s: %q is not an IP address or CIDR range", key, value)
+		}
+	}
+	return nil
+}
+
+func getEnvList(key string, defaultVal []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return append([]string(nil), defaultVal...)
+	}
+
+	var values []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+func getEnvValues(key string) []string {
+	var values []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+func getEnvIntList(key string, defaultVal []int) []int {
+	var values []int
+	for _, item := range getEnvList(key, nil) {
+		if parsed, err := strconv.Atoi(item); err == nil {
+			values = append(values, parsed)
+		}
+	}
+	if values == nil {
+		return append([]int(nil), defaultVal...)
+	}
+	return values
+}
+
+func getEnvMethods(key string, defaultVal []string) []string {
+	methods := getEnvList(key, defaultVal)
+	for i, method := range methods {
+		methods[i] = strings.ToUpper(method)
+	}
+	return methods
+}
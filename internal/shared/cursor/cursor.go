@@ -0,0 +1,77 @@
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrNoSecret      = errors.New("cursor: no secret configured")
+)
+
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret string) *Codec {
+	return &Codec{secret: []byte(secret)}
+}
+
+func (codec *Codec) Encode(v interface{}) (string, error) {
+	if len(codec.secret) == 0 {
+		return "", ErrNoSecret
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("cursor: failed to encode: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(codec.sign(payload)), nil
+}
+
+func (codec *Codec) Decode(token string, v interface{}) error {
+	if len(codec.secret) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, ErrNoSecret)
+	}
+
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return fmt.Errorf("%w: malformed payload", ErrInvalidCursor)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidCursor)
+	}
+
+	if !hmac.Equal(signature, codec.sign(payload)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: unexpected payload", ErrInvalidCursor)
+	}
+
+	return nil
+}
+
+func (codec *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, codec.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
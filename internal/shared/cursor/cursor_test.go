@@ -0,0 +1,105 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sortKeys struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        uint      `json:"id"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	codec := NewCodec("test-secret")
+	want := sortKeys{
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+		ID:        42,
+	}
+
+	token, err := codec.Encode(want)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got sortKeys
+	if err := codec.Decode(token, &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.ID != want.ID {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDecodeRejectsInvalidTokens(t *testing.T) {
+	codec := NewCodec("test-secret")
+
+	token, err := codec.Encode(sortKeys{ID: 42})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	payload, signature, _ := strings.Cut(token, ".")
+
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"created_at":"0001-01-01T00:00:00Z","id":1}`))
+
+	otherToken, err := NewCodec("other-secret").Encode(sortKeys{ID: 42})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Empty", token: ""},
+		{name: "Missing signature", token: payload},
+		{name: "Garbage", token: "!!!.???"},
+		{name: "Tampered payload", token: forgedPayload + "." + signature},
+		{name: "Truncated signature", token: payload + "." + signature[:10]},
+		{name: "Signed with another secret", token: otherToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got sortKeys
+			err := codec.Decode(tt.token, &got)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsUnexpectedPayload(t *testing.T) {
+	codec := NewCodec("test-secret")
+
+	token, err := codec.Encode(map[string]interface{}{"offset": 100})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got sortKeys
+	if err := codec.Decode(token, &got); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestEmptySecretFailsClosed(t *testing.T) {
+	token, err := NewCodec("test-secret").Encode(sortKeys{ID: 42})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	codec := NewCodec("")
+	if _, err := codec.Encode(sortKeys{ID: 42}); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("Expected ErrNoSecret, got %v", err)
+	}
+	var got sortKeys
+	if err := codec.Decode(token, &got); !errors.Is(err, ErrInvalidCursor) || !errors.Is(err, ErrNoSecret) {
+		t.Errorf("Expected ErrInvalidCursor and ErrNoSecret, got %v", err)
+	}
+}
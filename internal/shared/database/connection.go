@@ -1,18 +1,33 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"content-service/internal/shared/config"
 
+	"github.com/glebarez/sqlite"
+	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
-	dsn := cfg.GetDSN()
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+
+	sqlitePragmas    = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	sqliteWALPragma  = "_pragma=journal_mode(WAL)"
+	sqliteMemoryPath = ":memory:"
+)
 
+var memoryDatabases atomic.Int64
+
+func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
 	var logLevel logger.LogLevel
 	if cfg.IsProduction() {
 		logLevel = logger.Error
@@ -20,26 +35,81 @@ func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
 		logLevel = logger.Info
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+	db, err := gorm.Open(dialector(cfg), &gorm.Config{
+		Logger:               newQueryLogger(logLevel, cfg.DB.SlowQueryThreshold),
+		DisableAutomaticPing: true,
+		NowFunc: func() time.Time {
+			return time.Now().Local().Truncate(time.Microsecond)
+		},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := registerQueryTimeout(db, cfg.DB.QueryTimeout); err != nil {
+		return nil, err
+	}
+	policy := NewRetryPolicy(cfg.DB)
+	if err := db.Use(policy); err != nil {
+		return nil, fmt.Errorf("failed to register database retry policy: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	sqlDB.SetMaxOpenConns(cfg.DB.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(cfg.DB.MaxIdleConns)
-	sqlDB.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
-	sqlDB.SetConnMaxIdleTime(cfg.DB.ConnMaxIdleTime)
+	if cfg.DB.Driver == DriverSQLite {
+		sqlDB.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(max(cfg.DB.MaxIdleConns, 1))
+	} else {
+		sqlDB.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+		sqlDB.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.DB.ConnMaxIdleTime)
+	}
 
-	if err := sqlDB.Ping(); err != nil {
+	if err := waitForDatabase(context.Background(), sqlDB.PingContext, policy, cfg.DB.ConnectAttempts); err != nil {
+		_ = sqlDB.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return db, nil
 }
+
+func dialector(cfg *config.Config) gorm.Dialector {
+	if cfg.DB.Driver != DriverSQLite {
+		return postgres.Open(cfg.GetDSN())
+	}
+
+	if cfg.DB.SQLitePath == sqliteMemoryPath {
+		return sqlite.Open(fmt.Sprintf("file:/content-service-%d?vfs=memdb&%s", memoryDatabases.Add(1), sqlitePragmas))
+	}
+
+	separator := "?"
+	if strings.Contains(cfg.DB.SQLitePath, "?") {
+		separator = "&"
+	}
+	return sqlite.Open(cfg.DB.SQLitePath + separator + sqlitePragmas + "&" + sqliteWALPragma)
+}
+
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverSQLite
+}
+
+func waitForDatabase(ctx context.Context, ping func(ctx context.Context) error, policy *RetryPolicy, attempts int) error {
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil || attempt >= attempts {
+			return err
+		}
+
+		delay := policy.Backoff(attempt)
+		log.Warn().Err(err).Int("attempt", attempt).Int("attempts", attempts).Dur("retry_in", delay).Msg("Database unavailable, retrying")
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
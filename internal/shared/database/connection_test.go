@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"gorm.io/gorm"
+)
+
+func openSQLite(t *testing.T, path string) *gorm.DB {
+	t.Helper()
+	db, err := ConnectDB(&config.Config{
+		Environment: "test",
+		DB: config.DBConfig{
+			Driver:          DriverSQLite,
+			SQLitePath:      path,
+			MaxOpenConns:    4,
+			ConnectAttempts: 1,
+			RetryAttempts:   1,
+			RetryBackoffMin: time.Millisecond,
+			RetryBackoffMax: time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to open SQLite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestSQLiteMemoryDatabaseIsShared(t *testing.T) {
+	sqlDB, err := openSQLite(t, sqliteMemoryPath).DB()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	first, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer first.Close()
+	second, err := sqlDB.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer second.Close()
+
+	if _, err := first.ExecContext(ctx, "CREATE TABLE items (id integer)"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := second.ExecContext(ctx, "INSERT INTO items VALUES (1)"); err != nil {
+		t.Errorf("Expected the second connection to see the table, got %v", err)
+	}
+	if other := openSQLite(t, sqliteMemoryPath); other.Exec("SELECT * FROM items").Error == nil {
+		t.Error("Expected separate in-memory databases per connection pool")
+	}
+}
+
+func TestSQLiteReadOutsideTx(t *testing.T) {
+	db := openSQLite(t, filepath.Join(t.TempDir(), "content.db"))
+	if err := db.Exec("CREATE TABLE items (id integer)").Error; err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var outside int64
+	err := WithTx(ctx, db, func(ctx context.Context) error {
+		if err := Conn(ctx, db).Exec("INSERT INTO items VALUES (1)").Error; err != nil {
+			return err
+		}
+		return db.WithContext(ctx).Raw("SELECT count(*) FROM items").Scan(&outside).Error
+	})
+	if err != nil {
+		t.Fatalf("Expected a read outside the transaction to succeed, got %v", err)
+	}
+	if outside != 0 {
+		t.Errorf("Expected the uncommitted row to be invisible outside the transaction, got %d rows", outside)
+	}
+
+	var committed int64
+	if err := db.Raw("SELECT count(*) FROM items").Scan(&committed).Error; err != nil || committed != 1 {
+		t.Errorf("Expected 1 committed row, got %d (%v)", committed, err)
+	}
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"content-service/internal/shared/tracking"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+type QueryCounts struct {
+	Slow   int64 `json:"slow_queries"`
+	Failed int64 `json:"failed_queries"`
+}
+
+var (
+	slowQueries   atomic.Int64
+	failedQueries atomic.Int64
+)
+
+func QueryStats() QueryCounts {
+	return QueryCounts{Slow: slowQueries.Load(), Failed: failedQueries.Load()}
+}
+
+type queryLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+func newQueryLogger(level logger.LogLevel, slowThreshold time.Duration) *queryLogger {
+	return &queryLogger{level: level, slowThreshold: slowThreshold}
+}
+
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *queryLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Info {
+		log.Ctx(ctx).Info().Str("caller", utils.FileWithLineNum()).Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *queryLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Warn {
+		log.Ctx(ctx).Warn().Str("caller", utils.FileWithLineNum()).Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *queryLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Error {
+		log.Ctx(ctx).Error().Str("caller", utils.FileWithLineNum()).Msg(fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *queryLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	return sql, nil
+}
+
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		failedQueries.Add(1)
+		sql, rows := fc()
+		log.Ctx(ctx).Error().Err(err).Str("sql", sql).Int64("rows", rows).Dur("elapsed", elapsed).Str("caller", utils.FileWithLineNum()).Msg("Query failed")
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold:
+		slowQueries.Add(1)
+		sql, rows := fc()
+		caller := utils.FileWithLineNum()
+		log.Ctx(ctx).Warn().Str("sql", sql).Int64("rows", rows).Dur("elapsed", elapsed).Dur("threshold", l.slowThreshold).Str("caller", caller).Msg("Slow query")
+		tracking.CaptureWarning(ctx, "Slow query", []string{"slow-query", caller}, map[string]any{
+			"sql":          sql,
+			"rows":         rows,
+			"elapsed_ms":   elapsed.Milliseconds(),
+			"threshold_ms": l.slowThreshold.Milliseconds(),
+			"caller":       caller,
+		})
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		log.Ctx(ctx).Debug().Str("sql", sql).Int64("rows", rows).Dur("elapsed", elapsed).Msg("Query")
+	}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	gormtests "gorm.io/gorm/utils/tests"
+)
+
+func TestQueryLogger(t *testing.T) {
+	var buf bytes.Buffer
+	previous, previousContext := log.Logger, zerolog.DefaultContextLogger
+	log.Logger = zerolog.New(&buf)
+	zerolog.DefaultContextLogger = &log.Logger
+	defer func() { log.Logger, zerolog.DefaultContextLogger = previous, previousContext }()
+
+	query := func() (string, int64) { return `SELECT * FROM "articles"`, 3 }
+
+	tests := []struct {
+		name        string
+		level       logger.LogLevel
+		elapsed     time.Duration
+		err         error
+		wantMessage string
+		wantSlow    int64
+		wantFailed  int64
+	}{
+		{name: "Slow query", level: logger.Error, elapsed: 300 * time.Millisecond, wantMessage: "Slow query", wantSlow: 1},
+		{name: "Fast query in production", level: logger.Error, elapsed: time.Millisecond},
+		{name: "Fast query in development", level: logger.Info, elapsed: time.Millisecond, wantMessage: "Query"},
+		{name: "Failed query", level: logger.Error, elapsed: time.Millisecond, err: errors.New("connection reset"), wantMessage: "Query failed", wantFailed: 1},
+		{name: "Record not found is not an error", level: logger.Error, elapsed: time.Millisecond, err: gorm.ErrRecordNotFound},
+		{name: "Silent", level: logger.Silent, elapsed: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			before := QueryStats()
+			l := newQueryLogger(logger.Info, 200*time.Millisecond).LogMode(tt.level)
+			l.Trace(context.Background(), time.Now().Add(-tt.elapsed), query, tt.err)
+
+			after := QueryStats()
+			if slow := after.Slow - before.Slow; slow != tt.wantSlow {
+				t.Errorf("Expected %d slow queries counted, got %d", tt.wantSlow, slow)
+			}
+			if failed := after.Failed - before.Failed; failed != tt.wantFailed {
+				t.Errorf("Expected %d failed queries counted, got %d", tt.wantFailed, failed)
+			}
+
+			if tt.wantMessage == "" {
+				if buf.Len() != 0 {
+					t.Errorf("Expected no log line, got %s", buf.String())
+				}
+				return
+			}
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", buf.String())
+			}
+			if entry["message"] != tt.wantMessage {
+				t.Errorf("Expected message %q, got %v", tt.wantMessage, entry["message"])
+			}
+			if entry["sql"] != `SELECT * FROM "articles"` {
+				t.Errorf("Expected SQL to be logged, got %v", entry["sql"])
+			}
+		})
+	}
+}
+
+func TestQueryLoggerOmitsParameters(t *testing.T) {
+	var buf bytes.Buffer
+	previous, previousContext := log.Logger, zerolog.DefaultContextLogger
+	log.Logger = zerolog.New(&buf)
+	zerolog.DefaultContextLogger = &log.Logger
+	defer func() { log.Logger, zerolog.DefaultContextLogger = previous, previousContext }()
+
+	db, err := gorm.Open(gormtests.DummyDialector{}, &gorm.Config{DryRun: true, Logger: newQueryLogger(logger.Info, 0)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var rows []map[string]any
+	db.Table("users").Where("email = ?", "alice@example.com").Find(&rows)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log line, got %q", buf.String())
+	}
+	sql, _ := entry["sql"].(string)
+	if strings.Contains(sql, "alice@example.com") || !strings.Contains(sql, "email = ?") {
+		t.Errorf("Expected SQL with placeholders instead of values, got %q", sql)
+	}
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+type statsSource interface {
+	Stats() sql.DBStats
+}
+
+type PoolStats struct {
+	MaxOpen            int
+	Open               int
+	InUse              int
+	Idle               int
+	WaitCount          int64
+	WaitDuration       time.Duration
+	Saturation         float64
+	RecentWaits        int64
+	RecentWaitDuration time.Duration
+}
+
+func newPoolStats(current, previous sql.DBStats) PoolStats {
+	stats := PoolStats{
+		MaxOpen:            current.MaxOpenConnections,
+		Open:               current.OpenConnections,
+		InUse:              current.InUse,
+		Idle:               current.Idle,
+		WaitCount:          current.WaitCount,
+		WaitDuration:       current.WaitDuration,
+		RecentWaits:        current.WaitCount - previous.WaitCount,
+		RecentWaitDuration: current.WaitDuration - previous.WaitDuration,
+	}
+	if stats.MaxOpen > 0 {
+		stats.Saturation = float64(stats.InUse) / float64(stats.MaxOpen)
+	}
+	return stats
+}
+
+type PoolMonitor struct {
+	db         statsSource
+	waitWarn   time.Duration
+	saturation float64
+
+	mu       sync.Mutex
+	previous sql.DBStats
+}
+
+func NewPoolMonitor(db *sql.DB, cfg config.DBConfig) *PoolMonitor {
+	return newPoolMonitor(db, cfg)
+}
+
+func newPoolMonitor(db statsSource, cfg config.DBConfig) *PoolMonitor {
+	return &PoolMonitor{
+		db:         db,
+		waitWarn:   cfg.PoolWaitWarn,
+		saturation: cfg.PoolSaturation,
+		previous:   db.Stats(),
+	}
+}
+
+func (monitor *PoolMonitor) Stats() PoolStats {
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+	return newPoolStats(monitor.db.Stats(), monitor.previous)
+}
+
+func (monitor *PoolMonitor) sample() PoolStats {
+	current := monitor.db.Stats()
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+	stats := newPoolStats(current, monitor.previous)
+	monitor.previous = current
+	return stats
+}
+
+func (monitor *PoolMonitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := monitor.sample()
+		event := log.Info()
+		message := "Database pool stats"
+		if monitor.waitWarn > 0 && stats.RecentWaitDuration >= monitor.waitWarn {
+			event = log.Warn().Dur("threshold", monitor.waitWarn)
+			message = "Database pool wait spike"
+		}
+		event.
+			Int("max_open_connections", stats.MaxOpen).
+			Int("open_connections", stats.Open).
+			Int("in_use", stats.InUse).
+			Int("idle", stats.Idle).
+			Float64("saturation", stats.Saturation).
+			Int64("wait_count", stats.WaitCount).
+			Dur("wait_duration", stats.WaitDuration).
+			Int64("recent_waits", stats.RecentWaits).
+			Dur("recent_wait_duration", stats.RecentWaitDuration).
+			Dur("interval", interval).
+			Msg(message)
+	}
+}
+
+func (monitor *PoolMonitor) Check(ctx context.Context) error {
+	stats := monitor.Stats()
+	if stats.MaxOpen == 0 || stats.Saturation < monitor.saturation || stats.RecentWaits == 0 {
+		return nil
+	}
+	return fmt.Errorf("pool saturated: %d of %d connections in use, %d waits for a connection (%s) since the last sample",
+		stats.InUse, stats.MaxOpen, stats.RecentWaits, stats.RecentWaitDuration.Round(time.Millisecond))
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+)
+
+type fakeStats struct {
+	stats sql.DBStats
+}
+
+func (f *fakeStats) Stats() sql.DBStats {
+	return f.stats
+}
+
+func TestPoolMonitorCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		current sql.DBStats
+		wantErr bool
+	}{
+		{
+			name:    "Idle pool",
+			current: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 2, Idle: 2},
+		},
+		{
+			name:    "Busy pool without waits",
+			current: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 10, InUse: 10},
+		},
+		{
+			name:    "Saturated pool with waits",
+			current: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 10, InUse: 10, WaitCount: 8, WaitDuration: 2 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "Waits below the saturation threshold",
+			current: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 5, InUse: 5, WaitCount: 8, WaitDuration: 2 * time.Second},
+		},
+		{
+			name:    "Unlimited pool",
+			current: sql.DBStats{OpenConnections: 50, InUse: 50, WaitCount: 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeStats{}
+			monitor := newPoolMonitor(source, config.DBConfig{PoolSaturation: 0.9})
+			source.stats = tt.current
+
+			err := monitor.Check(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPoolMonitorSample(t *testing.T) {
+	source := &fakeStats{stats: sql.DBStats{MaxOpenConnections: 4, WaitCount: 3, WaitDuration: time.Second}}
+	monitor := newPoolMonitor(source, config.DBConfig{PoolSaturation: 0.9})
+
+	source.stats = sql.DBStats{MaxOpenConnections: 4, InUse: 4, WaitCount: 5, WaitDuration: 1500 * time.Millisecond}
+	stats := monitor.sample()
+	if stats.RecentWaits != 2 || stats.RecentWaitDuration != 500*time.Millisecond {
+		t.Errorf("Expected 2 recent waits over 500ms, got %d over %s", stats.RecentWaits, stats.RecentWaitDuration)
+	}
+	if stats.Saturation != 1 {
+		t.Errorf("Expected saturation 1, got %v", stats.Saturation)
+	}
+	if err := monitor.Check(context.Background()); err != nil {
+		t.Errorf("Expected no saturation error right after a sample, got %v", err)
+	}
+}
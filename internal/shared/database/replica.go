@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	ReplicaResolver = "replicas"
+
+	replicaProbeTimeout = 5 * time.Second
+	replicaLagQuery     = `SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+)
+
+type replica struct {
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+type Replicas struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+func ConnectReplicas(db *gorm.DB, cfg *config.Config) (*Replicas, error) {
+	if len(cfg.DB.ReplicaDSNs) == 0 {
+		return nil, nil
+	}
+
+	primary, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	replicas := &Replicas{maxLag: cfg.DB.ReplicaMaxLag}
+	dialectors := make([]gorm.Dialector, 0, len(cfg.DB.ReplicaDSNs)+1)
+	for i, dsn := range cfg.DB.ReplicaDSNs {
+		sqlDB, err := sql.Open("pgx", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database replica %d: %w", i+1, err)
+		}
+		sqlDB.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+		sqlDB.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.DB.ConnMaxIdleTime)
+
+		node := &replica{db: sqlDB}
+		node.healthy.Store(true)
+		replicas.replicas = append(replicas.replicas, node)
+		dialectors = append(dialectors, postgres.New(postgres.Config{Conn: sqlDB}))
+	}
+	dialectors = append(dialectors, postgres.New(postgres.Config{Conn: primary}))
+
+	err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   replicas,
+	}, ReplicaResolver))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register database replicas: %w", err)
+	}
+
+	replicas.probe(context.Background())
+	return replicas, nil
+}
+
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(ReplicaResolver))
+}
+
+func (replicas *Replicas) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	start := replicas.next.Add(1)
+	for i := range replicas.replicas {
+		index := int((start + uint64(i)) % uint64(len(replicas.replicas)))
+		if replicas.replicas[index].healthy.Load() {
+			return pools[index]
+		}
+	}
+	return pools[len(pools)-1]
+}
+
+func (replicas *Replicas) healthy() int {
+	healthy := 0
+	for _, node := range replicas.replicas {
+		if node.healthy.Load() {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+func (replicas *Replicas) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			replicas.probe(ctx)
+		}
+	}
+}
+
+func (replicas *Replicas) Check(ctx context.Context) error {
+	healthy := replicas.healthy()
+	if healthy == len(replicas.replicas) {
+		return nil
+	}
+	if healthy == 0 {
+		return fmt.Errorf("all %d replicas unavailable, reads use the primary", len(replicas.replicas))
+	}
+	return fmt.Errorf("%d of %d replicas unavailable", len(replicas.replicas)-healthy, len(replicas.replicas))
+}
+
+func (replicas *Replicas) probe(ctx context.Context) {
+	for i, node := range replicas.replicas {
+		replicas.update(i, replicas.check(ctx, node))
+	}
+}
+
+func (replicas *Replicas) check(ctx context.Context, node *replica) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaProbeTimeout)
+	defer cancel()
+
+	if err := node.db.PingContext(ctx); err != nil {
+		return err
+	}
+	if replicas.maxLag <= 0 {
+		return nil
+	}
+
+	var seconds float64
+	if err := node.db.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		return fmt.Errorf("failed to read replication lag: %w", err)
+	}
+	if lag := time.Duration(seconds * float64(time.Second)); lag > replicas.maxLag {
+		return fmt.Errorf("replication lag %s exceeds %s", lag.Round(time.Millisecond), replicas.maxLag)
+	}
+	return nil
+}
+
+func (replicas *Replicas) update(index int, err error) {
+	healthy := err == nil
+	if replicas.replicas[index].healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		log.Info().Int("replica", index+1).Msg("Database replica available")
+		return
+	}
+	log.Warn().Err(err).Int("replica", index+1).Msg("Database replica unavailable, reads fall back to the primary")
+}
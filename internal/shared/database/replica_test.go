@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func newTestReplicas(healthy ...bool) *Replicas {
+	replicas := &Replicas{}
+	for _, up := range healthy {
+		node := &replica{}
+		node.healthy.Store(up)
+		replicas.replicas = append(replicas.replicas, node)
+	}
+	return replicas
+}
+
+func TestReplicasResolve(t *testing.T) {
+	first, second, primary := &sql.DB{}, &sql.DB{}, &sql.DB{}
+	pools := []gorm.ConnPool{first, second, primary}
+
+	tests := []struct {
+		name    string
+		healthy []bool
+		want    map[gorm.ConnPool]bool
+	}{
+		{name: "All replicas healthy", healthy: []bool{true, true}, want: map[gorm.ConnPool]bool{first: true, second: true}},
+		{name: "One replica down", healthy: []bool{false, true}, want: map[gorm.ConnPool]bool{second: true}},
+		{name: "All replicas down", healthy: []bool{false, false}, want: map[gorm.ConnPool]bool{primary: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas := newTestReplicas(tt.healthy...)
+			seen := map[gorm.ConnPool]bool{}
+			for range 4 {
+				seen[replicas.Resolve(pools)] = true
+			}
+			if len(seen) != len(tt.want) {
+				t.Errorf("Expected reads spread over %d pools, got %d", len(tt.want), len(seen))
+			}
+			for pool := range seen {
+				if !tt.want[pool] {
+					t.Errorf("Expected no reads on pool %p", pool)
+				}
+			}
+		})
+	}
+}
+
+func TestReplicasCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		healthy []bool
+		wantErr bool
+	}{
+		{name: "All replicas healthy", healthy: []bool{true, true}},
+		{name: "One replica down", healthy: []bool{true, false}, wantErr: true},
+		{name: "All replicas down", healthy: []bool{false, false}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestReplicas(tt.healthy...).Check(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReplicasUpdate(t *testing.T) {
+	replicas := newTestReplicas(true)
+
+	replicas.update(0, errors.New("connection refused"))
+	if replicas.replicas[0].healthy.Load() {
+		t.Errorf("Expected the replica to be marked unhealthy after a failed probe")
+	}
+
+	replicas.update(0, nil)
+	if !replicas.replicas[0].healthy.Load() {
+		t.Errorf("Expected the replica to be marked healthy after a successful probe")
+	}
+}
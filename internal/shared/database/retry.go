@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math/rand/v2"
+	"syscall"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const retryPluginName = "content:retry"
+
+type RetryPolicy struct {
+	Attempts   int
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+}
+
+func NewRetryPolicy(cfg config.DBConfig) *RetryPolicy {
+	return &RetryPolicy{Attempts: cfg.RetryAttempts, BackoffMin: cfg.RetryBackoffMin, BackoffMax: cfg.RetryBackoffMax}
+}
+
+func (policy *RetryPolicy) Name() string {
+	return retryPluginName
+}
+
+func (policy *RetryPolicy) Initialize(db *gorm.DB) error {
+	return nil
+}
+
+func (policy *RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := policy.BackoffMin
+	for i := 1; i < attempt && delay < policy.BackoffMax; i++ {
+		delay *= 2
+	}
+	delay = min(delay, policy.BackoffMax)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func (policy *RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.Attempts || !IsTransient(err) {
+			return err
+		}
+
+		delay := policy.Backoff(attempt)
+		log.Ctx(ctx).Warn().Err(err).Int("attempt", attempt).Dur("retry_in", delay).Msg("Transient database error, retrying")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01"
+	}
+
+	return pgconn.SafeToRetry(err) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func retryPolicy(db *gorm.DB) *RetryPolicy {
+	if plugin, ok := db.Config.Plugins[retryPluginName]; ok {
+		return plugin.(*RetryPolicy)
+	}
+	return nil
+}
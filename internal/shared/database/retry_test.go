@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "Deadlock", err: fmt.Errorf("failed to save: %w", &pgconn.PgError{Code: "40P01"}), want: true},
+		{name: "Connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "Bad connection", err: driver.ErrBadConn, want: true},
+		{name: "Connection failure mid-statement", err: &pgconn.PgError{Code: "08006"}},
+		{name: "Admin shutdown", err: &pgconn.PgError{Code: "57P01"}},
+		{name: "Connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET)},
+		{name: "Broken pipe", err: fmt.Errorf("write: %w", syscall.EPIPE)},
+		{name: "Unexpected EOF", err: io.ErrUnexpectedEOF},
+		{name: "Unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "Query timeout", err: context.DeadlineExceeded},
+		{name: "Other error", err: errors.New("record not found")},
+		{name: "No error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := &pgconn.PgError{Code: "40001"}
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "Success", errs: []error{nil}, wantCalls: 1},
+		{name: "Transient then success", errs: []error{transient, nil}, wantCalls: 2},
+		{name: "Attempts exhausted", errs: []error{transient, transient, transient, nil}, wantCalls: 3, wantErr: true},
+		{name: "Permanent error", errs: []error{errors.New("invalid input"), nil}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &RetryPolicy{Attempts: 3, BackoffMin: time.Millisecond, BackoffMax: time.Millisecond}
+			calls := 0
+			err := policy.Do(context.Background(), func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDoCanceled(t *testing.T) {
+	policy := &RetryPolicy{Attempts: 5, BackoffMin: time.Hour, BackoffMax: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := policy.Do(ctx, func() error {
+		calls++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if calls != 1 || err == nil {
+		t.Errorf("Expected to stop after 1 call with an error, got %d calls and %v", calls, err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{BackoffMin: 100 * time.Millisecond, BackoffMax: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: 100 * time.Millisecond},
+		{attempt: 2, max: 200 * time.Millisecond},
+		{attempt: 4, max: 800 * time.Millisecond},
+		{attempt: 10, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Attempt %d", tt.attempt), func(t *testing.T) {
+			for range 20 {
+				delay := policy.Backoff(tt.attempt)
+				if delay < tt.max/2 || delay > tt.max {
+					t.Fatalf("Expected a delay within %s..%s, got %s", tt.max/2, tt.max, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForDatabase(t *testing.T) {
+	policy := &RetryPolicy{BackoffMin: time.Millisecond, BackoffMax: time.Millisecond}
+	tests := []struct {
+		name      string
+		failures  int
+		attempts  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "Available", attempts: 3, wantCalls: 1},
+		{name: "Available after retries", failures: 2, attempts: 3, wantCalls: 3},
+		{name: "Unavailable", failures: 5, attempts: 3, wantCalls: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := waitForDatabase(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return syscall.ECONNREFUSED
+				}
+				return nil
+			}, policy, tt.attempts)
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d pings, got %d", tt.wantCalls, calls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
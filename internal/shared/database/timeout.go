@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	queryTimeoutStart     = "content:query_timeout_start"
+	queryTimeoutEnd       = "content:query_timeout_end"
+	queryTimeoutCancelKey = "content:query_timeout_cancel"
+	queryTimeoutParentKey = "content:query_timeout_parent"
+)
+
+func registerQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	start := startQueryTimeout(timeout)
+	callbacks := db.Callback()
+	errs := []error{
+		callbacks.Create().Before("*").Register(queryTimeoutStart, start),
+		callbacks.Create().After("*").Register(queryTimeoutEnd, endQueryTimeout),
+		callbacks.Query().Before("*").Register(queryTimeoutStart, start),
+		callbacks.Query().After("*").Register(queryTimeoutEnd, endQueryTimeout),
+		callbacks.Update().Before("*").Register(queryTimeoutStart, start),
+		callbacks.Update().After("*").Register(queryTimeoutEnd, endQueryTimeout),
+		callbacks.Delete().Before("*").Register(queryTimeoutStart, start),
+		callbacks.Delete().After("*").Register(queryTimeoutEnd, endQueryTimeout),
+		callbacks.Raw().Before("*").Register(queryTimeoutStart, start),
+		callbacks.Raw().After("*").Register(queryTimeoutEnd, endQueryTimeout),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to register query timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+func startQueryTimeout(timeout time.Duration) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		parent := tx.Statement.Context
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		tx.InstanceSet(queryTimeoutParentKey, parent)
+		tx.InstanceSet(queryTimeoutCancelKey, cancel)
+		tx.Statement.Context = ctx
+	}
+}
+
+func endQueryTimeout(tx *gorm.DB) {
+	if cancel, ok := tx.InstanceGet(queryTimeoutCancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+	if parent, ok := tx.InstanceGet(queryTimeoutParentKey); ok {
+		tx.Statement.Context = parent.(context.Context)
+	}
+}
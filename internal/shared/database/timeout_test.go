@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	gormtests "gorm.io/gorm/utils/tests"
+)
+
+type timeoutProbe struct {
+	ID   uint
+	Name string
+}
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{name: "Timeout applied", timeout: time.Second, wantDeadline: true},
+		{name: "Timeout disabled", timeout: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openDryRun(t)
+			if err := registerQueryTimeout(db, tt.timeout); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var deadlines []bool
+			probe := func(tx *gorm.DB) {
+				_, ok := tx.Statement.Context.Deadline()
+				deadlines = append(deadlines, ok)
+			}
+			if err := db.Callback().Query().Before("gorm:query").Register("test:probe", probe); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			parent := context.Background()
+			tx := db.WithContext(parent).Model(&timeoutProbe{}).Where("name = ?", "a")
+			var count int64
+			tx.Count(&count)
+			var rows []timeoutProbe
+			tx.Find(&rows)
+
+			if len(deadlines) != 2 {
+				t.Fatalf("Expected 2 queries, got %d", len(deadlines))
+			}
+			for i, ok := range deadlines {
+				if ok != tt.wantDeadline {
+					t.Errorf("Expected deadline %v on query %d, got %v", tt.wantDeadline, i+1, ok)
+				}
+			}
+			if tx.Statement.Context != parent {
+				t.Errorf("Expected the parent context to be restored after the query")
+			}
+		})
+	}
+}
+
+func openDryRun(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(gormtests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return db
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func()
+	done        bool
+}
+
+func WithTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	if InTx(ctx) {
+		return fn(ctx)
+	}
+
+	var state *txState
+	transaction := func() error {
+		state = &txState{}
+		return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			state.tx = tx
+			return fn(context.WithValue(ctx, txKey{}, state))
+		})
+	}
+
+	var err error
+	if policy := retryPolicy(db); policy != nil {
+		err = policy.Do(ctx, transaction)
+	} else {
+		err = transaction()
+	}
+	if state != nil {
+		state.done = true
+	}
+	if err != nil {
+		return err
+	}
+	for _, hook := range state.afterCommit {
+		hook()
+	}
+	return nil
+}
+
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && !state.done {
+		return state.tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+func InTx(ctx context.Context) bool {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	return ok && !state.done
+}
+
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && !state.done {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
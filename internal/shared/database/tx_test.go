@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithTx(t *testing.T) {
+	db := openDryRun(t)
+	tx := db.Set("test:tx", true)
+	outer := context.WithValue(context.Background(), txKey{}, &txState{tx: tx})
+
+	t.Run("Nested calls join the outer transaction", func(t *testing.T) {
+		var joined bool
+		err := WithTx(outer, db, func(ctx context.Context) error {
+			_, inTx := Conn(ctx, db).Get("test:tx")
+			joined = ctx == outer && inTx
+			return nil
+		})
+		if err != nil || !joined {
+			t.Errorf("Expected to run in the outer transaction, got joined=%v err=%v", joined, err)
+		}
+	})
+
+	t.Run("Failed begin skips the work and the hooks", func(t *testing.T) {
+		called := false
+		err := WithTx(context.Background(), db, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		if err == nil || called {
+			t.Errorf("Expected an error without running the work, got called=%v err=%v", called, err)
+		}
+	})
+}
+
+func TestAfterCommit(t *testing.T) {
+	ran := 0
+	AfterCommit(context.Background(), func() { ran++ })
+	if ran != 1 {
+		t.Errorf("Expected the hook to run immediately outside a transaction, got %d runs", ran)
+	}
+
+	state := &txState{}
+	ctx := context.WithValue(context.Background(), txKey{}, state)
+	AfterCommit(ctx, func() { ran++ })
+	if ran != 1 || len(state.afterCommit) != 1 {
+		t.Errorf("Expected the hook to wait for the commit, got %d runs and %d queued", ran, len(state.afterCommit))
+	}
+	if !InTx(ctx) || InTx(context.Background()) {
+		t.Errorf("Expected InTx to report only the transaction context")
+	}
+}
+
+func TestAfterCommitLeavesTransaction(t *testing.T) {
+	db := openSQLite(t, ":memory:")
+
+	var hookErr error
+	hookInTx := true
+	err := WithTx(context.Background(), db, func(ctx context.Context) error {
+		AfterCommit(ctx, func() {
+			hookInTx = InTx(ctx)
+			var one int
+			hookErr = Conn(ctx, db).Raw("SELECT 1").Scan(&one).Error
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx() unexpected error: %v", err)
+	}
+	if hookInTx || hookErr != nil {
+		t.Errorf("Expected hooks to run outside the committed transaction, got inTx=%v err=%v", hookInTx, hookErr)
+	}
+}
+
+func TestConnWithoutTx(t *testing.T) {
+	db := openDryRun(t)
+	ctx := context.WithValue(context.Background(), txKey{}, "not a transaction")
+	if conn := Conn(ctx, db); conn.Statement.Context != ctx {
+		t.Errorf("Expected the base connection with the request context")
+	}
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Event struct {
+	Name       string
+	Payload    any
+	ActorID    uint
+	OccurredAt time.Time
+}
+
+type Handler func(event Event) error
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+type job struct {
+	event      Event
+	subscriber subscriber
+}
+
+type Bus struct {
+	mu     sync.RWMutex
+	sync   map[string][]subscriber
+	async  map[string][]subscriber
+	jobs   chan job
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewBus(queueSize int) *Bus {
+	return &Bus{
+		sync:  make(map[string][]subscriber),
+		async: make(map[string][]subscriber),
+		jobs:  make(chan job, queueSize),
+	}
+}
+
+func (bus *Bus) Subscribe(name string, handler Handler, events ...string) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	for _, event := range events {
+		bus.sync[event] = append(bus.sync[event], subscriber{name: name, handler: handler})
+	}
+}
+
+func (bus *Bus) SubscribeAsync(name string, handler Handler, events ...string) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	for _, event := range events {
+		bus.async[event] = append(bus.async[event], subscriber{name: name, handler: handler})
+	}
+}
+
+func (bus *Bus) Publish(event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	bus.mu.RLock()
+	syncSubs := bus.sync[event.Name]
+	var inline []subscriber
+	for _, sub := range bus.async[event.Name] {
+		if bus.closed {
+			inline = append(inline, sub)
+			continue
+		}
+		select {
+		case bus.jobs <- job{event: event, subscriber: sub}:
+		default:
+			log.Warn().Str("event", event.Name).Str("subscriber", sub.name).Msg("Event queue full, handling synchronously")
+			inline = append(inline, sub)
+		}
+	}
+	bus.mu.RUnlock()
+
+	for _, sub := range slices.Concat(syncSubs, inline) {
+		run(job{event: event, subscriber: sub})
+	}
+}
+
+func (bus *Bus) Start(workers int) {
+	for range workers {
+		bus.wg.Add(1)
+		go bus.work()
+	}
+}
+
+func (bus *Bus) Close(ctx context.Context) error {
+	bus.mu.Lock()
+	if !bus.closed {
+		bus.closed = true
+		close(bus.jobs)
+	}
+	bus.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		bus.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("events: %d queued events not handled: %w", len(bus.jobs), ctx.Err())
+	}
+}
+
+func (bus *Bus) work() {
+	defer bus.wg.Done()
+	for job := range bus.jobs {
+		run(job)
+	}
+}
+
+func run(job job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Str("event", job.event.Name).Str("subscriber", job.subscriber.name).Msg("Event handler panicked")
+		}
+	}()
+
+	if err := job.subscriber.handler(job.event); err != nil {
+		log.Error().Err(err).Str("event", job.event.Name).Str("subscriber", job.subscriber.name).Msg("Event handler failed")
+	}
+}
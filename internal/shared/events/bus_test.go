@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublish(t *testing.T) {
+	bus := NewBus(10)
+
+	var mu sync.Mutex
+	var got []string
+	record := func(prefix string) Handler {
+		return func(event Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, prefix+":"+event.Name)
+			return nil
+		}
+	}
+	bus.Subscribe("sync", record("sync"), "created", "deleted")
+	bus.Subscribe("failing", func(Event) error { return errors.New("boom") }, "created")
+	bus.Subscribe("panicking", func(Event) error { panic("boom") }, "created")
+	bus.SubscribeAsync("async", record("async"), "created")
+
+	bus.Publish(Event{Name: "created"})
+	if len(got) != 1 || got[0] != "sync:created" {
+		t.Errorf("Expected only the sync handler to run before the bus starts, got %v", got)
+	}
+
+	bus.Start(2)
+	bus.Publish(Event{Name: "deleted"})
+	bus.Publish(Event{Name: "ignored"})
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := map[string]int{"sync:created": 1, "sync:deleted": 1, "async:created": 1}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d handled events, got %v", len(want), got)
+	}
+	for _, entry := range got {
+		if want[entry] != 1 {
+			t.Errorf("Unexpected handled event %q in %v", entry, got)
+		}
+	}
+
+	bus.Publish(Event{Name: "created"})
+	if got[len(got)-1] != "async:created" {
+		t.Errorf("Expected async handlers to run inline after close, got %v", got)
+	}
+}
+
+func TestPublishQueueFull(t *testing.T) {
+	bus := NewBus(1)
+	var handled atomic.Int32
+	bus.SubscribeAsync("counter", func(Event) error {
+		handled.Add(1)
+		return nil
+	}, "created")
+
+	for range 3 {
+		bus.Publish(Event{Name: "created"})
+	}
+	if handled.Load() != 2 {
+		t.Errorf("Expected overflowing events to be handled inline, got %d", handled.Load())
+	}
+
+	bus.Start(1)
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if handled.Load() != 3 {
+		t.Errorf("Expected the queued event to be drained, got %d", handled.Load())
+	}
+}
+
+func TestCloseTimeout(t *testing.T) {
+	bus := NewBus(10)
+	release := make(chan struct{})
+	defer close(release)
+	bus.SubscribeAsync("slow", func(Event) error {
+		<-release
+		return nil
+	}, "created")
+	bus.Start(1)
+	bus.Publish(Event{Name: "created"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+const (
+	MediaType = "application/vnd.api+json"
+	Version   = "1.1"
+)
+
+type Identifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type Relationship struct {
+	Data *Identifier    `json:"data"`
+	Meta map[string]any `json:"meta,omitempty"`
+}
+
+type Resource struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id"`
+	Attributes    map[string]any          `json:"attributes"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Links         map[string]string       `json:"links,omitempty"`
+}
+
+type Document struct {
+	Data    any               `json:"data"`
+	Meta    any               `json:"meta,omitempty"`
+	Links   map[string]string `json:"links,omitempty"`
+	JSONAPI map[string]string `json:"jsonapi"`
+}
+
+type ErrorObject struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func Requested(c *gin.Context) bool {
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == MediaType {
+			return true
+		}
+	}
+	return false
+}
+
+func VaryAccept(c *gin.Context) {
+	for _, value := range c.Writer.Header().Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.TrimSpace(field) == "Accept" {
+				return
+			}
+		}
+	}
+	c.Writer.Header().Add("Vary", "Accept")
+}
+
+func ID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func To(resourceType string, id uint) Relationship {
+	return Relationship{Data: &Identifier{Type: resourceType, ID: ID(id)}}
+}
+
+func Attributes(value any, omit ...string) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]any)
+	if err := json.Unmarshal(encoded, &attributes); err != nil {
+		return nil, err
+	}
+	delete(attributes, "id")
+	for _, key := range omit {
+		delete(attributes, key)
+	}
+	return attributes, nil
+}
+
+func Render(c *gin.Context, status int, doc Document) {
+	doc.JSONAPI = map[string]string{"version": Version}
+	c.Header("Content-Type", MediaType)
+	c.Render(status, render.JSON{Data: doc})
+}
+
+func Error(c *gin.Context, status int, detail string) {
+	c.Header("Content-Type", MediaType)
+	c.Render(status, render.JSON{Data: gin.H{
+		"errors":  []ErrorObject{{Status: strconv.Itoa(status), Title: http.StatusText(status), Detail: detail}},
+		"jsonapi": map[string]string{"version": Version},
+	}})
+}
+
+func link(u *url.URL, set map[string]string, remove ...string) string {
+	query := u.Query()
+	for _, key := range remove {
+		query.Del(key)
+	}
+	for key, value := range set {
+		query.Set(key, value)
+	}
+	return (&url.URL{Path: u.Path, RawQuery: query.Encode()}).String()
+}
+
+func PageLinks(u *url.URL, page, limit int, hasNext bool, lastPage int) map[string]string {
+	pageLink := func(page int) string {
+		return link(u, map[string]string{"page": strconv.Itoa(page), "limit": strconv.Itoa(limit)}, "offset")
+	}
+	links := map[string]string{
+		"self":  u.RequestURI(),
+		"first": pageLink(1),
+	}
+	if page > 1 {
+		links["prev"] = pageLink(page - 1)
+	}
+	if hasNext {
+		links["next"] = pageLink(page + 1)
+	}
+	if lastPage > 0 {
+		links["last"] = pageLink(lastPage)
+	}
+	return links
+}
+
+func OffsetLinks(u *url.URL, offset, limit int, hasNext bool, last int) map[string]string {
+	offsetLink := func(offset int) string {
+		return link(u, map[string]string{"offset": strconv.Itoa(offset), "limit": strconv.Itoa(limit)}, "page")
+	}
+	links := map[string]string{
+		"self":  u.RequestURI(),
+		"first": offsetLink(0),
+	}
+	if offset > 0 {
+		links["prev"] = offsetLink(max(offset-limit, 0))
+	}
+	if hasNext {
+		links["next"] = offsetLink(offset + limit)
+	}
+	if last >= 0 {
+		links["last"] = offsetLink(last)
+	}
+	return links
+}
+
+func CursorLinks(u *url.URL, next string) map[string]string {
+	links := map[string]string{
+		"self":  u.RequestURI(),
+		"first": link(u, nil, "cursor"),
+	}
+	if next != "" {
+		links["next"] = link(u, map[string]string{"cursor": next})
+	}
+	return links
+}
@@ -28,4 +28,5 @@ func InitLogger(environment string) {
 	}
 
 	log.Logger = log.With().Caller().Logger()
+	zerolog.DefaultContextLogger = &log.Logger
 }
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type SlowRequestCounter struct {
+	total atomic.Int64
+}
+
+func NewSlowRequestCounter() *SlowRequestCounter {
+	return &SlowRequestCounter{}
+}
+
+func (counter *SlowRequestCounter) Total() int64 {
+	return counter.total.Load()
+}
+
+func AccessLogMiddleware(slowThreshold time.Duration, slowRequests *SlowRequestCounter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		query := c.Request.URL.RawQuery
+
+		c.Next()
+
+		latency := time.Since(start)
+		slow := slowThreshold > 0 && latency > slowThreshold
+		status := c.Writer.Status()
+		logger := log.Ctx(c.Request.Context())
+		var event *zerolog.Event
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = logger.Error()
+		case status >= http.StatusBadRequest || slow:
+			event = logger.Warn()
+		default:
+			event = logger.Info()
+		}
+
+		event = event.
+			Str("method", c.Request.Method).
+			Str("path", path).
+			Int("status", status).
+			Dur("latency", latency).
+			Int("bytes", max(c.Writer.Size(), 0))
+		if route := c.FullPath(); route != "" {
+			event = event.Str("route", route)
+		}
+		if userID, err := GetUserID(c); err == nil {
+			event = event.Uint(UserIDKey, userID)
+		}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate); len(errs) > 0 {
+			event = event.Str("error", errs.String())
+		}
+		if !slow {
+			event.Msg("Request handled")
+			return
+		}
+
+		if slowRequests != nil {
+			slowRequests.total.Add(1)
+		}
+		event.
+			Str("query", query).
+			Dur("threshold", slowThreshold).
+			Str("user_agent", c.Request.UserAgent()).
+			Msg("Slow request")
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "access-log-test-secret"}}
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), AccessLogMiddleware(0, nil))
+	router.GET("/articles/:id", JWTAuthMiddleware(cfg), func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	token, err := CreateTestToken(12, "", cfg.JWT.Secret)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		wantLevel  string
+		wantStatus float64
+		wantUserID float64
+		wantRoute  string
+	}{
+		{name: "Authenticated request", path: "/articles/5", token: token, wantLevel: "info", wantStatus: http.StatusOK, wantUserID: 12, wantRoute: "/articles/:id"},
+		{name: "Unauthenticated request", path: "/articles/5", wantLevel: "warn", wantStatus: http.StatusUnauthorized, wantRoute: "/articles/:id"},
+		{name: "Unknown route", path: "/missing", wantLevel: "warn", wantStatus: http.StatusNotFound},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			requestID := "access-log-" + strconv.Itoa(i)
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, requestID)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", buf.String())
+			}
+			if entry["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, entry["level"])
+			}
+			if entry["status"] != tt.wantStatus {
+				t.Errorf("Expected status %v, got %v", tt.wantStatus, entry["status"])
+			}
+			if entry["method"] != http.MethodGet || entry["path"] != tt.path {
+				t.Errorf("Expected GET %s, got %v %v", tt.path, entry["method"], entry["path"])
+			}
+			if entry[RequestIDKey] != requestID {
+				t.Errorf("Expected request ID %q, got %v", requestID, entry[RequestIDKey])
+			}
+			if userID, _ := entry[UserIDKey].(float64); userID != tt.wantUserID {
+				t.Errorf("Expected user ID %v, got %v", tt.wantUserID, entry[UserIDKey])
+			}
+			if route, _ := entry["route"].(string); route != tt.wantRoute {
+				t.Errorf("Expected route %q, got %q", tt.wantRoute, route)
+			}
+			if _, ok := entry["latency"]; !ok {
+				t.Errorf("Expected latency to be logged")
+			}
+		})
+	}
+}
+
+func TestAccessLogMiddlewareSlowRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	slowRequests := NewSlowRequestCounter()
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), AccessLogMiddleware(20*time.Millisecond, slowRequests))
+	router.GET("/fast", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(30 * time.Millisecond)
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		wantLevel   string
+		wantMessage string
+		wantSlow    int64
+	}{
+		{name: "Fast request", path: "/fast", wantLevel: "info", wantMessage: "Request handled"},
+		{name: "Slow request", path: "/slow?page=2", wantLevel: "warn", wantMessage: "Slow request", wantSlow: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", buf.String())
+			}
+			if entry["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, entry["level"])
+			}
+			if entry["message"] != tt.wantMessage {
+				t.Errorf("Expected message %q, got %v", tt.wantMessage, entry["message"])
+			}
+			if tt.wantSlow > 0 && entry["query"] != "page=2" {
+				t.Errorf("Expected query %q, got %v", "page=2", entry["query"])
+			}
+			if slowRequests.Total() != tt.wantSlow {
+				t.Errorf("Expected %d slow requests, got %d", tt.wantSlow, slowRequests.Total())
+			}
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"crypto/rsa"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"content-service/internal/shared/config"
@@ -13,56 +19,266 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-const UserIDKey = "user_id"
+const (
+	UserIDKey    = "user_id"
+	UserRoleKey  = "user_role"
+	UserEmailKey = "user_email"
+
+	AuthModeJWT           = "jwt"
+	AuthModeIntrospection = "introspection"
+)
 
 var ErrUserIDNotFound = errors.New("user_id not found in context")
 
 type Claims struct {
-	UserID uint `json:"user_id"`
+	UserID        uint     `json:"user_id"`
+	Role          string   `json:"role,omitempty"`
+	Scope         string   `json:"scope,omitempty"`
+	Permissions   []string `json:"permissions,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	EmailVerified bool     `json:"email_verified,omitempty"`
 	jwt.RegisteredClaims
 }
 
+func (claims *Claims) VerifiedEmail() string {
+	if !claims.EmailVerified {
+		return ""
+	}
+	return claims.Email
+}
+
+var (
+	hmacMethods = []string{"HS256", "HS384", "HS512"}
+	rsaMethods  = []string{"RS256", "RS384", "RS512"}
+)
+
+type Verifier struct {
+	secret       []byte
+	allowHMAC    bool
+	publicKey    *rsa.PublicKey
+	jwks         *JWKS
+	introspector *Introspector
+	issuer       string
+	audience     string
+}
+
+func NewVerifier(cfg *config.Config) (*Verifier, error) {
+	verifier := &Verifier{
+		secret:    []byte(cfg.JWT.Secret),
+		allowHMAC: hmacAllowed(cfg),
+		issuer:    cfg.JWT.Issuer,
+		audience:  cfg.JWT.Audience,
+	}
+	if cfg.Auth.Mode == AuthModeIntrospection {
+		verifier.introspector = NewIntrospector(cfg.Auth.Introspection)
+		return verifier, nil
+	}
+	if cfg.JWT.JWKSURL != "" {
+		verifier.jwks = NewJWKS(cfg.JWT.JWKSURL, cfg.JWT.JWKSRefresh)
+	}
+	if cfg.JWT.PublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.JWT.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+		verifier.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+		}
+		if bits := verifier.publicKey.N.BitLen(); bits < minRSAKeyBits {
+			return nil, fmt.Errorf("JWT public key has %d bits, at least %d are required", bits, minRSAKeyBits)
+		}
+	}
+	return verifier, nil
+}
+
+func hmacAllowed(cfg *config.Config) bool {
+	return cfg.JWT.AllowHS256 || (cfg.JWT.JWKSURL == "" && cfg.JWT.PublicKeyFile == "")
+}
+
+var verifiers sync.Map
+
+func VerifierFor(cfg *config.Config) (*Verifier, error) {
+	if verifier, ok := verifiers.Load(cfg); ok {
+		return verifier.(*Verifier), nil
+	}
+	verifier, err := NewVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := verifiers.LoadOrStore(cfg, verifier)
+	return actual.(*Verifier), nil
+}
+
+func (verifier *Verifier) methods() []string {
+	var methods []string
+	if verifier.publicKey != nil || verifier.jwks != nil {
+		methods = append(methods, rsaMethods...)
+	}
+	if verifier.allowHMAC {
+		methods = append(methods, hmacMethods...)
+	}
+	return methods
+}
+
+func (verifier *Verifier) key(token *jwt.Token) (interface{}, error) {
+	return verifier.signingKey(token, verifier.jwks.Key)
+}
+
+func (verifier *Verifier) cachedKey(token *jwt.Token) (interface{}, error) {
+	return verifier.signingKey(token, verifier.jwks.Cached)
+}
+
+func (verifier *Verifier) signingKey(token *jwt.Token, jwksKey func(kid string) (*rsa.PublicKey, error)) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return verifier.secret, nil
+	case *jwt.SigningMethodRSA:
+		if verifier.publicKey != nil {
+			return verifier.publicKey, nil
+		}
+		kid, _ := token.Header["kid"].(string)
+		return jwksKey(kid)
+	}
+	return nil, jwt.ErrSignatureInvalid
+}
+
+func (verifier *Verifier) Parse(tokenString string) (*Claims, error) {
+	if verifier.introspector != nil {
+		return verifier.introspector.Introspect(tokenString)
+	}
+	return verifier.parse(tokenString, verifier.key)
+}
+
+func (verifier *Verifier) ParseCached(tokenString string) (*Claims, error) {
+	if verifier.introspector != nil {
+		return verifier.introspector.Cached(tokenString)
+	}
+	return verifier.parse(tokenString, verifier.cachedKey)
+}
+
+func (verifier *Verifier) parse(tokenString string, key jwt.Keyfunc) (*Claims, error) {
+	options := []jwt.ParserOption{jwt.WithValidMethods(verifier.methods())}
+	if verifier.issuer != "" {
+		options = append(options, jwt.WithIssuer(verifier.issuer))
+	}
+	if verifier.audience != "" {
+		options = append(options, jwt.WithAudience(verifier.audience))
+	}
+
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, key, options...); err != nil {
+		return nil, err
+	}
+	if claims.UserID == 0 {
+		if subject, err := strconv.ParseUint(claims.Subject, 10, 32); err == nil {
+			claims.UserID = uint(subject)
+		}
+	}
+	if claims.UserID == 0 {
+		return nil, ErrUserIDNotFound
+	}
+	return claims, nil
+}
+
 func JWTAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	verifier, err := VerifierFor(cfg)
+	signatures := signatureVerifierFor(cfg)
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
+			if signature := c.GetHeader(SignatureHeader); signature != "" && signatures != nil {
+				authenticateSignature(c, signatures, signature)
+				return
+			}
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header is required"})
 			c.Abort()
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
-			c.Abort()
+		authenticate(c, verifier, err, authHeader)
+	}
+}
+
+func OptionalJWTAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	verifier, err := VerifierFor(cfg)
+	signatures := signatureVerifierFor(cfg)
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			if signature := c.GetHeader(SignatureHeader); signature != "" && signatures != nil {
+				authenticateSignature(c, signatures, signature)
+				return
+			}
+			c.Next()
 			return
 		}
 
-		tokenString := parts[1]
-		claims := &Claims{}
+		authenticate(c, verifier, err, authHeader)
+	}
+}
 
-		_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(cfg.JWT.Secret), nil
-		})
+func authenticate(c *gin.Context, verifier *Verifier, verifierErr error, authHeader string) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+		c.Abort()
+		return
+	}
 
-		if err != nil {
-			log.Warn().Err(err).Msg("Error parsing JWT token")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
-			return
-		}
+	if verifierErr != nil {
+		log.Error().Err(verifierErr).Msg("JWT verifier is not configured")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		c.Abort()
+		return
+	}
+
+	claims, err := verifier.Parse(parts[1])
+	if errors.Is(err, ErrIntrospectionUnavailable) {
+		log.Error().Err(err).Msg("Token introspection failed")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "authorization server unavailable"})
+		c.Abort()
+		return
+	}
+	if errors.Is(err, ErrUserIDNotFound) {
+		log.Warn().Msg("user_id not found in JWT token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in token"})
+		c.Abort()
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Msg("Error parsing JWT token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		c.Abort()
+		return
+	}
+
+	c.Set(UserIDKey, claims.UserID)
+	c.Set(UserRoleKey, claims.Role)
+	if email := claims.VerifiedEmail(); email != "" {
+		c.Set(UserEmailKey, email)
+	}
+	if scopes := claims.Scopes(); len(scopes) > 0 {
+		c.Set(UserScopesKey, scopes)
+	}
+	if !checkScopes(c) {
+		return
+	}
+	c.Next()
+}
 
-		if claims.UserID == 0 {
-			log.Warn().Msg("user_id not found in JWT token")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in token"})
+func ParseToken(tokenString, secret string) (*Claims, error) {
+	verifier := &Verifier{secret: []byte(secret), allowHMAC: true}
+	return verifier.Parse(tokenString)
+}
+
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(roles, GetUserRole(c)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: " + strings.Join(roles, " or ") + " role required"})
 			c.Abort()
 			return
 		}
-
-		c.Set(UserIDKey, claims.UserID)
 		c.Next()
 	}
 }
@@ -85,16 +301,23 @@ func GetUserID(c *gin.Context) (uint, error) {
 	}
 }
 
-func CreateTestToken(userID uint, secret string) (string, error) {
+func GetUserRole(c *gin.Context) string {
+	return c.GetString(UserRoleKey)
+}
+
+func GetUserEmail(c *gin.Context) string {
+	return c.GetString(UserEmailKey)
+}
+
+func CreateTestToken(userID uint, role, secret string, scopes ...string) (string, error) {
+	return signClaims(&Claims{UserID: userID, Role: role, Scope: strings.Join(scopes, " ")}, secret, 24*time.Hour)
+}
+
+func signClaims(claims *Claims, secret string, ttl time.Duration) (string, error) {
 	now := time.Now()
-	claims := &Claims{
-		UserID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-		},
-	}
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(secret))
@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret-key-min-32-chars-----"
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func jwksServer(t *testing.T, keys map[string]*rsa.PublicKey) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		set := []map[string]string{}
+		for kid, key := range keys {
+			set = append(set, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": set})
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func TestVerifier(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to encode public key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+
+	server, _ := jwksServer(t, map[string]*rsa.PublicKey{"key-1": &privateKey.PublicKey})
+
+	hmacOnly := config.JWTConfig{Secret: testSecret}
+	withPEM := config.JWTConfig{Secret: testSecret, PublicKeyFile: keyFile, Issuer: "https://id.example.com", Audience: "content-service", JWKSRefresh: time.Hour}
+	withJWKS := config.JWTConfig{Secret: testSecret, JWKSURL: server.URL, JWKSRefresh: time.Hour}
+	withFallback := withJWKS
+	withFallback.AllowHS256 = true
+
+	hs256 := signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", jwt.MapClaims{"user_id": 1})
+	rs256 := func(key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+		return signToken(t, jwt.SigningMethodRS256, key, kid, claims)
+	}
+
+	tests := []struct {
+		name       string
+		jwt        config.JWTConfig
+		token      string
+		wantUserID uint
+		wantErr    bool
+	}{
+		{name: "HS256 by default", jwt: hmacOnly, token: hs256, wantUserID: 1},
+		{name: "RS256 without keys", jwt: hmacOnly, token: rs256(privateKey, "", jwt.MapClaims{"user_id": 2}), wantErr: true},
+		{name: "PEM key", jwt: withPEM, token: rs256(privateKey, "", jwt.MapClaims{"user_id": 2, "iss": "https://id.example.com", "aud": "content-service"}), wantUserID: 2},
+		{name: "PEM key with subject", jwt: withPEM, token: rs256(privateKey, "", jwt.MapClaims{"sub": "7", "iss": "https://id.example.com", "aud": []string{"content-service"}}), wantUserID: 7},
+		{name: "Wrong issuer", jwt: withPEM, token: rs256(privateKey, "", jwt.MapClaims{"user_id": 2, "iss": "https://evil.example.com", "aud": "content-service"}), wantErr: true},
+		{name: "Wrong audience", jwt: withPEM, token: rs256(privateKey, "", jwt.MapClaims{"user_id": 2, "iss": "https://id.example.com", "aud": "billing"}), wantErr: true},
+		{name: "Wrong key", jwt: withPEM, token: rs256(otherKey, "", jwt.MapClaims{"user_id": 2, "iss": "https://id.example.com", "aud": "content-service"}), wantErr: true},
+		{name: "HS256 rejected once RS256 is configured", jwt: withPEM, token: hs256, wantErr: true},
+		{name: "JWKS key", jwt: withJWKS, token: rs256(privateKey, "key-1", jwt.MapClaims{"user_id": 3}), wantUserID: 3},
+		{name: "JWKS unknown kid", jwt: withJWKS, token: rs256(otherKey, "key-2", jwt.MapClaims{"user_id": 3}), wantErr: true},
+		{name: "Explicit HS256 fallback", jwt: withFallback, token: hs256, wantUserID: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(&config.Config{JWT: tt.jwt})
+			if err != nil {
+				t.Fatalf("NewVerifier() unexpected error: %v", err)
+			}
+			claims, err := verifier.Parse(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got claims %+v", claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if claims.UserID != tt.wantUserID {
+				t.Errorf("Expected user %d, got %d", tt.wantUserID, claims.UserID)
+			}
+		})
+	}
+}
+
+func TestVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: testSecret}}
+	router := gin.New()
+	router.GET("/api/me", JWTAuthMiddleware(cfg), func(c *gin.Context) {
+		c.String(http.StatusOK, GetUserEmail(c))
+	})
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   string
+	}{
+		{name: "Verified email", claims: jwt.MapClaims{"user_id": 1, "email": "jane@example.com", "email_verified": true}, want: "jane@example.com"},
+		{name: "Unverified email", claims: jwt.MapClaims{"user_id": 1, "email": "jane@example.com"}, want: ""},
+		{name: "No email", claims: jwt.MapClaims{"user_id": 1}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+			req.Header.Set("Authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", tt.claims))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK || w.Body.String() != tt.want {
+				t.Errorf("Expected email %q, got %d %q", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestJWKSRefresh(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{"key-1": &first.PublicKey}
+	server, fetches := jwksServer(t, keys)
+	jwks := NewJWKS(server.URL, time.Hour)
+
+	if _, err := jwks.Key("key-1"); err != nil {
+		t.Fatalf("Key() unexpected error: %v", err)
+	}
+	if _, err := jwks.Key("key-1"); err != nil {
+		t.Fatalf("Key() unexpected error: %v", err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("Expected cached keys to be reused, got %d fetches", got)
+	}
+
+	keys["key-2"] = &second.PublicKey
+	if _, err := jwks.Key("key-2"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey inside the refetch window, got %v", err)
+	}
+
+	jwks.attempted.Store(0)
+	if _, err := jwks.Key("key-2"); err != nil {
+		t.Errorf("Expected a rotated key to be picked up, got %v", err)
+	}
+
+	jwks.set.Store(&keySet{keys: jwks.set.Load().keys, fetchedAt: time.Now().Add(-2 * time.Hour)})
+	jwks.attempted.Store(0)
+	server.Close()
+	if _, err := jwks.Key("key-1"); err != nil {
+		t.Errorf("Expected cached keys to survive a failed refresh, got %v", err)
+	}
+}
+
+func TestJWKSConcurrentFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	server, fetches := jwksServer(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey, "weak": &weak.PublicKey})
+	jwks := NewJWKS(server.URL, time.Hour)
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := jwks.Key("key-1"); err != nil {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := fetches.Load(); got != 1 || failures.Load() != 0 {
+		t.Errorf("Expected concurrent lookups to share one fetch, got %d fetches and %d failures", got, failures.Load())
+	}
+
+	if _, err := jwks.Key("weak"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected a 1024-bit key to be rejected, got %v", err)
+	}
+}
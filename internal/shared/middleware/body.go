@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BodyRule struct {
+	MaxBytes     int64
+	ContentTypes []string
+}
+
+type BodyRules struct {
+	routes map[string]BodyRule
+	mu     sync.RWMutex
+}
+
+func NewBodyRules() *BodyRules {
+	return &BodyRules{routes: make(map[string]BodyRule)}
+}
+
+func (r *BodyRules) Set(method, path string, rule BodyRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[method+" "+path] = rule
+}
+
+func (r *BodyRules) Lookup(method, path string) (BodyRule, bool) {
+	if r == nil {
+		return BodyRule{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.routes[method+" "+path]
+	return rule, ok
+}
+
+func RequestBodyMiddleware(cfg *config.Config, rules *BodyRules) gin.HandlerFunc {
+	defaults := BodyRule{MaxBytes: cfg.Body.MaxBytes, ContentTypes: cfg.Body.ContentTypes}
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody || c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		rule := defaults
+		if route, ok := rules.Lookup(c.Request.Method, c.FullPath()); ok {
+			if route.MaxBytes > 0 {
+				rule.MaxBytes = route.MaxBytes
+			}
+			if len(route.ContentTypes) > 0 {
+				rule.ContentTypes = route.ContentTypes
+			}
+		}
+
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil || !slices.Contains(rule.ContentTypes, strings.ToLower(mediaType)) {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+				"error": "content type must be " + joinAlternatives(rule.ContentTypes),
+			})
+			return
+		}
+
+		if rule.MaxBytes > 0 {
+			if c.Request.ContentLength > rule.MaxBytes {
+				abortTooLarge(c, rule.MaxBytes)
+				return
+			}
+			if c.Request.ContentLength < 0 {
+				body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, rule.MaxBytes))
+				if err != nil {
+					abortTooLarge(c, rule.MaxBytes)
+					return
+				}
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+				c.Request.ContentLength = int64(len(body))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func abortTooLarge(c *gin.Context, maxBytes int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": fmt.Sprintf("request body cannot exceed %d bytes", maxBytes),
+	})
+}
+
+func joinAlternatives(values []string) string {
+	if len(values) < 2 {
+		return strings.Join(values, "")
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestBodyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Body: config.BodyConfig{MaxBytes: 16, ContentTypes: []string{"application/json"}},
+	}
+	rules := NewBodyRules()
+	rules.Set(http.MethodPost, "/import", BodyRule{MaxBytes: 64, ContentTypes: []string{"application/json", "application/zip"}})
+
+	router := gin.New()
+	router.Use(RequestBodyMiddleware(cfg, rules))
+	echo := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, string(body))
+	}
+	router.POST("/articles", echo)
+	router.POST("/import", echo)
+	router.DELETE("/articles", echo)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		chunked     bool
+		wantStatus  int
+	}{
+		{name: "JSON body", method: http.MethodPost, path: "/articles", contentType: "application/json", body: `{"a":1}`, wantStatus: http.StatusOK},
+		{name: "JSON with charset", method: http.MethodPost, path: "/articles", contentType: "application/json; charset=utf-8", body: `{"a":1}`, wantStatus: http.StatusOK},
+		{name: "Missing content type", method: http.MethodPost, path: "/articles", body: `{"a":1}`, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "Form body", method: http.MethodPost, path: "/articles", contentType: "application/x-www-form-urlencoded", body: "a=1", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "Body too large", method: http.MethodPost, path: "/articles", contentType: "application/json", body: `{"title":"too long"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Chunked body too large", method: http.MethodPost, path: "/articles", contentType: "application/json", body: `{"title":"too long"}`, chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Chunked body within limit", method: http.MethodPost, path: "/articles", contentType: "application/json", body: `{"a":1}`, chunked: true, wantStatus: http.StatusOK},
+		{name: "Empty body skips checks", method: http.MethodDelete, path: "/articles", wantStatus: http.StatusOK},
+		{name: "Route allows zip", method: http.MethodPost, path: "/import", contentType: "application/zip", body: "PK", wantStatus: http.StatusOK},
+		{name: "Route raises limit", method: http.MethodPost, path: "/import", contentType: "application/json", body: `{"title":"too long"}`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("Expected body %q to reach the handler, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("Error names the accepted types", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/import", strings.NewReader("text"))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if !strings.Contains(w.Body.String(), "content type must be application/json or application/zip") {
+			t.Errorf("Expected accepted types in error, got %s", w.Body.String())
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	sensitiveKey       = `[A-Za-z0-9_\-]*(?i:password|passwd|secret|token|api[_\-]?key|authorization|credential|signature)[A-Za-z0-9_\-]*`
+	sensitiveJSONValue = regexp.MustCompile(`("` + sensitiveKey + `"\s*:\s*)("(?:[^"\\]|\\.)*(?:"|$)|[^\s,}\]]+)`)
+	sensitiveFormValue = regexp.MustCompile(`((?:^|&)` + sensitiveKey + `=)[^&]*`)
+)
+
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	maxBytes int
+	size     int
+}
+
+func (w *bodyLogWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyLogWriter) capture(data []byte) {
+	w.size += len(data)
+	if room := w.maxBytes - w.body.Len(); room > 0 {
+		w.body.Write(data[:min(room, len(data))])
+	}
+}
+
+func BodyLogMiddleware(cfg *config.Config) gin.HandlerFunc {
+	maxBytes := cfg.App.LogHTTPBodyMaxBytes
+
+	return func(c *gin.Context) {
+		var request []byte
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			body := c.Request.Body
+			head, _ := io.ReadAll(io.LimitReader(body, int64(maxBytes)+1))
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(head), body), body}
+			request = head
+		}
+
+		writer := &bodyLogWriter{ResponseWriter: c.Writer, maxBytes: maxBytes}
+		c.Writer = writer
+		defer func() { c.Writer = writer.ResponseWriter }()
+
+		c.Next()
+
+		event := log.Ctx(c.Request.Context()).Info().
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Int("status", writer.Status())
+		if len(request) > 0 {
+			event = event.
+				Str("request_content_type", c.ContentType()).
+				Str("request_body", sanitizeBody(c.ContentType(), request[:min(len(request), maxBytes)], len(request) > maxBytes))
+		}
+		if writer.size > 0 {
+			contentType := writer.Header().Get("Content-Type")
+			event = event.
+				Str("response_content_type", contentType).
+				Int("response_bytes", writer.size).
+				Str("response_body", sanitizeBody(contentType, writer.body.Bytes(), writer.size > maxBytes))
+		}
+		event.Msg("HTTP body")
+	}
+}
+
+func sanitizeBody(contentType string, body []byte, truncated bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var text string
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		text = sensitiveJSONValue.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+	case mediaType == "application/x-www-form-urlencoded":
+		text = sensitiveFormValue.ReplaceAllString(string(body), "${1}"+redacted)
+	case strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+xml") || mediaType == "application/xml":
+		text = string(body)
+	default:
+		return "[" + mediaType + " body omitted]"
+	}
+	if truncated {
+		text += "...[truncated]"
+	}
+	return text
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestBodyLogMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	cfg := &config.Config{App: config.AppConfig{LogHTTPBody: true, LogHTTPBodyMaxBytes: 64}}
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), BodyLogMiddleware(cfg))
+	router.POST("/echo", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, c.ContentType(), body)
+	})
+
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		wantRequest  string
+		wantResponse string
+	}{
+		{
+			name:         "Secrets in JSON are redacted",
+			contentType:  "application/json",
+			body:         `{"email":"a@b.c","password":"hunter2","refresh_token":"abc"}`,
+			wantRequest:  `{"email":"a@b.c","password":"[REDACTED]","refresh_token":"[REDACTED]"}`,
+			wantResponse: `{"email":"a@b.c","password":"[REDACTED]","refresh_token":"[REDACTED]"}`,
+		},
+		{
+			name:         "Truncated JSON keeps secrets redacted",
+			contentType:  "application/json",
+			body:         `{"title":"Hello","content":"` + strings.Repeat("x", 20) + `","api_key":"` + strings.Repeat("k", 40) + `"}`,
+			wantRequest:  `{"title":"Hello","content":"` + strings.Repeat("x", 20) + `","api_key":"[REDACTED]"...[truncated]`,
+			wantResponse: `{"title":"Hello","content":"` + strings.Repeat("x", 20) + `","api_key":"[REDACTED]"...[truncated]`,
+		},
+		{
+			name:         "Form values are redacted",
+			contentType:  "application/x-www-form-urlencoded",
+			body:         "username=alice&client_secret=s3cret",
+			wantRequest:  "username=alice&client_secret=[REDACTED]",
+			wantResponse: "username=alice&client_secret=[REDACTED]",
+		},
+		{
+			name:         "Binary bodies are omitted",
+			contentType:  "application/zip",
+			body:         "PK\x03\x04",
+			wantRequest:  "[application/zip body omitted]",
+			wantResponse: "[application/zip body omitted]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.body {
+				t.Errorf("Expected the handler to read the full body %q, got %q", tt.body, w.Body.String())
+			}
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("Expected one JSON log line, got %q", buf.String())
+			}
+			if entry["request_body"] != tt.wantRequest {
+				t.Errorf("Expected request body %q, got %v", tt.wantRequest, entry["request_body"])
+			}
+			if entry["response_body"] != tt.wantResponse {
+				t.Errorf("Expected response body %q, got %v", tt.wantResponse, entry["response_body"])
+			}
+			if entry[RequestIDKey] == nil {
+				t.Errorf("Expected the log line to carry the request ID")
+			}
+		})
+	}
+}
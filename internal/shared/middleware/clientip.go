@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ClientIPKey = "client_ip"
+
+func ConfigureClientIP(engine *gin.Engine, header string, trustedProxies []string) error {
+	if err := engine.SetTrustedProxies(trustedProxies); err != nil {
+		return err
+	}
+	if header == "" {
+		return nil
+	}
+	engine.ForwardedByClientIP = true
+	engine.RemoteIPHeaders = []string{http.CanonicalHeaderKey(header)}
+	return nil
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestConfigureClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		header         string
+		trustedProxies []string
+		headers        map[string]string
+		wantIP         string
+	}{
+		{
+			name:           "Custom header is used",
+			header:         "X-Client-IP",
+			trustedProxies: []string{"192.0.2.0/24"},
+			headers: map[string]string{
+				"X-Client-IP":     "203.0.113.7",
+				"X-Forwarded-For": "198.51.100.1",
+			},
+			wantIP: "203.0.113.7",
+		},
+		{
+			name:           "X-Real-IP only",
+			header:         "x-real-ip",
+			trustedProxies: []string{"192.0.2.0/24"},
+			headers: map[string]string{
+				"X-Real-IP":       "203.0.113.8",
+				"X-Forwarded-For": "198.51.100.2",
+			},
+			wantIP: "203.0.113.8",
+		},
+		{
+			name:           "Invalid header value falls back to remote address",
+			header:         "X-Client-IP",
+			trustedProxies: []string{"192.0.2.0/24"},
+			headers: map[string]string{
+				"X-Client-IP": "not-an-ip",
+			},
+			wantIP: "192.0.2.1",
+		},
+		{
+			name:           "Default headers from a trusted proxy",
+			header:         "",
+			trustedProxies: []string{"192.0.2.1"},
+			headers: map[string]string{
+				"X-Forwarded-For": "198.51.100.3",
+			},
+			wantIP: "198.51.100.3",
+		},
+		{
+			name:   "No trusted proxies ignores forwarded headers",
+			header: "X-Forwarded-For",
+			headers: map[string]string{
+				"X-Forwarded-For": "198.51.100.4",
+			},
+			wantIP: "192.0.2.1",
+		},
+		{
+			name:           "Trusted proxy forwards client IP",
+			header:         "X-Real-IP",
+			trustedProxies: []string{"192.0.2.0/24"},
+			headers: map[string]string{
+				"X-Real-IP": "203.0.113.9",
+			},
+			wantIP: "203.0.113.9",
+		},
+		{
+			name:           "Untrusted peer cannot spoof client IP",
+			header:         "X-Real-IP",
+			trustedProxies: []string{"10.0.0.0/8"},
+			headers: map[string]string{
+				"X-Real-IP": "203.0.113.10",
+			},
+			wantIP: "192.0.2.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			if err := ConfigureClientIP(router, tt.header, tt.trustedProxies); err != nil {
+				t.Fatalf("Failed to configure client IP: %v", err)
+			}
+			router.Use(RateLimitMiddleware(&config.Config{}, store))
+			router.GET("/", func(c *gin.Context) {
+				c.String(http.StatusOK, c.ClientIP())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.wantIP {
+				t.Errorf("Expected client IP %q, got %q", tt.wantIP, w.Body.String())
+			}
+
+			store.mu.RLock()
+			_, keyed := store.limiters["ip:"+tt.wantIP+":read"]
+			store.mu.RUnlock()
+			if !keyed {
+				t.Errorf("Expected rate limiter to be keyed by %q", tt.wantIP)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"os"
+	"strings"
 
 	"content-service/internal/shared/config"
 
@@ -10,6 +11,8 @@ import (
 )
 
 func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
+	allowMethods := strings.Join(cfg.App.AllowedMethods, ", ")
+
 	return func(c *gin.Context) {
 		allowOrigin := os.Getenv("CORS_ALLOWED_ORIGIN")
 		if allowOrigin == "" {
@@ -22,9 +25,9 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 
 		if allowOrigin != "" {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", allowOrigin)
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
-			c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-Match, If-None-Match, Idempotency-Key")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, ETag, Idempotent-Replayed, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-RateLimit-Warning, Retry-After")
 
 			if allowOrigin != "*" {
 				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
@@ -32,6 +35,7 @@ func CORSMiddleware(cfg *config.Config) gin.HandlerFunc {
 		}
 
 		if c.Request.Method == "OPTIONS" {
+			setRouteAllow(c, cfg.App.AllowedMethods)
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGzipMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Compression: config.CompressionConfig{
+			ContentTypes: []string{"application/json", "text/*"},
+		},
+	}
+
+	router := gin.New()
+	router.Use(GzipMiddleware(cfg))
+	router.GET("/json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(http.StatusOK, "plain text")
+	})
+	router.GET("/binary", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/octet-stream", []byte{0x00, 0x01, 0x02})
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantCompressed bool
+		wantBody       string
+	}{
+		{
+			name:           "JSON is compressed",
+			path:           "/json",
+			acceptEncoding: "gzip, deflate",
+			wantCompressed: true,
+			wantBody:       `{"status":"ok"}`,
+		},
+		{
+			name:           "Wildcard text type is compressed",
+			path:           "/text",
+			acceptEncoding: "gzip",
+			wantCompressed: true,
+			wantBody:       "plain text",
+		},
+		{
+			name:           "Non-listed type is not compressed",
+			path:           "/binary",
+			acceptEncoding: "gzip",
+			wantCompressed: false,
+			wantBody:       "\x00\x01\x02",
+		},
+		{
+			name:           "Client without gzip support",
+			path:           "/json",
+			acceptEncoding: "",
+			wantCompressed: false,
+			wantBody:       `{"status":"ok"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if vary := w.Header().Get("Vary"); vary != "Accept-Encoding" {
+				t.Errorf("Expected Vary: Accept-Encoding, got %q", vary)
+			}
+
+			compressed := w.Header().Get("Content-Encoding") == "gzip"
+			if compressed != tt.wantCompressed {
+				t.Fatalf("Expected compressed=%v, got Content-Encoding %q", tt.wantCompressed, w.Header().Get("Content-Encoding"))
+			}
+
+			body := w.Body.String()
+			if compressed {
+				reader, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("Failed to open gzip body: %v", err)
+				}
+				decoded, err := io.ReadAll(reader)
+				if err != nil {
+					t.Fatalf("Failed to read gzip body: %v", err)
+				}
+				body = string(decoded)
+			}
+
+			if body != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, body)
+			}
+		})
+	}
+}
+
+func TestGzipMiddlewareFlush(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Compression: config.CompressionConfig{
+			ContentTypes: []string{"text/*"},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	router.Use(GzipMiddleware(cfg))
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		_, _ = c.Writer.WriteString("first chunk")
+		c.Writer.Flush()
+
+		if !w.Flushed {
+			t.Error("Expected the flush to reach the underlying writer")
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+		if err != nil {
+			t.Fatalf("Failed to open flushed gzip body: %v", err)
+		}
+		chunk := make([]byte, len("first chunk"))
+		if _, err := io.ReadFull(reader, chunk); err != nil || string(chunk) != "first chunk" {
+			t.Errorf("Expected the first chunk to be readable after a flush, got %q (%v)", chunk, err)
+		}
+
+		_, _ = c.Writer.WriteString(", second chunk")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	router.ServeHTTP(w, req)
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to open gzip body: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read gzip body: %v", err)
+	}
+	if string(body) != "first chunk, second chunk" {
+		t.Errorf("Expected body %q, got %q", "first chunk, second chunk", body)
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InFlightCounter struct {
+	current atomic.Int64
+	total   atomic.Int64
+}
+
+func NewInFlightCounter() *InFlightCounter {
+	return &InFlightCounter{}
+}
+
+func (ic *InFlightCounter) Current() int64 {
+	return ic.current.Load()
+}
+
+func (ic *InFlightCounter) Total() int64 {
+	return ic.total.Load()
+}
+
+func InFlightMiddleware(counter *InFlightCounter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		counter.current.Add(1)
+		counter.total.Add(1)
+		defer counter.current.Add(-1)
+
+		c.Next()
+	}
+}
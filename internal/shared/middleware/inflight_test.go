@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestInFlightMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	counter := NewInFlightCounter()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	router := gin.New()
+	router.Use(InFlightMiddleware(counter))
+	router.GET("/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		close(done)
+	}()
+
+	<-entered
+	if got := counter.Current(); got != 1 {
+		t.Errorf("Expected 1 request in flight, got %d", got)
+	}
+
+	close(release)
+	<-done
+	if got := counter.Current(); got != 0 {
+		t.Errorf("Expected 0 requests in flight after completion, got %d", got)
+	}
+
+	func() {
+		defer func() { _ = recover() }()
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+	}()
+	if got := counter.Current(); got != 0 {
+		t.Errorf("Expected counter to be decremented after panic, got %d", got)
+	}
+
+	if got := counter.Total(); got != 2 {
+		t.Errorf("Expected 2 requests served, got %d", got)
+	}
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const introspectionTimeout = 5 * time.Second
+
+var (
+	ErrTokenInactive            = errors.New("token is not active")
+	ErrIntrospectionUnavailable = errors.New("token introspection unavailable")
+	ErrNotIntrospected          = errors.New("token has not been introspected")
+)
+
+type introspectionResponse struct {
+	Active        bool   `json:"active"`
+	Subject       string `json:"sub"`
+	UserID        any    `json:"user_id"`
+	Role          string `json:"role"`
+	Scope         string `json:"scope"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Issuer        string `json:"iss"`
+	Expires       int64  `json:"exp"`
+	ClientID      string `json:"client_id"`
+}
+
+type introspected struct {
+	claims  *Claims
+	err     error
+	expires time.Time
+}
+
+type Introspector struct {
+	url          string
+	clientID     string
+	clientSecret string
+	ttl          time.Duration
+	size         int
+	client       *http.Client
+	cache        map[string]introspected
+	mu           sync.Mutex
+}
+
+func NewIntrospector(cfg config.IntrospectionConfig) *Introspector {
+	return &Introspector{
+		url:          cfg.URL,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		ttl:          cfg.CacheTTL,
+		size:         cfg.CacheSize,
+		client:       &http.Client{Timeout: introspectionTimeout},
+		cache:        make(map[string]introspected),
+	}
+}
+
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (introspector *Introspector) Cached(token string) (*Claims, error) {
+	introspector.mu.Lock()
+	entry, ok := introspector.cache[tokenKey(token)]
+	introspector.mu.Unlock()
+	if !ok || !time.Now().Before(entry.expires) {
+		return nil, ErrNotIntrospected
+	}
+	return entry.claims, entry.err
+}
+
+func (introspector *Introspector) Introspect(token string) (*Claims, error) {
+	if claims, err := introspector.Cached(token); !errors.Is(err, ErrNotIntrospected) {
+		return claims, err
+	}
+
+	key := tokenKey(token)
+	resp, err := introspector.request(token)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := introspected{expires: time.Now().Add(introspector.ttl)}
+	entry.claims, entry.err = resp.claims()
+	if resp.Expires > 0 {
+		if expires := time.Unix(resp.Expires, 0); expires.Before(entry.expires) {
+			entry.expires = expires
+		}
+	}
+	introspector.store(key, entry)
+	return entry.claims, entry.err
+}
+
+func (introspector *Introspector) request(token string) (*introspectionResponse, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequest(http.MethodPost, introspector.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionUnavailable, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if introspector.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(introspector.clientID), url.QueryEscape(introspector.clientSecret))
+	}
+
+	resp, err := introspector.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrIntrospectionUnavailable, resp.StatusCode)
+	}
+
+	var body introspectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %v", ErrIntrospectionUnavailable, err)
+	}
+	return &body, nil
+}
+
+func (introspector *Introspector) store(key string, entry introspected) {
+	if !time.Now().Before(entry.expires) {
+		return
+	}
+
+	introspector.mu.Lock()
+	defer introspector.mu.Unlock()
+
+	if len(introspector.cache) >= introspector.size {
+		now := time.Now()
+		for cached, existing := range introspector.cache {
+			if !now.Before(existing.expires) {
+				delete(introspector.cache, cached)
+			}
+		}
+	}
+	for cached := range introspector.cache {
+		if len(introspector.cache) < introspector.size {
+			break
+		}
+		delete(introspector.cache, cached)
+	}
+	introspector.cache[key] = entry
+}
+
+func (resp *introspectionResponse) claims() (*Claims, error) {
+	if !resp.Active {
+		return nil, ErrTokenInactive
+	}
+
+	claims := &Claims{
+		Role:          resp.Role,
+		Scope:         resp.Scope,
+		Email:         resp.Email,
+		EmailVerified: resp.EmailVerified,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: resp.Subject,
+			Issuer:  resp.Issuer,
+		},
+	}
+	if resp.Expires > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Unix(resp.Expires, 0))
+	}
+
+	userID := resp.Subject
+	switch value := resp.UserID.(type) {
+	case float64:
+		userID = strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		userID = value
+	}
+	if id, err := strconv.ParseUint(userID, 10, 32); err == nil {
+		claims.UserID = uint(id)
+	}
+	if claims.UserID == 0 {
+		return nil, ErrUserIDNotFound
+	}
+	return claims, nil
+}
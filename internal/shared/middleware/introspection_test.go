@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIntrospection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "content-service" || pass != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var body map[string]any
+		switch r.PostForm.Get("token") {
+		case "active-token":
+			body = map[string]any{"active": true, "sub": "42", "role": "admin", "exp": time.Now().Add(time.Hour).Unix()}
+		case "user-id-token":
+			body = map[string]any{"active": true, "sub": "svc-account", "user_id": 7}
+		case "service-token":
+			body = map[string]any{"active": true, "sub": "svc-account", "client_id": "batch"}
+		default:
+			body = map[string]any{"active": false}
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Auth: config.AuthConfig{
+		Mode: AuthModeIntrospection,
+		Introspection: config.IntrospectionConfig{
+			URL:          server.URL,
+			ClientID:     "content-service",
+			ClientSecret: "s3cret",
+			CacheTTL:     time.Minute,
+			CacheSize:    100,
+		},
+	}}
+
+	router := gin.New()
+	router.GET("/api/me", JWTAuthMiddleware(cfg), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": GetUserRole(c)})
+	})
+	call := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantStatus int
+		wantUserID uint
+		wantRole   string
+	}{
+		{name: "Active token", token: "active-token", wantStatus: http.StatusOK, wantUserID: 42, wantRole: "admin"},
+		{name: "Active token with user_id", token: "user-id-token", wantStatus: http.StatusOK, wantUserID: 7},
+		{name: "Active token without a user", token: "service-token", wantStatus: http.StatusUnauthorized},
+		{name: "Inactive token", token: "revoked-token", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := call(tt.token)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				UserID uint   `json:"user_id"`
+				Role   string `json:"role"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.UserID != tt.wantUserID || resp.Role != tt.wantRole {
+				t.Errorf("Expected user %d with role %q, got %+v", tt.wantUserID, tt.wantRole, resp)
+			}
+		})
+	}
+
+	before := calls.Load()
+	if w := call("active-token"); w.Code != http.StatusOK {
+		t.Errorf("Expected cached token to be accepted, got %d", w.Code)
+	}
+	if w := call("revoked-token"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected cached inactive token to be rejected, got %d", w.Code)
+	}
+	if got := calls.Load(); got != before {
+		t.Errorf("Expected cached introspection results, got %d extra calls", got-before)
+	}
+
+	failing.Store(true)
+	if w := call("new-token"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d when the authorization server fails, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w := call("active-token"); w.Code != http.StatusOK {
+		t.Errorf("Expected cached token to survive an outage, got %d", w.Code)
+	}
+}
+
+func TestIntrospectorCacheBounds(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": true, "sub": "1", "exp": time.Now().Add(time.Hour).Unix()})
+	}))
+	defer server.Close()
+
+	introspector := NewIntrospector(config.IntrospectionConfig{URL: server.URL, CacheTTL: time.Minute, CacheSize: 2})
+	for _, token := range []string{"a", "b", "c"} {
+		if _, err := introspector.Introspect(token); err != nil {
+			t.Fatalf("Introspect() unexpected error: %v", err)
+		}
+	}
+	if got := len(introspector.cache); got != 2 {
+		t.Errorf("Expected the cache to hold 2 entries, got %d", got)
+	}
+
+	uncached := NewIntrospector(config.IntrospectionConfig{URL: server.URL, CacheSize: 2})
+	for range 2 {
+		if _, err := uncached.Introspect("a"); err != nil {
+			t.Fatalf("Introspect() unexpected error: %v", err)
+		}
+	}
+	if got := len(uncached.cache); got != 0 {
+		t.Errorf("Expected no caching with a zero TTL, got %d entries", got)
+	}
+
+	server.Close()
+	if _, err := uncached.Introspect("a"); !errors.Is(err, ErrIntrospectionUnavailable) {
+		t.Errorf("Expected ErrIntrospectionUnavailable, got %v", err)
+	}
+}
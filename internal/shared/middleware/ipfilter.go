@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type IPFilter struct {
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+func NewIPFilter(allow, deny []string) (*IPFilter, error) {
+	allowed, err := parsePrefixes(allow)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := parsePrefixes(deny)
+	if err != nil {
+		return nil, err
+	}
+	return &IPFilter{allow: allowed, deny: denied}, nil
+}
+
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP or CIDR %q: %w", value, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP or CIDR %q: %w", value, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func (filter *IPFilter) Enabled() bool {
+	return len(filter.allow) > 0 || len(filter.deny) > 0
+}
+
+func (filter *IPFilter) Allowed(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range filter.deny {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if len(filter.allow) == 0 {
+		return true
+	}
+	for _, prefix := range filter.allow {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func IPFilterMiddleware(filter *IPFilter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !filter.Enabled() {
+			c.Next()
+			return
+		}
+
+		ip := c.ClientIP()
+		if !filter.Allowed(ip) {
+			log.Ctx(c.Request.Context()).Warn().Str("ip", ip).Str("path", c.Request.URL.Path).Msg("Request blocked by IP filter")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied from this address"})
+			return
+		}
+		c.Next()
+	}
+}
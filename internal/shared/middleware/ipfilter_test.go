@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIPFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		allow      []string
+		deny       []string
+		remoteAddr string
+		wantStatus int
+	}{
+		{name: "No lists allow everyone", remoteAddr: "198.51.100.1:1234", wantStatus: http.StatusOK},
+		{name: "Address in allowed range", allow: []string{"10.8.0.0/16"}, remoteAddr: "10.8.3.4:1234", wantStatus: http.StatusOK},
+		{name: "Address outside allowed range", allow: []string{"10.8.0.0/16"}, remoteAddr: "10.9.0.1:1234", wantStatus: http.StatusForbidden},
+		{name: "Single allowed address", allow: []string{"203.0.113.5"}, remoteAddr: "203.0.113.5:1234", wantStatus: http.StatusOK},
+		{name: "Deny wins over allow", allow: []string{"10.0.0.0/8"}, deny: []string{"10.8.3.0/24"}, remoteAddr: "10.8.3.4:1234", wantStatus: http.StatusForbidden},
+		{name: "Deny only", deny: []string{"198.51.100.0/24"}, remoteAddr: "198.51.100.7:1234", wantStatus: http.StatusForbidden},
+		{name: "IPv6 range", allow: []string{"2001:db8::/32"}, remoteAddr: "[2001:db8::1]:1234", wantStatus: http.StatusOK},
+		{name: "IPv4-mapped IPv6 address", allow: []string{"10.8.0.0/16"}, remoteAddr: "[::ffff:10.8.0.1]:1234", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewIPFilter(tt.allow, tt.deny)
+			if err != nil {
+				t.Fatalf("Failed to create filter: %v", err)
+			}
+
+			router := gin.New()
+			router.GET("/admin", IPFilterMiddleware(filter), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			req.RemoteAddr = tt.remoteAddr
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+
+	t.Run("Invalid range", func(t *testing.T) {
+		if _, err := NewIPFilter([]string{"10.0.0.0/33"}, nil); err == nil {
+			t.Errorf("Expected error for invalid CIDR")
+		}
+		if _, err := NewIPFilter(nil, []string{"vpn"}); err == nil {
+			t.Errorf("Expected error for invalid address")
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	jwksFetchTimeout  = 10 * time.Second
+	jwksRefetchWindow = 30 * time.Second
+	minRSAKeyBits     = 2048
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type keySet struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type JWKS struct {
+	url       string
+	refresh   time.Duration
+	client    *http.Client
+	set       atomic.Pointer[keySet]
+	attempted atomic.Int64
+	fetches   singleflight.Group
+}
+
+func NewJWKS(url string, refresh time.Duration) *JWKS {
+	return &JWKS{url: url, refresh: refresh, client: &http.Client{Timeout: jwksFetchTimeout}}
+}
+
+func (jwks *JWKS) Key(kid string) (*rsa.PublicKey, error) {
+	set := jwks.set.Load()
+	key, known := set.lookup(kid)
+	if known && time.Since(set.fetchedAt) <= jwks.refresh {
+		return key, nil
+	}
+
+	_, _, _ = jwks.fetches.Do("", func() (interface{}, error) {
+		if !jwks.claimRefetch() {
+			return nil, nil
+		}
+		if err := jwks.fetch(); err != nil {
+			log.Warn().Err(err).Str("url", jwks.url).Msg("Failed to refresh JWKS, keeping cached keys")
+		}
+		return nil, nil
+	})
+	return jwks.Cached(kid)
+}
+
+func (jwks *JWKS) Cached(kid string) (*rsa.PublicKey, error) {
+	key, ok := jwks.set.Load().lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+func (jwks *JWKS) claimRefetch() bool {
+	last := jwks.attempted.Load()
+	if time.Since(time.Unix(0, last)) <= jwksRefetchWindow {
+		return false
+	}
+	return jwks.attempted.CompareAndSwap(last, time.Now().UnixNano())
+}
+
+func (set *keySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	if set == nil {
+		return nil, false
+	}
+	if kid == "" && len(set.keys) == 1 {
+		for _, key := range set.keys {
+			return key, true
+		}
+	}
+	key, ok := set.keys[kid]
+	return key, ok
+}
+
+func (jwks *JWKS) fetch() error {
+	resp, err := jwks.client.Get(jwks.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		publicKey, err := key.rsa()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", key.Kid).Msg("Skipping invalid JWKS key")
+			continue
+		}
+		keys[key.Kid] = publicKey
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no usable RSA signing keys")
+	}
+
+	jwks.set.Store(&keySet{keys: keys, fetchedAt: time.Now()})
+	return nil
+}
+
+func (key jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+	modulus := new(big.Int).SetBytes(n)
+	if modulus.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("modulus has %d bits, at least %d are required", modulus.BitLen(), minRSAKeyBits)
+	}
+	return &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}, nil
+}
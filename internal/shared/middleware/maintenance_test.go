@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	maintenance := NewMaintenance(false, 2*time.Minute)
+
+	router := gin.New()
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	api := router.Group("/api", MaintenanceMiddleware(maintenance, "/api/admin"))
+	api.GET("/articles", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	api.GET("/admin/maintenance", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	if w := request("/api/articles"); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d with maintenance off, got %d", http.StatusOK, w.Code)
+	}
+
+	maintenance.SetEnabled(true)
+
+	w := request("/api/articles")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d with maintenance on, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "120" {
+		t.Errorf("Expected Retry-After 120, got %q", got)
+	}
+	if w := request("/health"); w.Code != http.StatusOK {
+		t.Errorf("Expected health to stay up during maintenance, got %d", w.Code)
+	}
+	if w := request("/api/admin/maintenance"); w.Code != http.StatusOK {
+		t.Errorf("Expected exempt admin route to stay up during maintenance, got %d", w.Code)
+	}
+
+	maintenance.SetEnabled(false)
+
+	if w := request("/api/articles"); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d after maintenance is turned off, got %d", http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AllowedMethodsMiddleware(cfg *config.Config) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(cfg.App.AllowedMethods))
+	for _, method := range cfg.App.AllowedMethods {
+		allowed[method] = true
+	}
+	allowHeader := strings.Join(cfg.App.AllowedMethods, ", ")
+
+	return func(c *gin.Context) {
+		if !allowed[c.Request.Method] {
+			c.Header("Allow", allowHeader)
+			c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "method not allowed"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func MethodNotAllowedHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setRouteAllow(c, cfg.App.AllowedMethods)
+		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "method not allowed"})
+	}
+}
+
+func setRouteAllow(c *gin.Context, configured []string) {
+	routed := strings.Split(c.Writer.Header().Get("Allow"), ", ")
+	if len(routed) == 0 || routed[0] == "" {
+		return
+	}
+	if slices.Contains(routed, http.MethodGet) {
+		routed = append(routed, http.MethodHead)
+	}
+	routed = append(routed, http.MethodOptions)
+
+	var allow []string
+	for _, method := range configured {
+		if slices.Contains(routed, method) {
+			allow = append(allow, method)
+		}
+	}
+	c.Header("Allow", strings.Join(allow, ", "))
+}
+
+type headWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *headWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	w.size += len(data)
+	return len(data), nil
+}
+
+func (w *headWriter) Flush() {}
+
+func (w *headWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+
+	header := w.Header()
+	bodyAllowed := w.status >= http.StatusOK && w.status != http.StatusNoContent && w.status != http.StatusNotModified
+	if bodyAllowed && header.Get("Content-Length") == "" && header.Get("Transfer-Encoding") == "" {
+		header.Set("Content-Length", strconv.Itoa(w.size))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func HeadHandler(cfg *config.Config, next http.Handler) http.Handler {
+	enabled := slices.Contains(cfg.App.AllowedMethods, http.MethodHead)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !enabled || r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		get := r.Clone(r.Context())
+		get.Method = http.MethodGet
+		writer := &headWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, get)
+		writer.finish()
+	})
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAllowedMethodsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Environment: "development",
+		App: config.AppConfig{
+			AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		},
+	}
+
+	router := gin.New()
+	router.Use(AllowedMethodsMiddleware(cfg))
+	router.Use(CORSMiddleware(cfg))
+	router.Any("/api/articles", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+	}{
+		{name: "Allowed GET", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "Allowed POST", method: http.MethodPost, wantStatus: http.StatusOK},
+		{name: "Disallowed TRACE", method: http.MethodTrace, wantStatus: http.StatusMethodNotAllowed},
+		{name: "Disallowed PATCH", method: http.MethodPatch, wantStatus: http.StatusMethodNotAllowed},
+		{name: "Disallowed DELETE", method: http.MethodDelete, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/articles", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				if got := w.Header().Get("Allow"); got != "GET, POST, OPTIONS" {
+					t.Errorf("Expected Allow header %q, got %q", "GET, POST, OPTIONS", got)
+				}
+			}
+		})
+	}
+
+	t.Run("CORS advertises the same methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodOptions, "/api/articles", nil))
+
+		if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST, OPTIONS" {
+			t.Errorf("Expected Access-Control-Allow-Methods %q, got %q", "GET, POST, OPTIONS", got)
+		}
+	})
+}
+
+func TestHeadAndOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Environment: "development",
+		App: config.AppConfig{
+			AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"},
+		},
+	}
+
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.NoMethod(MethodNotAllowedHandler(cfg))
+	router.Use(AllowedMethodsMiddleware(cfg))
+	router.Use(CORSMiddleware(cfg))
+	router.GET("/api/articles/:id", func(c *gin.Context) {
+		c.Header("ETag", `"1-abc"`)
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "title": "Hello"})
+	})
+	router.PUT("/api/articles/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/api/articles", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	handler := HeadHandler(cfg, router)
+
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/api/articles/1", nil))
+
+	t.Run("HEAD mirrors GET without a body", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/api/articles/1", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("Expected an empty body, got %q", w.Body.String())
+		}
+		if got, want := w.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+			t.Errorf("Expected Content-Length %s, got %s", want, got)
+		}
+		for _, name := range []string{"Content-Type", "ETag"} {
+			if w.Header().Get(name) != get.Header().Get(name) {
+				t.Errorf("Expected %s %q, got %q", name, get.Header().Get(name), w.Header().Get(name))
+			}
+		}
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantAllow  string
+	}{
+		{name: "OPTIONS on an item", method: http.MethodOptions, path: "/api/articles/1", wantStatus: http.StatusNoContent, wantAllow: "GET, HEAD, PUT, OPTIONS"},
+		{name: "OPTIONS on a collection", method: http.MethodOptions, path: "/api/articles", wantStatus: http.StatusNoContent, wantAllow: "POST, OPTIONS"},
+		{name: "Unrouted method", method: http.MethodDelete, path: "/api/articles/1", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, HEAD, PUT, OPTIONS"},
+		{name: "HEAD without GET", method: http.MethodHead, path: "/api/articles", wantStatus: http.StatusMethodNotAllowed, wantAllow: "POST, OPTIONS"},
+		{name: "Unknown path", method: http.MethodHead, path: "/api/missing", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Expected Allow %q, got %q", tt.wantAllow, got)
+			}
+		})
+	}
+
+	t.Run("HEAD disabled by configuration", func(t *testing.T) {
+		restricted := &config.Config{App: config.AppConfig{AllowedMethods: []string{"GET", "OPTIONS"}}}
+		w := httptest.NewRecorder()
+		HeadHandler(restricted, router).ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/api/articles/1", nil))
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+		}
+	})
+}
@@ -1,21 +1,65 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"content-service/internal/shared/config"
+
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 )
 
 const (
-	RateLimitTokens = 100
-	RateLimitRefill = time.Second / 10
-	CleanupInterval = 10 * time.Minute
-	LimiterTTL      = 30 * time.Minute
+	RateLimitWarningHeader   = "X-RateLimit-Warning"
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+
+	RateLimitTokens      = 100
+	RateLimitRefill      = time.Second / 10
+	WriteRateLimitTokens = 20
+	WriteRateLimitRefill = time.Second / 2
+	CleanupInterval      = 10 * time.Minute
+	LimiterTTL           = 30 * time.Minute
+
+	RateLimitBackendMemory = "memory"
+	RateLimitBackendRedis  = "redis"
 )
 
+type RateLimit struct {
+	Tokens int
+	Refill time.Duration
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type RateLimiterStore interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+	Close() error
+}
+
+func NewRateLimiterStore(cfg *config.Config) (RateLimiterStore, error) {
+	switch cfg.RateLimit.Backend {
+	case RateLimitBackendRedis:
+		return NewRedisRateLimiterStore(cfg.Cache.Redis)
+	case RateLimitBackendMemory, "":
+		return store, nil
+	default:
+		return nil, fmt.Errorf("no rate limiter for backend %q", cfg.RateLimit.Backend)
+	}
+}
+
 type rateLimiter struct {
 	tokens         int
 	maxTokens      int
@@ -36,7 +80,7 @@ func newRateLimiter(maxTokens int, refillRate time.Duration) *rateLimiter {
 	}
 }
 
-func (rl *rateLimiter) allow() bool {
+func (rl *rateLimiter) allow() (int, bool) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -47,15 +91,28 @@ func (rl *rateLimiter) allow() bool {
 	tokensToAdd := int(elapsed / rl.refillRate)
 	if tokensToAdd > 0 {
 		rl.tokens = min(rl.maxTokens, rl.tokens+tokensToAdd)
-		rl.lastRefillTime = now
+		rl.lastRefillTime = rl.lastRefillTime.Add(time.Duration(tokensToAdd) * rl.refillRate)
+		if rl.tokens == rl.maxTokens {
+			rl.lastRefillTime = now
+		}
 	}
 
 	if rl.tokens > 0 {
 		rl.tokens--
-		return true
+		return rl.tokens, true
 	}
 
-	return false
+	return 0, false
+}
+
+func (rl *rateLimiter) untilRefill(tokens int) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if tokens <= 0 {
+		return 0
+	}
+	return max(0, time.Duration(tokens)*rl.refillRate-time.Since(rl.lastRefillTime))
 }
 
 type rateLimiterStore struct {
@@ -73,9 +130,27 @@ func newRateLimiterStore() *rateLimiterStore {
 	return store
 }
 
-func (s *rateLimiterStore) getLimiter(ip string) *rateLimiter {
+func (s *rateLimiterStore) Allow(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	limiter := s.getLimiter(key, limit)
+	remaining, ok := limiter.allow()
+	result := RateLimitResult{
+		Allowed:   ok,
+		Remaining: remaining,
+		Reset:     limiter.untilRefill(limit.Tokens - remaining),
+	}
+	if !ok {
+		result.RetryAfter = limiter.untilRefill(1)
+	}
+	return result, nil
+}
+
+func (s *rateLimiterStore) Close() error {
+	return nil
+}
+
+func (s *rateLimiterStore) getLimiter(key string, limit RateLimit) *rateLimiter {
 	s.mu.RLock()
-	limiter, exists := s.limiters[ip]
+	limiter, exists := s.limiters[key]
 	s.mu.RUnlock()
 
 	if exists {
@@ -83,10 +158,10 @@ func (s *rateLimiterStore) getLimiter(ip string) *rateLimiter {
 	}
 
 	s.mu.Lock()
-	limiter, exists = s.limiters[ip]
+	limiter, exists = s.limiters[key]
 	if !exists {
-		limiter = newRateLimiter(RateLimitTokens, RateLimitRefill)
-		s.limiters[ip] = limiter
+		limiter = newRateLimiter(limit.Tokens, limit.Refill)
+		s.limiters[key] = limiter
 	}
 	s.mu.Unlock()
 
@@ -102,10 +177,10 @@ func (s *rateLimiterStore) cleanup() {
 		now := time.Now()
 		removed := 0
 
-		for ip, limiter := range s.limiters {
+		for key, limiter := range s.limiters {
 			limiter.mu.Lock()
 			if now.Sub(limiter.lastAccessTime) > LimiterTTL {
-				delete(s.limiters, ip)
+				delete(s.limiters, key)
 				removed++
 			}
 			limiter.mu.Unlock()
@@ -121,18 +196,74 @@ func (s *rateLimiterStore) cleanup() {
 
 var store = newRateLimiterStore()
 
-func RateLimitMiddleware() gin.HandlerFunc {
+type rateBudget struct {
+	tier  string
+	limit RateLimit
+}
+
+func newRateBudget(tier string, tokens int, perSecond float64, defaultTokens int, defaultRefill time.Duration) rateBudget {
+	budget := rateBudget{tier: tier, limit: RateLimit{Tokens: defaultTokens, Refill: defaultRefill}}
+	if tokens > 0 {
+		budget.limit.Tokens = tokens
+	}
+	if perSecond > 0 {
+		budget.limit.Refill = time.Duration(float64(time.Second) / perSecond)
+	}
+	return budget
+}
+
+func rateLimitKey(c *gin.Context, verifier *Verifier) string {
+	if verifier != nil {
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			if claims, err := verifier.ParseCached(token); err == nil {
+				return "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+			}
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
+
+func RateLimitMiddleware(cfg *config.Config, limiters RateLimiterStore) gin.HandlerFunc {
+	warnThreshold := cfg.RateLimit.WarnThreshold
+	read := newRateBudget("read", cfg.RateLimit.ReadBurst, cfg.RateLimit.ReadPerSecond, RateLimitTokens, RateLimitRefill)
+	write := newRateBudget("write", cfg.RateLimit.WriteBurst, cfg.RateLimit.WritePerSecond, WriteRateLimitTokens, WriteRateLimitRefill)
+	verifier, err := VerifierFor(cfg)
+	if err != nil {
+		verifier = nil
+	}
+
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		limiter := store.getLimiter(ip)
+		budget := write
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			budget = read
+		}
 
-		if !limiter.allow() {
+		result, err := limiters.Allow(c.Request.Context(), rateLimitKey(c, verifier)+":"+budget.tier, budget.limit)
+		if err != nil {
+			log.Warn().Err(err).Msg("Rate limiter unavailable, allowing request")
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(budget.limit.Tokens))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(RateLimitResetHeader, seconds(result.Reset))
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error": "rate limit exceeded, please try again later",
 			})
 			c.Abort()
 			return
 		}
+		if result.Remaining < warnThreshold {
+			c.Header(RateLimitWarningHeader, "true")
+		}
 		c.Next()
 	}
 }
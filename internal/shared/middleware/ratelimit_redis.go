@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const RateLimitKeyPrefix = "ratelimit:"
+
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local refill = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000000 + tonumber(clock[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+local added = math.floor((now - ts) / refill)
+if added > 0 then
+	tokens = math.min(capacity, tokens + added)
+	ts = ts + added * refill
+	if tokens == capacity then
+		ts = now
+	end
+end
+
+local allowed = 0
+if tokens > 0 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', string.format('%.0f', ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity * refill / 1000) + 1000)
+return {allowed, tokens, now - ts}
+`)
+
+type RedisRateLimiterStore struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimiterStore(cfg config.RedisConfig) (*RedisRateLimiterStore, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", cfg.Addr, err)
+	}
+	return &RedisRateLimiterStore{client: client}, nil
+}
+
+func (store *RedisRateLimiterStore) Allow(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	refill := limit.Refill.Microseconds()
+	values, err := tokenBucketScript.Run(ctx, store.client, []string{RateLimitKeyPrefix + key}, limit.Tokens, refill).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("rate limit script failed: %w", err)
+	}
+	if len(values) != 3 {
+		return RateLimitResult{}, fmt.Errorf("rate limit script returned %d values", len(values))
+	}
+
+	remaining := int(values[1])
+	elapsed := time.Duration(values[2]) * time.Microsecond
+	result := RateLimitResult{
+		Allowed:   values[0] == 1,
+		Remaining: remaining,
+	}
+	if missing := limit.Tokens - remaining; missing > 0 {
+		result.Reset = max(0, time.Duration(missing)*limit.Refill-elapsed)
+	}
+	if !result.Allowed {
+		result.RetryAfter = max(0, limit.Refill-elapsed)
+	}
+	return result, nil
+}
+
+func (store *RedisRateLimiterStore) Ping(ctx context.Context) error {
+	return store.client.Ping(ctx).Err()
+}
+
+func (store *RedisRateLimiterStore) Close() error {
+	return store.client.Close()
+}
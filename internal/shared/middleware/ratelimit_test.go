@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitMiddlewareWarning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		RateLimit: config.RateLimitConfig{WarnThreshold: 5},
+	}
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(cfg, store))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "192.0.2.200:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var last *httptest.ResponseRecorder
+	for i := 1; i <= RateLimitTokens*2; i++ {
+		w := send()
+		if w.Code == http.StatusTooManyRequests {
+			if i <= RateLimitTokens {
+				t.Fatalf("Expected 429 only after %d requests, got it on request %d", RateLimitTokens, i)
+			}
+			if w.Header().Get(RateLimitWarningHeader) != "" {
+				t.Errorf("Expected no warning header on 429 response")
+			}
+			if last == nil || last.Header().Get(RateLimitWarningHeader) != "true" {
+				t.Errorf("Expected the last allowed request to carry the warning header")
+			}
+			return
+		}
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if i <= RateLimitTokens-cfg.RateLimit.WarnThreshold && w.Header().Get(RateLimitWarningHeader) != "" {
+			t.Fatalf("Expected no warning header on request %d, got %q", i, w.Header().Get(RateLimitWarningHeader))
+		}
+		last = w
+	}
+
+	t.Fatalf("Expected rate limit to be exceeded")
+}
+
+func TestRateLimitKeyAvoidsIntrospection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"active": true, "sub": "42"})
+	}))
+	defer server.Close()
+
+	verifier, err := NewVerifier(&config.Config{Auth: config.AuthConfig{
+		Mode:          AuthModeIntrospection,
+		Introspection: config.IntrospectionConfig{URL: server.URL, CacheTTL: time.Minute, CacheSize: 10},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create verifier: %v", err)
+	}
+	key := func() string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.RemoteAddr = "192.0.2.10:1234"
+		c.Request.Header.Set("Authorization", "Bearer opaque-token")
+		return rateLimitKey(c, verifier)
+	}
+
+	if got := key(); got != "ip:192.0.2.10" || calls.Load() != 0 {
+		t.Errorf("Expected an unknown token to be keyed by IP without introspection, got %q after %d calls", got, calls.Load())
+	}
+	if _, err := verifier.Parse("opaque-token"); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if got := key(); got != "user:42" || calls.Load() != 1 {
+		t.Errorf("Expected an introspected token to be keyed by user, got %q after %d calls", got, calls.Load())
+	}
+}
+
+func TestRateLimitTiers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "rate-limit-test-secret"},
+		RateLimit: config.RateLimitConfig{
+			ReadBurst:      3,
+			ReadPerSecond:  0.5,
+			WriteBurst:     1,
+			WritePerSecond: 0.5,
+		},
+	}
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(cfg, store))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	userToken, err := CreateTestToken(41, "", cfg.JWT.Secret)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	otherToken, err := CreateTestToken(42, "", cfg.JWT.Secret)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	send := func(method, addr, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		req.RemoteAddr = addr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Write budget is separate from read budget", func(t *testing.T) {
+		if w := send(http.MethodPost, "192.0.2.210:1234", ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		w := send(http.MethodPost, "192.0.2.210:1234", "")
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+		}
+		if w.Header().Get("Retry-After") != "2" {
+			t.Errorf("Expected Retry-After 2, got %q", w.Header().Get("Retry-After"))
+		}
+		if w := send(http.MethodGet, "192.0.2.210:1234", ""); w.Code != http.StatusOK {
+			t.Errorf("Expected reads to stay allowed, got %d", w.Code)
+		}
+	})
+
+	t.Run("Headers describe the read budget", func(t *testing.T) {
+		w := send(http.MethodGet, "192.0.2.211:1234", "")
+		if w.Header().Get(RateLimitLimitHeader) != "3" {
+			t.Errorf("Expected limit 3, got %q", w.Header().Get(RateLimitLimitHeader))
+		}
+		if w.Header().Get(RateLimitRemainingHeader) != "2" {
+			t.Errorf("Expected remaining 2, got %q", w.Header().Get(RateLimitRemainingHeader))
+		}
+		if w.Header().Get(RateLimitResetHeader) != "2" {
+			t.Errorf("Expected reset 2, got %q", w.Header().Get(RateLimitResetHeader))
+		}
+	})
+
+	t.Run("Authenticated users are limited by user ID", func(t *testing.T) {
+		for i := range 3 {
+			addr := "192.0.2." + strconv.Itoa(220+i) + ":1234"
+			if w := send(http.MethodGet, addr, userToken); w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d on request %d, got %d", http.StatusOK, i+1, w.Code)
+			}
+		}
+		if w := send(http.MethodGet, "192.0.2.229:1234", userToken); w.Code != http.StatusTooManyRequests {
+			t.Errorf("Expected user budget to be shared across IPs, got %d", w.Code)
+		}
+		if w := send(http.MethodGet, "192.0.2.229:1234", otherToken); w.Code != http.StatusOK {
+			t.Errorf("Expected another user to have its own budget, got %d", w.Code)
+		}
+	})
+
+	t.Run("Invalid token falls back to client IP", func(t *testing.T) {
+		send(http.MethodGet, "192.0.2.230:1234", "invalid")
+		store.mu.RLock()
+		_, keyed := store.limiters["ip:192.0.2.230:read"]
+		store.mu.RUnlock()
+		if !keyed {
+			t.Errorf("Expected rate limiter to be keyed by client IP")
+		}
+	})
+}
+
+type failingRateLimiterStore struct{}
+
+func (failingRateLimiterStore) Allow(context.Context, string, RateLimit) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("connection refused")
+}
+
+func (failingRateLimiterStore) Close() error {
+	return nil
+}
+
+func TestRateLimiterStore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Backend selection", func(t *testing.T) {
+		limiters, err := NewRateLimiterStore(&config.Config{RateLimit: config.RateLimitConfig{Backend: RateLimitBackendMemory}})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if limiters != RateLimiterStore(store) {
+			t.Errorf("Expected the in-memory store for the memory backend")
+		}
+
+		if _, err := NewRateLimiterStore(&config.Config{RateLimit: config.RateLimitConfig{Backend: "memcached"}}); err == nil {
+			t.Errorf("Expected error for unknown backend")
+		}
+	})
+
+	t.Run("Unavailable store allows requests", func(t *testing.T) {
+		router := gin.New()
+		router.Use(RateLimitMiddleware(&config.Config{}, failingRateLimiterStore{}))
+		router.GET("/", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if w.Header().Get(RateLimitLimitHeader) != "" {
+			t.Errorf("Expected no rate limit headers, got %q", w.Header().Get(RateLimitLimitHeader))
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type PanicReporter func(c *gin.Context, recovered any)
+
+func RecoveryMiddleware(reporters ...PanicReporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			logger := log.Ctx(c.Request.Context())
+			if err, ok := recovered.(error); ok && isBrokenConnection(err) {
+				logger.Warn().Err(err).Str("method", c.Request.Method).Str("path", c.Request.URL.Path).Msg("Client connection closed")
+				c.Abort()
+				return
+			}
+
+			event := logger.Error().
+				Str("panic", fmt.Sprint(recovered)).
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Str("stack", string(debug.Stack()))
+			if userID, err := GetUserID(c); err == nil {
+				event = event.Uint(UserIDKey, userID)
+			}
+			event.Msg("Panic recovered")
+
+			for _, report := range reporters {
+				report(c, recovered)
+			}
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}()
+		c.Next()
+	}
+}
+
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
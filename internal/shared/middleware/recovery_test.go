@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = previous }()
+
+	var reported []any
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware(func(c *gin.Context, recovered any) {
+		reported = append(reported, recovered)
+	}))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	router.GET("/partial", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("late boom")
+	})
+	router.GET("/abort", func(c *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	t.Run("Panic answers JSON 500", func(t *testing.T) {
+		buf.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("Expected status %d, got %d", http.StatusInternalServerError, w.Code)
+		}
+		if w.Body.String() != `{"error":"internal server error"}` {
+			t.Errorf("Expected JSON error body, got %s", w.Body.String())
+		}
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Expected one JSON log line, got %q", buf.String())
+		}
+		if entry["panic"] != "boom" || entry["level"] != "error" {
+			t.Errorf("Expected error log for panic, got %v", entry)
+		}
+		if stack, _ := entry["stack"].(string); !strings.Contains(stack, "recovery_test.go") {
+			t.Errorf("Expected stack trace to point at the handler")
+		}
+		if entry[RequestIDKey] != w.Header().Get(RequestIDHeader) {
+			t.Errorf("Expected request ID %q in log, got %v", w.Header().Get(RequestIDHeader), entry[RequestIDKey])
+		}
+		if len(reported) != 1 || reported[0] != "boom" {
+			t.Errorf("Expected panic to be reported once, got %v", reported)
+		}
+	})
+
+	t.Run("Written response is kept", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/partial", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != "partial" {
+			t.Errorf("Expected partial response to be left alone, got %d %q", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Abort handler is re-raised", func(t *testing.T) {
+		defer func() {
+			if recovered := recover(); recovered != http.ErrAbortHandler {
+				t.Errorf("Expected http.ErrAbortHandler to propagate, got %v", recovered)
+			}
+		}()
+		req := httptest.NewRequest(http.MethodGet, "/abort", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const TokenTypeBearer = "Bearer"
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+func RefreshEnabled(cfg *config.Config) bool {
+	return cfg.Auth.Mode != AuthModeIntrospection && cfg.JWT.RefreshSecret != "" && hmacAllowed(cfg)
+}
+
+func IssueAccessToken(cfg *config.Config, userID uint, role, scope string) (string, error) {
+	return signClaims(&Claims{UserID: userID, Role: role, Scope: scope}, cfg.JWT.Secret, cfg.JWT.AccessTTL)
+}
+
+func IssueRefreshToken(cfg *config.Config, userID uint, role, scope string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	claims := &Claims{UserID: userID, Role: role, Scope: scope}
+	claims.ID = hex.EncodeToString(id)
+	return signClaims(claims, cfg.JWT.RefreshSecret, cfg.JWT.RefreshTTL)
+}
+
+func ParseRefreshToken(cfg *config.Config, tokenString string) (*Claims, error) {
+	return ParseToken(tokenString, cfg.JWT.RefreshSecret)
+}
+
+func RefreshHandler(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RefreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "refresh_token is required"})
+			return
+		}
+
+		claims, err := ParseRefreshToken(cfg, req.RefreshToken)
+		if err != nil {
+			log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Error parsing refresh token")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+			return
+		}
+
+		accessToken, err := IssueAccessToken(cfg, claims.UserID, claims.Role, claims.Scope)
+		if err != nil {
+			log.Ctx(c.Request.Context()).Error().Err(err).Msg("Failed to issue access token")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, TokenResponse{
+			AccessToken: accessToken,
+			TokenType:   TokenTypeBearer,
+			ExpiresIn:   int(cfg.JWT.AccessTTL.Seconds()),
+			Scope:       claims.Scope,
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRefreshHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{JWT: config.JWTConfig{
+		Secret:        testSecret,
+		RefreshSecret: "test-refresh-secret-min-32-chars-",
+		AccessTTL:     15 * time.Minute,
+		RefreshTTL:    24 * time.Hour,
+	}}
+
+	router := gin.New()
+	router.POST("/api/auth/refresh", RefreshHandler(cfg))
+	router.GET("/api/me", JWTAuthMiddleware(cfg), RequireScope("articles:read"), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": GetUserRole(c)})
+	})
+
+	refreshToken, err := IssueRefreshToken(cfg, 5, auth.RoleModerator, "articles:read")
+	if err != nil {
+		t.Fatalf("IssueRefreshToken() unexpected error: %v", err)
+	}
+	accessToken, err := CreateTestToken(5, "", testSecret)
+	if err != nil {
+		t.Fatalf("CreateTestToken() unexpected error: %v", err)
+	}
+
+	refresh := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "Missing token", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "Access token is not a refresh token", body: `{"refresh_token": "` + accessToken + `"}`, wantStatus: http.StatusUnauthorized},
+		{name: "Garbage", body: `{"refresh_token": "nope"}`, wantStatus: http.StatusUnauthorized},
+		{name: "Refresh token", body: `{"refresh_token": "` + refreshToken + `"}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := refresh(tt.body); w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := refresh(`{"refresh_token": "` + refreshToken + `"}`)
+	var resp TokenResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.TokenType != TokenTypeBearer || resp.ExpiresIn != 900 || resp.Scope != "articles:read" {
+		t.Errorf("Unexpected token response: %+v", resp)
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Expected Cache-Control: no-store, got %q", w.Header().Get("Cache-Control"))
+	}
+
+	claims, err := ParseToken(resp.AccessToken, testSecret)
+	if err != nil {
+		t.Fatalf("ParseToken() unexpected error: %v", err)
+	}
+	if ttl := time.Until(claims.ExpiresAt.Time); ttl > 15*time.Minute || ttl < 14*time.Minute {
+		t.Errorf("Expected the access token to expire in 15 minutes, got %s", ttl)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "Bearer "+resp.AccessToken)
+	me := httptest.NewRecorder()
+	router.ServeHTTP(me, req)
+	if me.Code != http.StatusOK || !strings.Contains(me.Body.String(), `"role":"moderator"`) {
+		t.Errorf("Expected the minted token to authenticate as a moderator, got %d: %s", me.Code, me.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	req.Header.Set("Authorization", "Bearer "+refreshToken)
+	me = httptest.NewRecorder()
+	router.ServeHTTP(me, req)
+	if me.Code != http.StatusUnauthorized {
+		t.Errorf("Expected a refresh token to be rejected as an access token, got %d", me.Code)
+	}
+}
+
+func TestRefreshEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want bool
+	}{
+		{name: "Refresh secret set", cfg: config.Config{JWT: config.JWTConfig{RefreshSecret: "secret"}}, want: true},
+		{name: "No refresh secret", cfg: config.Config{}, want: false},
+		{name: "RS256 only", cfg: config.Config{JWT: config.JWTConfig{RefreshSecret: "secret", JWKSURL: "https://id.example.com/jwks"}}, want: false},
+		{name: "RS256 with HS256 fallback", cfg: config.Config{JWT: config.JWTConfig{RefreshSecret: "secret", JWKSURL: "https://id.example.com/jwks", AllowHS256: true}}, want: true},
+		{name: "Introspection", cfg: config.Config{JWT: config.JWTConfig{RefreshSecret: "secret"}, Auth: config.AuthConfig{Mode: AuthModeIntrospection}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RefreshEnabled(&tt.cfg); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	UserScopesKey     = "user_scopes"
+	requiredScopesKey = "required_scopes"
+
+	ScopeArticlesRead  = "articles:read"
+	ScopeArticlesWrite = "articles:write"
+)
+
+func (claims *Claims) Scopes() []string {
+	scopes := strings.Fields(claims.Scope)
+	for _, permission := range claims.Permissions {
+		if !slices.Contains(scopes, permission) {
+			scopes = append(scopes, permission)
+		}
+	}
+	return scopes
+}
+
+func (claims *Claims) HasScope(scope string) bool {
+	scopes := claims.Scopes()
+	return len(scopes) == 0 || slices.Contains(scopes, scope)
+}
+
+func ReadScope(resource string) string {
+	return resource + ":read"
+}
+
+func WriteScope(resource string) string {
+	return resource + ":write"
+}
+
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		required := c.GetStringSlice(requiredScopesKey)
+		for _, scope := range scopes {
+			if !slices.Contains(required, scope) {
+				required = append(required, scope)
+			}
+		}
+		c.Set(requiredScopesKey, required)
+
+		if _, authenticated := c.Get(UserIDKey); authenticated && !checkScopes(c) {
+			return
+		}
+		c.Next()
+	}
+}
+
+func ResourceScopes(resource string) gin.HandlerFunc {
+	read, write := RequireScope(ReadScope(resource)), RequireScope(WriteScope(resource))
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			read(c)
+		default:
+			write(c)
+		}
+	}
+}
+
+func GetUserScopes(c *gin.Context) []string {
+	return c.GetStringSlice(UserScopesKey)
+}
+
+func checkScopes(c *gin.Context) bool {
+	granted, scoped := c.Get(UserScopesKey)
+	if !scoped {
+		return true
+	}
+	for _, scope := range c.GetStringSlice(requiredScopesKey) {
+		if slices.Contains(granted.([]string), scope) {
+			continue
+		}
+		c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient scope: " + scope + " required", "missing_scope": scope})
+		c.Abort()
+		return false
+	}
+	return true
+}
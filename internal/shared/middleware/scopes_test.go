@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: testSecret}}
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	router := gin.New()
+	articles := router.Group("/api/articles", ResourceScopes("articles"))
+	articles.GET("", OptionalJWTAuthMiddleware(cfg), ok)
+	articles.POST("", JWTAuthMiddleware(cfg), ok)
+	router.GET("/api/reports", JWTAuthMiddleware(cfg), RequireScope("reports:read"), ok)
+
+	token := func(claims jwt.MapClaims) string {
+		claims["user_id"] = 1
+		return signToken(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims)
+	}
+	unscoped := token(jwt.MapClaims{})
+	reader := token(jwt.MapClaims{"scope": "articles:read reports:read"})
+	writer := token(jwt.MapClaims{"permissions": []string{"articles:read", "articles:write"}})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		token       string
+		wantStatus  int
+		wantMissing string
+	}{
+		{name: "Anonymous read", method: http.MethodGet, path: "/api/articles", wantStatus: http.StatusOK},
+		{name: "Token without scopes reads", method: http.MethodGet, path: "/api/articles", token: unscoped, wantStatus: http.StatusOK},
+		{name: "Token without scopes writes", method: http.MethodPost, path: "/api/articles", token: unscoped, wantStatus: http.StatusOK},
+		{name: "Read scope reads", method: http.MethodGet, path: "/api/articles", token: reader, wantStatus: http.StatusOK},
+		{name: "Read scope cannot write", method: http.MethodPost, path: "/api/articles", token: reader, wantStatus: http.StatusForbidden, wantMissing: "articles:write"},
+		{name: "Permissions claim writes", method: http.MethodPost, path: "/api/articles", token: writer, wantStatus: http.StatusOK},
+		{name: "Scope after authentication", method: http.MethodGet, path: "/api/reports", token: reader, wantStatus: http.StatusOK},
+		{name: "Missing scope after authentication", method: http.MethodGet, path: "/api/reports", token: writer, wantStatus: http.StatusForbidden, wantMissing: "reports:read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantMissing == "" {
+				return
+			}
+			var resp struct {
+				MissingScope string `json:"missing_scope"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.MissingScope != tt.wantMissing {
+				t.Errorf("Expected missing scope %q, got %s", tt.wantMissing, w.Body.String())
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != `Bearer error="insufficient_scope", scope="`+tt.wantMissing+`"` {
+				t.Errorf("Unexpected WWW-Authenticate header %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"strconv"
+
+	"content-service/internal/shared/tracking"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gin-gonic/gin"
+)
+
+func ErrorTrackingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := tracking.NewContext(c.Request.Context(), func(scope *sentry.Scope) {
+			scope.SetRequest(c.Request)
+			scope.SetTag(RequestIDKey, GetRequestID(c))
+			scope.AddEventProcessor(func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+				if route := c.FullPath(); route != "" {
+					if event.Tags == nil {
+						event.Tags = make(map[string]string)
+					}
+					event.Tags["route"] = route
+				}
+				if userID, err := GetUserID(c); err == nil {
+					event.User.ID = strconv.FormatUint(uint64(userID), 10)
+				}
+				return event
+			})
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func ReportPanic(c *gin.Context, recovered any) {
+	tracking.CapturePanic(c.Request.Context(), recovered)
+}
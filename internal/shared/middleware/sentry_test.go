@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/tracking"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorTracking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var mu sync.Mutex
+	var envelopes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		envelopes = append(envelopes, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Environment: "test",
+		Sentry:      config.SentryConfig{DSN: "http://public@" + strings.TrimPrefix(server.URL, "http://") + "/1", SampleRate: 1},
+	}
+	if err := tracking.Init(cfg); err != nil {
+		t.Fatalf("Failed to initialize tracking: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), ErrorTrackingMiddleware(), RecoveryMiddleware(ReportPanic))
+	router.GET("/articles/:id", func(c *gin.Context) {
+		c.Set(UserIDKey, uint(31))
+		panic("sentry boom")
+	})
+	router.GET("/articles", func(c *gin.Context) {
+		c.Set(UserIDKey, uint(32))
+		tracking.CaptureError(c.Request.Context(), errors.New("database unavailable"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "Panic", path: "/articles/1", want: []string{"sentry boom", `"request_id":"sentry-0"`, `"route":"/articles/:id"`, `"id":"31"`, `"environment":"test"`}},
+		{name: "Handler error", path: "/articles", want: []string{"database unavailable", `"request_id":"sentry-1"`, `"route":"/articles"`, `"id":"32"`}},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			envelopes = nil
+			mu.Unlock()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, "sentry-"+strconv.Itoa(i))
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tracking.Flush(5 * time.Second) {
+				t.Fatalf("Expected Sentry events to be flushed")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(envelopes) != 1 {
+				t.Fatalf("Expected one Sentry envelope, got %d", len(envelopes))
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(envelopes[0], want) {
+					t.Errorf("Expected envelope to contain %s", want)
+				}
+			}
+		})
+	}
+}
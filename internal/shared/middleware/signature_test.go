@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	const secret = "cms-signing-secret"
+	verifier := NewSignatureVerifier(config.SigningConfig{Secret: secret, UserID: 9, Tolerance: 5 * time.Minute})
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"title":"Pushed"}`)
+
+	const method, target = http.MethodPut, "/api/v1/articles/1?lenient=true"
+	sign := func(secret string, timestamp int64) string {
+		return SignRequest(secret, timestamp, method, target, body)
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		method  string
+		target  string
+		body    []byte
+		wantErr error
+	}{
+		{name: "Valid signature", header: sign(secret, now.Unix()), body: body},
+		{name: "Replayed signature", header: sign(secret, now.Unix()), body: body, wantErr: ErrSignatureReplayed},
+		{name: "Tampered body", header: sign(secret, now.Unix()-1), body: []byte(`{"title":"Changed"}`), wantErr: ErrSignatureInvalid},
+		{name: "Other method", header: sign(secret, now.Unix()-2), method: http.MethodDelete, body: body, wantErr: ErrSignatureInvalid},
+		{name: "Other path", header: sign(secret, now.Unix()-3), target: "/api/v1/articles/2?lenient=true", body: body, wantErr: ErrSignatureInvalid},
+		{name: "Other query", header: sign(secret, now.Unix()-4), target: "/api/v1/articles/1", body: body, wantErr: ErrSignatureInvalid},
+		{name: "Wrong secret", header: sign("other-secret", now.Unix()), body: body, wantErr: ErrSignatureInvalid},
+		{name: "Old timestamp", header: sign(secret, now.Add(-6*time.Minute).Unix()), body: body, wantErr: ErrSignatureExpired},
+		{name: "Future timestamp", header: sign(secret, now.Add(6*time.Minute).Unix()), body: body, wantErr: ErrSignatureExpired},
+		{name: "Missing timestamp", header: "v1=abcd", body: body, wantErr: ErrSignatureInvalid},
+		{name: "Malformed signature", header: "t=1700000000,v1=zz", body: body, wantErr: ErrSignatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.method == "" {
+				tt.method = method
+			}
+			if tt.target == "" {
+				tt.target = target
+			}
+			err := verifier.Verify(tt.header, tt.method, tt.target, tt.body, now)
+			if err != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSignedRequestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "signed-request-test-secret"},
+		Auth: config.AuthConfig{Signing: config.SigningConfig{
+			Secret:    "cms-signing-secret",
+			UserID:    9,
+			Role:      auth.RoleModerator,
+			Scopes:    []string{ScopeArticlesWrite},
+			Tolerance: time.Minute,
+		}},
+	}
+
+	router := gin.New()
+	router.POST("/articles", RequireScope(ScopeArticlesWrite), JWTAuthMiddleware(cfg), func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "role": GetUserRole(c), "body": string(body)})
+	})
+	router.GET("/articles", RequireScope(ScopeArticlesRead), JWTAuthMiddleware(cfg), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(method, body, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/articles", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"title":"Pushed"}`
+	signature := SignRequest(cfg.Auth.Signing.Secret, time.Now().Unix(), http.MethodPost, "/articles", []byte(body))
+
+	w := send(http.MethodPost, body, signature)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	want := `{"body":"{\"title\":\"Pushed\"}","role":"moderator","user_id":9}`
+	if w.Body.String() != want {
+		t.Errorf("Expected %s, got %s", want, w.Body.String())
+	}
+
+	if w := send(http.MethodPost, body, signature); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected replay to answer %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+	if w := send(http.MethodPost, body, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected unsigned request to answer %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	readSignature := SignRequest(cfg.Auth.Signing.Secret, time.Now().Unix(), http.MethodGet, "/articles", nil)
+	if w := send(http.MethodGet, "", readSignature); w.Code != http.StatusForbidden {
+		t.Errorf("Expected scope check to answer %d, got %d", http.StatusForbidden, w.Code)
+	}
+
+	t.Run("Disabled without secret", func(t *testing.T) {
+		cfg := &config.Config{JWT: config.JWTConfig{Secret: "signed-request-test-secret"}}
+		router := gin.New()
+		router.POST("/articles", JWTAuthMiddleware(cfg), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(body))
+		req.Header.Set(SignatureHeader, SignRequest("", time.Now().Unix(), http.MethodPost, "/articles", []byte(body)))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+}
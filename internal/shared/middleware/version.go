@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIVersionHeader    = "X-API-Version"
+	APIPrefix           = "/api"
+	APIVersionMediaType = "application/vnd.content-service."
+)
+
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+type Deprecation struct {
+	Since  time.Time
+	Sunset time.Time
+	Link   string
+}
+
+type DeprecationRegistry struct {
+	routes   map[string]Deprecation
+	versions map[string]Deprecation
+	mu       sync.RWMutex
+}
+
+func NewDeprecationRegistry() *DeprecationRegistry {
+	return &DeprecationRegistry{routes: make(map[string]Deprecation), versions: make(map[string]Deprecation)}
+}
+
+func (r *DeprecationRegistry) Deprecate(method, path string, deprecation Deprecation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[method+" "+path] = deprecation
+}
+
+func (r *DeprecationRegistry) Lookup(method, path string) (Deprecation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if deprecation, ok := r.routes[method+" "+path]; ok {
+		return deprecation, true
+	}
+	if version, ok := APIVersionOf(path); ok {
+		deprecation, ok := r.versions[version]
+		return deprecation, ok
+	}
+	return Deprecation{}, false
+}
+
+func (r *DeprecationRegistry) DeprecateVersion(version string, deprecation Deprecation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versions[version] = deprecation
+}
+
+func APIVersionOf(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, APIPrefix+"/")
+	if !ok {
+		return "", false
+	}
+	version, _, _ := strings.Cut(rest, "/")
+	return version, apiVersionPattern.MatchString(version)
+}
+
+func acceptedAPIVersions(accept string) []string {
+	var versions []string
+	for _, mediaType := range strings.Split(accept, ",") {
+		mediaType, _, _ = strings.Cut(mediaType, ";")
+		version, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(mediaType)), APIVersionMediaType)
+		if !ok {
+			continue
+		}
+		version = strings.TrimSuffix(version, "+json")
+		if apiVersionPattern.MatchString(version) {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+func NegotiateAPIVersion(next http.Handler, fallback string, supported ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if path != APIPrefix && !strings.HasPrefix(path, APIPrefix+"/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := APIVersionOf(path); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept")
+		version := fallback
+		if requested := acceptedAPIVersions(r.Header.Get("Accept")); len(requested) > 0 {
+			i := slices.IndexFunc(requested, func(v string) bool { return slices.Contains(supported, v) })
+			if i < 0 {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusNotAcceptable)
+				fmt.Fprintf(w, `{"error":"unsupported API version, supported versions: %s"}`, strings.Join(supported, ", "))
+				return
+			}
+			version = requested[i]
+		}
+
+		prefix := APIPrefix + "/" + version
+		r.URL.Path = prefix + strings.TrimPrefix(path, APIPrefix)
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = prefix + strings.TrimPrefix(r.URL.RawPath, APIPrefix)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func APIVersionMiddleware(version string, deprecations *DeprecationRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(APIVersionHeader, version)
+
+		if deprecation, ok := deprecations.Lookup(c.Request.Method, c.FullPath()); ok {
+			if deprecation.Since.IsZero() {
+				c.Header("Deprecation", "true")
+			} else {
+				c.Header("Deprecation", fmt.Sprintf("@%d", deprecation.Since.Unix()))
+			}
+			if !deprecation.Sunset.IsZero() {
+				c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				c.Header("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, deprecation.Link))
+			}
+		}
+
+		c.Next()
+	}
+}
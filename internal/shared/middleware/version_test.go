@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	deprecations := NewDeprecationRegistry()
+	deprecations.Deprecate(http.MethodGet, "/api/legacy/:id", Deprecation{
+		Since:  since,
+		Sunset: sunset,
+		Link:   "https://example.com/migration",
+	})
+
+	router := gin.New()
+	router.Use(APIVersionMiddleware("1.4.0+abc123", deprecations))
+	router.GET("/api/legacy/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/api/legacy/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.GET("/api/current", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{
+			name:            "Flagged route",
+			method:          http.MethodGet,
+			path:            "/api/legacy/42",
+			wantDeprecation: "@1735689600",
+			wantSunset:      "Tue, 01 Jul 2025 00:00:00 GMT",
+			wantLink:        `<https://example.com/migration>; rel="deprecation"`,
+		},
+		{
+			name:   "Same path with another method",
+			method: http.MethodPost,
+			path:   "/api/legacy/42",
+		},
+		{
+			name:   "Current route",
+			method: http.MethodGet,
+			path:   "/api/current",
+		},
+		{
+			name:   "Unmatched route",
+			method: http.MethodGet,
+			path:   "/missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Header().Get(APIVersionHeader); got != "1.4.0+abc123" {
+				t.Errorf("Expected %s %q, got %q", APIVersionHeader, "1.4.0+abc123", got)
+			}
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Expected Deprecation %q, got %q", tt.wantDeprecation, got)
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Expected Sunset %q, got %q", tt.wantSunset, got)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Expected Link %q, got %q", tt.wantLink, got)
+			}
+		})
+	}
+}
+
+func TestVersionDeprecation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deprecations := NewDeprecationRegistry()
+	deprecations.DeprecateVersion("v1", Deprecation{Sunset: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)})
+	deprecations.Deprecate(http.MethodGet, "/api/v1/legacy", Deprecation{Link: "https://example.com/legacy"})
+
+	router := gin.New()
+	router.Use(APIVersionMiddleware("1.4.0", deprecations))
+	for _, path := range []string{"/api/v1/articles", "/api/v1/legacy", "/api/v2/articles"} {
+		router.GET(path, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+	}
+
+	tests := []struct {
+		name            string
+		path            string
+		wantDeprecation string
+		wantSunset      string
+		wantLink        string
+	}{
+		{name: "Retired version", path: "/api/v1/articles", wantDeprecation: "true", wantSunset: "Thu, 01 Jan 2026 00:00:00 GMT"},
+		{name: "Route flag wins over version", path: "/api/v1/legacy", wantDeprecation: "true", wantLink: `<https://example.com/legacy>; rel="deprecation"`},
+		{name: "Current version", path: "/api/v2/articles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Expected Deprecation %q, got %q", tt.wantDeprecation, got)
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Expected Sunset %q, got %q", tt.wantSunset, got)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Expected Link %q, got %q", tt.wantLink, got)
+			}
+		})
+	}
+}
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	for _, version := range []string{"v1", "v2"} {
+		router.GET("/api/"+version+"/articles/:id", func(c *gin.Context) {
+			c.String(http.StatusOK, version+" "+c.Param("id"))
+		})
+	}
+	router.GET("/health", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	handler := NegotiateAPIVersion(router, "v1", "v1", "v2")
+
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		wantCode int
+		wantBody string
+		wantVary string
+	}{
+		{name: "Versioned path", path: "/api/v2/articles/7", accept: "application/vnd.content-service.v1+json", wantCode: http.StatusOK, wantBody: "v2 7"},
+		{name: "Unversioned path uses the fallback", path: "/api/articles/7", wantCode: http.StatusOK, wantBody: "v1 7", wantVary: "Accept"},
+		{name: "Accept header picks the version", path: "/api/articles/7", accept: "application/vnd.content-service.v2+json", wantCode: http.StatusOK, wantBody: "v2 7", wantVary: "Accept"},
+		{name: "First supported version wins", path: "/api/articles/7", accept: "application/vnd.content-service.v9+json, application/vnd.content-service.v2+json;q=0.5", wantCode: http.StatusOK, wantBody: "v2 7", wantVary: "Accept"},
+		{name: "Plain JSON uses the fallback", path: "/api/articles/7", accept: "application/json", wantCode: http.StatusOK, wantBody: "v1 7", wantVary: "Accept"},
+		{name: "Unsupported version", path: "/api/articles/7", accept: "application/vnd.content-service.v9+json", wantCode: http.StatusNotAcceptable, wantVary: "Accept"},
+		{name: "Unknown path version", path: "/api/v9/articles/7", wantCode: http.StatusNotFound},
+		{name: "Outside the API", path: "/health", accept: "application/vnd.content-service.v9+json", wantCode: http.StatusOK, wantBody: "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if got := w.Header().Get("Vary"); got != tt.wantVary {
+				t.Errorf("Expected Vary %q, got %q", tt.wantVary, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package negotiate
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeXML     = "application/xml"
+	MediaTypeMsgPack = "application/msgpack"
+
+	XMLRoot = "response"
+	XMLItem = "item"
+)
+
+type Renderer func(c *gin.Context, status int, data any) error
+
+var (
+	renderers = map[string]Renderer{
+		MediaTypeJSON:           renderJSON,
+		MediaTypeXML:            renderXML,
+		"text/xml":              renderXML,
+		MediaTypeMsgPack:        renderMsgPack,
+		"application/x-msgpack": renderMsgPack,
+	}
+	aliases = map[string]string{
+		"text/xml":              MediaTypeXML,
+		"application/x-msgpack": MediaTypeMsgPack,
+	}
+	mu sync.RWMutex
+)
+
+func Register(mediaType string, renderer Renderer) {
+	mu.Lock()
+	defer mu.Unlock()
+	renderers[mediaType] = renderer
+}
+
+func MediaType(c *gin.Context) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	best, bestQuality := MediaTypeJSON, 0.0
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		if _, ok := renderers[mediaType]; !ok || quality <= bestQuality {
+			continue
+		}
+		best, bestQuality = mediaType, quality
+	}
+	if canonical, ok := aliases[best]; ok {
+		return canonical
+	}
+	return best
+}
+
+func Render(c *gin.Context, status int, data any) error {
+	mediaType := MediaType(c)
+	mu.RLock()
+	renderer := renderers[mediaType]
+	mu.RUnlock()
+	return renderer(c, status, data)
+}
+
+func renderJSON(c *gin.Context, status int, data any) error {
+	c.JSON(status, data)
+	return nil
+}
+
+func renderMsgPack(c *gin.Context, status int, data any) error {
+	generic, err := normalize(data)
+	if err != nil {
+		return err
+	}
+	c.Render(status, render.MsgPack{Data: generic})
+	return nil
+}
+
+func renderXML(c *gin.Context, status int, data any) error {
+	generic, err := normalize(data)
+	if err != nil {
+		return err
+	}
+	var body strings.Builder
+	body.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&body)
+	if err := encodeXML(encoder, XMLRoot, generic); err != nil {
+		return err
+	}
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	c.Data(status, MediaTypeXML+"; charset=utf-8", []byte(body.String()))
+	return nil
+}
+
+func normalize(data any) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return numbers(generic), nil
+}
+
+func numbers(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, item := range value {
+			value[key] = numbers(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = numbers(item)
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	}
+	return value
+}
+
+func encodeXML(encoder *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !validName(name) {
+		start = xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if err := encodeXML(encoder, key, value[key]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range value {
+			if err := encodeXML(encoder, XMLItem, item); err != nil {
+				return err
+			}
+		}
+	case nil:
+	case int64:
+		if err := encoder.EncodeToken(xml.CharData(strconv.FormatInt(value, 10))); err != nil {
+			return err
+		}
+	case float64:
+		if err := encoder.EncodeToken(xml.CharData(strconv.FormatFloat(value, 'f', -1, 64))); err != nil {
+			return err
+		}
+	case bool:
+		if err := encoder.EncodeToken(xml.CharData(strconv.FormatBool(value))); err != nil {
+			return err
+		}
+	case string:
+		if err := encoder.EncodeToken(xml.CharData(value)); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+func validName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		letter := r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if !letter && (i == 0 || r != '-' && r != '.' && (r < '0' || r > '9')) {
+			return false
+		}
+	}
+	return true
+}
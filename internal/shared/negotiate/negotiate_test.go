@@ -0,0 +1,157 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+type payload struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Tags      []string  `json:"tags"`
+	Views     int       `json:"views"`
+	CreatedAt time.Time `json:"created_at"`
+	Secret    string    `json:"-"`
+}
+
+func TestMediaType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "No Accept header", want: MediaTypeJSON},
+		{name: "Wildcard", accept: "*/*", want: MediaTypeJSON},
+		{name: "XML", accept: "application/xml", want: MediaTypeXML},
+		{name: "Text XML", accept: "text/xml", want: MediaTypeXML},
+		{name: "MessagePack", accept: "application/msgpack", want: MediaTypeMsgPack},
+		{name: "Legacy MessagePack", accept: "application/x-msgpack", want: MediaTypeMsgPack},
+		{name: "Quality values", accept: "application/xml;q=0.5, application/msgpack;q=0.9", want: MediaTypeMsgPack},
+		{name: "Unsupported falls back to JSON", accept: "text/html", want: MediaTypeJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
+			if got := MediaType(c); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := gin.H{
+		"data": []payload{{ID: 7, Title: "Fish & Chips", Tags: []string{"food"}, Views: 3, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Secret: "hidden"}},
+		"meta": gin.H{"page": 1, "total": 1},
+	}
+	render := func(accept string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("Accept", accept)
+		if err := Render(c, http.StatusOK, body); err != nil {
+			t.Fatalf("Render() unexpected error: %v", err)
+		}
+		return w
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		w := render(MediaTypeJSON)
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), MediaTypeJSON) {
+			t.Errorf("Expected JSON content type, got %q", w.Header().Get("Content-Type"))
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		w := render(MediaTypeXML)
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), MediaTypeXML) {
+			t.Errorf("Expected XML content type, got %q", w.Header().Get("Content-Type"))
+		}
+		var decoded struct {
+			XMLName xml.Name `xml:"response"`
+			Data    struct {
+				Items []struct {
+					ID        uint     `xml:"id"`
+					Title     string   `xml:"title"`
+					Tags      []string `xml:"tags>item"`
+					CreatedAt string   `xml:"created_at"`
+					Secret    string   `xml:"secret"`
+				} `xml:"item"`
+			} `xml:"data"`
+			Meta struct {
+				Total int `xml:"total"`
+			} `xml:"meta"`
+		}
+		if err := xml.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(decoded.Data.Items) != 1 {
+			t.Fatalf("Expected 1 item, got %d", len(decoded.Data.Items))
+		}
+		item := decoded.Data.Items[0]
+		if item.ID != 7 || item.Title != "Fish & Chips" || len(item.Tags) != 1 || item.CreatedAt != "2024-05-01T10:00:00Z" {
+			t.Errorf("Unexpected item: %+v", item)
+		}
+		if item.Secret != "" {
+			t.Errorf("Expected fields hidden from JSON to stay hidden, got %q", item.Secret)
+		}
+		if decoded.Meta.Total != 1 {
+			t.Errorf("Expected meta total 1, got %d", decoded.Meta.Total)
+		}
+	})
+
+	t.Run("MessagePack", func(t *testing.T) {
+		w := render(MediaTypeMsgPack)
+		if !strings.HasPrefix(w.Header().Get("Content-Type"), MediaTypeMsgPack) {
+			t.Errorf("Expected MessagePack content type, got %q", w.Header().Get("Content-Type"))
+		}
+		var decoded struct {
+			Data []struct {
+				ID    uint   `codec:"id"`
+				Title string `codec:"title"`
+				Views int    `codec:"views"`
+			} `codec:"data"`
+		}
+		if err := codec.NewDecoderBytes(w.Body.Bytes(), &codec.MsgpackHandle{}).Decode(&decoded); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(decoded.Data) != 1 || decoded.Data[0].ID != 7 || decoded.Data[0].Views != 3 {
+			t.Errorf("Unexpected data: %+v", decoded.Data)
+		}
+	})
+}
+
+func TestEncodeXMLInvalidNames(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Accept", MediaTypeXML)
+	if err := Render(c, http.StatusOK, gin.H{"pt-BR": "ok", "1st": "ok"}); err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	if !strings.Contains(w.Body.String(), `<entry key="1st">ok</entry>`) || !strings.Contains(w.Body.String(), "<pt-BR>ok</pt-BR>") {
+		t.Errorf("Unexpected XML: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ItemResult struct {
+	Index    int      `json:"index"`
+	Status   int      `json:"status"`
+	ID       *uint    `json:"id,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type MultiStatusSummary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type MultiStatusBody struct {
+	Results []ItemResult       `json:"results"`
+	Summary MultiStatusSummary `json:"summary"`
+}
+
+type MultiStatus struct {
+	results []ItemResult
+}
+
+func NewMultiStatus(size int) *MultiStatus {
+	return &MultiStatus{results: make([]ItemResult, 0, size)}
+}
+
+func (ms *MultiStatus) Success(index, status int, id uint) {
+	ms.results = append(ms.results, ItemResult{Index: index, Status: status, ID: &id})
+}
+
+func (ms *MultiStatus) SuccessWithWarning(index, status int, id uint, warning string) {
+	result := ItemResult{Index: index, Status: status, ID: &id}
+	if warning != "" {
+		result.Warnings = []string{warning}
+	}
+	ms.results = append(ms.results, result)
+}
+
+func (ms *MultiStatus) Failure(index, status int, message string) {
+	ms.results = append(ms.results, ItemResult{Index: index, Status: status, Error: message})
+}
+
+func (ms *MultiStatus) FailureWithID(index, status int, id uint, message string) {
+	ms.results = append(ms.results, ItemResult{Index: index, Status: status, ID: &id, Error: message})
+}
+
+func (ms *MultiStatus) Body() MultiStatusBody {
+	results := make([]ItemResult, len(ms.results))
+	copy(results, ms.results)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Index < results[j].Index
+	})
+
+	summary := MultiStatusSummary{Total: len(results)}
+	for _, result := range results {
+		if result.Status >= 200 && result.Status < 300 {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	return MultiStatusBody{Results: results, Summary: summary}
+}
+
+func (ms *MultiStatus) Write(c *gin.Context) {
+	c.JSON(http.StatusMultiStatus, ms.Body())
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMultiStatusMixedBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ms := NewMultiStatus(4)
+	ms.Success(2, http.StatusCreated, 12)
+	ms.Failure(1, http.StatusBadRequest, "title is required")
+	ms.Success(0, http.StatusCreated, 11)
+	ms.FailureWithID(3, http.StatusForbidden, 7, "forbidden")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	ms.Write(c)
+
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected status %d, got %d", http.StatusMultiStatus, w.Code)
+	}
+
+	var body MultiStatusBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+
+	want := []struct {
+		status int
+		id     uint
+		hasID  bool
+		err    string
+	}{
+		{status: http.StatusCreated, id: 11, hasID: true},
+		{status: http.StatusBadRequest, err: "title is required"},
+		{status: http.StatusCreated, id: 12, hasID: true},
+		{status: http.StatusForbidden, id: 7, hasID: true, err: "forbidden"},
+	}
+
+	if len(body.Results) != len(want) {
+		t.Fatalf("Expected %d results, got %d", len(want), len(body.Results))
+	}
+
+	for i, w := range want {
+		got := body.Results[i]
+		if got.Index != i {
+			t.Errorf("Result %d: expected index %d, got %d", i, i, got.Index)
+		}
+		if got.Status != w.status {
+			t.Errorf("Result %d: expected status %d, got %d", i, w.status, got.Status)
+		}
+		if w.hasID && (got.ID == nil || *got.ID != w.id) {
+			t.Errorf("Result %d: expected id %d, got %v", i, w.id, got.ID)
+		}
+		if !w.hasID && got.ID != nil {
+			t.Errorf("Result %d: expected no id, got %d", i, *got.ID)
+		}
+		if got.Error != w.err {
+			t.Errorf("Result %d: expected error %q, got %q", i, w.err, got.Error)
+		}
+	}
+
+	if body.Summary.Total != 4 || body.Summary.Succeeded != 2 || body.Summary.Failed != 2 {
+		t.Errorf("Expected summary 4/2/2, got %+v", body.Summary)
+	}
+}
+
+func TestMultiStatusAllSuccess(t *testing.T) {
+	ms := NewMultiStatus(2)
+	ms.Success(0, http.StatusOK, 1)
+	ms.Success(1, http.StatusOK, 2)
+
+	body := ms.Body()
+	if body.Summary.Failed != 0 || body.Summary.Succeeded != 2 {
+		t.Errorf("Expected all items to succeed, got %+v", body.Summary)
+	}
+	for _, result := range body.Results {
+		if result.Status != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, result.Status)
+		}
+	}
+}
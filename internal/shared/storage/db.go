@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Blob struct {
+	Key       string `gorm:"primaryKey;type:varchar(255)"`
+	Content   string `gorm:"type:text;not null"`
+	CreatedAt time.Time
+}
+
+func (Blob) TableName() string {
+	return "content_blobs"
+}
+
+type dbStore struct {
+	db *gorm.DB
+}
+
+func NewDBStore(db *gorm.DB) ContentStore {
+	return &dbStore{db: db}
+}
+
+func (store *dbStore) Put(ctx context.Context, key, content string) error {
+	err := database.Conn(ctx, store.db).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Blob{Key: key, Content: content}).Error
+	if err != nil {
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+	return nil
+}
+
+func (store *dbStore) Get(ctx context.Context, key string) (string, error) {
+	var blob Blob
+	if err := database.Conn(ctx, store.db).Where("key = ?", key).First(&blob).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("storage: failed to get %q: %w", key, err)
+	}
+	return blob.Content, nil
+}
+
+func (store *dbStore) Delete(ctx context.Context, key string) error {
+	if err := database.Conn(ctx, store.db).Where("key = ?", key).Delete(&Blob{}).Error; err != nil {
+		return fmt.Errorf("storage: failed to delete %q: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+type MemoryStore struct {
+	objects map[string]string
+	mu      sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string]string)}
+}
+
+func (store *MemoryStore) Put(ctx context.Context, key, content string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.objects[key] = content
+	return nil
+}
+
+func (store *MemoryStore) Get(ctx context.Context, key string) (string, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	content, ok := store.objects[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return content, nil
+}
+
+func (store *MemoryStore) Delete(ctx context.Context, key string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.objects, key)
+	return nil
+}
+
+func (store *MemoryStore) Len() int {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return len(store.objects)
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"content-service/internal/shared/config"
+)
+
+const BackendDisk = "disk"
+
+type ObjectStore interface {
+	PutObject(key string, data []byte, contentType string) error
+	GetObject(key string) ([]byte, error)
+	DeleteObject(key string) error
+}
+
+func NewObjectStore(cfg *config.Config) (ObjectStore, error) {
+	switch cfg.Media.Backend {
+	case BackendDisk:
+		return NewDiskStore(cfg.Media.Dir)
+	case BackendS3:
+		return NewS3ObjectStore(cfg.ContentStore.S3, cfg.Media.Bucket)
+	default:
+		return nil, fmt.Errorf("unknown media store backend %q", cfg.Media.Backend)
+	}
+}
+
+type diskStore struct {
+	root string
+}
+
+func NewDiskStore(root string) (ObjectStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: failed to create %q: %w", root, err)
+	}
+	return &diskStore{root: root}, nil
+}
+
+func (store *diskStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, ".."+string(filepath.Separator)) || clean == ".." {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(store.root, clean), nil
+}
+
+func (store *diskStore) PutObject(key string, data []byte, contentType string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("storage: failed to put %q: %w", key, err)
+	}
+	return nil
+}
+
+func (store *diskStore) GetObject(key string) ([]byte, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("storage: failed to get %q: %w", key, err)
+	}
+	return data, nil
+}
+
+func (store *diskStore) DeleteObject(key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("storage: failed to delete %q: %w", key, err)
+	}
+	return nil
+}
+
+type MemoryObjectStore struct {
+	objects map[string][]byte
+	mu      sync.RWMutex
+}
+
+func NewMemoryObjectStore() *MemoryObjectStore {
+	return &MemoryObjectStore{objects: make(map[string][]byte)}
+}
+
+func (store *MemoryObjectStore) PutObject(key string, data []byte, contentType string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.objects[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (store *MemoryObjectStore) GetObject(key string) ([]byte, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	data, ok := store.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (store *MemoryObjectStore) DeleteObject(key string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.objects, key)
+	return nil
+}
+
+func (store *MemoryObjectStore) Len() int {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return len(store.objects)
+}
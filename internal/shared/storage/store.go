@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"content-service/internal/shared/config"
+
+	"gorm.io/gorm"
+)
+
+const (
+	BackendDB = "db"
+	BackendS3 = "s3"
+)
+
+var ErrNotFound = errors.New("content not found")
+
+type ContentStore interface {
+	Put(ctx context.Context, key, content string) error
+	Get(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func ContentKey(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "content/" + hex.EncodeToString(sum[:])
+}
+
+func NewContentStore(cfg *config.Config, db *gorm.DB) (ContentStore, error) {
+	switch cfg.ContentStore.Backend {
+	case BackendDB:
+		return NewDBStore(db), nil
+	case BackendS3:
+		return NewS3Store(cfg.ContentStore.S3)
+	default:
+		return nil, fmt.Errorf("unknown content store backend %q", cfg.ContentStore.Backend)
+	}
+}
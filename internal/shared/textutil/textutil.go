@@ -0,0 +1,119 @@
+package textutil
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	WordsPerMinute = 200
+	CharsPerMinute = 500
+
+	Ellipsis = "…"
+)
+
+var cjkLanguages = map[string]bool{
+	"zh": true,
+	"ja": true,
+	"ko": true,
+}
+
+func BaseLanguage(tag string) string {
+	base, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	base, _, _ = strings.Cut(base, "_")
+	return strings.ToLower(base)
+}
+
+func IsCJKLanguage(tag string) bool {
+	return cjkLanguages[BaseLanguage(tag)]
+}
+
+func PreferredLanguage(acceptLanguage string) string {
+	first, _, _ := strings.Cut(acceptLanguage, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	tag = strings.TrimSpace(tag)
+	if tag == "*" {
+		return ""
+	}
+	return tag
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func ContainsCJK(text string) bool {
+	return strings.IndexFunc(text, isCJK) >= 0
+}
+
+func Count(text, lang string) (words, chars int) {
+	cjk := IsCJKLanguage(lang) || (lang == "" && ContainsCJK(text))
+	if !cjk {
+		return len(strings.Fields(text)), 0
+	}
+
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			chars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case unicode.IsSpace(r) || unicode.IsPunct(r):
+			inWord = false
+		}
+	}
+	return words, chars
+}
+
+func ReadingMinutes(text, lang string) int {
+	words, chars := Count(text, lang)
+	if words == 0 && chars == 0 {
+		return 0
+	}
+
+	minutes := float64(words)/WordsPerMinute + float64(chars)/CharsPerMinute
+	return max(1, int(math.Ceil(minutes)))
+}
+
+func Excerpt(text string, maxRunes int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:maxRunes])
+	if !unicode.IsSpace(runes[maxRunes]) {
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+
+	cut = strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return cut + Ellipsis
+}
+
+func Paragraphs(text string) int {
+	paragraphs := 0
+	inParagraph := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			inParagraph = false
+			continue
+		}
+		if !inParagraph {
+			paragraphs++
+			inParagraph = true
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,116 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		lang      string
+		wantWords int
+		wantChars int
+	}{
+		{name: "English", text: "The quick brown fox.", lang: "en", wantWords: 4},
+		{name: "Chinese", text: "我们今天学习Go语言。", lang: "zh", wantWords: 1, wantChars: 8},
+		{name: "Japanese region tag", text: "日本語の文章です", lang: "ja-JP", wantChars: 8},
+		{name: "Detected without language", text: "你好世界", lang: "", wantChars: 4},
+		{name: "Declared non-CJK language wins", text: "你好世界", lang: "en", wantWords: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, chars := Count(tt.text, tt.lang)
+			if words != tt.wantWords || chars != tt.wantChars {
+				t.Errorf("Expected %d words and %d chars, got %d and %d", tt.wantWords, tt.wantChars, words, chars)
+			}
+		})
+	}
+}
+
+func TestReadingMinutes(t *testing.T) {
+	english := strings.Repeat("word ", 1000)
+	chinese := strings.Repeat("汉", 1000)
+
+	if got := ReadingMinutes(english, "en"); got != 5 {
+		t.Errorf("Expected 1000 English words to take 5 minutes, got %d", got)
+	}
+	if got := ReadingMinutes(chinese, "zh"); got != 2 {
+		t.Errorf("Expected 1000 Chinese characters to take 2 minutes, got %d", got)
+	}
+	if got := ReadingMinutes(chinese, "en"); got != 1 {
+		t.Errorf("Expected unspaced text counted as words to take 1 minute, got %d", got)
+	}
+	if got := ReadingMinutes("", "en"); got != 0 {
+		t.Errorf("Expected empty text to take 0 minutes, got %d", got)
+	}
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "zh-CN,zh;q=0.9,en;q=0.8", want: "zh-CN"},
+		{header: "ja;q=0.9", want: "ja"},
+		{header: "*", want: ""},
+		{header: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := PreferredLanguage(tt.header); got != tt.want {
+			t.Errorf("PreferredLanguage(%q): expected %q, got %q", tt.header, tt.want, got)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxRunes int
+		want     string
+	}{
+		{name: "Short text is kept", text: "Hello world", maxRunes: 20, want: "Hello world"},
+		{name: "Whitespace is collapsed", text: "Hello \n\n  world", maxRunes: 20, want: "Hello world"},
+		{name: "Cut at word boundary", text: "The quick brown fox jumps", maxRunes: 12, want: "The quick…"},
+		{name: "Cut right before a space", text: "The quick brown fox", maxRunes: 9, want: "The quick…"},
+		{name: "Trailing punctuation is dropped", text: "Hello, world and more", maxRunes: 8, want: "Hello…"},
+		{name: "Single long word", text: "Supercalifragilistic", maxRunes: 5, want: "Super…"},
+		{name: "CJK text without spaces", text: "日本語の文章はスペースがありません", maxRunes: 5, want: "日本語の文…"},
+		{name: "Empty", text: "", maxRunes: 5, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Excerpt(tt.text, tt.maxRunes); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "Empty", text: "", want: 0},
+		{name: "Whitespace only", text: " \n\t\n", want: 0},
+		{name: "Single line", text: "One paragraph.", want: 1},
+		{name: "Wrapped lines", text: "First line\nsecond line", want: 1},
+		{name: "Blank line separated", text: "First.\n\nSecond.\n\n\nThird.", want: 3},
+		{name: "Windows line endings", text: "First.\r\n\r\nSecond.\r\n", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Paragraphs(tt.text); got != tt.want {
+				t.Errorf("Expected %d paragraphs, got %d", tt.want, got)
+			}
+		})
+	}
+}
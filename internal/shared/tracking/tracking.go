@@ -0,0 +1,77 @@
+package tracking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/buildinfo"
+	"content-service/internal/shared/config"
+
+	"github.com/getsentry/sentry-go"
+)
+
+func Init(cfg *config.Config) error {
+	environment := cfg.Sentry.Environment
+	if environment == "" {
+		environment = cfg.Environment
+	}
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:         cfg.Sentry.DSN,
+		Environment: environment,
+		Release:     buildinfo.APIVersion(),
+		SampleRate:  cfg.Sentry.SampleRate,
+	}); err != nil {
+		return fmt.Errorf("failed to initialize sentry: %w", err)
+	}
+	return nil
+}
+
+func Flush(timeout time.Duration) bool {
+	return sentry.Flush(timeout)
+}
+
+func NewContext(ctx context.Context, configure func(scope *sentry.Scope)) context.Context {
+	hub := sentry.CurrentHub().Clone()
+	if hub.Client() == nil {
+		return ctx
+	}
+	hub.ConfigureScope(configure)
+	return sentry.SetHubOnContext(ctx, hub)
+}
+
+func hubFrom(ctx context.Context) *sentry.Hub {
+	if hub := sentry.GetHubFromContext(ctx); hub != nil {
+		return hub
+	}
+	return sentry.CurrentHub()
+}
+
+func CaptureError(ctx context.Context, err error) {
+	hub := hubFrom(ctx)
+	if hub.Client() == nil {
+		return
+	}
+	hub.CaptureException(err)
+}
+
+func CapturePanic(ctx context.Context, recovered any) {
+	hub := hubFrom(ctx)
+	if hub.Client() == nil {
+		return
+	}
+	hub.RecoverWithContext(ctx, recovered)
+}
+
+func CaptureWarning(ctx context.Context, message string, fingerprint []string, extra map[string]any) {
+	hub := hubFrom(ctx)
+	if hub.Client() == nil {
+		return
+	}
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(sentry.LevelWarning)
+		scope.SetFingerprint(fingerprint)
+		scope.SetContext("details", extra)
+		hub.CaptureMessage(message)
+	})
+}
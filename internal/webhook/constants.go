@@ -0,0 +1,32 @@
+package webhook
+
+import "content-service/internal/article"
+
+const (
+	MaxURLLength        = 2048
+	MinSecretLength     = 16
+	MaxSecretLength     = 255
+	SecretPrefix        = "whsec_"
+	SecretBytes         = 24
+	MaxEndpointsPerUser = 20
+
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+
+	DeliveryBatchSize = 20
+	MaxErrorLength    = 500
+	MaxResponseBytes  = 64 << 10
+
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+	UserAgent       = "content-service-webhooks"
+)
+
+var Events = []string{
+	article.EventArticleCreated,
+	article.EventArticleUpdated,
+	article.EventArticleDeleted,
+	article.EventArticlePublished,
+}
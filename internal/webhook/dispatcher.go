@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"content-service/internal/shared/config"
+
+	"github.com/rs/zerolog/log"
+)
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func backoff(attempt int, base, limit time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+type Dispatcher struct {
+	repo   Repository
+	cfg    config.WebhookConfig
+	client *http.Client
+	wake   chan struct{}
+}
+
+func NewDispatcher(repo Repository, cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		cfg:    cfg,
+		client: newClient(cfg.Timeout, cfg.AllowPrivateTargets),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (dispatcher *Dispatcher) Notify() {
+	select {
+	case dispatcher.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (dispatcher *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatcher.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-dispatcher.wake:
+		}
+
+		for {
+			n, err := dispatcher.DeliverDue(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to deliver webhooks")
+			}
+			if err != nil || n < DeliveryBatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+func (dispatcher *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := dispatcher.repo.ClaimDue(ctx, time.Now(), 2*dispatcher.cfg.Timeout, DeliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoints := make(map[uint]*Endpoint)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			if endpoint, err = dispatcher.repo.GetEndpoint(ctx, delivery.EndpointID); err != nil {
+				log.Warn().Err(err).Uint("delivery_id", delivery.ID).Msg("Failed to load webhook of delivery")
+				continue
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatcher.attempt(ctx, endpoint, delivery)
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+func (dispatcher *Dispatcher) attempt(ctx context.Context, endpoint *Endpoint, delivery *Delivery) {
+	var status int
+	var err error
+	if endpoint.Active {
+		status, err = dispatcher.send(ctx, endpoint, delivery)
+	} else {
+		err = fmt.Errorf("webhook is inactive")
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	switch {
+	case err == nil:
+		delivery.Status = DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	case !endpoint.Active || delivery.Attempts >= dispatcher.cfg.MaxAttempts:
+		delivery.Status = DeliveryFailed
+		delivery.LastError = truncate(err.Error(), MaxErrorLength)
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(backoff(delivery.Attempts, dispatcher.cfg.BackoffBase, dispatcher.cfg.BackoffMax))
+		delivery.LastError = truncate(err.Error(), MaxErrorLength)
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := dispatcher.repo.SaveAttempt(ctx, delivery); err != nil {
+		log.Error().Err(err).Uint("delivery_id", delivery.ID).Msg("Failed to record webhook attempt")
+	}
+}
+
+func (dispatcher *Dispatcher) send(ctx context.Context, endpoint *Endpoint, delivery *Delivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, time.Now().Unix(), body))
+
+	resp, err := dispatcher.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, MaxResponseBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,11 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+	ErrForbidden        = errors.New("forbidden: you can only manage your own webhooks")
+	ErrLimitReached     = errors.New("webhook limit reached")
+	ErrValidation       = errors.New("validation error")
+)
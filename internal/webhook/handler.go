@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/middleware"
+	"content-service/internal/shared/tracking"
+	"content-service/internal/shared/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Events      []string `json:"events" validate:"required,min=1"`
+	AllArticles bool     `json:"all_articles"`
+}
+
+type UpdateWebhookRequest struct {
+	URL         *string   `json:"url" validate:"omitempty,url,max=2048"`
+	Events      *[]string `json:"events" validate:"omitempty,min=1"`
+	AllArticles *bool     `json:"all_articles"`
+	Active      *bool     `json:"active"`
+}
+
+func parseID(raw string) (uint, error) {
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func getViewer(c *gin.Context) article.Viewer {
+	userID, _ := middleware.GetUserID(c)
+	return article.Viewer{
+		UserID: userID,
+		Role:   middleware.GetUserRole(c),
+	}
+}
+
+func parsePagination(c *gin.Context) (page, limit int) {
+	page = article.DefaultPage
+	limit = article.DefaultLimit
+
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 {
+		limit = min(l, article.MaxLimit)
+	}
+	return page, limit
+}
+
+var errorToStatus = map[error]int{
+	ErrNotFound:         http.StatusNotFound,
+	ErrDeliveryNotFound: http.StatusNotFound,
+	ErrForbidden:        http.StatusForbidden,
+	ErrLimitReached:     http.StatusConflict,
+	ErrValidation:       http.StatusBadRequest,
+}
+
+func (handler *Handler) handleError(c *gin.Context, err error) {
+	for target, status := range errorToStatus {
+		if errors.Is(err, target) {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	log.Ctx(c.Request.Context()).Error().Err(err).Msg("Internal error")
+	tracking.CaptureError(c.Request.Context(), err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
+
+func (handler *Handler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	endpoint, err := handler.service.CreateEndpoint(c.Request.Context(), getViewer(c), CreateInput{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      req.Events,
+		AllArticles: req.AllArticles,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+func (handler *Handler) ListWebhooks(c *gin.Context) {
+	endpoints, err := handler.service.ListEndpoints(c.Request.Context(), getViewer(c))
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": endpoints})
+}
+
+func (handler *Handler) GetWebhook(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	endpoint, err := handler.service.GetEndpoint(c.Request.Context(), getViewer(c), id)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+func (handler *Handler) UpdateWebhook(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validationErrors := validation.NormalizeValidationErrors(err, req)
+		c.JSON(http.StatusBadRequest, gin.H{"errors": validationErrors})
+		return
+	}
+
+	endpoint, err := handler.service.UpdateEndpoint(c.Request.Context(), getViewer(c), id, UpdateInput{
+		URL:         req.URL,
+		Events:      req.Events,
+		AllArticles: req.AllArticles,
+		Active:      req.Active,
+	})
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+func (handler *Handler) DeleteWebhook(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+
+	if err := handler.service.DeleteEndpoint(c.Request.Context(), getViewer(c), id); err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (handler *Handler) ListDeliveries(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+	page, limit := parsePagination(c)
+
+	deliveries, total, err := handler.service.ListDeliveries(c.Request.Context(), getViewer(c), id, c.Query("status"), page, limit)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+		"meta": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": int((total + int64(limit) - 1) / int64(limit)),
+		},
+	})
+}
+
+func (handler *Handler) ReplayDelivery(c *gin.Context) {
+	id, err := parseID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook ID"})
+		return
+	}
+	deliveryID, err := parseID(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery ID"})
+		return
+	}
+
+	delivery, err := handler.service.ReplayDelivery(c.Request.Context(), getViewer(c), id, deliveryID)
+	if err != nil {
+		handler.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
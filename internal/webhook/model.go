@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"content-service/internal/article"
+)
+
+type Endpoint struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index" json:"user_id"`
+	URL         string    `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret      string    `gorm:"type:varchar(255);not null" json:"-"`
+	Events      []string  `gorm:"type:text;serializer:json;not null" json:"events"`
+	AllArticles bool      `gorm:"not null;default:false" json:"all_articles"`
+	Active      bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+type CreatedEndpoint struct {
+	Endpoint
+	Secret string `json:"secret"`
+}
+
+type Delivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	EndpointID     uint       `gorm:"not null;index" json:"endpoint_id"`
+	EventID        string     `gorm:"type:varchar(64);not null;index" json:"event_id"`
+	Event          string     `gorm:"type:varchar(64);not null" json:"event"`
+	Payload        string     `gorm:"type:text;not null" json:"-"`
+	Status         string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int        `gorm:"not null;default:0" json:"response_status,omitempty"`
+	LastError      string     `gorm:"type:text;not null;default:''" json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `gorm:"index" json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	ReplayOf       *uint      `json:"replay_of,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d Delivery) MarshalJSON() ([]byte, error) {
+	type delivery Delivery
+	return json.Marshal(struct {
+		delivery
+		Payload json.RawMessage `json:"payload"`
+	}{delivery(d), json.RawMessage(d.Payload)})
+}
+
+type Payload struct {
+	EventID    string            `json:"event_id"`
+	Event      string            `json:"event"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Article    article.EventData `json:"article"`
+}
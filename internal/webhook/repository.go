@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"content-service/internal/shared/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	GetEndpoint(ctx context.Context, id uint) (*Endpoint, error)
+	ListEndpoints(ctx context.Context, userID uint) ([]Endpoint, error)
+	CountEndpoints(ctx context.Context, userID uint) (int64, error)
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	DeleteEndpoint(ctx context.Context, id uint) error
+	SubscribedEndpoints(ctx context.Context, ownerID uint) ([]Endpoint, error)
+	CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+	GetDelivery(ctx context.Context, id uint) (*Delivery, error)
+	ListDeliveries(ctx context.Context, endpointID uint, status string, page, limit int) ([]Delivery, int64, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	SaveAttempt(ctx context.Context, delivery *Delivery) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &webhookRepository{db: db}
+}
+
+func (repo *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	if err := database.Conn(ctx, repo.db).Create(endpoint).Error; err != nil {
+		return fmt.Errorf("repo: failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (repo *webhookRepository) GetEndpoint(ctx context.Context, id uint) (*Endpoint, error) {
+	var endpoint Endpoint
+	if err := database.Conn(ctx, repo.db).First(&endpoint, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get webhook by id %d: %w", id, err)
+	}
+	return &endpoint, nil
+}
+
+func (repo *webhookRepository) ListEndpoints(ctx context.Context, userID uint) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	if err := database.Conn(ctx, repo.db).Where("user_id = ?", userID).Order("id ASC").Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("repo: failed to list webhooks of user %d: %w", userID, err)
+	}
+	return endpoints, nil
+}
+
+func (repo *webhookRepository) CountEndpoints(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := database.Conn(ctx, repo.db).Model(&Endpoint{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("repo: failed to count webhooks of user %d: %w", userID, err)
+	}
+	return count, nil
+}
+
+func (repo *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	result := database.Conn(ctx, repo.db).Model(endpoint).Select("url", "events", "all_articles", "active").Updates(endpoint)
+	if result.Error != nil {
+		return fmt.Errorf("repo: failed to update webhook %d: %w", endpoint.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (repo *webhookRepository) DeleteEndpoint(ctx context.Context, id uint) error {
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("endpoint_id = ?", id).Delete(&Delivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&Endpoint{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("repo: failed to delete webhook %d: %w", id, err)
+	}
+	return nil
+}
+
+func (repo *webhookRepository) SubscribedEndpoints(ctx context.Context, ownerID uint) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	err := database.Conn(ctx, repo.db).
+		Where("active = ?", true).
+		Where("user_id = ? OR all_articles = ?", ownerID, true).
+		Order("id ASC").
+		Find(&endpoints).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to find webhooks for user %d: %w", ownerID, err)
+	}
+	return endpoints, nil
+}
+
+func (repo *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []Delivery) error {
+	if err := database.Conn(ctx, repo.db).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("repo: failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (repo *webhookRepository) GetDelivery(ctx context.Context, id uint) (*Delivery, error) {
+	var delivery Delivery
+	if err := database.Conn(ctx, repo.db).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("repo: failed to get delivery by id %d: %w", id, err)
+	}
+	return &delivery, nil
+}
+
+func (repo *webhookRepository) ListDeliveries(ctx context.Context, endpointID uint, status string, page, limit int) ([]Delivery, int64, error) {
+	query := database.Conn(ctx, repo.db).Model(&Delivery{}).Where("endpoint_id = ?", endpointID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to count deliveries of webhook %d: %w", endpointID, err)
+	}
+
+	var deliveries []Delivery
+	err := query.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("repo: failed to list deliveries of webhook %d: %w", endpointID, err)
+	}
+	return deliveries, total, nil
+}
+
+func (repo *webhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+	var deliveries []Delivery
+	err := database.Conn(ctx, repo.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		return tx.Model(&Delivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo: failed to claim due deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (repo *webhookRepository) SaveAttempt(ctx context.Context, delivery *Delivery) error {
+	err := database.Conn(ctx, repo.db).Model(delivery).
+		Select("status", "attempts", "response_status", "last_error", "next_attempt_at", "delivered_at").
+		Updates(delivery).Error
+	if err != nil {
+		return fmt.Errorf("repo: failed to record attempt of delivery %d: %w", delivery.ID, err)
+	}
+	return nil
+}
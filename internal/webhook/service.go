@@ -0,0 +1,359 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/events"
+)
+
+type CreateInput struct {
+	URL         string
+	Secret      string
+	Events      []string
+	AllArticles bool
+}
+
+type UpdateInput struct {
+	URL         *string
+	Events      *[]string
+	AllArticles *bool
+	Active      *bool
+}
+
+type Service interface {
+	CreateEndpoint(ctx context.Context, viewer article.Viewer, input CreateInput) (*CreatedEndpoint, error)
+	ListEndpoints(ctx context.Context, viewer article.Viewer) ([]Endpoint, error)
+	GetEndpoint(ctx context.Context, viewer article.Viewer, id uint) (*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, viewer article.Viewer, id uint) error
+	ListDeliveries(ctx context.Context, viewer article.Viewer, id uint, status string, page, limit int) ([]Delivery, int64, error)
+	ReplayDelivery(ctx context.Context, viewer article.Viewer, id, deliveryID uint) (*Delivery, error)
+	HandleArticleEvent(event events.Event) error
+}
+
+type Notifier interface {
+	Notify()
+}
+
+type webhookService struct {
+	repo         Repository
+	notifier     Notifier
+	resolver     Resolver
+	allowPrivate bool
+}
+
+type Option func(*webhookService)
+
+func WithResolver(resolver Resolver) Option {
+	return func(svc *webhookService) {
+		svc.resolver = resolver
+	}
+}
+
+func WithPrivateTargets(allow bool) Option {
+	return func(svc *webhookService) {
+		svc.allowPrivate = allow
+	}
+}
+
+func NewService(repo Repository, notifier Notifier, opts ...Option) Service {
+	svc := &webhookService{repo: repo, notifier: notifier, resolver: net.DefaultResolver}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("%w: url is required", ErrValidation)
+	}
+	if len(raw) > MaxURLLength {
+		return "", fmt.Errorf("%w: url cannot exceed %d characters", ErrValidation, MaxURLLength)
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("%w: url must be an absolute http or https URL", ErrValidation)
+	}
+	return raw, nil
+}
+
+func (svc *webhookService) checkTarget(ctx context.Context, target string) error {
+	if svc.allowPrivate {
+		return nil
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrValidation)
+	}
+	if err := checkHost(ctx, svc.resolver, parsed.Hostname()); err != nil {
+		if errors.Is(err, ErrBlockedTarget) {
+			return fmt.Errorf("%w: url must resolve to a public address", ErrValidation)
+		}
+		return fmt.Errorf("%w: url host could not be resolved", ErrValidation)
+	}
+	return nil
+}
+
+func validateEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrValidation)
+	}
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("%w: event must be one of: %s", ErrValidation, strings.Join(Events, ", "))
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+func canManage(viewer article.Viewer, endpoint *Endpoint) bool {
+	return viewer.IsAdmin() || (viewer.UserID != 0 && viewer.UserID == endpoint.UserID)
+}
+
+func (svc *webhookService) CreateEndpoint(ctx context.Context, viewer article.Viewer, input CreateInput) (*CreatedEndpoint, error) {
+	target, err := validateURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.checkTarget(ctx, target); err != nil {
+		return nil, err
+	}
+	events, err := validateEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+	if input.AllArticles && !viewer.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can subscribe to all articles", ErrForbidden)
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		generated, err := randomHex(SecretBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+		secret = SecretPrefix + generated
+	}
+	if len(secret) < MinSecretLength || len(secret) > MaxSecretLength {
+		return nil, fmt.Errorf("%w: secret must be %d..%d characters", ErrValidation, MinSecretLength, MaxSecretLength)
+	}
+
+	count, err := svc.repo.CountEndpoints(ctx, viewer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	if count >= MaxEndpointsPerUser {
+		return nil, fmt.Errorf("%w: at most %d webhooks per user", ErrLimitReached, MaxEndpointsPerUser)
+	}
+
+	endpoint := &Endpoint{
+		UserID:      viewer.UserID,
+		URL:         target,
+		Secret:      secret,
+		Events:      events,
+		AllArticles: input.AllArticles,
+		Active:      true,
+	}
+	if err := svc.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return &CreatedEndpoint{Endpoint: *endpoint, Secret: secret}, nil
+}
+
+func (svc *webhookService) ListEndpoints(ctx context.Context, viewer article.Viewer) ([]Endpoint, error) {
+	endpoints, err := svc.repo.ListEndpoints(ctx, viewer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return endpoints, nil
+}
+
+func (svc *webhookService) GetEndpoint(ctx context.Context, viewer article.Viewer, id uint) (*Endpoint, error) {
+	endpoint, err := svc.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(viewer, endpoint) {
+		return nil, ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (svc *webhookService) UpdateEndpoint(ctx context.Context, viewer article.Viewer, id uint, input UpdateInput) (*Endpoint, error) {
+	endpoint, err := svc.GetEndpoint(ctx, viewer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL == nil && input.Events == nil && input.AllArticles == nil && input.Active == nil {
+		return nil, fmt.Errorf("%w: no fields to update", ErrValidation)
+	}
+	if input.URL != nil {
+		if endpoint.URL, err = validateURL(*input.URL); err != nil {
+			return nil, err
+		}
+		if err := svc.checkTarget(ctx, endpoint.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Events != nil {
+		if endpoint.Events, err = validateEvents(*input.Events); err != nil {
+			return nil, err
+		}
+	}
+	if input.AllArticles != nil {
+		if *input.AllArticles && !viewer.IsAdmin() {
+			return nil, fmt.Errorf("%w: only admins can subscribe to all articles", ErrForbidden)
+		}
+		endpoint.AllArticles = *input.AllArticles
+	}
+	if input.Active != nil {
+		endpoint.Active = *input.Active
+	}
+
+	if err := svc.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return endpoint, nil
+}
+
+func (svc *webhookService) DeleteEndpoint(ctx context.Context, viewer article.Viewer, id uint) error {
+	if _, err := svc.GetEndpoint(ctx, viewer, id); err != nil {
+		return err
+	}
+	return svc.repo.DeleteEndpoint(ctx, id)
+}
+
+func (svc *webhookService) ListDeliveries(ctx context.Context, viewer article.Viewer, id uint, status string, page, limit int) ([]Delivery, int64, error) {
+	switch status {
+	case "", DeliveryPending, DeliverySucceeded, DeliveryFailed:
+	default:
+		return nil, 0, fmt.Errorf("%w: status must be one of: %s, %s, %s", ErrValidation, DeliveryPending, DeliverySucceeded, DeliveryFailed)
+	}
+	if _, err := svc.GetEndpoint(ctx, viewer, id); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := svc.repo.ListDeliveries(ctx, id, status, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+func (svc *webhookService) ReplayDelivery(ctx context.Context, viewer article.Viewer, id, deliveryID uint) (*Delivery, error) {
+	if _, err := svc.GetEndpoint(ctx, viewer, id); err != nil {
+		return nil, err
+	}
+	original, err := svc.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.EndpointID != id {
+		return nil, ErrDeliveryNotFound
+	}
+
+	now := time.Now()
+	replays := []Delivery{{
+		EndpointID:    id,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+	}}
+	if err := svc.repo.CreateDeliveries(ctx, replays); err != nil {
+		return nil, fmt.Errorf("failed to replay delivery: %w", err)
+	}
+	svc.notify()
+	return &replays[0], nil
+}
+
+func (svc *webhookService) HandleArticleEvent(event events.Event) error {
+	a, ok := event.Payload.(article.Article)
+	if !ok {
+		return fmt.Errorf("unexpected payload %T for event %s", event.Payload, event.Name)
+	}
+
+	ctx := context.Background()
+	endpoints, err := svc.repo.SubscribedEndpoints(ctx, a.UserID)
+	if err != nil {
+		return err
+	}
+
+	var subscribed []Endpoint
+	for _, endpoint := range endpoints {
+		if slices.Contains(endpoint.Events, event.Name) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	eventID, err := randomHex(16)
+	if err != nil {
+		return fmt.Errorf("failed to generate event id: %w", err)
+	}
+	payload, err := json.Marshal(Payload{
+		EventID:    eventID,
+		Event:      event.Name,
+		OccurredAt: event.OccurredAt.UTC(),
+		Article:    article.NewEventData(&a),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]Delivery, 0, len(subscribed))
+	for _, endpoint := range subscribed {
+		deliveries = append(deliveries, Delivery{
+			EndpointID:    endpoint.ID,
+			EventID:       eventID,
+			Event:         event.Name,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	if err := svc.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	svc.notify()
+	return nil
+}
+
+func (svc *webhookService) notify() {
+	if svc.notifier != nil {
+		svc.notifier.Notify()
+	}
+}
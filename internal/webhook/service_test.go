@@ -0,0 +1,597 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"content-service/internal/article"
+	"content-service/internal/shared/auth"
+	"content-service/internal/shared/config"
+	"content-service/internal/shared/events"
+)
+
+type staticResolver map[string][]string
+
+func (resolver staticResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := resolver[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+var testResolver = staticResolver{
+	"hooks.example.com":    {"93.184.215.14"},
+	"owner.example.com":    {"93.184.215.14"},
+	"other.example.com":    {"93.184.215.14"},
+	"admin.example.com":    {"93.184.215.14"},
+	"internal.example.com": {"93.184.215.14", "10.0.0.5"},
+	"metadata.example.com": {"169.254.169.254"},
+}
+
+type mockRepository struct {
+	mu             sync.Mutex
+	endpoints      map[uint]*Endpoint
+	deliveries     map[uint]*Delivery
+	nextEndpointID uint
+	nextDeliveryID uint
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		endpoints:      make(map[uint]*Endpoint),
+		deliveries:     make(map[uint]*Delivery),
+		nextEndpointID: 1,
+		nextDeliveryID: 1,
+	}
+}
+
+func (m *mockRepository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoint.ID = m.nextEndpointID
+	m.nextEndpointID++
+	copied := *endpoint
+	m.endpoints[endpoint.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) GetEndpoint(ctx context.Context, id uint) (*Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	endpoint, ok := m.endpoints[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (m *mockRepository) ListEndpoints(ctx context.Context, userID uint) ([]Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var list []Endpoint
+	for id := uint(1); id < m.nextEndpointID; id++ {
+		if endpoint, ok := m.endpoints[id]; ok && endpoint.UserID == userID {
+			list = append(list, *endpoint)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockRepository) CountEndpoints(ctx context.Context, userID uint) (int64, error) {
+	list, _ := m.ListEndpoints(ctx, userID)
+	return int64(len(list)), nil
+}
+
+func (m *mockRepository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.endpoints[endpoint.ID]; !ok {
+		return ErrNotFound
+	}
+	copied := *endpoint
+	m.endpoints[endpoint.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) DeleteEndpoint(ctx context.Context, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.endpoints[id]; !ok {
+		return ErrNotFound
+	}
+	for deliveryID, delivery := range m.deliveries {
+		if delivery.EndpointID == id {
+			delete(m.deliveries, deliveryID)
+		}
+	}
+	delete(m.endpoints, id)
+	return nil
+}
+
+func (m *mockRepository) SubscribedEndpoints(ctx context.Context, ownerID uint) ([]Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var list []Endpoint
+	for id := uint(1); id < m.nextEndpointID; id++ {
+		endpoint, ok := m.endpoints[id]
+		if ok && endpoint.Active && (endpoint.UserID == ownerID || endpoint.AllArticles) {
+			list = append(list, *endpoint)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockRepository) CreateDeliveries(ctx context.Context, deliveries []Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range deliveries {
+		deliveries[i].ID = m.nextDeliveryID
+		m.nextDeliveryID++
+		copied := deliveries[i]
+		m.deliveries[copied.ID] = &copied
+	}
+	return nil
+}
+
+func (m *mockRepository) GetDelivery(ctx context.Context, id uint) (*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery, ok := m.deliveries[id]
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (m *mockRepository) ListDeliveries(ctx context.Context, endpointID uint, status string, page, limit int) ([]Delivery, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var list []Delivery
+	for id := m.nextDeliveryID - 1; id > 0; id-- {
+		delivery, ok := m.deliveries[id]
+		if ok && delivery.EndpointID == endpointID && (status == "" || delivery.Status == status) {
+			list = append(list, *delivery)
+		}
+	}
+	total := int64(len(list))
+	offset := (page - 1) * limit
+	if offset >= len(list) {
+		return nil, total, nil
+	}
+	return list[offset:min(offset+limit, len(list))], total, nil
+}
+
+func (m *mockRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []Delivery
+	for id := uint(1); id < m.nextDeliveryID && len(claimed) < limit; id++ {
+		delivery, ok := m.deliveries[id]
+		if !ok || delivery.Status != DeliveryPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		claimed = append(claimed, *delivery)
+		leased := now.Add(lease)
+		delivery.NextAttemptAt = &leased
+	}
+	return claimed, nil
+}
+
+func (m *mockRepository) SaveAttempt(ctx context.Context, delivery *Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *delivery
+	m.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+type countingNotifier struct {
+	calls int
+}
+
+func (n *countingNotifier) Notify() {
+	n.calls++
+}
+
+func TestEndpoints(t *testing.T) {
+	repo := newMockRepository()
+	service := NewService(repo, nil, WithResolver(testResolver))
+
+	owner := article.Viewer{UserID: 1}
+	stranger := article.Viewer{UserID: 2}
+	admin := article.Viewer{UserID: 3, Role: auth.RoleAdmin}
+
+	created, err := service.CreateEndpoint(context.Background(), owner, CreateInput{
+		URL:    " https://hooks.example.com/articles ",
+		Events: []string{"Article.Updated", article.EventArticleCreated, article.EventArticleCreated},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.URL != "https://hooks.example.com/articles" {
+		t.Errorf("Expected the URL to be trimmed, got %q", created.URL)
+	}
+	if !slices.Equal(created.Events, []string{article.EventArticleCreated, article.EventArticleUpdated}) {
+		t.Errorf("Expected normalized events, got %v", created.Events)
+	}
+	if !strings.HasPrefix(created.Secret, SecretPrefix) || len(created.Secret) != len(SecretPrefix)+2*SecretBytes {
+		t.Errorf("Expected a generated secret, got %q", created.Secret)
+	}
+	if !created.Active {
+		t.Error("Expected a new webhook to be active")
+	}
+
+	createTests := []struct {
+		name    string
+		viewer  article.Viewer
+		input   CreateInput
+		wantErr error
+	}{
+		{name: "Relative URL", viewer: owner, input: CreateInput{URL: "/hooks", Events: Events}, wantErr: ErrValidation},
+		{name: "Unsupported scheme", viewer: owner, input: CreateInput{URL: "ftp://hooks.example.com", Events: Events}, wantErr: ErrValidation},
+		{name: "No events", viewer: owner, input: CreateInput{URL: "https://hooks.example.com"}, wantErr: ErrValidation},
+		{name: "Unknown event", viewer: owner, input: CreateInput{URL: "https://hooks.example.com", Events: []string{"article.viewed"}}, wantErr: ErrValidation},
+		{name: "Short secret", viewer: owner, input: CreateInput{URL: "https://hooks.example.com", Events: Events, Secret: "short"}, wantErr: ErrValidation},
+		{name: "All articles as author", viewer: owner, input: CreateInput{URL: "https://hooks.example.com", Events: Events, AllArticles: true}, wantErr: ErrForbidden},
+		{name: "All articles as admin", viewer: admin, input: CreateInput{URL: "https://hooks.example.com", Events: Events, AllArticles: true}},
+		{name: "Loopback address", viewer: owner, input: CreateInput{URL: "http://127.0.0.1:8080/hooks", Events: Events}, wantErr: ErrValidation},
+		{name: "Private address", viewer: owner, input: CreateInput{URL: "http://[fd00::1]/hooks", Events: Events}, wantErr: ErrValidation},
+		{name: "Host resolving to a private address", viewer: owner, input: CreateInput{URL: "https://internal.example.com", Events: Events}, wantErr: ErrValidation},
+		{name: "Host resolving to link-local", viewer: owner, input: CreateInput{URL: "http://metadata.example.com/latest", Events: Events}, wantErr: ErrValidation},
+		{name: "Unresolvable host", viewer: owner, input: CreateInput{URL: "https://missing.example.com", Events: Events}, wantErr: ErrValidation},
+	}
+	for _, tt := range createTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateEndpoint(context.Background(), tt.viewer, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := service.GetEndpoint(context.Background(), stranger, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another user's webhook, got %v", err)
+	}
+	if _, err := service.GetEndpoint(context.Background(), admin, created.ID); err != nil {
+		t.Errorf("Expected admins to see any webhook, got %v", err)
+	}
+
+	inactive := false
+	updated, err := service.UpdateEndpoint(context.Background(), owner, created.ID, UpdateInput{Active: &inactive, Events: &[]string{article.EventArticleDeleted}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Active || !slices.Equal(updated.Events, []string{article.EventArticleDeleted}) {
+		t.Errorf("Unexpected updated webhook: %+v", updated)
+	}
+	private := "http://localhost/hooks"
+	if _, err := service.UpdateEndpoint(context.Background(), owner, created.ID, UpdateInput{URL: &private}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for a private URL, got %v", err)
+	}
+	if _, err := service.UpdateEndpoint(context.Background(), owner, created.ID, UpdateInput{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an empty update, got %v", err)
+	}
+	all := true
+	if _, err := service.UpdateEndpoint(context.Background(), owner, created.ID, UpdateInput{AllArticles: &all}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected ErrForbidden, got %v", err)
+	}
+
+	for range MaxEndpointsPerUser - 1 {
+		if _, err := service.CreateEndpoint(context.Background(), owner, CreateInput{URL: "https://hooks.example.com", Events: Events}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if _, err := service.CreateEndpoint(context.Background(), owner, CreateInput{URL: "https://hooks.example.com", Events: Events}); !errors.Is(err, ErrLimitReached) {
+		t.Errorf("Expected ErrLimitReached, got %v", err)
+	}
+
+	if err := service.DeleteEndpoint(context.Background(), stranger, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := service.DeleteEndpoint(context.Background(), owner, created.ID); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if endpoints, _ := service.ListEndpoints(context.Background(), owner); len(endpoints) != MaxEndpointsPerUser-1 {
+		t.Errorf("Expected %d webhooks, got %d", MaxEndpointsPerUser-1, len(endpoints))
+	}
+}
+
+func TestHandleArticleEvent(t *testing.T) {
+	repo := newMockRepository()
+	notifier := &countingNotifier{}
+	service := NewService(repo, notifier, WithResolver(testResolver))
+
+	owner := article.Viewer{UserID: 1}
+	other := article.Viewer{UserID: 2}
+	admin := article.Viewer{UserID: 3, Role: auth.RoleAdmin}
+
+	ownHook, _ := service.CreateEndpoint(context.Background(), owner, CreateInput{URL: "https://owner.example.com", Events: Events})
+	publishedOnly, _ := service.CreateEndpoint(context.Background(), owner, CreateInput{URL: "https://owner.example.com/published", Events: []string{article.EventArticlePublished}})
+	otherHook, _ := service.CreateEndpoint(context.Background(), other, CreateInput{URL: "https://other.example.com", Events: Events})
+	allHook, _ := service.CreateEndpoint(context.Background(), admin, CreateInput{URL: "https://admin.example.com", Events: Events, AllArticles: true})
+
+	a := article.Article{ID: 7, UserID: owner.UserID, Title: "Hello", Slug: "hello", Status: article.StatusPublished, Content: "secret draft body"}
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleCreated, Payload: a}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var endpointIDs []uint
+	var eventIDs []string
+	for _, delivery := range repo.deliveries {
+		endpointIDs = append(endpointIDs, delivery.EndpointID)
+		eventIDs = append(eventIDs, delivery.EventID)
+	}
+	slices.Sort(endpointIDs)
+	if !slices.Equal(endpointIDs, []uint{ownHook.ID, allHook.ID}) {
+		t.Errorf("Expected deliveries to webhooks %d and %d, got %v", ownHook.ID, allHook.ID, endpointIDs)
+	}
+	if slices.Contains(endpointIDs, publishedOnly.ID) || slices.Contains(endpointIDs, otherHook.ID) {
+		t.Error("Expected unsubscribed webhooks to be skipped")
+	}
+	if len(eventIDs) == 2 && eventIDs[0] != eventIDs[1] {
+		t.Error("Expected all deliveries of one event to share an event ID")
+	}
+	if notifier.calls != 1 {
+		t.Errorf("Expected the dispatcher to be notified once, got %d", notifier.calls)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal([]byte(repo.deliveries[1].Payload), &payload); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if payload.Event != article.EventArticleCreated || payload.Article.ID != 7 || payload.Article.Slug != "hello" {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+	if strings.Contains(repo.deliveries[1].Payload, "secret draft body") {
+		t.Error("Expected the payload to omit article content")
+	}
+
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleCreated, Payload: article.Article{ID: 8, UserID: 99}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repo.deliveries) != 3 {
+		t.Errorf("Expected only the all-articles webhook to receive another user's event, got %d deliveries", len(repo.deliveries))
+	}
+
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleCreated, Payload: "not an article"}); err == nil {
+		t.Error("Expected an error for an unexpected payload")
+	}
+
+	original := repo.deliveries[1]
+	replay, err := service.ReplayDelivery(context.Background(), owner, original.EndpointID, original.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if replay.ReplayOf == nil || *replay.ReplayOf != original.ID || replay.EventID != original.EventID || replay.Status != DeliveryPending {
+		t.Errorf("Unexpected replay: %+v", replay)
+	}
+	if _, err := service.ReplayDelivery(context.Background(), other, original.EndpointID, original.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another user's webhook, got %v", err)
+	}
+	if _, err := service.ReplayDelivery(context.Background(), owner, publishedOnly.ID, original.ID); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("Expected ErrDeliveryNotFound for a delivery of another webhook, got %v", err)
+	}
+	if _, _, err := service.ListDeliveries(context.Background(), owner, ownHook.ID, "unknown", 1, 10); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation for an unknown status, got %v", err)
+	}
+	if deliveries, total, _ := service.ListDeliveries(context.Background(), owner, ownHook.ID, DeliveryPending, 1, 10); total != 2 || deliveries[0].ID != replay.ID {
+		t.Errorf("Expected the replay first among 2 pending deliveries, got %d", total)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 10, want: time.Hour},
+		{attempt: 100, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempt, 30*time.Second, time.Hour); got != tt.want {
+			t.Errorf("backoff(%d): expected %v, got %v", tt.attempt, tt.want, got)
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMockRepository()
+	dispatcher := NewDispatcher(repo, config.WebhookConfig{
+		MaxAttempts: 2,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Hour,
+		Timeout:     5 * time.Second,
+
+		AllowPrivateTargets: true,
+	})
+	service := NewService(repo, dispatcher, WithPrivateTargets(true))
+
+	owner := article.Viewer{UserID: 1}
+	endpoint, _ := service.CreateEndpoint(context.Background(), owner, CreateInput{URL: server.URL, Secret: "0123456789abcdef", Events: Events})
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleUpdated, Payload: article.Article{ID: 1, UserID: 1, Slug: "hello"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx := context.Background()
+	if n, err := dispatcher.DeliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected 1 delivery attempt, got %d (%v)", n, err)
+	}
+	delivery := repo.deliveries[1]
+	if delivery.Status != DeliveryPending || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusInternalServerError {
+		t.Errorf("Expected a pending retry after a 500, got %+v", delivery)
+	}
+	if delivery.NextAttemptAt == nil || time.Until(*delivery.NextAttemptAt) < 50*time.Second {
+		t.Errorf("Expected the retry to be backed off, got %v", delivery.NextAttemptAt)
+	}
+	if n, _ := dispatcher.DeliverDue(ctx); n != 0 {
+		t.Errorf("Expected no deliveries due before the backoff elapses, got %d", n)
+	}
+
+	req := requests[0]
+	if req.Header.Get(HeaderEvent) != article.EventArticleUpdated || req.Header.Get(HeaderDelivery) != "1" {
+		t.Errorf("Unexpected headers: %v", req.Header)
+	}
+	signature := req.Header.Get(HeaderSignature)
+	rawTimestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+	timestamp, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("Failed to parse signature timestamp: %v", err)
+	}
+	if signature != Sign(endpoint.Secret, timestamp, bodies[0]) {
+		t.Errorf("Expected a valid signature, got %q", signature)
+	}
+
+	now := time.Now()
+	delivery.NextAttemptAt = &now
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delivery := repo.deliveries[1]; delivery.Status != DeliveryFailed || delivery.Attempts != 2 || delivery.NextAttemptAt != nil {
+		t.Errorf("Expected the delivery to fail after max attempts, got %+v", delivery)
+	}
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	replay, err := service.ReplayDelivery(ctx, owner, endpoint.ID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delivery := repo.deliveries[replay.ID]; delivery.Status != DeliverySucceeded || delivery.DeliveredAt == nil || delivery.LastError != "" {
+		t.Errorf("Expected the replay to succeed, got %+v", delivery)
+	}
+	if string(bodies[2]) != string(bodies[0]) {
+		t.Error("Expected the replay to resend the original payload")
+	}
+}
+
+func TestBlockedAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14"},
+		{addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c"},
+		{addr: "127.0.0.1", want: true},
+		{addr: "::1", want: true},
+		{addr: "10.1.2.3", want: true},
+		{addr: "172.16.0.1", want: true},
+		{addr: "192.168.1.1", want: true},
+		{addr: "169.254.169.254", want: true},
+		{addr: "100.100.100.200", want: true},
+		{addr: "0.0.0.0", want: true},
+		{addr: "fd00:ec2::254", want: true},
+		{addr: "fe80::1", want: true},
+		{addr: "::ffff:127.0.0.1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := blockedAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDispatcherBlocksPrivateTargets(t *testing.T) {
+	var hits int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMockRepository()
+	repo.endpoints[1] = &Endpoint{ID: 1, UserID: 1, URL: server.URL, Secret: "0123456789abcdef", Events: Events, Active: true}
+	repo.nextEndpointID = 2
+	dispatcher := NewDispatcher(repo, config.WebhookConfig{
+		MaxAttempts: 2,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Hour,
+		Timeout:     5 * time.Second,
+	})
+	service := NewService(repo, dispatcher, WithResolver(testResolver))
+	if err := service.HandleArticleEvent(events.Event{Name: article.EventArticleUpdated, Payload: article.Article{ID: 1, UserID: 1, Slug: "hello"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := dispatcher.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delivery := repo.deliveries[1]; delivery.Status != DeliveryPending || !strings.Contains(delivery.LastError, ErrBlockedTarget.Error()) {
+		t.Errorf("Expected the loopback delivery to be refused, got %+v", delivery)
+	}
+	if hits != 0 {
+		t.Errorf("Expected no requests to reach the loopback server, got %d", hits)
+	}
+}
+
+func TestDispatcherIgnoresRedirects(t *testing.T) {
+	var redirected bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := newClient(5*time.Second, true)
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTemporaryRedirect || redirected {
+		t.Errorf("Expected the redirect not to be followed, got status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_status;
+ALTER TABLE articles DROP COLUMN IF EXISTS status;
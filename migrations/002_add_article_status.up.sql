@@ -0,0 +1,3 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published';
+
+CREATE INDEX IF NOT EXISTS idx_articles_status ON articles(status);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_article_revisions_article_revision;
+DROP TABLE IF EXISTS article_revisions;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    editor_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_revisions_article_revision ON article_revisions(article_id, revision);
+
+INSERT INTO article_revisions (article_id, revision, title, content, editor_id, created_at)
+SELECT id, 1, title, content, user_id, updated_at FROM articles
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_article_slug_redirects_article_id;
+DROP INDEX IF EXISTS idx_article_slug_redirects_slug;
+DROP TABLE IF EXISTS article_slug_redirects;
+DROP INDEX IF EXISTS idx_articles_slug;
+ALTER TABLE articles DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,17 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS slug VARCHAR(255);
+
+UPDATE articles SET slug = 'article-' || id WHERE slug IS NULL;
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles(slug);
+
+CREATE TABLE IF NOT EXISTS article_slug_redirects (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(255) NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_article_slug_redirects_slug ON article_slug_redirects(slug);
+CREATE INDEX IF NOT EXISTS idx_article_slug_redirects_article_id ON article_slug_redirects(article_id);
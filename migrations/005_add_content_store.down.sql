@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS content_blobs;
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS content_ref;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_ref;
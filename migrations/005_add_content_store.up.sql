@@ -0,0 +1,8 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_ref VARCHAR(255);
+ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS content_ref VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS content_blobs (
+    key VARCHAR(255) PRIMARY KEY,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
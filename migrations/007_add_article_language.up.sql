@@ -0,0 +1 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS language VARCHAR(16);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_content_length;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_length;
@@ -0,0 +1,5 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_length INTEGER NOT NULL DEFAULT 0;
+
+UPDATE articles SET content_length = char_length(content) WHERE content_ref IS NULL OR content_ref = '';
+
+CREATE INDEX IF NOT EXISTS idx_articles_content_length ON articles(content_length);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_articles_category_id;
+ALTER TABLE articles DROP COLUMN IF EXISTS category_id;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_article_reactions_user_id;
+DROP TABLE IF EXISTS article_reactions;
+ALTER TABLE articles DROP COLUMN IF EXISTS likes_count;
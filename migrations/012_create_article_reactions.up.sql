@@ -0,0 +1,10 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS likes_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS article_reactions (
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (article_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_reactions_user_id ON article_reactions(user_id);
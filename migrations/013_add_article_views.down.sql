@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_views;
+ALTER TABLE articles DROP COLUMN IF EXISTS views;
@@ -0,0 +1,3 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS reading_time_minutes;
+ALTER TABLE articles DROP COLUMN IF EXISTS excerpt_custom;
+ALTER TABLE articles DROP COLUMN IF EXISTS excerpt;
@@ -0,0 +1,3 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS excerpt VARCHAR(300);
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS excerpt_custom BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS reading_time_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_article_authors_user_id;
+DROP TABLE IF EXISTS article_authors;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS article_authors (
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (article_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_authors_user_id ON article_authors(user_id);
+
+INSERT INTO article_authors (article_id, user_id, role, created_at)
+SELECT id, user_id, 'owner', created_at FROM articles
+ON CONFLICT DO NOTHING;
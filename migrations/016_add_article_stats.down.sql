@@ -0,0 +1,2 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS paragraph_count;
+ALTER TABLE articles DROP COLUMN IF EXISTS word_count;
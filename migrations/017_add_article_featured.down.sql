@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_articles_is_featured;
+ALTER TABLE articles DROP COLUMN IF EXISTS featured_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS is_featured;
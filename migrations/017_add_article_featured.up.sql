@@ -0,0 +1,4 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS is_featured BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS featured_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_articles_is_featured ON articles(is_featured);
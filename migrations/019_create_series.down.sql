@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_series_entries_series_id;
+DROP TABLE IF EXISTS series_entries;
+DROP INDEX IF EXISTS idx_series_user_id;
+DROP INDEX IF EXISTS idx_series_slug;
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS series (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(255),
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    user_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_series_slug ON series(slug);
+CREATE INDEX IF NOT EXISTS idx_series_user_id ON series(user_id);
+
+CREATE TABLE IF NOT EXISTS series_entries (
+    article_id INTEGER PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    series_id INTEGER NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_entries_series_id ON series_entries(series_id);
@@ -0,0 +1,3 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE articles DROP COLUMN IF EXISTS review_note;
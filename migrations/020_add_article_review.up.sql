@@ -0,0 +1,3 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS review_note TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS reviewed_by INTEGER;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
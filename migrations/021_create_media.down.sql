@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_article_media_article_id;
+DROP TABLE IF EXISTS article_media;
+DROP INDEX IF EXISTS idx_media_user_id;
+DROP INDEX IF EXISTS idx_media_storage_key;
+DROP TABLE IF EXISTS media;
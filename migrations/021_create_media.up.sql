@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS media (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_storage_key ON media(storage_key);
+CREATE INDEX IF NOT EXISTS idx_media_user_id ON media(user_id);
+
+CREATE TABLE IF NOT EXISTS article_media (
+    media_id INTEGER NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (media_id, article_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_media_article_id ON article_media(article_id);
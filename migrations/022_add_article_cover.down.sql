@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_articles_cover_media_id;
+
+ALTER TABLE articles DROP COLUMN IF EXISTS cover_media_id;
+ALTER TABLE articles DROP COLUMN IF EXISTS cover_image_url;
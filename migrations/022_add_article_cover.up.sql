@@ -0,0 +1,4 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS cover_image_url VARCHAR(2048) NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS cover_media_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_articles_cover_media_id ON articles(cover_media_id);
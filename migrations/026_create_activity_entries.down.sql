@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_activity_entries_article_id;
+DROP INDEX IF EXISTS idx_activity_entries_user_occurred;
+DROP TABLE IF EXISTS activity_entries;
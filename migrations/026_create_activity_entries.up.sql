@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS activity_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    article_id INTEGER NOT NULL,
+    event VARCHAR(64) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_entries_user_occurred ON activity_entries(user_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_activity_entries_article_id ON activity_entries(article_id);
+
+INSERT INTO activity_entries (user_id, article_id, event, title, slug, status, occurred_at)
+SELECT user_id, id, 'article.created', title, slug, status, created_at
+FROM articles
+WHERE deleted_at IS NULL;
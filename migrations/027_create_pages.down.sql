@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pages_status;
+DROP INDEX IF EXISTS idx_pages_slug;
+DROP TABLE IF EXISTS pages;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_reports_status;
+DROP INDEX IF EXISTS idx_reports_reporter_created;
+DROP INDEX IF EXISTS idx_reports_article_reporter;
+DROP TABLE IF EXISTS reports;
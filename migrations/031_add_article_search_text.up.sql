@@ -0,0 +1,15 @@
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+
+UPDATE articles SET search_text = content_blobs.content
+FROM content_blobs
+WHERE content_blobs.key = articles.content_ref AND articles.content_ref <> '';
+
+DROP INDEX IF EXISTS idx_articles_search_vector;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE articles ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(content, '') || ' ' || coalesce(search_text, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector);
@@ -0,0 +1,3 @@
+ALTER TABLE outbox_messages DROP COLUMN IF EXISTS dead_at;
+ALTER TABLE outbox_messages DROP COLUMN IF EXISTS last_error;
+ALTER TABLE outbox_messages DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1 @@
+UPDATE notification_preferences SET email = '';